@@ -3,6 +3,8 @@ package main
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
 	"log"
 	"log/slog"
@@ -14,11 +16,13 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/go-redis/redis/v8"
 	"github.com/joho/godotenv"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	gormLogger "gorm.io/gorm/logger"
 
 	"github.com/X3nonxe/gopsy-backend/internal/config"
@@ -26,8 +30,14 @@ import (
 	"github.com/X3nonxe/gopsy-backend/internal/delivery/http/middleware"
 	"github.com/X3nonxe/gopsy-backend/internal/delivery/http/router"
 	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/internal/mailer"
 	"github.com/X3nonxe/gopsy-backend/internal/repository"
+	"github.com/X3nonxe/gopsy-backend/internal/storage"
 	"github.com/X3nonxe/gopsy-backend/internal/usecase"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_crypto"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_jwt"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_password"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_redis"
 )
 
 func main() {
@@ -58,13 +68,23 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Setup redis
+	redisStore, err := setupRedis(cfg, zapLogger)
+	if err != nil {
+		zapLogger.Error("Failed to setup redis", zap.Error(err))
+		os.Exit(1)
+	}
+
 	// Setup dependencies
-	deps, err := setupDependencies(db, cfg, zapLogger)
+	deps, err := setupDependencies(db, redisStore, cfg, zapLogger)
 	if err != nil {
 		zapLogger.Error("Failed to setup dependencies", zap.Error(err))
 		os.Exit(1)
 	}
 
+	// Arsip ekspor data pribadi yang kedaluwarsa dihapus di latar belakang
+	startDataExportCleanup(deps.DataPrivacy, zapLogger)
+
 	// Setup HTTP server
 	server := setupHTTPServer(deps, cfg, zapLogger)
 
@@ -197,17 +217,190 @@ func setupDatabase(cfg *config.Config, logger *zap.Logger) (*gorm.DB, error) {
 	return db, nil
 }
 
+// setupRedis menghubungkan ke Redis bila dikonfigurasi, atau memakai penyimpanan in-memory.
+func setupRedis(cfg *config.Config, logger *zap.Logger) (app_redis.Redis, error) {
+	if cfg.Redis.Addr == "" {
+		logger.Warn("REDIS_ADDR is not set, using in-memory store; token revocations are not shared between instances")
+		return app_redis.NewMemoryRedis(), nil
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.Redis.Addr,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to ping redis: %w", err)
+	}
+
+	logger.Info("Redis connection established", zap.String("addr", cfg.Redis.Addr))
+	return app_redis.NewRedis(client), nil
+}
+
+// setupJWTKeys memuat kunci penanda tangan JWT. Tanpa JWT_SIGNING_KEY_FILE, token ditandatangani
+// dengan HS256 memakai JWT_SECRET_KEY dan JWKS kosong.
+func setupJWTKeys(cfg *config.Config, logger *zap.Logger) (*app_jwt.KeySet, error) {
+	if cfg.JWT.SigningKeyFile == "" {
+		logger.Warn("JWT_SIGNING_KEY_FILE is not set, signing tokens with HS256")
+		return app_jwt.NewKeySet(app_jwt.NewHMACKey([]byte(cfg.JWT.Secret)))
+	}
+
+	signingKey, err := loadJWTKey(cfg.JWT.SigningKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	verificationKeys := make([]*app_jwt.Key, 0, len(cfg.JWT.VerificationKeyFiles))
+	for _, path := range cfg.JWT.VerificationKeyFiles {
+		key, err := loadJWTKey(path)
+		if err != nil {
+			return nil, err
+		}
+		verificationKeys = append(verificationKeys, key)
+	}
+
+	keys, err := app_jwt.NewKeySet(signingKey, verificationKeys...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build JWT key set: %w", err)
+	}
+
+	logger.Info("JWT signing key loaded",
+		zap.String("kid", keys.SigningKeyID()),
+		zap.String("alg", signingKey.Method.Alg()),
+		zap.Int("verification_keys", len(verificationKeys)),
+	)
+	return keys, nil
+}
+
+// setupMailer memilih implementasi Mailer sesuai MAIL_DRIVER.
+func setupMailer(cfg *config.Config, logger *zap.Logger) domain.Mailer {
+	if cfg.Mail.Driver == "smtp" {
+		return mailer.NewSMTPMailer(mailer.SMTPConfig{
+			Host:     cfg.Mail.SMTPHost,
+			Port:     cfg.Mail.SMTPPort,
+			Username: cfg.Mail.SMTPUsername,
+			Password: cfg.Mail.SMTPPassword,
+			From:     cfg.Mail.From,
+		}, logger)
+	}
+
+	logger.Warn("MAIL_DRIVER is outbox, emails are written to disk instead of being sent",
+		zap.String("dir", cfg.Mail.OutboxDir))
+	return mailer.NewOutboxMailer(cfg.Mail.OutboxDir, cfg.Mail.From, logger)
+}
+
+// setupStorage memilih implementasi FileStorage sesuai STORAGE_DRIVER. SignedFileServer hanya
+// dikembalikan untuk driver local karena file S3 dilayani langsung oleh bucket.
+func setupStorage(cfg *config.Config, logger *zap.Logger) (domain.FileStorage, domain.SignedFileServer) {
+	if cfg.Storage.Driver == "s3" {
+		return storage.NewS3Storage(storage.S3Config{
+			Endpoint:        cfg.Storage.S3Endpoint,
+			PublicEndpoint:  cfg.Storage.S3PublicEndpoint,
+			Region:          cfg.Storage.S3Region,
+			Bucket:          cfg.Storage.S3Bucket,
+			AccessKeyID:     cfg.Storage.S3AccessKeyID,
+			SecretAccessKey: cfg.Storage.S3SecretKey,
+			UsePathStyle:    cfg.Storage.S3UsePathStyle,
+		}, nil, logger), nil
+	}
+
+	signingKey := []byte(cfg.Storage.SigningKey)
+	if len(signingKey) == 0 {
+		logger.Warn("STORAGE_SIGNING_KEY is not set, deriving the file URL signing key from JWT_SECRET_KEY")
+		// Diberi prefix agar tidak sama dengan kunci MFA yang juga diturunkan dari JWT_SECRET_KEY
+		key := sha256.Sum256([]byte("file-url-signing:" + cfg.JWT.Secret))
+		signingKey = key[:]
+	}
+
+	localStorage := storage.NewLocalStorage(cfg.Storage.LocalDir, cfg.Storage.PublicBaseURL, signingKey, logger)
+	return localStorage, localStorage
+}
+
+// setupMFACipher menyiapkan AES-GCM untuk mengenkripsi secret TOTP. MFA_ENCRYPTION_KEY hanya boleh
+// kosong di development; kuncinya lalu diturunkan dari JWT_SECRET_KEY sehingga mengganti secret
+// tersebut membuat semua enrollment MFA tidak bisa dibaca.
+func setupMFACipher(cfg *config.Config, logger *zap.Logger) (*app_crypto.AEAD, error) {
+	if cfg.MFA.EncryptionKey == "" {
+		logger.Warn("MFA_ENCRYPTION_KEY is not set, deriving the MFA encryption key from JWT_SECRET_KEY")
+		key := sha256.Sum256([]byte(cfg.JWT.Secret))
+		return app_crypto.NewAESGCM(key[:])
+	}
+
+	key, err := base64.StdEncoding.DecodeString(cfg.MFA.EncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode MFA_ENCRYPTION_KEY: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("MFA_ENCRYPTION_KEY must be 32 bytes, got %d", len(key))
+	}
+	return app_crypto.NewAESGCM(key)
+}
+
+func loadJWTKey(path string) (*app_jwt.Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWT key %q: %w", path, err)
+	}
+	key, err := app_jwt.ParseKeyPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JWT key %q: %w", path, err)
+	}
+	return key, nil
+}
+
 func runMigrations(db *gorm.DB, logger *zap.Logger) error {
 	logger.Info("Starting database migrations...")
 
 	// List all models to migrate in proper order (considering foreign keys)
 	models := []interface{}{
 		&domain.User{},
+		&domain.RefreshToken{},
+		&domain.Session{},
+		&domain.PasswordResetToken{},
+		&domain.EmailVerificationToken{},
+		&domain.UserMFA{},
+		&domain.MFARecoveryCode{},
+		&domain.MFARolePolicy{},
+		&domain.RolePermission{},
+		&domain.PsychologistProfile{},
 		&domain.WaktuKonsultasi{},
+		&domain.PengecualianJadwal{},
+		&domain.PengaturanSesi{},
+		&domain.Konsultasi{},
+		&domain.KonsultasiStatusHistory{},
+		&domain.DataExport{},
 		// Add other models here as they are created
 		// Make sure to maintain proper order for foreign key dependencies
 	}
 
+	// Akun lama dianggap terverifikasi saat kolom email_verified_at pertama kali ditambahkan
+	backfillEmailVerified := db.Migrator().HasTable(&domain.User{}) &&
+		!db.Migrator().HasColumn(&domain.User{}, "EmailVerifiedAt")
+	// Pemetaan permission bawaan hanya diisi saat tabel role_permissions pertama kali dibuat
+	seedRolePermissions := !db.Migrator().HasTable(&domain.RolePermission{})
+	// Permission verifikasi psikolog diberikan ke role bawaan saat tabel profilnya pertama kali dibuat
+	grantProfilePermissions := !seedRolePermissions && !db.Migrator().HasTable(&domain.PsychologistProfile{})
+	// Permission MFA dan hapus akun menggantikan pemeriksaan role; diberikan ke role bawaan saat tabel
+	// data_exports pertama kali dibuat
+	grantAccountPermissions := !seedRolePermissions && !db.Migrator().HasTable(&domain.DataExport{})
+
+	// Kolom profile_picture lama berisi URL; diganti nama sebelum AutoMigrate agar tidak ada dua kolom
+	if db.Migrator().HasColumn(&domain.User{}, "profile_picture") {
+		if err := db.Migrator().RenameColumn(&domain.User{}, "profile_picture", "profile_picture_key"); err != nil {
+			return fmt.Errorf("failed to rename profile_picture column: %w", err)
+		}
+		err := db.Model(&domain.User{}).
+			Where("profile_picture_key LIKE ?", "%://%").
+			Update("profile_picture_key", nil).Error
+		if err != nil {
+			return fmt.Errorf("failed to clear legacy profile picture URLs: %w", err)
+		}
+	}
+
 	// Run migrations
 	for _, model := range models {
 		logger.Debug("Migrating model", zap.String("model", fmt.Sprintf("%T", model)))
@@ -216,56 +409,317 @@ func runMigrations(db *gorm.DB, logger *zap.Logger) error {
 		}
 	}
 
+	if backfillEmailVerified {
+		err := db.Model(&domain.User{}).
+			Where("email_verified_at IS NULL").
+			Update("email_verified_at", gorm.Expr("created_at")).Error
+		if err != nil {
+			return fmt.Errorf("failed to backfill email_verified_at: %w", err)
+		}
+	}
+
+	// Username bukan kolom unik; database lama yang dibuat AutoMigrate masih memiliki constraint ini
+	if db.Migrator().HasConstraint(&domain.User{}, "uni_users_username") {
+		if err := db.Migrator().DropConstraint(&domain.User{}, "uni_users_username"); err != nil {
+			return fmt.Errorf("failed to drop username unique constraint: %w", err)
+		}
+	}
+
+	if seedRolePermissions {
+		var rows []domain.RolePermission
+		for _, role := range domain.Roles {
+			for _, permission := range domain.DefaultRolePermissions[role] {
+				rows = append(rows, domain.RolePermission{Role: role, Permission: permission})
+			}
+		}
+		if err := db.Create(&rows).Error; err != nil {
+			return fmt.Errorf("failed to seed role permissions: %w", err)
+		}
+	}
+
+	if grantProfilePermissions {
+		rows := []domain.RolePermission{
+			{Role: domain.RolePsikolog, Permission: domain.PermissionPsychologistProfileWrite},
+			{Role: domain.RoleAdmin, Permission: domain.PermissionPsychologistsVerify},
+		}
+		if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error; err != nil {
+			return fmt.Errorf("failed to grant psychologist profile permissions: %w", err)
+		}
+	}
+
+	if grantAccountPermissions {
+		rows := []domain.RolePermission{
+			{Role: domain.RoleAdmin, Permission: domain.PermissionMFAManage},
+			{Role: domain.RolePsikolog, Permission: domain.PermissionMFAManage},
+			{Role: domain.RoleKlien, Permission: domain.PermissionAccountDelete},
+		}
+		if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error; err != nil {
+			return fmt.Errorf("failed to grant account permissions: %w", err)
+		}
+	}
+
+	// Constraint yang tidak bisa dideklarasikan lewat tag GORM
+	if err := repository.EnsureKonsultasiConstraints(db); err != nil {
+		return err
+	}
+	if err := repository.EnsureAvailabilityConstraints(db); err != nil {
+		return err
+	}
+	if err := repository.EnsureDataExportConstraints(db); err != nil {
+		return err
+	}
+
 	logger.Info("Database migrations completed successfully")
 	return nil
 }
 
 // Dependencies holds all application dependencies
 type Dependencies struct {
-	UserHandler         *handler.UserHandler
-	AvailabilityHandler *handler.AvailabilityHandler
-	Config              *config.Config
-	Validator           *validator.Validate
-	DB                  *gorm.DB
-	Logger              *zap.Logger
+	UserHandler                *handler.UserHandler
+	UserAdminHandler           *handler.UserAdminHandler
+	AuthHandler                *handler.AuthHandler
+	PasswordHandler            *handler.PasswordHandler
+	EmailVerificationHandler   *handler.EmailVerificationHandler
+	MFAHandler                 *handler.MFAHandler
+	SessionHandler             *handler.SessionHandler
+	PermissionHandler          *handler.PermissionHandler
+	PsychologistProfileHandler *handler.PsychologistProfileHandler
+	ProfilePictureHandler      *handler.ProfilePictureHandler
+	MediaHandler               *handler.MediaHandler
+	DataPrivacyHandler         *handler.DataPrivacyHandler
+	AvailabilityHandler        *handler.AvailabilityHandler
+	ConsultationHandler        *handler.ConsultationHandler
+	TokenRevocations           domain.TokenRevocationStore
+	Permissions                domain.PermissionChecker
+	DataPrivacy                domain.DataPrivacyUsecase
+	JWTKeys                    *app_jwt.KeySet
+	Config                     *config.Config
+	Validator                  *validator.Validate
+	DB                         *gorm.DB
+	Logger                     *zap.Logger
 }
 
-func setupDependencies(db *gorm.DB, cfg *config.Config, logger *zap.Logger) (*Dependencies, error) {
+func setupDependencies(db *gorm.DB, redisStore app_redis.Redis, cfg *config.Config, logger *zap.Logger) (*Dependencies, error) {
 	// Initialize validator with custom validation rules
 	validate := validator.New()
 
 	// You can register custom validation rules here
 	// validate.RegisterValidation("custom_rule", customValidationFunc)
 
+	// Booking menggunakan zona waktu yang sama dengan database
+	location, err := time.LoadLocation(cfg.Database.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load time zone %q: %w", cfg.Database.TimeZone, err)
+	}
+
 	// Setup repositories with logger
 	userRepository := repository.NewUserRepository(db, logger)
 	availabilityRepository := repository.NewAvailabilityRepository(db, logger)
+	consultationRepository := repository.NewConsultationRepository(db, logger)
+	refreshTokenRepository := repository.NewRefreshTokenRepository(db, logger)
+	sessionRepository := repository.NewSessionRepository(db, logger)
+	passwordResetRepository := repository.NewPasswordResetRepository(db, logger)
+	emailVerificationRepository := repository.NewEmailVerificationRepository(db, logger)
+	mfaRepository := repository.NewMFARepository(db, logger)
+	permissionRepository := repository.NewPermissionRepository(db, logger)
+	psychologistProfileRepository := repository.NewPsychologistProfileRepository(db, logger)
+	dataPrivacyRepository := repository.NewDataPrivacyRepository(db, logger)
+	appMailer := setupMailer(cfg, logger)
+	fileStorage, signedFiles := setupStorage(cfg, logger)
+	tokenRevocations := app_jwt.NewJWT(redisStore)
+
+	jwtKeys, err := setupJWTKeys(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	mfaCipher, err := setupMFACipher(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
 
 	// Setup use cases with logger
+	authUsecase := usecase.NewAuthUsecase(
+		refreshTokenRepository,
+		sessionRepository,
+		userRepository,
+		tokenRevocations,
+		jwtKeys,
+		time.Duration(cfg.JWT.AccessTokenMinutes)*time.Minute,
+		time.Duration(cfg.JWT.RefreshTokenHours)*time.Hour,
+		logger,
+	)
+	emailVerificationUsecase := usecase.NewEmailVerificationUsecase(
+		userRepository,
+		emailVerificationRepository,
+		appMailer,
+		redisStore,
+		cfg.Verification.VerifyURL,
+		time.Duration(cfg.Verification.TokenHours)*time.Hour,
+		time.Duration(cfg.Verification.ResendCooldownSeconds)*time.Second,
+		logger,
+	)
+	sessionUsecase := usecase.NewSessionUsecase(sessionRepository, authUsecase, logger)
+	permissionUsecase := usecase.NewPermissionUsecase(permissionRepository, time.Minute, logger)
+	mfaUsecase := usecase.NewMFAUsecase(
+		mfaRepository,
+		userRepository,
+		authUsecase,
+		mfaCipher,
+		redisStore,
+		cfg.MFA.Issuer,
+		time.Duration(cfg.MFA.ChallengeMinutes)*time.Minute,
+		logger,
+	)
+	loginAttemptUsecase := usecase.NewLoginAttemptUsecase(redisStore, domain.LoginAttemptPolicy{
+		FreeAttempts: cfg.Login.FreeAttempts,
+		MaxAttempts:  cfg.Login.MaxFailedAttempts,
+		BaseDelay:    time.Duration(cfg.Login.DelayBaseSeconds) * time.Second,
+		MaxDelay:     time.Duration(cfg.Login.DelayMaxSeconds) * time.Second,
+		Lockout:      time.Duration(cfg.Login.LockoutMinutes) * time.Minute,
+		Window:       time.Duration(cfg.Login.AttemptWindowMinutes) * time.Minute,
+	}, logger)
+	passwordPolicy := app_password.Policy{
+		MinLength:        cfg.Password.MinLength,
+		MaxLength:        cfg.Password.MaxLength,
+		RequireUppercase: cfg.Password.RequireUppercase,
+		RequireLowercase: cfg.Password.RequireLowercase,
+		RequireDigit:     cfg.Password.RequireDigit,
+		RequireSymbol:    cfg.Password.RequireSymbol,
+		RejectCommon:     cfg.Password.RejectCommon,
+	}
 	userUsecase := usecase.NewUserUsecase(
 		userRepository,
-		cfg.JWT.Secret,
-		cfg.JWT.ExpirationHours,
+		availabilityRepository,
+		psychologistProfileRepository,
+		authUsecase,
+		emailVerificationUsecase,
+		mfaUsecase,
+		loginAttemptUsecase,
+		passwordPolicy,
+		location,
+		logger,
+	)
+	passwordUsecase := usecase.NewPasswordUsecase(
+		userRepository,
+		passwordResetRepository,
+		authUsecase,
+		appMailer,
+		cfg.Password.ResetURL,
+		time.Duration(cfg.Password.ResetTokenMinutes)*time.Minute,
+		passwordPolicy,
+		logger,
+	)
+	availabilityUsecase := usecase.NewAvailabilityUsecase(
+		availabilityRepository,
+		consultationRepository,
+		userRepository,
+		psychologistProfileRepository,
+		location,
+		logger,
+	)
+	consultationUsecase := usecase.NewConsultationUsecase(
+		consultationRepository,
+		availabilityRepository,
+		userRepository,
+		psychologistProfileRepository,
+		location,
+		logger,
+	)
+	psychologistProfileUsecase := usecase.NewPsychologistProfileUsecase(psychologistProfileRepository, userRepository, location, logger)
+	userAdminUsecase := usecase.NewUserAdminUsecase(userRepository, authUsecase, logger)
+	profilePictureUsecase := usecase.NewProfilePictureUsecase(
+		userRepository,
+		fileStorage,
+		int64(cfg.Storage.UploadMaxBytes),
+		time.Duration(cfg.Storage.URLMinutes)*time.Minute,
+		logger,
+	)
+	dataPrivacyUsecase := usecase.NewDataPrivacyUsecase(
+		dataPrivacyRepository,
+		userRepository,
+		consultationRepository,
+		authUsecase,
+		fileStorage,
+		appMailer,
+		time.Duration(cfg.Privacy.DataExportHours)*time.Hour,
+		time.Duration(cfg.Storage.URLMinutes)*time.Minute,
 		logger,
 	)
-	availabilityUsecase := usecase.NewAvailabilityUsecase(availabilityRepository, logger)
 
 	// Setup handlers with logger
-	userHandler := handler.NewUserHandler(userUsecase, validate, logger)
+	userHandler := handler.NewUserHandler(userUsecase, profilePictureUsecase, validate, logger)
+	userAdminHandler := handler.NewUserAdminHandler(userAdminUsecase, profilePictureUsecase, validate, logger)
+	authHandler := handler.NewAuthHandler(authUsecase, jwtKeys, validate, logger)
+	passwordHandler := handler.NewPasswordHandler(passwordUsecase, validate, logger)
+	emailVerificationHandler := handler.NewEmailVerificationHandler(emailVerificationUsecase, validate, logger)
+	mfaHandler := handler.NewMFAHandler(mfaUsecase, profilePictureUsecase, validate, logger)
+	sessionHandler := handler.NewSessionHandler(sessionUsecase, logger)
+	permissionHandler := handler.NewPermissionHandler(permissionUsecase, validate, logger)
+	psychologistProfileHandler := handler.NewPsychologistProfileHandler(psychologistProfileUsecase, validate, logger)
+	profilePictureHandler := handler.NewProfilePictureHandler(profilePictureUsecase, logger)
+	var mediaHandler *handler.MediaHandler
+	if signedFiles != nil {
+		mediaHandler = handler.NewMediaHandler(signedFiles, logger)
+	}
+	dataPrivacyHandler := handler.NewDataPrivacyHandler(dataPrivacyUsecase, validate, logger)
 	availabilityHandler := handler.NewAvailabilityHandler(availabilityUsecase, validate, logger)
+	consultationHandler := handler.NewConsultationHandler(consultationUsecase, validate, logger)
 
 	logger.Info("Dependencies initialized successfully")
 
 	return &Dependencies{
-		UserHandler:         userHandler,
-		AvailabilityHandler: availabilityHandler,
-		Config:              cfg,
-		Validator:           validate,
-		DB:                  db,
-		Logger:              logger,
+		UserHandler:                userHandler,
+		UserAdminHandler:           userAdminHandler,
+		AuthHandler:                authHandler,
+		PasswordHandler:            passwordHandler,
+		EmailVerificationHandler:   emailVerificationHandler,
+		MFAHandler:                 mfaHandler,
+		SessionHandler:             sessionHandler,
+		PermissionHandler:          permissionHandler,
+		PsychologistProfileHandler: psychologistProfileHandler,
+		ProfilePictureHandler:      profilePictureHandler,
+		MediaHandler:               mediaHandler,
+		DataPrivacyHandler:         dataPrivacyHandler,
+		AvailabilityHandler:        availabilityHandler,
+		ConsultationHandler:        consultationHandler,
+		TokenRevocations:           tokenRevocations,
+		Permissions:                permissionUsecase,
+		DataPrivacy:                dataPrivacyUsecase,
+		JWTKeys:                    jwtKeys,
+		Config:                     cfg,
+		Validator:                  validate,
+		DB:                         db,
+		Logger:                     logger,
 	}, nil
 }
 
+// startDataExportCleanup melanjutkan ekspor pending dan menjalankan PurgeExpiredExports saat
+// aplikasi mulai lalu setiap jam. Ekspor yang terputus karena restart dilanjutkan di sini.
+func startDataExportCleanup(privacy domain.DataPrivacyUsecase, logger *zap.Logger) {
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			resumed, err := privacy.ResumePendingExports(context.Background())
+			if err != nil {
+				logger.Error("Failed to resume pending data exports", zap.Error(err))
+			} else if resumed > 0 {
+				logger.Info("Pending data exports resumed", zap.Int("count", resumed))
+			}
+
+			purged, err := privacy.PurgeExpiredExports(context.Background())
+			if err != nil {
+				logger.Error("Failed to purge expired data exports", zap.Error(err))
+			} else if purged > 0 {
+				logger.Info("Expired data exports purged", zap.Int("count", purged))
+			}
+			<-ticker.C
+		}
+	}()
+}
+
 func setupHTTPServer(deps *Dependencies, cfg *config.Config, logger *zap.Logger) *http.Server {
 	// Set Gin mode based on environment
 	switch cfg.Environment {
@@ -293,7 +747,26 @@ func setupHTTPServer(deps *Dependencies, cfg *config.Config, logger *zap.Logger)
 	setupHealthChecks(engine, deps.DB, logger)
 
 	// Setup API routes
-	router.SetupRouter(engine, deps.UserHandler, deps.AvailabilityHandler, cfg.JWT.Secret)
+	router.SetupRouter(
+		engine,
+		deps.UserHandler,
+		deps.UserAdminHandler,
+		deps.AuthHandler,
+		deps.PasswordHandler,
+		deps.EmailVerificationHandler,
+		deps.MFAHandler,
+		deps.SessionHandler,
+		deps.PermissionHandler,
+		deps.PsychologistProfileHandler,
+		deps.ProfilePictureHandler,
+		deps.MediaHandler,
+		deps.DataPrivacyHandler,
+		deps.AvailabilityHandler,
+		deps.ConsultationHandler,
+		deps.JWTKeys,
+		deps.TokenRevocations,
+		deps.Permissions,
+	)
 
 	// Configure HTTP server with proper timeouts
 	server := &http.Server{
@@ -443,4 +916,4 @@ func startServerWithGracefulShutdown(server *http.Server, port string, logger *z
 	}
 
 	logger.Info("Server exited gracefully")
-}
\ No newline at end of file
+}
@@ -4,13 +4,22 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 )
 
 type Config struct {
-	Environment string         `json:"environment"`
-	Server      ServerConfig   `json:"server"`
-	Database    DatabaseConfig `json:"database"`
-	JWT         JWTConfig      `json:"jwt"`
+	Environment  string             `json:"environment"`
+	Server       ServerConfig       `json:"server"`
+	Database     DatabaseConfig     `json:"database"`
+	JWT          JWTConfig          `json:"jwt"`
+	Redis        RedisConfig        `json:"redis"`
+	Mail         MailConfig         `json:"mail"`
+	Password     PasswordConfig     `json:"password"`
+	Verification VerificationConfig `json:"verification"`
+	MFA          MFAConfig          `json:"mfa"`
+	Login        LoginConfig        `json:"login"`
+	Storage      StorageConfig      `json:"storage"`
+	Privacy      PrivacyConfig      `json:"privacy"`
 }
 
 type ServerConfig struct {
@@ -33,9 +42,102 @@ type DatabaseConfig struct {
 	ConnMaxLifetime int    `json:"conn_max_lifetime"`
 }
 
+// JWTConfig berisi konfigurasi token. Jika SigningKeyFile diisi, token ditandatangani dengan
+// RS256/EdDSA dan Secret tidak dipakai; VerificationKeyFiles berisi kunci lama yang masih diterima
+// selama masa rotasi.
 type JWTConfig struct {
-	Secret          string `json:"secret"`
-	ExpirationHours int    `json:"expiration_hours"`
+	Secret               string   `json:"secret"`
+	SigningKeyFile       string   `json:"signing_key_file"`
+	VerificationKeyFiles []string `json:"verification_key_files"`
+	AccessTokenMinutes   int      `json:"access_token_minutes"`
+	RefreshTokenHours    int      `json:"refresh_token_hours"`
+}
+
+// RedisConfig berisi koneksi Redis. Addr kosong berarti aplikasi memakai penyimpanan in-memory.
+type RedisConfig struct {
+	Addr     string `json:"addr"`
+	Password string `json:"password"`
+	DB       int    `json:"db"`
+}
+
+// MailConfig berisi konfigurasi pengiriman email. Driver "smtp" mengirim lewat server SMTP,
+// sedangkan "outbox" menulis email ke OutboxDir untuk development.
+type MailConfig struct {
+	Driver       string `json:"driver"`
+	From         string `json:"from"`
+	OutboxDir    string `json:"outbox_dir"`
+	SMTPHost     string `json:"smtp_host"`
+	SMTPPort     string `json:"smtp_port"`
+	SMTPUsername string `json:"smtp_username"`
+	SMTPPassword string `json:"smtp_password"`
+}
+
+// PasswordConfig berisi konfigurasi reset password dan kebijakan kekuatan password. RejectCommon
+// menolak password yang ada di daftar password umum dan bocor yang dibundel aplikasi.
+type PasswordConfig struct {
+	ResetURL          string `json:"reset_url"`
+	ResetTokenMinutes int    `json:"reset_token_minutes"`
+	MinLength         int    `json:"min_length"`
+	MaxLength         int    `json:"max_length"`
+	RequireUppercase  bool   `json:"require_uppercase"`
+	RequireLowercase  bool   `json:"require_lowercase"`
+	RequireDigit      bool   `json:"require_digit"`
+	RequireSymbol     bool   `json:"require_symbol"`
+	RejectCommon      bool   `json:"reject_common"`
+}
+
+// VerificationConfig berisi konfigurasi verifikasi email.
+type VerificationConfig struct {
+	VerifyURL             string `json:"verify_url"`
+	TokenHours            int    `json:"token_hours"`
+	ResendCooldownSeconds int    `json:"resend_cooldown_seconds"`
+}
+
+// MFAConfig berisi konfigurasi TOTP. EncryptionKey adalah kunci AES-256 dalam base64 untuk
+// mengenkripsi secret TOTP dan wajib diisi di luar development; di development, kunci yang kosong
+// diturunkan dari JWT_SECRET_KEY.
+type MFAConfig struct {
+	Issuer           string `json:"issuer"`
+	EncryptionKey    string `json:"encryption_key"`
+	ChallengeMinutes int    `json:"challenge_minutes"`
+}
+
+// LoginConfig berisi perlindungan brute-force login per email. Setelah FreeAttempts kegagalan,
+// login diperlambat mulai DelayBaseSeconds hingga DelayMaxSeconds; setelah MaxFailedAttempts,
+// email dikunci selama LockoutMinutes.
+type LoginConfig struct {
+	FreeAttempts         int `json:"free_attempts"`
+	MaxFailedAttempts    int `json:"max_failed_attempts"`
+	DelayBaseSeconds     int `json:"delay_base_seconds"`
+	DelayMaxSeconds      int `json:"delay_max_seconds"`
+	LockoutMinutes       int `json:"lockout_minutes"`
+	AttemptWindowMinutes int `json:"attempt_window_minutes"`
+}
+
+// StorageConfig berisi konfigurasi penyimpanan file unggahan. Driver "local" menyimpan file di
+// LocalDir dan melayaninya lewat PublicBaseURL, sedangkan "s3" memakai bucket yang kompatibel
+// dengan S3. SigningKey menandatangani URL driver local; jika kosong, kunci diturunkan dari
+// JWT_SECRET_KEY.
+type StorageConfig struct {
+	Driver           string `json:"driver"`
+	LocalDir         string `json:"local_dir"`
+	PublicBaseURL    string `json:"public_base_url"`
+	SigningKey       string `json:"signing_key"`
+	URLMinutes       int    `json:"url_minutes"`
+	UploadMaxBytes   int    `json:"upload_max_bytes"`
+	S3Endpoint       string `json:"s3_endpoint"`
+	S3PublicEndpoint string `json:"s3_public_endpoint"`
+	S3Region         string `json:"s3_region"`
+	S3Bucket         string `json:"s3_bucket"`
+	S3AccessKeyID    string `json:"s3_access_key_id"`
+	S3SecretKey      string `json:"s3_secret_key"`
+	S3UsePathStyle   bool   `json:"s3_use_path_style"`
+}
+
+// PrivacyConfig berisi konfigurasi ekspor data pribadi. Arsip ekspor dihapus setelah
+// DataExportHours sejak selesai dibuat.
+type PrivacyConfig struct {
+	DataExportHours int `json:"data_export_hours"`
 }
 
 func Load() (*Config, error) {
@@ -60,8 +162,72 @@ func Load() (*Config, error) {
 			ConnMaxLifetime: getEnvAsInt("DB_CONN_MAX_LIFETIME", 300),
 		},
 		JWT: JWTConfig{
-			Secret:          getEnv("JWT_SECRET_KEY", ""),
-			ExpirationHours: getEnvAsInt("JWT_EXPIRATION_IN_HOURS", 24),
+			Secret:               getEnv("JWT_SECRET_KEY", ""),
+			SigningKeyFile:       getEnv("JWT_SIGNING_KEY_FILE", ""),
+			VerificationKeyFiles: getEnvAsSlice("JWT_VERIFICATION_KEY_FILES"),
+			AccessTokenMinutes:   getEnvAsInt("JWT_ACCESS_TOKEN_EXPIRATION_IN_MINUTES", 15),
+			RefreshTokenHours:    getEnvAsInt("JWT_REFRESH_TOKEN_EXPIRATION_IN_HOURS", 720),
+		},
+		Redis: RedisConfig{
+			Addr:     getEnv("REDIS_ADDR", ""),
+			Password: getEnv("REDIS_PASSWORD", ""),
+			DB:       getEnvAsInt("REDIS_DB", 0),
+		},
+		Mail: MailConfig{
+			Driver:       getEnv("MAIL_DRIVER", "outbox"),
+			From:         getEnv("MAIL_FROM", "no-reply@gopsy.local"),
+			OutboxDir:    getEnv("MAIL_OUTBOX_DIR", "tmp/outbox"),
+			SMTPHost:     getEnv("SMTP_HOST", ""),
+			SMTPPort:     getEnv("SMTP_PORT", "587"),
+			SMTPUsername: getEnv("SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+		},
+		Password: PasswordConfig{
+			ResetURL:          getEnv("PASSWORD_RESET_URL", "http://localhost:3000/reset-password"),
+			ResetTokenMinutes: getEnvAsInt("PASSWORD_RESET_TOKEN_EXPIRATION_IN_MINUTES", 60),
+			MinLength:         getEnvAsInt("PASSWORD_MIN_LENGTH", 8),
+			MaxLength:         getEnvAsInt("PASSWORD_MAX_LENGTH", 72),
+			RequireUppercase:  getEnvAsBool("PASSWORD_REQUIRE_UPPERCASE", true),
+			RequireLowercase:  getEnvAsBool("PASSWORD_REQUIRE_LOWERCASE", true),
+			RequireDigit:      getEnvAsBool("PASSWORD_REQUIRE_DIGIT", true),
+			RequireSymbol:     getEnvAsBool("PASSWORD_REQUIRE_SYMBOL", false),
+			RejectCommon:      getEnvAsBool("PASSWORD_REJECT_COMMON", true),
+		},
+		Verification: VerificationConfig{
+			VerifyURL:             getEnv("EMAIL_VERIFICATION_URL", "http://localhost:3000/verify-email"),
+			TokenHours:            getEnvAsInt("EMAIL_VERIFICATION_TOKEN_EXPIRATION_IN_HOURS", 48),
+			ResendCooldownSeconds: getEnvAsInt("EMAIL_VERIFICATION_RESEND_COOLDOWN_IN_SECONDS", 60),
+		},
+		MFA: MFAConfig{
+			Issuer:           getEnv("MFA_ISSUER", "Gopsy"),
+			EncryptionKey:    getEnv("MFA_ENCRYPTION_KEY", ""),
+			ChallengeMinutes: getEnvAsInt("MFA_CHALLENGE_EXPIRATION_IN_MINUTES", 5),
+		},
+		Login: LoginConfig{
+			FreeAttempts:         getEnvAsInt("LOGIN_FREE_ATTEMPTS", 3),
+			MaxFailedAttempts:    getEnvAsInt("LOGIN_MAX_FAILED_ATTEMPTS", 10),
+			DelayBaseSeconds:     getEnvAsInt("LOGIN_DELAY_BASE_SECONDS", 1),
+			DelayMaxSeconds:      getEnvAsInt("LOGIN_DELAY_MAX_SECONDS", 30),
+			LockoutMinutes:       getEnvAsInt("LOGIN_LOCKOUT_MINUTES", 15),
+			AttemptWindowMinutes: getEnvAsInt("LOGIN_ATTEMPT_WINDOW_MINUTES", 15),
+		},
+		Storage: StorageConfig{
+			Driver:           getEnv("STORAGE_DRIVER", "local"),
+			LocalDir:         getEnv("STORAGE_LOCAL_DIR", "tmp/uploads"),
+			PublicBaseURL:    getEnv("STORAGE_PUBLIC_BASE_URL", "http://localhost:8080/media"),
+			SigningKey:       getEnv("STORAGE_SIGNING_KEY", ""),
+			URLMinutes:       getEnvAsInt("STORAGE_URL_EXPIRATION_IN_MINUTES", 15),
+			UploadMaxBytes:   getEnvAsInt("STORAGE_UPLOAD_MAX_BYTES", 5<<20),
+			S3Endpoint:       getEnv("S3_ENDPOINT", ""),
+			S3PublicEndpoint: getEnv("S3_PUBLIC_ENDPOINT", ""),
+			S3Region:         getEnv("S3_REGION", "us-east-1"),
+			S3Bucket:         getEnv("S3_BUCKET", ""),
+			S3AccessKeyID:    getEnv("S3_ACCESS_KEY_ID", ""),
+			S3SecretKey:      getEnv("S3_SECRET_ACCESS_KEY", ""),
+			S3UsePathStyle:   getEnvAsBool("S3_USE_PATH_STYLE", true),
+		},
+		Privacy: PrivacyConfig{
+			DataExportHours: getEnvAsInt("DATA_EXPORT_RETENTION_HOURS", 72),
 		},
 	}
 
@@ -73,8 +239,57 @@ func Load() (*Config, error) {
 }
 
 func (c *Config) validate() error {
-	if c.JWT.Secret == "" {
-		return fmt.Errorf("JWT_SECRET_KEY is required")
+	if c.JWT.Secret == "" && c.JWT.SigningKeyFile == "" {
+		return fmt.Errorf("JWT_SECRET_KEY or JWT_SIGNING_KEY_FILE is required")
+	}
+	// Kunci MFA turunan JWT_SECRET_KEY hanya untuk development: rotasi secret JWT akan membuat
+	// semua secret TOTP tidak bisa didekripsi
+	if c.MFA.EncryptionKey == "" && c.Environment != "development" {
+		return fmt.Errorf("MFA_ENCRYPTION_KEY is required outside development")
+	}
+	if c.MFA.EncryptionKey == "" && c.JWT.Secret == "" {
+		return fmt.Errorf("MFA_ENCRYPTION_KEY is required when JWT_SECRET_KEY is not set")
+	}
+	switch c.Mail.Driver {
+	case "outbox":
+	case "smtp":
+		if c.Mail.SMTPHost == "" {
+			return fmt.Errorf("SMTP_HOST is required when MAIL_DRIVER is smtp")
+		}
+	default:
+		return fmt.Errorf("unsupported MAIL_DRIVER %q", c.Mail.Driver)
+	}
+	switch c.Storage.Driver {
+	case "local":
+		if c.Storage.SigningKey == "" && c.JWT.Secret == "" {
+			return fmt.Errorf("STORAGE_SIGNING_KEY is required when JWT_SECRET_KEY is not set")
+		}
+	case "s3":
+		if c.Storage.S3Endpoint == "" || c.Storage.S3Bucket == "" {
+			return fmt.Errorf("S3_ENDPOINT and S3_BUCKET are required when STORAGE_DRIVER is s3")
+		}
+	default:
+		return fmt.Errorf("unsupported STORAGE_DRIVER %q", c.Storage.Driver)
+	}
+	// Presigned URL S3 berlaku paling lama tujuh hari
+	if c.Storage.URLMinutes < 1 || c.Storage.URLMinutes > 7*24*60 {
+		return fmt.Errorf("STORAGE_URL_EXPIRATION_IN_MINUTES must be between 1 and 10080")
+	}
+	if c.Storage.UploadMaxBytes < 1 {
+		return fmt.Errorf("STORAGE_UPLOAD_MAX_BYTES must be at least 1")
+	}
+	if c.Privacy.DataExportHours < 1 {
+		return fmt.Errorf("DATA_EXPORT_RETENTION_HOURS must be at least 1")
+	}
+	if c.Login.MaxFailedAttempts <= c.Login.FreeAttempts {
+		return fmt.Errorf("LOGIN_MAX_FAILED_ATTEMPTS must be greater than LOGIN_FREE_ATTEMPTS")
+	}
+	if c.Password.MinLength < 1 {
+		return fmt.Errorf("PASSWORD_MIN_LENGTH must be at least 1")
+	}
+	// bcrypt hanya memproses 72 byte pertama, sisanya ditolak
+	if c.Password.MaxLength < c.Password.MinLength || c.Password.MaxLength > 72 {
+		return fmt.Errorf("PASSWORD_MAX_LENGTH must be between PASSWORD_MIN_LENGTH and 72")
 	}
 	if c.Database.Password == "" {
 		return fmt.Errorf("DB_PASS is required")
@@ -97,3 +312,22 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvAsSlice(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/X3nonxe/gopsy-backend/internal/delivery/http/response"
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_jwt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type AuthHandler struct {
+	authUsecase domain.AuthUsecase
+	keys        *app_jwt.KeySet
+	validator   *validator.Validate
+	logger      *zap.Logger
+}
+
+// NewAuthHandler membuat instance baru dari AuthHandler.
+func NewAuthHandler(au domain.AuthUsecase, keys *app_jwt.KeySet, v *validator.Validate, logger *zap.Logger) *AuthHandler {
+	return &AuthHandler{
+		authUsecase: au,
+		keys:        keys,
+		validator:   v,
+		logger:      logger,
+	}
+}
+
+// Refresh menukar refresh token dengan pasangan access token dan refresh token baru.
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var payload domain.RefreshTokenPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		h.logger.Warn("Invalid request payload", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	tokens, err := h.authUsecase.Refresh(c.Request.Context(), &payload, clientInfo(c))
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to refresh token")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Token refreshed successfully", tokens)
+}
+
+// Logout mencabut access token yang sedang dipakai. Refresh token pada body bersifat opsional.
+func (h *AuthHandler) Logout(c *gin.Context) {
+	claims, ok := currentAccessToken(c, h.logger)
+	if !ok {
+		return
+	}
+
+	var payload domain.LogoutPayload
+	if err := c.ShouldBindJSON(&payload); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Warn("Invalid request payload", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if err := h.authUsecase.Logout(c.Request.Context(), claims, &payload); err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to logout")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Logged out successfully", nil)
+}
+
+// LogoutAll mencabut semua token user sehingga semua perangkat harus login ulang.
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	userID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	if err := h.authUsecase.LogoutAll(c.Request.Context(), userID); err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to logout from all devices")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Logged out from all devices successfully", nil)
+}
+
+// JWKS mempublikasikan public key penanda tangan access token. Response mengikuti format JWK Set
+// (RFC 7517) tanpa envelope agar bisa langsung dipakai verifier eksternal.
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.keys.JWKS())
+}
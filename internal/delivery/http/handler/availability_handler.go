@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"strconv"
@@ -84,7 +85,7 @@ func (h *AvailabilityHandler) SetAvailability(c *gin.Context) {
 	response.Success(c, http.StatusOK, "Availability schedule updated successfully", nil)
 }
 
-// GetAvailability menangani permintaan untuk mendapatkan jadwal ketersediaan psikolog.
+// GetAvailability menangani permintaan klien untuk melihat jadwal mingguan seorang psikolog.
 func (h *AvailabilityHandler) GetAvailability(c *gin.Context) {
 	// Ambil psikolog ID dari path parameter
 	psikologIDStr := c.Param("psikolog_id")
@@ -94,22 +95,328 @@ func (h *AvailabilityHandler) GetAvailability(c *gin.Context) {
 		response.Error(c, http.StatusBadRequest, "Invalid psikolog ID format", nil)
 		return
 	}
+
+	h.writeWeeklySchedule(c, uint(psikologIDInt), h.availabilityUsecase.GetWeeklySchedule)
+}
+
+// GetOwnAvailability menangani permintaan psikolog untuk melihat jadwal mingguannya sendiri.
+func (h *AvailabilityHandler) GetOwnAvailability(c *gin.Context) {
+	psikologID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	h.writeWeeklySchedule(c, psikologID, h.availabilityUsecase.GetOwnWeeklySchedule)
+}
+
+// GetAvailabilityByDay menangani permintaan untuk melihat jadwal psikolog pada satu hari
+// melalui path parameter hari.
+func (h *AvailabilityHandler) GetAvailabilityByDay(c *gin.Context) {
+	psikologIDStr := c.Param("psikolog_id")
+	psikologIDInt, err := strconv.ParseUint(psikologIDStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid psikolog ID format", zap.String("psikolog_id", psikologIDStr))
+		response.Error(c, http.StatusBadRequest, "Invalid psikolog ID format", nil)
+		return
+	}
 	psikologID := uint(psikologIDInt)
 
-	// Panggil usecase untuk mendapatkan jadwal
-	availability, err := h.availabilityUsecase.GetAvailability(c.Request.Context(), psikologID)
+	schedule, err := h.availabilityUsecase.GetWeeklySchedule(c.Request.Context(), psikologID, c.Param("hari"))
 	if err != nil {
-		var domainErr *domain.DomainError
-		if errors.As(err, &domainErr) {
-			h.logger.Warn("Domain error occurred", zap.Error(err))
-			response.Error(c, domainErr.HTTPStatus, domainErr.Message, nil)
-			return
-		}
+		writeUsecaseError(c, h.logger, err, "Failed to get availability")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Availability retrieved successfully", schedule)
+}
+
+// writeWeeklySchedule menulis jadwal mingguan psikolog dari getSchedule dengan filter ?hari= opsional.
+func (h *AvailabilityHandler) writeWeeklySchedule(c *gin.Context, psikologID uint, getSchedule func(ctx context.Context, psikologID uint, hari string) (*domain.JadwalMingguan, error)) {
+	var query domain.WeeklyScheduleQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.Warn("Invalid query parameters", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	if err := h.validator.Struct(query); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	schedule, err := getSchedule(c.Request.Context(), psikologID, query.Hari)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to get availability")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Availability retrieved successfully", schedule)
+}
+
+// GetBookableSlots menangani permintaan klien untuk melihat slot bertanggal yang masih bisa dipesan.
+func (h *AvailabilityHandler) GetBookableSlots(c *gin.Context) {
+	psikologIDStr := c.Param("psikolog_id")
+	psikologIDInt, err := strconv.ParseUint(psikologIDStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid psikolog ID format", zap.String("psikolog_id", psikologIDStr))
+		response.Error(c, http.StatusBadRequest, "Invalid psikolog ID format", nil)
+		return
+	}
+	psikologID := uint(psikologIDInt)
+
+	var query domain.BookableSlotsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.Warn("Invalid query parameters", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	if err := h.validator.Struct(query); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	slots, err := h.availabilityUsecase.GetBookableSlots(c.Request.Context(), psikologID, &query)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to get bookable slots")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Bookable slots retrieved successfully", slots)
+}
+
+// AddException menangani permintaan psikolog untuk menambahkan libur, blok jam, atau tambahan jam praktik.
+func (h *AvailabilityHandler) AddException(c *gin.Context) {
+	psikologID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	var payload domain.AvailabilityExceptionPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		h.logger.Warn("Invalid request payload", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	exception, err := h.availabilityUsecase.AddException(c.Request.Context(), psikologID, &payload)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to add availability exception")
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Availability exception added successfully", exception)
+}
+
+// GetExceptions menangani permintaan psikolog untuk melihat pengecualian jadwalnya.
+func (h *AvailabilityHandler) GetExceptions(c *gin.Context) {
+	psikologID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	var query domain.AvailabilityExceptionQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.Warn("Invalid query parameters", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	if err := h.validator.Struct(query); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	exceptions, err := h.availabilityUsecase.GetExceptions(c.Request.Context(), psikologID, &query)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to get availability exceptions")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Availability exceptions retrieved successfully", exceptions)
+}
+
+// DeleteException menangani permintaan psikolog untuk menghapus pengecualian jadwal.
+func (h *AvailabilityHandler) DeleteException(c *gin.Context) {
+	psikologID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid exception ID format", zap.String("id", idStr))
+		response.Error(c, http.StatusBadRequest, "Invalid exception ID format", nil)
+		return
+	}
+
+	if err := h.availabilityUsecase.DeleteException(c.Request.Context(), psikologID, uint(id)); err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to delete availability exception")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Availability exception deleted successfully", nil)
+}
+
+// GetSessionSettings menangani permintaan psikolog untuk melihat durasi sesi dan jeda antar sesinya.
+func (h *AvailabilityHandler) GetSessionSettings(c *gin.Context) {
+	psikologID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	settings, err := h.availabilityUsecase.GetSessionSettings(c.Request.Context(), psikologID)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to get session settings")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Session settings retrieved successfully", settings)
+}
+
+// UpdateSessionSettings menangani permintaan psikolog untuk mengubah durasi sesi dan jeda antar sesi.
+func (h *AvailabilityHandler) UpdateSessionSettings(c *gin.Context) {
+	psikologID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	var payload domain.SessionSettingsPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		h.logger.Warn("Invalid request payload", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	settings, err := h.availabilityUsecase.UpdateSessionSettings(c.Request.Context(), psikologID, &payload)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to update session settings")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Session settings updated successfully", settings)
+}
+
+// AddSlot menangani permintaan psikolog untuk menambahkan satu slot ketersediaan.
+func (h *AvailabilityHandler) AddSlot(c *gin.Context) {
+	psikologID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	var payload domain.SlotPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		h.logger.Warn("Invalid request payload", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	slot, err := h.availabilityUsecase.AddSlot(c.Request.Context(), psikologID, &payload)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to add availability slot")
+		return
+	}
+
+	setETag(c, slot.Version)
+	response.Success(c, http.StatusCreated, "Availability slot added successfully", slot)
+}
+
+// UpdateSlot menangani permintaan psikolog untuk mengubah satu slot ketersediaan.
+// Versi slot yang terakhir dilihat klien dikirim lewat header If-Match.
+func (h *AvailabilityHandler) UpdateSlot(c *gin.Context) {
+	psikologID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	id, ok := h.slotIDParam(c)
+	if !ok {
+		return
+	}
+
+	version, ok := ifMatchVersion(c, h.logger)
+	if !ok {
+		return
+	}
+
+	var payload domain.SlotPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		h.logger.Warn("Invalid request payload", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	slot, err := h.availabilityUsecase.UpdateSlot(c.Request.Context(), psikologID, id, version, &payload)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to update availability slot")
+		return
+	}
+
+	setETag(c, slot.Version)
+	response.Success(c, http.StatusOK, "Availability slot updated successfully", slot)
+}
 
-		h.logger.Error("Failed to get availability", zap.Error(err), zap.Uint("psikolog_id", psikologID))
-		response.Error(c, http.StatusInternalServerError, "Failed to get availability", nil)
+// DeleteSlot menangani permintaan psikolog untuk menghapus satu slot ketersediaan.
+func (h *AvailabilityHandler) DeleteSlot(c *gin.Context) {
+	psikologID, ok := currentUserID(c, h.logger)
+	if !ok {
 		return
 	}
 
-	response.Success(c, http.StatusOK, "Availability retrieved successfully", availability)
+	id, ok := h.slotIDParam(c)
+	if !ok {
+		return
+	}
+
+	version, ok := ifMatchVersion(c, h.logger)
+	if !ok {
+		return
+	}
+
+	if err := h.availabilityUsecase.DeleteSlot(c.Request.Context(), psikologID, id, version); err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to delete availability slot")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Availability slot deleted successfully", nil)
+}
+
+// slotIDParam mengambil ID slot ketersediaan dari path parameter.
+func (h *AvailabilityHandler) slotIDParam(c *gin.Context) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid slot ID format", zap.String("id", idStr))
+		response.Error(c, http.StatusBadRequest, "Invalid slot ID format", nil)
+		return 0, false
+	}
+	return uint(id), true
 }
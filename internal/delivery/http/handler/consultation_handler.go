@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/X3nonxe/gopsy-backend/internal/delivery/http/response"
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type ConsultationHandler struct {
+	consultationUsecase domain.ConsultationUsecase
+	validator           *validator.Validate
+	logger              *zap.Logger
+}
+
+// NewConsultationHandler membuat instance baru dari ConsultationHandler.
+func NewConsultationHandler(
+	cu domain.ConsultationUsecase,
+	v *validator.Validate,
+	logger *zap.Logger,
+) *ConsultationHandler {
+	return &ConsultationHandler{
+		consultationUsecase: cu,
+		validator:           v,
+		logger:              logger,
+	}
+}
+
+// RequestConsultation menangani permintaan klien untuk memesan sesi konsultasi.
+func (h *ConsultationHandler) RequestConsultation(c *gin.Context) {
+	klienID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	var payload domain.RequestKonsultasiPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		h.logger.Warn("Invalid request payload", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	konsultasi, err := h.consultationUsecase.RequestConsultation(c.Request.Context(), klienID, &payload)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to request consultation")
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Consultation requested successfully", konsultasi)
+}
+
+// GetConsultationRequests menangani permintaan psikolog untuk melihat permintaan konsultasi yang masuk.
+func (h *ConsultationHandler) GetConsultationRequests(c *gin.Context) {
+	psikologID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	var query domain.ConsultationRequestQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.Warn("Invalid query parameters", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	if err := h.validator.Struct(query); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	requests, err := h.consultationUsecase.GetConsultationRequests(c.Request.Context(), psikologID, &query)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to get consultation requests")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Consultation requests retrieved successfully", requests)
+}
+
+// UpdateConsultationRequestStatus menangani permintaan psikolog untuk mengubah status konsultasi.
+func (h *ConsultationHandler) UpdateConsultationRequestStatus(c *gin.Context) {
+	psikologID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	id, ok := h.consultationIDParam(c)
+	if !ok {
+		return
+	}
+
+	var payload domain.UpdateKonsultasiStatusPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		h.logger.Warn("Invalid request payload", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	konsultasi, err := h.consultationUsecase.UpdateConsultationStatus(c.Request.Context(), psikologID, id, &payload)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to update consultation status")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Consultation status updated successfully", konsultasi)
+}
+
+// CancelConsultation menangani permintaan klien untuk membatalkan konsultasi.
+func (h *ConsultationHandler) CancelConsultation(c *gin.Context) {
+	klienID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	id, ok := h.consultationIDParam(c)
+	if !ok {
+		return
+	}
+
+	// Body bersifat opsional, hanya berisi alasan pembatalan
+	var payload domain.CancelKonsultasiPayload
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			h.logger.Warn("Invalid request payload", zap.Error(err))
+			response.Error(c, http.StatusBadRequest, "Invalid request payload", err)
+			return
+		}
+		if err := h.validator.Struct(payload); err != nil {
+			h.logger.Warn("Validation failed", zap.Error(err))
+			response.Error(c, http.StatusBadRequest, "Validation failed", err)
+			return
+		}
+	}
+
+	konsultasi, err := h.consultationUsecase.CancelConsultation(c.Request.Context(), klienID, id, &payload)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to cancel consultation")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Consultation cancelled successfully", konsultasi)
+}
+
+// GetClientHistory menangani permintaan klien untuk melihat riwayat konsultasinya.
+func (h *ConsultationHandler) GetClientHistory(c *gin.Context) {
+	klienID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	var query domain.ConsultationHistoryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.Warn("Invalid query parameters", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	if err := h.validator.Struct(query); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	history, err := h.consultationUsecase.GetClientHistory(c.Request.Context(), klienID, &query)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to get consultation history")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Consultation history retrieved successfully", history)
+}
+
+// consultationIDParam mengambil ID konsultasi dari path parameter.
+func (h *ConsultationHandler) consultationIDParam(c *gin.Context) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid consultation ID format", zap.String("id", idStr))
+		response.Error(c, http.StatusBadRequest, "Invalid consultation ID format", nil)
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/X3nonxe/gopsy-backend/internal/delivery/http/response"
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type DataPrivacyHandler struct {
+	privacyUsecase domain.DataPrivacyUsecase
+	validator      *validator.Validate
+	logger         *zap.Logger
+}
+
+// NewDataPrivacyHandler membuat instance baru dari DataPrivacyHandler.
+func NewDataPrivacyHandler(du domain.DataPrivacyUsecase, v *validator.Validate, logger *zap.Logger) *DataPrivacyHandler {
+	return &DataPrivacyHandler{
+		privacyUsecase: du,
+		validator:      v,
+		logger:         logger,
+	}
+}
+
+// RequestExport meminta salinan data pribadi user yang sedang login. Arsip dibuat di latar
+// belakang, sehingga response berisi ekspor dengan status pending.
+func (h *DataPrivacyHandler) RequestExport(c *gin.Context) {
+	userID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	export, err := h.privacyUsecase.RequestExport(c.Request.Context(), userID)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to request data export")
+		return
+	}
+
+	response.Success(c, http.StatusAccepted, "Data export requested, you will be notified by email when it is ready", export)
+}
+
+// ListExports menampilkan ekspor data pribadi milik user yang sedang login.
+func (h *DataPrivacyHandler) ListExports(c *gin.Context) {
+	userID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	exports, err := h.privacyUsecase.ListExports(c.Request.Context(), userID)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to retrieve data exports")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Data exports retrieved successfully", exports)
+}
+
+// GetExport menampilkan satu ekspor beserta link unduhan bertanda tangan jika arsip sudah siap.
+func (h *DataPrivacyHandler) GetExport(c *gin.Context) {
+	userID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	export, err := h.privacyUsecase.GetExport(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to retrieve data export")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Data export retrieved successfully", export)
+}
+
+// EraseAccount menghapus akun user yang sedang login setelah password dikonfirmasi.
+func (h *DataPrivacyHandler) EraseAccount(c *gin.Context) {
+	userID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	var payload domain.EraseAccountPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		h.logger.Warn("Invalid request payload", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	if err := h.privacyUsecase.EraseAccount(c.Request.Context(), userID, &payload); err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to delete account")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Account deleted successfully", nil)
+}
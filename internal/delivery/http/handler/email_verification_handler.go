@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/X3nonxe/gopsy-backend/internal/delivery/http/response"
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type EmailVerificationHandler struct {
+	verificationUsecase domain.EmailVerificationUsecase
+	validator           *validator.Validate
+	logger              *zap.Logger
+}
+
+// NewEmailVerificationHandler membuat instance baru dari EmailVerificationHandler.
+func NewEmailVerificationHandler(evu domain.EmailVerificationUsecase, v *validator.Validate, logger *zap.Logger) *EmailVerificationHandler {
+	return &EmailVerificationHandler{
+		verificationUsecase: evu,
+		validator:           v,
+		logger:              logger,
+	}
+}
+
+// VerifyEmail memverifikasi email memakai token dari email verifikasi.
+func (h *EmailVerificationHandler) VerifyEmail(c *gin.Context) {
+	var payload domain.VerifyEmailPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		h.logger.Warn("Invalid request payload", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	if err := h.verificationUsecase.VerifyEmail(c.Request.Context(), &payload); err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to verify email")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Email verified successfully", nil)
+}
+
+// ResendVerification mengirim ulang email verifikasi untuk user yang sedang login.
+func (h *EmailVerificationHandler) ResendVerification(c *gin.Context) {
+	userID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	if err := h.verificationUsecase.ResendVerification(c.Request.Context(), userID); err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to send verification email")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Verification email sent", nil)
+}
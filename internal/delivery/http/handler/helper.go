@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/X3nonxe/gopsy-backend/internal/delivery/http/response"
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// currentUserID mengambil ID user dari token JWT yang sudah diverifikasi middleware.
+func currentUserID(c *gin.Context, logger *zap.Logger) (uint, bool) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		logger.Warn("User ID not found in token")
+		response.Error(c, http.StatusUnauthorized, "User ID not found in token", nil)
+		return 0, false
+	}
+
+	id, ok := userID.(uint)
+	if !ok {
+		logger.Error("Invalid user ID type in token", zap.Any("userID", userID))
+		response.Error(c, http.StatusUnauthorized, "Invalid user ID format", nil)
+		return 0, false
+	}
+
+	return id, true
+}
+
+// userIDParam membaca ID user dari path parameter ":id".
+func userIDParam(c *gin.Context, logger *zap.Logger) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		logger.Warn("Invalid user ID format", zap.String("id", idStr))
+		response.Error(c, http.StatusBadRequest, "Invalid user ID format", nil)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// currentAccessToken mengambil klaim access token yang sudah diverifikasi middleware.
+func currentAccessToken(c *gin.Context, logger *zap.Logger) (*domain.AccessTokenClaims, bool) {
+	value, exists := c.Get("accessToken")
+	claims, ok := value.(*domain.AccessTokenClaims)
+	if !exists || !ok {
+		logger.Warn("Access token claims not found in context")
+		response.Error(c, http.StatusUnauthorized, "Invalid token", nil)
+		return nil, false
+	}
+	return claims, true
+}
+
+// clientInfo mengambil user agent dan IP klien untuk pencatatan sesi.
+func clientInfo(c *gin.Context) *domain.ClientInfo {
+	return &domain.ClientInfo{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+}
+
+// writeUsecaseError memetakan error dari usecase ke response HTTP.
+func writeUsecaseError(c *gin.Context, logger *zap.Logger, err error, fallbackMessage string) {
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) {
+		if domainErr.HTTPStatus >= http.StatusInternalServerError {
+			logger.Error("Domain error occurred", zap.Error(err))
+		} else {
+			logger.Warn("Domain error occurred", zap.Error(err))
+		}
+		response.Error(c, domainErr.HTTPStatus, domainErr.Message, nil)
+		return
+	}
+
+	logger.Error(fallbackMessage, zap.Error(err))
+	response.Error(c, http.StatusInternalServerError, fallbackMessage, nil)
+}
+
+// setETag menulis header ETag dari versi resource.
+func setETag(c *gin.Context, version uint) {
+	c.Header("ETag", fmt.Sprintf("%q", strconv.FormatUint(uint64(version), 10)))
+}
+
+// ifMatchVersion membaca versi resource dari header If-Match. Header wajib ada agar perubahan
+// tidak menimpa data yang sudah diubah perangkat lain.
+func ifMatchVersion(c *gin.Context, logger *zap.Logger) (uint, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		logger.Warn("Missing If-Match header")
+		response.Error(c, http.StatusPreconditionRequired, "If-Match header is required", nil)
+		return 0, false
+	}
+
+	tag := strings.Trim(strings.TrimPrefix(header, "W/"), `"`)
+	version, err := strconv.ParseUint(tag, 10, 32)
+	if err != nil {
+		logger.Warn("Invalid If-Match header", zap.String("if_match", header))
+		response.Error(c, http.StatusPreconditionFailed, "Invalid If-Match header", nil)
+		return 0, false
+	}
+
+	return uint(version), true
+}
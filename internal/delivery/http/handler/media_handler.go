@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type MediaHandler struct {
+	files  domain.SignedFileServer
+	logger *zap.Logger
+}
+
+// NewMediaHandler membuat handler yang melayani file dari penyimpanan lokal lewat URL bertanda tangan.
+func NewMediaHandler(files domain.SignedFileServer, logger *zap.Logger) *MediaHandler {
+	return &MediaHandler{
+		files:  files,
+		logger: logger,
+	}
+}
+
+// Serve mengirim file pada path "*key" jika query expires dan signature valid.
+func (h *MediaHandler) Serve(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+
+	file, err := h.files.Open(c.Request.Context(), key, c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to open file")
+		return
+	}
+	defer file.Content.Close()
+
+	// Key foto profil tidak pernah ditimpa, jadi isi file aman di-cache selama URL berlaku
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Header("Content-Type", file.ContentType)
+	c.Header("X-Content-Type-Options", "nosniff")
+	http.ServeContent(c.Writer, c.Request, "", file.ModTime, file.Content)
+}
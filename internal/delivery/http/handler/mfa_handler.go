@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/X3nonxe/gopsy-backend/internal/delivery/http/response"
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type MFAHandler struct {
+	mfaUsecase     domain.MFAUsecase
+	pictureUsecase domain.ProfilePictureUsecase
+	validator      *validator.Validate
+	logger         *zap.Logger
+}
+
+// NewMFAHandler membuat instance baru dari MFAHandler.
+func NewMFAHandler(mu domain.MFAUsecase, pu domain.ProfilePictureUsecase, v *validator.Validate, logger *zap.Logger) *MFAHandler {
+	return &MFAHandler{
+		mfaUsecase:     mu,
+		pictureUsecase: pu,
+		validator:      v,
+		logger:         logger,
+	}
+}
+
+// EnrollWithChallenge memulai enrollment MFA saat login untuk user yang diwajibkan memakai MFA.
+func (h *MFAHandler) EnrollWithChallenge(c *gin.Context) {
+	var payload domain.MFAChallengePayload
+	if !h.bind(c, &payload) {
+		return
+	}
+
+	enrollment, err := h.mfaUsecase.EnrollWithChallenge(c.Request.Context(), &payload)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to enroll MFA")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "MFA enrollment started", enrollment)
+}
+
+// VerifyChallenge menyelesaikan login dengan kode TOTP atau kode pemulihan.
+func (h *MFAHandler) VerifyChallenge(c *gin.Context) {
+	var payload domain.MFAVerifyPayload
+	if !h.bind(c, &payload) {
+		return
+	}
+
+	loginResponse, err := h.mfaUsecase.VerifyChallenge(c.Request.Context(), &payload, clientInfo(c))
+	if err != nil {
+		var throttled *domain.LoginThrottledError
+		if errors.As(err, &throttled) {
+			c.Header("Retry-After", strconv.Itoa(throttled.RetryAfterSeconds()))
+		}
+		writeUsecaseError(c, h.logger, err, "Failed to verify MFA")
+		return
+	}
+
+	withProfilePicture(h.pictureUsecase, loginResponse.User)
+	response.Success(c, http.StatusOK, "Login successful", loginResponse)
+}
+
+// Enroll membuat secret TOTP baru untuk user yang sedang login.
+func (h *MFAHandler) Enroll(c *gin.Context) {
+	userID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	enrollment, err := h.mfaUsecase.Enroll(c.Request.Context(), userID)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to enroll MFA")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "MFA enrollment started", enrollment)
+}
+
+// ConfirmEnrollment mengaktifkan MFA dan mengembalikan kode pemulihan.
+func (h *MFAHandler) ConfirmEnrollment(c *gin.Context) {
+	userID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	var payload domain.MFACodePayload
+	if !h.bind(c, &payload) {
+		return
+	}
+
+	codes, err := h.mfaUsecase.ConfirmEnrollment(c.Request.Context(), userID, &payload)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to enable MFA")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "MFA enabled successfully", codes)
+}
+
+// RegenerateRecoveryCodes mengganti kode pemulihan user yang sedang login.
+func (h *MFAHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	userID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	var payload domain.MFACodePayload
+	if !h.bind(c, &payload) {
+		return
+	}
+
+	codes, err := h.mfaUsecase.RegenerateRecoveryCodes(c.Request.Context(), userID, &payload)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to generate recovery codes")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Recovery codes generated successfully", codes)
+}
+
+// Disable menonaktifkan MFA user yang sedang login.
+func (h *MFAHandler) Disable(c *gin.Context) {
+	userID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	var payload domain.MFACodePayload
+	if !h.bind(c, &payload) {
+		return
+	}
+
+	if err := h.mfaUsecase.Disable(c.Request.Context(), userID, &payload); err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to disable MFA")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "MFA disabled successfully", nil)
+}
+
+// GetPolicies menampilkan kebijakan MFA per role.
+func (h *MFAHandler) GetPolicies(c *gin.Context) {
+	policies, err := h.mfaUsecase.GetPolicies(c.Request.Context())
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to retrieve MFA policies")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "MFA policies retrieved successfully", policies)
+}
+
+// SetPolicy mengatur apakah MFA wajib untuk role pada path.
+func (h *MFAHandler) SetPolicy(c *gin.Context) {
+	var payload domain.MFAPolicyPayload
+	if !h.bind(c, &payload) {
+		return
+	}
+
+	policy, err := h.mfaUsecase.SetPolicy(c.Request.Context(), c.Param("role"), &payload)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to update MFA policy")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "MFA policy updated successfully", policy)
+}
+
+// bind membaca dan memvalidasi body JSON. Response error sudah ditulis jika hasilnya false.
+func (h *MFAHandler) bind(c *gin.Context, payload interface{}) bool {
+	if err := c.ShouldBindJSON(payload); err != nil {
+		h.logger.Warn("Invalid request payload", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Invalid request payload", err)
+		return false
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Validation failed", err)
+		return false
+	}
+	return true
+}
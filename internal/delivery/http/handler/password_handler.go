@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/X3nonxe/gopsy-backend/internal/delivery/http/response"
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type PasswordHandler struct {
+	passwordUsecase domain.PasswordUsecase
+	validator       *validator.Validate
+	logger          *zap.Logger
+}
+
+// NewPasswordHandler membuat instance baru dari PasswordHandler.
+func NewPasswordHandler(pu domain.PasswordUsecase, v *validator.Validate, logger *zap.Logger) *PasswordHandler {
+	return &PasswordHandler{
+		passwordUsecase: pu,
+		validator:       v,
+		logger:          logger,
+	}
+}
+
+// ForgotPassword mengirim link reset password. Respons selalu sama agar email yang terdaftar
+// tidak bisa ditebak.
+func (h *PasswordHandler) ForgotPassword(c *gin.Context) {
+	var payload domain.ForgotPasswordPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		h.logger.Warn("Invalid request payload", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	if err := h.passwordUsecase.ForgotPassword(c.Request.Context(), &payload); err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to process password reset request")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "If the email is registered, a password reset link has been sent", nil)
+}
+
+// ResetPassword mengganti password memakai token dari email reset.
+func (h *PasswordHandler) ResetPassword(c *gin.Context) {
+	var payload domain.ResetPasswordPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		h.logger.Warn("Invalid request payload", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	if err := h.passwordUsecase.ResetPassword(c.Request.Context(), &payload); err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to reset password")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Password reset successfully", nil)
+}
+
+// ChangePassword mengganti password user yang sedang login. Sesi lain dicabut, sesi saat ini tetap aktif.
+func (h *PasswordHandler) ChangePassword(c *gin.Context) {
+	claims, ok := currentAccessToken(c, h.logger)
+	if !ok {
+		return
+	}
+
+	var payload domain.ChangePasswordPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		h.logger.Warn("Invalid request payload", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	if err := h.passwordUsecase.ChangePassword(c.Request.Context(), claims.UserID, claims.SessionID, &payload); err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to change password")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Password changed successfully", nil)
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/X3nonxe/gopsy-backend/internal/delivery/http/response"
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type PermissionHandler struct {
+	permissionUsecase domain.PermissionUsecase
+	validator         *validator.Validate
+	logger            *zap.Logger
+}
+
+// NewPermissionHandler membuat instance baru dari PermissionHandler.
+func NewPermissionHandler(pu domain.PermissionUsecase, v *validator.Validate, logger *zap.Logger) *PermissionHandler {
+	return &PermissionHandler{
+		permissionUsecase: pu,
+		validator:         v,
+		logger:            logger,
+	}
+}
+
+// ListPermissions menampilkan katalog permission yang bisa diberikan ke role.
+func (h *PermissionHandler) ListPermissions(c *gin.Context) {
+	response.Success(c, http.StatusOK, "Permissions retrieved successfully", h.permissionUsecase.ListPermissions(c.Request.Context()))
+}
+
+// ListRolePermissions menampilkan permission milik setiap role.
+func (h *PermissionHandler) ListRolePermissions(c *gin.Context) {
+	roles, err := h.permissionUsecase.ListRolePermissions(c.Request.Context())
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to retrieve role permissions")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Role permissions retrieved successfully", roles)
+}
+
+// SetRolePermissions mengganti seluruh permission milik role pada path.
+func (h *PermissionHandler) SetRolePermissions(c *gin.Context) {
+	var payload domain.SetRolePermissionsPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		h.logger.Warn("Invalid request payload", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	updated, err := h.permissionUsecase.SetRolePermissions(c.Request.Context(), c.Param("role"), &payload)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to update role permissions")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Role permissions updated successfully", updated)
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/X3nonxe/gopsy-backend/internal/delivery/http/response"
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// multipartOverhead adalah ruang tambahan di atas batas ukuran file untuk boundary dan header
+// bagian multipart.
+const multipartOverhead = 64 << 10
+
+type ProfilePictureHandler struct {
+	pictureUsecase domain.ProfilePictureUsecase
+	logger         *zap.Logger
+}
+
+// NewProfilePictureHandler membuat instance baru dari ProfilePictureHandler.
+func NewProfilePictureHandler(pu domain.ProfilePictureUsecase, logger *zap.Logger) *ProfilePictureHandler {
+	return &ProfilePictureHandler{
+		pictureUsecase: pu,
+		logger:         logger,
+	}
+}
+
+// Upload menerima foto profil dari field multipart "file" dan menggantikan foto sebelumnya.
+func (h *ProfilePictureHandler) Upload(c *gin.Context) {
+	userID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	maxBytes := h.pictureUsecase.MaxUploadBytes()
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes+multipartOverhead)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeUsecaseError(c, h.logger, domain.ErrProfilePictureTooLarge, "Failed to upload profile picture")
+			return
+		}
+		h.logger.Warn("Invalid multipart upload", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Multipart field \"file\" is required", nil)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to read uploaded file")
+		return
+	}
+	defer file.Close()
+
+	// Satu byte lebih dari batas cukup untuk mendeteksi file yang terlalu besar
+	data, err := io.ReadAll(io.LimitReader(file, maxBytes+1))
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to read uploaded file")
+		return
+	}
+
+	user, err := h.pictureUsecase.Upload(c.Request.Context(), userID, data)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to upload profile picture")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Profile picture uploaded successfully", withProfilePicture(h.pictureUsecase, domain.NewUserResponse(user)))
+}
+
+// Delete menghapus foto profil user yang sedang login.
+func (h *ProfilePictureHandler) Delete(c *gin.Context) {
+	userID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	user, err := h.pictureUsecase.Delete(c.Request.Context(), userID)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to delete profile picture")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Profile picture deleted successfully", domain.NewUserResponse(user))
+}
+
+// withProfilePicture mengisi URL bertanda tangan foto profil pada user response.
+func withProfilePicture(pictures domain.ProfilePictureUsecase, user *domain.UserResponse) *domain.UserResponse {
+	if user != nil {
+		user.ProfilePicture = pictures.URLs(user.ProfilePictureKey)
+	}
+	return user
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/X3nonxe/gopsy-backend/internal/delivery/http/response"
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type PsychologistProfileHandler struct {
+	profileUsecase domain.PsychologistProfileUsecase
+	validator      *validator.Validate
+	logger         *zap.Logger
+}
+
+// NewPsychologistProfileHandler membuat instance baru dari PsychologistProfileHandler.
+func NewPsychologistProfileHandler(pu domain.PsychologistProfileUsecase, v *validator.Validate, logger *zap.Logger) *PsychologistProfileHandler {
+	return &PsychologistProfileHandler{
+		profileUsecase: pu,
+		validator:      v,
+		logger:         logger,
+	}
+}
+
+// GetOwnProfile menampilkan profil profesional dan status verifikasi milik psikolog yang sedang login.
+func (h *PsychologistProfileHandler) GetOwnProfile(c *gin.Context) {
+	userID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	profile, err := h.profileUsecase.GetOwnProfile(c.Request.Context(), userID)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to retrieve psychologist profile")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Psychologist profile retrieved successfully", profile)
+}
+
+// UpdateOwnProfile menangani permintaan psikolog untuk mengisi atau mengubah profil profesionalnya.
+func (h *PsychologistProfileHandler) UpdateOwnProfile(c *gin.Context) {
+	userID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	var payload domain.PsychologistProfilePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		h.logger.Warn("Invalid request payload", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	profile, err := h.profileUsecase.UpdateOwnProfile(c.Request.Context(), userID, &payload)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to update psychologist profile")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Psychologist profile updated successfully", profile)
+}
+
+// ListProfiles menampilkan profil psikolog untuk ditinjau admin dengan filter ?status= opsional.
+func (h *PsychologistProfileHandler) ListProfiles(c *gin.Context) {
+	var query domain.PsychologistProfileQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.Warn("Invalid query parameters", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	if err := h.validator.Struct(query); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	result, err := h.profileUsecase.ListProfiles(c.Request.Context(), &query)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to retrieve psychologist profiles")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Psychologist profiles retrieved successfully", result)
+}
+
+// GetProfile menampilkan profil psikolog pada path untuk ditinjau admin.
+func (h *PsychologistProfileHandler) GetProfile(c *gin.Context) {
+	userID, ok := userIDParam(c, h.logger)
+	if !ok {
+		return
+	}
+
+	profile, err := h.profileUsecase.GetProfile(c.Request.Context(), userID)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to retrieve psychologist profile")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Psychologist profile retrieved successfully", profile)
+}
+
+// Review menangani keputusan admin untuk memverifikasi atau menolak profil psikolog pada path.
+func (h *PsychologistProfileHandler) Review(c *gin.Context) {
+	reviewerID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	userID, ok := userIDParam(c, h.logger)
+	if !ok {
+		return
+	}
+
+	var payload domain.PsychologistVerificationPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		h.logger.Warn("Invalid request payload", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	profile, err := h.profileUsecase.Review(c.Request.Context(), reviewerID, userID, &payload)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to review psychologist profile")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Psychologist profile reviewed successfully", profile)
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/X3nonxe/gopsy-backend/internal/delivery/http/response"
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type SessionHandler struct {
+	sessionUsecase domain.SessionUsecase
+	logger         *zap.Logger
+}
+
+// NewSessionHandler membuat instance baru dari SessionHandler.
+func NewSessionHandler(su domain.SessionUsecase, logger *zap.Logger) *SessionHandler {
+	return &SessionHandler{
+		sessionUsecase: su,
+		logger:         logger,
+	}
+}
+
+// ListOwnSessions menampilkan sesi aktif user yang sedang login.
+func (h *SessionHandler) ListOwnSessions(c *gin.Context) {
+	claims, ok := currentAccessToken(c, h.logger)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.sessionUsecase.ListSessions(c.Request.Context(), claims.UserID, claims.SessionID)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to retrieve sessions")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Sessions retrieved successfully", sessions)
+}
+
+// RevokeOwnSession mencabut satu sesi milik user yang sedang login.
+func (h *SessionHandler) RevokeOwnSession(c *gin.Context) {
+	claims, ok := currentAccessToken(c, h.logger)
+	if !ok {
+		return
+	}
+
+	if err := h.sessionUsecase.RevokeSession(c.Request.Context(), claims.UserID, c.Param("session_id")); err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to revoke session")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Session revoked successfully", nil)
+}
+
+// RevokeOtherOwnSessions mencabut semua sesi user kecuali sesi yang sedang dipakai.
+func (h *SessionHandler) RevokeOtherOwnSessions(c *gin.Context) {
+	claims, ok := currentAccessToken(c, h.logger)
+	if !ok {
+		return
+	}
+
+	if err := h.sessionUsecase.RevokeOtherSessions(c.Request.Context(), claims.UserID, claims.SessionID); err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to revoke sessions")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Other sessions revoked successfully", nil)
+}
+
+// ListUserSessions menampilkan sesi aktif user tertentu untuk admin.
+func (h *SessionHandler) ListUserSessions(c *gin.Context) {
+	userID, ok := userIDParam(c, h.logger)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.sessionUsecase.ListSessions(c.Request.Context(), userID, "")
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to retrieve sessions")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Sessions retrieved successfully", sessions)
+}
+
+// RevokeUserSession mencabut satu sesi user tertentu untuk admin.
+func (h *SessionHandler) RevokeUserSession(c *gin.Context) {
+	userID, ok := userIDParam(c, h.logger)
+	if !ok {
+		return
+	}
+
+	if err := h.sessionUsecase.RevokeSession(c.Request.Context(), userID, c.Param("session_id")); err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to revoke session")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Session revoked successfully", nil)
+}
+
+// RevokeAllUserSessions mencabut semua sesi user tertentu untuk admin.
+func (h *SessionHandler) RevokeAllUserSessions(c *gin.Context) {
+	userID, ok := userIDParam(c, h.logger)
+	if !ok {
+		return
+	}
+
+	if err := h.sessionUsecase.RevokeOtherSessions(c.Request.Context(), userID, ""); err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to revoke sessions")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "All sessions revoked successfully", nil)
+}
@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/X3nonxe/gopsy-backend/internal/delivery/http/response"
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type UserAdminHandler struct {
+	adminUsecase   domain.UserAdminUsecase
+	pictureUsecase domain.ProfilePictureUsecase
+	validator      *validator.Validate
+	logger         *zap.Logger
+}
+
+// NewUserAdminHandler membuat instance baru dari UserAdminHandler.
+func NewUserAdminHandler(au domain.UserAdminUsecase, pu domain.ProfilePictureUsecase, v *validator.Validate, logger *zap.Logger) *UserAdminHandler {
+	return &UserAdminHandler{
+		adminUsecase:   au,
+		pictureUsecase: pu,
+		validator:      v,
+		logger:         logger,
+	}
+}
+
+// ListUsers menampilkan user dengan filter ?search=, ?role=, dan ?status= opsional.
+func (h *UserAdminHandler) ListUsers(c *gin.Context) {
+	var query domain.AdminUserQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.Warn("Invalid query parameters", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	if err := h.validator.Struct(query); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	result, err := h.adminUsecase.ListUsers(c.Request.Context(), &query)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to retrieve users")
+		return
+	}
+
+	for _, item := range result.Items {
+		withProfilePicture(h.pictureUsecase, item.UserResponse)
+	}
+	response.Success(c, http.StatusOK, "Users retrieved successfully", result)
+}
+
+// GetUser menampilkan detail user pada path.
+func (h *UserAdminHandler) GetUser(c *gin.Context) {
+	userID, ok := userIDParam(c, h.logger)
+	if !ok {
+		return
+	}
+
+	user, err := h.adminUsecase.GetUser(c.Request.Context(), userID)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to retrieve user")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "User retrieved successfully", h.userResponse(user))
+}
+
+// ChangeRole mengganti role user pada path.
+func (h *UserAdminHandler) ChangeRole(c *gin.Context) {
+	adminID, userID, ok := h.adminAndUserID(c)
+	if !ok {
+		return
+	}
+
+	var payload domain.ChangeRolePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		h.logger.Warn("Invalid request payload", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	user, err := h.adminUsecase.ChangeRole(c.Request.Context(), adminID, userID, &payload)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to change role")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "User role changed successfully", h.userResponse(user))
+}
+
+// Deactivate menonaktifkan akun user pada path dan mencabut semua sesinya.
+func (h *UserAdminHandler) Deactivate(c *gin.Context) {
+	adminID, userID, ok := h.adminAndUserID(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.adminUsecase.Deactivate(c.Request.Context(), adminID, userID)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to deactivate user")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "User deactivated successfully", h.userResponse(user))
+}
+
+// Reactivate mengaktifkan kembali akun user pada path.
+func (h *UserAdminHandler) Reactivate(c *gin.Context) {
+	adminID, userID, ok := h.adminAndUserID(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.adminUsecase.Reactivate(c.Request.Context(), adminID, userID)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to reactivate user")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "User reactivated successfully", h.userResponse(user))
+}
+
+// DeleteUser menghapus akun user pada path.
+func (h *UserAdminHandler) DeleteUser(c *gin.Context) {
+	adminID, userID, ok := h.adminAndUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.adminUsecase.DeleteUser(c.Request.Context(), adminID, userID); err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to delete user")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "User deleted successfully", nil)
+}
+
+func (h *UserAdminHandler) adminAndUserID(c *gin.Context) (uint, uint, bool) {
+	adminID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return 0, 0, false
+	}
+	userID, ok := userIDParam(c, h.logger)
+	if !ok {
+		return 0, 0, false
+	}
+	return adminID, userID, true
+}
+
+func (h *UserAdminHandler) userResponse(user *domain.User) *domain.AdminUserResponse {
+	resp := domain.NewAdminUserResponse(user)
+	withProfilePicture(h.pictureUsecase, resp.UserResponse)
+	return resp
+}
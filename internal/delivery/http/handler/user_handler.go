@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/X3nonxe/gopsy-backend/internal/delivery/http/response"
 	"github.com/X3nonxe/gopsy-backend/internal/domain"
@@ -11,16 +13,18 @@ import (
 )
 
 type UserHandler struct {
-	userUsecase domain.UserUsecase
-	validator   *validator.Validate
-	logger      *zap.Logger
+	userUsecase    domain.UserUsecase
+	pictureUsecase domain.ProfilePictureUsecase
+	validator      *validator.Validate
+	logger         *zap.Logger
 }
 
-func NewUserHandler(uu domain.UserUsecase, v *validator.Validate, logger *zap.Logger) *UserHandler {
+func NewUserHandler(uu domain.UserUsecase, pu domain.ProfilePictureUsecase, v *validator.Validate, logger *zap.Logger) *UserHandler {
 	return &UserHandler{
-		userUsecase: uu,
-		validator:   v,
-		logger:      logger,
+		userUsecase:    uu,
+		pictureUsecase: pu,
+		validator:      v,
+		logger:         logger,
 	}
 }
 
@@ -91,16 +95,47 @@ func (h *UserHandler) Login(c *gin.Context) {
 		return
 	}
 
-	loginResponse, err := h.userUsecase.Login(c.Request.Context(), &payload)
+	loginResponse, err := h.userUsecase.Login(c.Request.Context(), &payload, clientInfo(c))
 	if err != nil {
+		var throttled *domain.LoginThrottledError
+		if errors.As(err, &throttled) {
+			c.Header("Retry-After", strconv.Itoa(throttled.RetryAfterSeconds()))
+		}
+		var domainErr *domain.DomainError
+		if errors.As(err, &domainErr) {
+			writeUsecaseError(c, h.logger, err, "Login failed")
+			return
+		}
+
 		statusCode := h.getStatusCodeFromError(err)
 		response.Error(c, statusCode, "Login failed", err)
 		return
 	}
 
+	withProfilePicture(h.pictureUsecase, loginResponse.User)
+	if loginResponse.MFARequired {
+		response.Success(c, http.StatusOK, "MFA verification required", loginResponse)
+		return
+	}
+
 	response.Success(c, http.StatusOK, "Login successful", loginResponse)
 }
 
+// UnlockLogin membuka penguncian login user akibat terlalu banyak password atau kode MFA salah.
+func (h *UserHandler) UnlockLogin(c *gin.Context) {
+	userID, ok := userIDParam(c, h.logger)
+	if !ok {
+		return
+	}
+
+	if err := h.userUsecase.UnlockLogin(c.Request.Context(), userID); err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to unlock login")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Login unlocked successfully", nil)
+}
+
 func (h *UserHandler) GetProfile(c *gin.Context) {
 	userID, exists := c.Get("userID")
 	if !exists {
@@ -120,8 +155,64 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 	response.Success(c, http.StatusOK, "Profile retrieved successfully", userResponse)
 }
 
+// UpdateProfile mengganti data profil user yang sedang login.
+func (h *UserHandler) UpdateProfile(c *gin.Context) {
+	userID, ok := currentUserID(c, h.logger)
+	if !ok {
+		return
+	}
+
+	var payload domain.UpdateProfilePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		h.logger.Warn("Invalid request payload", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	user, err := h.userUsecase.UpdateProfile(c.Request.Context(), userID, &payload)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to update profile")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Profile updated successfully", h.sanitizeUserResponse(user))
+}
+
+// GetAvailablePsychologists menampilkan direktori psikolog yang dapat difilter berdasarkan hari, jam, dan nama.
+func (h *UserHandler) GetAvailablePsychologists(c *gin.Context) {
+	var query domain.PsychologistDirectoryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	if err := h.validator.Struct(query); err != nil {
+		response.Error(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	result, err := h.userUsecase.GetAvailablePsychologists(c.Request.Context(), &query)
+	if err != nil {
+		writeUsecaseError(c, h.logger, err, "Failed to get psychologists")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Psychologists retrieved successfully", result)
+}
+
 // Helper methods
 func (h *UserHandler) getStatusCodeFromError(err error) int {
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr.HTTPStatus
+	}
+
 	switch err {
 	case domain.ErrEmailAlreadyExists:
 		return http.StatusConflict
@@ -137,12 +228,5 @@ func (h *UserHandler) getStatusCodeFromError(err error) int {
 }
 
 func (h *UserHandler) sanitizeUserResponse(user *domain.User) *domain.UserResponse {
-	return &domain.UserResponse{
-		ID:        user.ID,
-		Username:  user.Username,
-		Email:     user.Email,
-		Role:      user.Role,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-	}
+	return withProfilePicture(h.pictureUsecase, domain.NewUserResponse(user))
 }
@@ -9,6 +9,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_jwt"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
@@ -103,8 +105,9 @@ func CORS() gin.HandlerFunc {
 			c.Header("Access-Control-Allow-Origin", origin)
 		}
 
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Request-ID")
+		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Request-ID, If-Match")
+		c.Header("Access-Control-Expose-Headers", "ETag")
 		c.Header("Access-Control-Allow-Credentials", "true")
 
 		if c.Request.Method == "OPTIONS" {
@@ -147,7 +150,9 @@ func RateLimiter() gin.HandlerFunc {
 	}
 }
 
-func AuthMiddleware(secretKey string) gin.HandlerFunc {
+// AuthMiddleware memverifikasi access token dan menolak token yang sudah dicabut lewat logout atau
+// pencabutan sesi.
+func AuthMiddleware(keys *app_jwt.KeySet, revocations domain.TokenRevocationStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -164,13 +169,8 @@ func AuthMiddleware(secretKey string) gin.HandlerFunc {
 		}
 
 		tokenString := parts[1]
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-			return []byte(secretKey), nil
-		})
-
+		// Kunci verifikasi dipilih berdasarkan kid sehingga token dari kunci sebelumnya tetap diterima
+		token, err := jwt.Parse(tokenString, keys.Keyfunc, jwt.WithValidMethods(keys.ValidMethods()))
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
 			c.Abort()
@@ -195,8 +195,64 @@ func AuthMiddleware(secretKey string) gin.HandlerFunc {
 				return
 			}
 
+			jti, ok := claims["jti"].(string)
+			if !ok || jti == "" {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+				c.Abort()
+				return
+			}
+
+			sessionID, ok := claims["sid"].(string)
+			if !ok || sessionID == "" {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+				c.Abort()
+				return
+			}
+
+			var issuedAt, expiresAt time.Time
+			if iat, ok := claims["iat"].(float64); ok {
+				issuedAt = app_jwt.ParseNumericDate(iat)
+			}
+			if exp, ok := claims["exp"].(float64); ok {
+				expiresAt = time.Unix(int64(exp), 0)
+			}
+
+			// Cek denylist pada setiap request agar logout langsung berlaku
+			ctx := c.Request.Context()
+			revoked, err := revocations.IsTokenRevoked(ctx, jti)
+			if err == nil && !revoked {
+				revoked, err = revocations.IsSessionRevoked(ctx, sessionID)
+			}
+			if err == nil && !revoked {
+				revoked, err = revocations.IsUserTokenRevoked(ctx, uint(userIDFloat), issuedAt)
+			}
+			if err != nil {
+				slog.Error("Failed to check token revocation", "error", err)
+				c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Unable to verify token"})
+				c.Abort()
+				return
+			}
+			if revoked {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
+				c.Abort()
+				return
+			}
+
+			role, _ := claims["role"].(string)
+			emailVerified, _ := claims["email_verified"].(bool)
 			c.Set("userID", uint(userIDFloat))
 			c.Set("role", claims["role"])
+			c.Set("emailVerified", emailVerified)
+			c.Set("sessionID", sessionID)
+			c.Set("accessToken", &domain.AccessTokenClaims{
+				UserID:        uint(userIDFloat),
+				Role:          role,
+				EmailVerified: emailVerified,
+				JTI:           jti,
+				SessionID:     sessionID,
+				IssuedAt:      issuedAt,
+				ExpiresAt:     expiresAt,
+			})
 			c.Next()
 		} else {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
@@ -205,35 +261,46 @@ func AuthMiddleware(secretKey string) gin.HandlerFunc {
 	}
 }
 
-func RoleAuthMiddleware(requiredRoles ...string) gin.HandlerFunc {
+// RequirePermission menolak request jika role user tidak memiliki semua permission yang diminta.
+// Pemetaan role ke permission dibaca dari checker sehingga perubahan oleh admin berlaku tanpa
+// mengubah router.
+func RequirePermission(checker domain.PermissionChecker, requiredPermissions ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		role, exists := c.Get("role")
-		if !exists {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Role not found in token"})
-			c.Abort()
-			return
-		}
-
+		role, _ := c.Get("role")
 		userRole, ok := role.(string)
-		if !ok {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Invalid role format in token"})
+		if !ok || userRole == "" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Role not found in token"})
 			c.Abort()
 			return
 		}
 
-		// Check if user role is in required roles
-		hasPermission := false
-		for _, requiredRole := range requiredRoles {
-			if userRole == requiredRole {
-				hasPermission = true
-				break
+		for _, permission := range requiredPermissions {
+			granted, err := checker.HasPermission(c.Request.Context(), userRole, permission)
+			if err != nil {
+				slog.Error("Failed to check permission", "error", err, "permission", permission)
+				c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Unable to verify permissions"})
+				c.Abort()
+				return
+			}
+			if !granted {
+				c.JSON(http.StatusForbidden, gin.H{
+					"error": fmt.Sprintf("Access denied. Required permission: %s", permission),
+				})
+				c.Abort()
+				return
 			}
 		}
 
-		if !hasPermission {
-			c.JSON(http.StatusForbidden, gin.H{
-				"error": fmt.Sprintf("Access denied. Required roles: %v", requiredRoles),
-			})
+		c.Next()
+	}
+}
+
+// RequireVerifiedEmail menolak user yang belum memverifikasi email. Status verifikasi dibaca dari
+// access token, sehingga setelah verifikasi klien perlu melakukan refresh token.
+func RequireVerifiedEmail() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if verified, _ := c.Get("emailVerified"); verified != true {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Email address is not verified"})
 			c.Abort()
 			return
 		}
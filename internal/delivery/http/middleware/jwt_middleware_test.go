@@ -0,0 +1,101 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/X3nonxe/gopsy-backend/internal/delivery/http/middleware"
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/internal/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// newPermissionRouter memasang RequirePermission di belakang middleware yang mengisi role seperti
+// JWTMiddleware. Role kosong berarti token tidak membawa role.
+func newPermissionRouter(checker domain.PermissionChecker, role string, permissions ...string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if role != "" {
+			c.Set("role", role)
+		}
+		c.Next()
+	})
+	router.GET("/protected", middleware.RequirePermission(checker, permissions...), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func servePermissionRequest(router *gin.Engine) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/protected", nil))
+	return recorder
+}
+
+func TestRequirePermission(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockChecker := mocks.NewMockPermissionChecker(mockCtrl)
+
+	t.Run("Granted", func(t *testing.T) {
+		mockChecker.EXPECT().
+			HasPermission(gomock.Any(), domain.RoleKlien, domain.PermissionConsultationsBook).
+			Return(true, nil).
+			Times(1)
+
+		recorder := servePermissionRequest(newPermissionRouter(mockChecker, domain.RoleKlien, domain.PermissionConsultationsBook))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("Every Permission Is Required", func(t *testing.T) {
+		gomock.InOrder(
+			mockChecker.EXPECT().
+				HasPermission(gomock.Any(), domain.RoleAdmin, domain.PermissionUsersManage).
+				Return(true, nil),
+			mockChecker.EXPECT().
+				HasPermission(gomock.Any(), domain.RoleAdmin, domain.PermissionRolesManage).
+				Return(false, nil),
+		)
+
+		recorder := servePermissionRequest(newPermissionRouter(mockChecker, domain.RoleAdmin,
+			domain.PermissionUsersManage, domain.PermissionRolesManage))
+
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), domain.PermissionRolesManage)
+	})
+
+	t.Run("Denied", func(t *testing.T) {
+		mockChecker.EXPECT().
+			HasPermission(gomock.Any(), domain.RoleKlien, domain.PermissionAvailabilityWrite).
+			Return(false, nil).
+			Times(1)
+
+		recorder := servePermissionRequest(newPermissionRouter(mockChecker, domain.RoleKlien, domain.PermissionAvailabilityWrite))
+
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "Required permission: "+domain.PermissionAvailabilityWrite)
+	})
+
+	t.Run("Missing Role", func(t *testing.T) {
+		recorder := servePermissionRequest(newPermissionRouter(mockChecker, "", domain.PermissionConsultationsBook))
+
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+	})
+
+	t.Run("Checker Failure", func(t *testing.T) {
+		mockChecker.EXPECT().
+			HasPermission(gomock.Any(), domain.RolePsikolog, domain.PermissionAvailabilityWrite).
+			Return(false, assert.AnError).
+			Times(1)
+
+		recorder := servePermissionRequest(newPermissionRouter(mockChecker, domain.RolePsikolog, domain.PermissionAvailabilityWrite))
+
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	})
+}
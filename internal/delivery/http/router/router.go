@@ -3,50 +3,174 @@ package router
 import (
 	"github.com/X3nonxe/gopsy-backend/internal/delivery/http/handler"
 	"github.com/X3nonxe/gopsy-backend/internal/delivery/http/middleware"
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_jwt"
 	"github.com/gin-gonic/gin"
 )
 
 func SetupRouter(
-	engine *gin.Engine, 
-	userHandler *handler.UserHandler, 
+	engine *gin.Engine,
+	userHandler *handler.UserHandler,
+	userAdminHandler *handler.UserAdminHandler,
+	authHandler *handler.AuthHandler,
+	passwordHandler *handler.PasswordHandler,
+	emailVerificationHandler *handler.EmailVerificationHandler,
+	mfaHandler *handler.MFAHandler,
+	sessionHandler *handler.SessionHandler,
+	permissionHandler *handler.PermissionHandler,
+	psychologistProfileHandler *handler.PsychologistProfileHandler,
+	profilePictureHandler *handler.ProfilePictureHandler,
+	mediaHandler *handler.MediaHandler,
+	dataPrivacyHandler *handler.DataPrivacyHandler,
 	availabilityHandler *handler.AvailabilityHandler,
-	jwtSecret string,
-	) {
+	consultationHandler *handler.ConsultationHandler,
+	jwtKeys *app_jwt.KeySet,
+	tokenRevocations domain.TokenRevocationStore,
+	permissions domain.PermissionChecker,
+) {
+
+	authMiddleware := middleware.AuthMiddleware(jwtKeys, tokenRevocations)
+	requirePermission := func(required ...string) gin.HandlerFunc {
+		return middleware.RequirePermission(permissions, required...)
+	}
+
+	engine.GET("/.well-known/jwks.json", authHandler.JWKS)
+
+	// File dari penyimpanan lokal; akses dibatasi tanda tangan URL, bukan token login
+	if mediaHandler != nil {
+		engine.GET("/media/*key", mediaHandler.Serve)
+	}
 
 	authRoutes := engine.Group("/auth")
 	{
 		authRoutes.POST("/register", userHandler.Register)
 		authRoutes.POST("/login", userHandler.Login)
+		authRoutes.POST("/refresh", authHandler.Refresh)
+		authRoutes.POST("/forgot-password", passwordHandler.ForgotPassword)
+		authRoutes.POST("/reset-password", passwordHandler.ResetPassword)
+		authRoutes.POST("/verify-email", emailVerificationHandler.VerifyEmail)
+		authRoutes.POST("/mfa/enroll", mfaHandler.EnrollWithChallenge)
+		authRoutes.POST("/mfa/verify", mfaHandler.VerifyChallenge)
+		authRoutes.POST("/logout", authMiddleware, authHandler.Logout)
+		authRoutes.POST("/logout-all", authMiddleware, authHandler.LogoutAll)
 	}
 
-	authMiddleware := middleware.AuthMiddleware(jwtSecret)
-
 	apiRoutes := engine.Group("/api")
 	apiRoutes.Use(authMiddleware)
 	{
 		apiRoutes.GET("/profile", userHandler.GetProfile)
-		// apiRoutes.PUT("/profile", userHandler.UpdateProfile)
+		apiRoutes.PUT("/profile", userHandler.UpdateProfile)
+		apiRoutes.DELETE("/profile", requirePermission(domain.PermissionAccountDelete), dataPrivacyHandler.EraseAccount)
+		apiRoutes.PUT("/profile/picture", profilePictureHandler.Upload)
+		apiRoutes.DELETE("/profile/picture", profilePictureHandler.Delete)
+		apiRoutes.PUT("/profile/password", passwordHandler.ChangePassword)
+		apiRoutes.POST("/profile/email-verification", emailVerificationHandler.ResendVerification)
+		apiRoutes.GET("/profile/sessions", sessionHandler.ListOwnSessions)
+		apiRoutes.DELETE("/profile/sessions", sessionHandler.RevokeOtherOwnSessions)
+		apiRoutes.DELETE("/profile/sessions/:session_id", sessionHandler.RevokeOwnSession)
+		apiRoutes.POST("/profile/data-exports", dataPrivacyHandler.RequestExport)
+		apiRoutes.GET("/profile/data-exports", dataPrivacyHandler.ListExports)
+		apiRoutes.GET("/profile/data-exports/:id", dataPrivacyHandler.GetExport)
+	}
+
+	mfaRoutes := apiRoutes.Group("/profile/mfa", requirePermission(domain.PermissionMFAManage))
+	{
+		mfaRoutes.POST("/enroll", mfaHandler.Enroll)
+		mfaRoutes.POST("/confirm", mfaHandler.ConfirmEnrollment)
+		mfaRoutes.POST("/recovery-codes", mfaHandler.RegenerateRecoveryCodes)
+		mfaRoutes.DELETE("", mfaHandler.Disable)
 	}
 
 	adminRoutes := apiRoutes.Group("/admin")
-	adminRoutes.Use(middleware.RoleAuthMiddleware("admin"))
+
+	userAdminRoutes := adminRoutes.Group("", requirePermission(domain.PermissionUsersManage))
 	{
-		adminRoutes.POST("/register-psychologist", userHandler.RegisterPsychologist)
+		userAdminRoutes.POST("/register-psychologist", userHandler.RegisterPsychologist)
+		userAdminRoutes.GET("/users", userAdminHandler.ListUsers)
+		userAdminRoutes.GET("/users/:id", userAdminHandler.GetUser)
+		userAdminRoutes.DELETE("/users/:id", userAdminHandler.DeleteUser)
+		userAdminRoutes.PATCH("/users/:id/role", userAdminHandler.ChangeRole)
+		userAdminRoutes.POST("/users/:id/deactivate", userAdminHandler.Deactivate)
+		userAdminRoutes.POST("/users/:id/reactivate", userAdminHandler.Reactivate)
+		userAdminRoutes.DELETE("/users/:id/login-lockout", userHandler.UnlockLogin)
+		userAdminRoutes.GET("/users/:id/sessions", sessionHandler.ListUserSessions)
+		userAdminRoutes.DELETE("/users/:id/sessions", sessionHandler.RevokeAllUserSessions)
+		userAdminRoutes.DELETE("/users/:id/sessions/:session_id", sessionHandler.RevokeUserSession)
 	}
 
+	roleAdminRoutes := adminRoutes.Group("", requirePermission(domain.PermissionRolesManage))
+	{
+		roleAdminRoutes.GET("/permissions", permissionHandler.ListPermissions)
+		roleAdminRoutes.GET("/roles", permissionHandler.ListRolePermissions)
+		roleAdminRoutes.PUT("/roles/:role/permissions", permissionHandler.SetRolePermissions)
+		roleAdminRoutes.GET("/mfa-policies", mfaHandler.GetPolicies)
+		roleAdminRoutes.PUT("/mfa-policies/:role", mfaHandler.SetPolicy)
+	}
+
+	verificationAdminRoutes := adminRoutes.Group("/psychologist-profiles", requirePermission(domain.PermissionPsychologistsVerify))
+	{
+		verificationAdminRoutes.GET("", psychologistProfileHandler.ListProfiles)
+		verificationAdminRoutes.GET("/:id", psychologistProfileHandler.GetProfile)
+		verificationAdminRoutes.PATCH("/:id/verification", psychologistProfileHandler.Review)
+	}
+
+	// Endpoint yang mengubah jadwal atau booking hanya untuk email yang sudah terverifikasi
+	requireVerifiedEmail := middleware.RequireVerifiedEmail()
+
 	psychologistRoutes := apiRoutes.Group("/psychologist")
-	psychologistRoutes.Use(middleware.RoleAuthMiddleware("psikolog"))
+
+	professionalProfileRoutes := psychologistRoutes.Group("/profile", requirePermission(domain.PermissionPsychologistProfileWrite))
 	{
-		psychologistRoutes.POST("/availability", availabilityHandler.SetAvailability)
-		// psychologistRoutes.GET("/consultation-requests", scheduleHandler.GetConsultationRequests)
-		// psychologistRoutes.PATCH("/consultation-requests/:id", scheduleHandler.UpdateConsultationRequestStatus)
+		professionalProfileRoutes.GET("", psychologistProfileHandler.GetOwnProfile)
+		professionalProfileRoutes.PUT("", psychologistProfileHandler.UpdateOwnProfile)
+	}
+
+	availabilityRoutes := psychologistRoutes.Group("/availability", requirePermission(domain.PermissionAvailabilityWrite))
+	{
+		availabilityRoutes.GET("", availabilityHandler.GetOwnAvailability)
+		availabilityRoutes.GET("/exceptions", availabilityHandler.GetExceptions)
+		availabilityRoutes.GET("/settings", availabilityHandler.GetSessionSettings)
+	}
+
+	availabilityWriteRoutes := availabilityRoutes.Group("", requireVerifiedEmail)
+	{
+		availabilityWriteRoutes.POST("", availabilityHandler.SetAvailability)
+		availabilityWriteRoutes.POST("/slots", availabilityHandler.AddSlot)
+		availabilityWriteRoutes.PUT("/slots/:id", availabilityHandler.UpdateSlot)
+		availabilityWriteRoutes.DELETE("/slots/:id", availabilityHandler.DeleteSlot)
+		availabilityWriteRoutes.POST("/exceptions", availabilityHandler.AddException)
+		availabilityWriteRoutes.DELETE("/exceptions/:id", availabilityHandler.DeleteException)
+		availabilityWriteRoutes.PUT("/settings", availabilityHandler.UpdateSessionSettings)
+	}
+
+	consultationRequestRoutes := psychologistRoutes.Group("/consultation-requests", requirePermission(domain.PermissionConsultationsManage))
+	{
+		consultationRequestRoutes.GET("", consultationHandler.GetConsultationRequests)
+	}
+
+	consultationRequestWriteRoutes := consultationRequestRoutes.Group("", requireVerifiedEmail)
+	{
+		consultationRequestWriteRoutes.PATCH("/:id", consultationHandler.UpdateConsultationRequestStatus)
 	}
 
 	clientRoutes := apiRoutes.Group("/client")
-	clientRoutes.Use(middleware.RoleAuthMiddleware("klien"))
+
+	directoryRoutes := clientRoutes.Group("/psychologists", requirePermission(domain.PermissionPsychologistsRead))
+	{
+		directoryRoutes.GET("", userHandler.GetAvailablePsychologists)
+		directoryRoutes.GET("/:psikolog_id/availability", availabilityHandler.GetAvailability)
+		directoryRoutes.GET("/:psikolog_id/availability/:hari", availabilityHandler.GetAvailabilityByDay)
+		directoryRoutes.GET("/:psikolog_id/slots", availabilityHandler.GetBookableSlots)
+	}
+
+	bookingRoutes := clientRoutes.Group("", requirePermission(domain.PermissionConsultationsBook))
+	{
+		bookingRoutes.GET("/history", consultationHandler.GetClientHistory)
+	}
+
+	bookingWriteRoutes := bookingRoutes.Group("", requireVerifiedEmail)
 	{
-		// clientRoutes.GET("/psychologists", userHandler.GetAvailablePsychologists)
-		// clientRoutes.POST("/consultation-request", scheduleHandler.RequestConsultation)
-		// clientRoutes.GET("/history", scheduleHandler.GetClientHistory)
+		bookingWriteRoutes.POST("/consultation-request", consultationHandler.RequestConsultation)
+		bookingWriteRoutes.PATCH("/consultations/:id/cancel", consultationHandler.CancelConsultation)
 	}
 }
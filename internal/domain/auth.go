@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RefreshToken adalah refresh token yang tersimpan dalam bentuk hash. Token yang dirotasi dari
+// login yang sama berbagi FamilyID sehingga bisa dicabut bersamaan.
+type RefreshToken struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	UserID    uint       `json:"user_id" gorm:"not null;index"`
+	FamilyID  string     `json:"family_id" gorm:"type:varchar(36);not null;index"`
+	TokenHash string     `json:"-" gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+
+	User User `json:"-" gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName mengembalikan nama tabel untuk model RefreshToken.
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+// TokenPair adalah pasangan access token berumur pendek dan refresh token berumur panjang.
+type TokenPair struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// RefreshTokenPayload adalah payload untuk menukar refresh token dengan pasangan token baru.
+type RefreshTokenPayload struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// LogoutPayload adalah payload logout. RefreshToken bersifat opsional; jika diisi, keluarga
+// refresh token tersebut ikut dicabut.
+type LogoutPayload struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// AccessTokenClaims adalah klaim access token yang sudah diverifikasi oleh middleware.
+type AccessTokenClaims struct {
+	UserID        uint
+	Role          string
+	EmailVerified bool
+	JTI           string
+	SessionID     string
+	IssuedAt      time.Time
+	ExpiresAt     time.Time
+}
+
+// RefreshTokenRepository mendefinisikan kontrak penyimpanan refresh token.
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *RefreshToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*RefreshToken, error)
+	MarkUsed(ctx context.Context, id uint) error
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeAllForUser(ctx context.Context, userID uint) error
+}
+
+// TokenRevocationStore mendefinisikan kontrak denylist access token berbasis jti, user, dan sesi.
+type TokenRevocationStore interface {
+	RevokeToken(ctx context.Context, jti string, expiration time.Duration) error
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+	RevokeUserTokens(ctx context.Context, userID uint, issuedBefore time.Time, expiration time.Duration) error
+	IsUserTokenRevoked(ctx context.Context, userID uint, issuedAt time.Time) (bool, error)
+	RevokeSession(ctx context.Context, sessionID string, expiration time.Duration) error
+	IsSessionRevoked(ctx context.Context, sessionID string) (bool, error)
+}
+
+// AuthUsecase mendefinisikan kontrak penerbitan dan rotasi token. Setiap IssueTokens membuka sesi
+// baru; RevokeSession dan RevokeOtherSessions tidak memeriksa kepemilikan sesi.
+type AuthUsecase interface {
+	IssueTokens(ctx context.Context, user *User, client *ClientInfo) (*TokenPair, error)
+	Refresh(ctx context.Context, payload *RefreshTokenPayload, client *ClientInfo) (*TokenPair, error)
+	Logout(ctx context.Context, claims *AccessTokenClaims, payload *LogoutPayload) error
+	LogoutAll(ctx context.Context, userID uint) error
+	RevokeSession(ctx context.Context, sessionID string) error
+	RevokeOtherSessions(ctx context.Context, userID uint, exceptSessionID string) error
+}
+
+var (
+	// ErrRefreshTokenNotFound dikembalikan repository ketika hash refresh token tidak dikenal.
+	ErrRefreshTokenNotFound = NewDomainError(http.StatusUnauthorized, "Invalid refresh token")
+	// ErrInvalidRefreshToken dikembalikan ketika refresh token tidak dikenal, kedaluwarsa, atau sudah dicabut.
+	ErrInvalidRefreshToken = NewDomainError(http.StatusUnauthorized, "Invalid refresh token")
+	// ErrRefreshTokenReused dikembalikan ketika refresh token yang sudah dipakai digunakan lagi.
+	// Seluruh keluarga token dicabut sehingga pengguna harus login ulang.
+	ErrRefreshTokenReused = NewDomainError(http.StatusUnauthorized, "Refresh token has already been used")
+)
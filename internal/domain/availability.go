@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
 	"time"
 )
 
@@ -14,10 +15,11 @@ type WaktuKonsultasi struct {
 	Hari         string    `json:"hari" gorm:"not null;index"`
 	WaktuMulai   string    `json:"waktu_mulai" gorm:"type:time;not null"`
 	WaktuSelesai string    `json:"waktu_selesai" gorm:"type:time;not null"`
+	Version      uint      `json:"version" gorm:"not null;default:1"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 
-	User User `gorm:"foreignKey:PsikologID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	User User `json:"-" gorm:"foreignKey:PsikologID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 }
 
 // TableName mengembalikan nama tabel untuk model WaktuKonsultasi.
@@ -25,6 +27,150 @@ func (WaktuKonsultasi) TableName() string {
 	return "waktu_konsultasi"
 }
 
+// Batas dan nilai bawaan pengaturan sesi psikolog, dalam menit.
+const (
+	MinDurasiSesiMenit     = 15
+	MaxDurasiSesiMenit     = 240
+	MaxBufferMenit         = 120
+	DefaultDurasiSesiMenit = 60
+	DefaultBufferMenit     = 0
+)
+
+// PengaturanSesi menyimpan durasi sesi dan jeda antar sesi milik seorang psikolog.
+// Setiap jendela WaktuKonsultasi dipotong menjadi sesi-sesi berdasarkan pengaturan ini.
+type PengaturanSesi struct {
+	PsikologID      uint      `json:"psikolog_id" gorm:"primaryKey;autoIncrement:false"`
+	DurasiSesiMenit int       `json:"durasi_sesi_menit" gorm:"not null;default:60"`
+	BufferMenit     int       `json:"buffer_menit" gorm:"not null;default:0"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+
+	User User `json:"-" gorm:"foreignKey:PsikologID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName mengembalikan nama tabel untuk model PengaturanSesi.
+func (PengaturanSesi) TableName() string {
+	return "pengaturan_sesi"
+}
+
+// DefaultPengaturanSesi mengembalikan pengaturan bawaan untuk psikolog yang belum mengaturnya.
+func DefaultPengaturanSesi(psikologID uint) *PengaturanSesi {
+	return &PengaturanSesi{
+		PsikologID:      psikologID,
+		DurasiSesiMenit: DefaultDurasiSesiMenit,
+		BufferMenit:     DefaultBufferMenit,
+	}
+}
+
+// SessionDuration mengembalikan durasi satu sesi.
+func (p PengaturanSesi) SessionDuration() time.Duration {
+	return time.Duration(p.DurasiSesiMenit) * time.Minute
+}
+
+// Buffer mengembalikan jeda antar sesi.
+func (p PengaturanSesi) Buffer() time.Duration {
+	return time.Duration(p.BufferMenit) * time.Minute
+}
+
+// SessionSettingsPayload adalah payload untuk mengubah pengaturan sesi psikolog.
+type SessionSettingsPayload struct {
+	DurasiSesiMenit int `json:"durasi_sesi_menit" validate:"required,min=15,max=240"`
+	BufferMenit     int `json:"buffer_menit" validate:"min=0,max=120"`
+}
+
+// DaftarHari berisi nama hari yang valid, diurutkan sesuai time.Weekday (Minggu = 0).
+var DaftarHari = []string{"Minggu", "Senin", "Selasa", "Rabu", "Kamis", "Jumat", "Sabtu"}
+
+// IsValidHari memeriksa apakah nama hari termasuk dalam DaftarHari.
+func IsValidHari(hari string) bool {
+	for _, validDay := range DaftarHari {
+		if hari == validDay {
+			return true
+		}
+	}
+	return false
+}
+
+// HariFromWeekday mengonversi time.Weekday menjadi nama hari dalam Bahasa Indonesia.
+func HariFromWeekday(weekday time.Weekday) string {
+	return DaftarHari[weekday]
+}
+
+// HariOrder mengembalikan urutan hari dalam seminggu dimulai dari Senin (0) hingga Minggu (6).
+// Hari yang tidak dikenal diletakkan paling akhir.
+func HariOrder(hari string) int {
+	for i, validDay := range DaftarHari {
+		if hari == validDay {
+			return (i + 6) % 7
+		}
+	}
+	return len(DaftarHari)
+}
+
+// AvailabilitySummary adalah ringkasan satu slot ketersediaan untuk tampilan publik.
+type AvailabilitySummary struct {
+	Hari         string `json:"hari"`
+	WaktuMulai   string `json:"waktu_mulai"`
+	WaktuSelesai string `json:"waktu_selesai"`
+}
+
+// DaftarHariMingguan mengembalikan nama hari dalam urutan tampilan mingguan, Senin hingga Minggu.
+func DaftarHariMingguan() []string {
+	days := make([]string, 0, len(DaftarHari))
+	for i := range DaftarHari {
+		days = append(days, DaftarHari[(i+1)%len(DaftarHari)])
+	}
+	return days
+}
+
+// JadwalHarian adalah daftar slot ketersediaan psikolog pada satu hari.
+type JadwalHarian struct {
+	Hari  string            `json:"hari"`
+	Slots []WaktuKonsultasi `json:"slots"`
+}
+
+// JadwalMingguan adalah jadwal ketersediaan psikolog yang dikelompokkan per hari beserta
+// pengaturan sesi yang dipakai untuk memotongnya menjadi slot pemesanan.
+type JadwalMingguan struct {
+	PsikologID      uint           `json:"psikolog_id"`
+	DurasiSesiMenit int            `json:"durasi_sesi_menit"`
+	BufferMenit     int            `json:"buffer_menit"`
+	Jadwal          []JadwalHarian `json:"jadwal"`
+}
+
+// NewJadwalMingguan mengelompokkan slot ke dalam hari-hari pada days sesuai urutannya.
+// Hari tanpa slot tetap ditampilkan dengan daftar kosong.
+func NewJadwalMingguan(settings *PengaturanSesi, slots []WaktuKonsultasi, days []string) *JadwalMingguan {
+	byDay := make(map[string][]WaktuKonsultasi, len(days))
+	for _, slot := range slots {
+		byDay[slot.Hari] = append(byDay[slot.Hari], slot)
+	}
+
+	jadwal := make([]JadwalHarian, 0, len(days))
+	for _, hari := range days {
+		daySlots := byDay[hari]
+		if daySlots == nil {
+			daySlots = []WaktuKonsultasi{}
+		}
+		sort.Slice(daySlots, func(i, j int) bool {
+			return daySlots[i].WaktuMulai < daySlots[j].WaktuMulai
+		})
+		jadwal = append(jadwal, JadwalHarian{Hari: hari, Slots: daySlots})
+	}
+
+	return &JadwalMingguan{
+		PsikologID:      settings.PsikologID,
+		DurasiSesiMenit: settings.DurasiSesiMenit,
+		BufferMenit:     settings.BufferMenit,
+		Jadwal:          jadwal,
+	}
+}
+
+// WeeklyScheduleQuery adalah filter opsional jadwal mingguan dari query string.
+type WeeklyScheduleQuery struct {
+	Hari string `form:"hari" validate:"omitempty,oneof=Senin Selasa Rabu Kamis Jumat Sabtu Minggu"`
+}
+
 // SlotPayload adalah struktur untuk satu slot waktu dalam request.
 type SlotPayload struct {
 	Hari         string `json:"hari" validate:"required,oneof=Senin Selasa Rabu Kamis Jumat Sabtu Minggu"`
@@ -50,15 +196,96 @@ func (s *SlotPayload) Validate() error {
 		return NewDomainError(http.StatusBadRequest, "Start time must be before end time")
 	}
 
-	// Validasi minimal durasi (misalnya 30 menit)
-	minDuration := 30 * time.Minute
-	if endTime.Sub(startTime) < minDuration {
-		return NewDomainError(http.StatusBadRequest, "Minimum consultation duration is 30 minutes")
+	// Validasi minimal durasi; kecocokan dengan durasi sesi psikolog diperiksa di usecase
+	if endTime.Sub(startTime) < time.Duration(MinDurasiSesiMenit)*time.Minute {
+		return NewDomainError(http.StatusBadRequest,
+			fmt.Sprintf("Minimum consultation duration is %d minutes", MinDurasiSesiMenit))
 	}
 
 	return nil
 }
 
+// Jenis pengecualian jadwal.
+const (
+	PengecualianFullDay      = "full_day"
+	PengecualianPartialBlock = "partial_block"
+	PengecualianExtraHours   = "extra_hours"
+)
+
+// PengecualianJadwal adalah pengecualian bertanggal yang menimpa jadwal mingguan psikolog:
+// libur sehari penuh, blok sebagian jam, atau tambahan jam praktik.
+type PengecualianJadwal struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	PsikologID   uint      `json:"psikolog_id" gorm:"not null;index:idx_pengecualian_psikolog_tanggal"`
+	Tanggal      time.Time `json:"tanggal" gorm:"type:date;not null;index:idx_pengecualian_psikolog_tanggal"`
+	Jenis        string    `json:"jenis" gorm:"type:varchar(20);not null"`
+	WaktuMulai   *string   `json:"waktu_mulai,omitempty" gorm:"type:time"`
+	WaktuSelesai *string   `json:"waktu_selesai,omitempty" gorm:"type:time"`
+	Alasan       string    `json:"alasan,omitempty" gorm:"type:varchar(255)"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	User User `json:"-" gorm:"foreignKey:PsikologID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName mengembalikan nama tabel untuk model PengecualianJadwal.
+func (PengecualianJadwal) TableName() string {
+	return "pengecualian_jadwal"
+}
+
+// AvailabilityExceptionPayload adalah payload untuk menambahkan pengecualian jadwal.
+type AvailabilityExceptionPayload struct {
+	Tanggal      string `json:"tanggal" validate:"required,datetime=2006-01-02"`
+	Jenis        string `json:"jenis" validate:"required,oneof=full_day partial_block extra_hours"`
+	WaktuMulai   string `json:"waktu_mulai" validate:"omitempty,datetime=15:04:05"`
+	WaktuSelesai string `json:"waktu_selesai" validate:"omitempty,datetime=15:04:05"`
+	Alasan       string `json:"alasan" validate:"max=255"`
+}
+
+// Validate melakukan validasi bisnis pada AvailabilityExceptionPayload.
+func (p *AvailabilityExceptionPayload) Validate() error {
+	if _, err := time.Parse("2006-01-02", p.Tanggal); err != nil {
+		return NewDomainError(http.StatusBadRequest, "Invalid date format")
+	}
+
+	if p.Jenis == PengecualianFullDay {
+		if p.WaktuMulai != "" || p.WaktuSelesai != "" {
+			return NewDomainError(http.StatusBadRequest, "Full day exceptions must not specify a time range")
+		}
+		return nil
+	}
+
+	if p.WaktuMulai == "" || p.WaktuSelesai == "" {
+		return NewDomainError(http.StatusBadRequest, "Start and end time are required for this exception type")
+	}
+
+	if p.Jenis == PengecualianExtraHours {
+		// Tambahan jam mengikuti aturan slot ketersediaan biasa
+		slot := SlotPayload{WaktuMulai: p.WaktuMulai, WaktuSelesai: p.WaktuSelesai}
+		return slot.Validate()
+	}
+
+	startTime, err := time.Parse("15:04:05", p.WaktuMulai)
+	if err != nil {
+		return NewDomainError(http.StatusBadRequest, "Invalid start time format")
+	}
+	endTime, err := time.Parse("15:04:05", p.WaktuSelesai)
+	if err != nil {
+		return NewDomainError(http.StatusBadRequest, "Invalid end time format")
+	}
+	if !startTime.Before(endTime) {
+		return NewDomainError(http.StatusBadRequest, "Start time must be before end time")
+	}
+
+	return nil
+}
+
+// AvailabilityExceptionQuery adalah filter rentang tanggal pengecualian jadwal.
+type AvailabilityExceptionQuery struct {
+	From string `form:"from" validate:"omitempty,datetime=2006-01-02"`
+	To   string `form:"to" validate:"omitempty,datetime=2006-01-02"`
+}
+
 // SetAvailabilityPayload adalah payload untuk mengatur jadwal ketersediaan.
 type SetAvailabilityPayload struct {
 	Slots []SlotPayload `json:"slots" validate:"required,min=1,dive"`
@@ -90,6 +317,23 @@ func (p *SetAvailabilityPayload) Validate() error {
 	return nil
 }
 
+// ValidateAgainstExisting memastikan slot tidak overlapping dengan jadwal yang sudah tersimpan
+// pada hari yang sama. Slot dengan ID excludeID (slot yang sedang diubah) diabaikan.
+func (s *SlotPayload) ValidateAgainstExisting(existing []WaktuKonsultasi, excludeID uint) error {
+	for _, w := range existing {
+		if w.ID == excludeID || w.Hari != s.Hari {
+			continue
+		}
+		other := SlotPayload{Hari: w.Hari, WaktuMulai: w.WaktuMulai, WaktuSelesai: w.WaktuSelesai}
+		if isOverlapping(*s, other) {
+			return NewDomainError(http.StatusBadRequest,
+				fmt.Sprintf("Time overlapping detected for %s: slots %s-%s and %s-%s are overlapping",
+					s.Hari, s.WaktuMulai, s.WaktuSelesai, w.WaktuMulai, w.WaktuSelesai))
+		}
+	}
+	return nil
+}
+
 // validateNoOverlapping memeriksa apakah ada slot yang overlapping dalam satu hari.
 func validateNoOverlapping(slots []SlotPayload) error {
 	for i := 0; i < len(slots); i++ {
@@ -154,6 +398,16 @@ type AvailabilityRepository interface {
 	ReplaceAll(ctx context.Context, psikologID uint, slots []WaktuKonsultasi) error
 	GetByPsikologID(ctx context.Context, psikologID uint) ([]WaktuKonsultasi, error)
 	GetByPsikologIDAndDay(ctx context.Context, psikologID uint, day string) ([]WaktuKonsultasi, error)
+	GetByPsikologIDs(ctx context.Context, psikologIDs []uint) ([]WaktuKonsultasi, error)
+	CreateException(ctx context.Context, exception *PengecualianJadwal) error
+	DeleteException(ctx context.Context, psikologID, id uint) error
+	GetExceptions(ctx context.Context, psikologID uint, from, to time.Time) ([]PengecualianJadwal, error)
+	GetSessionSettings(ctx context.Context, psikologID uint) (*PengaturanSesi, error)
+	UpsertSessionSettings(ctx context.Context, settings *PengaturanSesi) error
+	GetSlotByID(ctx context.Context, psikologID, id uint) (*WaktuKonsultasi, error)
+	CreateSlot(ctx context.Context, slot *WaktuKonsultasi) error
+	UpdateSlot(ctx context.Context, slot *WaktuKonsultasi, expectedVersion uint) error
+	DeleteSlot(ctx context.Context, psikologID, id, expectedVersion uint) error
 }
 
 // AvailabilityUsecase mendefinisikan kontrak untuk logika bisnis ketersediaan.
@@ -161,4 +415,32 @@ type AvailabilityUsecase interface {
 	SetAvailability(ctx context.Context, psikologID uint, payload *SetAvailabilityPayload) error
 	GetAvailability(ctx context.Context, psikologID uint) ([]WaktuKonsultasi, error)
 	GetAvailabilityByDay(ctx context.Context, psikologID uint, day string) ([]WaktuKonsultasi, error)
+	GetWeeklySchedule(ctx context.Context, psikologID uint, hari string) (*JadwalMingguan, error)
+	GetOwnWeeklySchedule(ctx context.Context, psikologID uint, hari string) (*JadwalMingguan, error)
+	GetBookableSlots(ctx context.Context, psikologID uint, query *BookableSlotsQuery) ([]BookableSlot, error)
+	AddException(ctx context.Context, psikologID uint, payload *AvailabilityExceptionPayload) (*PengecualianJadwal, error)
+	GetExceptions(ctx context.Context, psikologID uint, query *AvailabilityExceptionQuery) ([]PengecualianJadwal, error)
+	DeleteException(ctx context.Context, psikologID, id uint) error
+	GetSessionSettings(ctx context.Context, psikologID uint) (*PengaturanSesi, error)
+	UpdateSessionSettings(ctx context.Context, psikologID uint, payload *SessionSettingsPayload) (*PengaturanSesi, error)
+	AddSlot(ctx context.Context, psikologID uint, payload *SlotPayload) (*WaktuKonsultasi, error)
+	UpdateSlot(ctx context.Context, psikologID, id, version uint, payload *SlotPayload) (*WaktuKonsultasi, error)
+	DeleteSlot(ctx context.Context, psikologID, id, version uint) error
 }
+
+var (
+	// ErrAvailabilitySlotNotFound dikembalikan ketika slot ketersediaan tidak ditemukan.
+	ErrAvailabilitySlotNotFound = NewDomainError(http.StatusNotFound, "Availability slot not found")
+	// ErrAvailabilitySlotVersionMismatch dikembalikan ketika slot sudah diubah oleh request lain
+	// sejak versi yang dikirim klien.
+	ErrAvailabilitySlotVersionMismatch = NewDomainError(http.StatusPreconditionFailed, "Availability slot has been modified by another request")
+	// ErrAvailabilitySlotOverlap dikembalikan repository ketika slot beririsan dengan slot lain yang
+	// disimpan request lain pada hari yang sama.
+	ErrAvailabilitySlotOverlap = NewDomainError(http.StatusConflict, "Availability slot overlaps another slot on the same day")
+)
+
+// ErrSessionSettingsNotFound dikembalikan ketika psikolog belum memiliki pengaturan sesi.
+var ErrSessionSettingsNotFound = NewDomainError(http.StatusNotFound, "Session settings not found")
+
+// ErrAvailabilityExceptionNotFound dikembalikan ketika pengecualian jadwal tidak ditemukan.
+var ErrAvailabilityExceptionNotFound = NewDomainError(http.StatusNotFound, "Availability exception not found")
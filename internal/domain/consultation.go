@@ -0,0 +1,219 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Status konsultasi.
+const (
+	KonsultasiStatusPending   = "pending"
+	KonsultasiStatusAccepted  = "accepted"
+	KonsultasiStatusRejected  = "rejected"
+	KonsultasiStatusCompleted = "completed"
+	KonsultasiStatusNoShow    = "no_show"
+	KonsultasiStatusCancelled = "cancelled"
+)
+
+// ActiveKonsultasiStatuses adalah status yang masih menempati slot waktu psikolog.
+var ActiveKonsultasiStatuses = []string{KonsultasiStatusPending, KonsultasiStatusAccepted}
+
+// konsultasiTransitions mendefinisikan state machine status konsultasi:
+// pending -> accepted/rejected, accepted -> completed/no_show, dan pembatalan
+// selama konsultasi masih aktif.
+var konsultasiTransitions = map[string][]string{
+	KonsultasiStatusPending:  {KonsultasiStatusAccepted, KonsultasiStatusRejected, KonsultasiStatusCancelled},
+	KonsultasiStatusAccepted: {KonsultasiStatusCompleted, KonsultasiStatusNoShow, KonsultasiStatusCancelled},
+}
+
+// IsValidKonsultasiStatus memeriksa apakah status dikenal oleh state machine.
+func IsValidKonsultasiStatus(status string) bool {
+	switch status {
+	case KonsultasiStatusPending, KonsultasiStatusAccepted, KonsultasiStatusRejected,
+		KonsultasiStatusCompleted, KonsultasiStatusNoShow, KonsultasiStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// CanTransitionKonsultasi memeriksa apakah perpindahan status from -> to diperbolehkan.
+func CanTransitionKonsultasi(from, to string) bool {
+	for _, allowed := range konsultasiTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// NewInvalidTransitionError membuat DomainError 409 untuk perpindahan status yang tidak sah.
+func NewInvalidTransitionError(from, to string) *DomainError {
+	return NewDomainError(http.StatusConflict,
+		fmt.Sprintf("Cannot change consultation status from %s to %s", from, to))
+}
+
+// Konsultasi merepresentasikan permintaan sesi konsultasi dari klien kepada psikolog.
+type Konsultasi struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	KlienID      uint      `json:"klien_id" gorm:"not null;index"`
+	PsikologID   uint      `json:"psikolog_id" gorm:"not null;index"`
+	WaktuMulai   time.Time `json:"waktu_mulai" gorm:"type:timestamptz;not null;index"`
+	WaktuSelesai time.Time `json:"waktu_selesai" gorm:"type:timestamptz;not null"`
+	Status       string    `json:"status" gorm:"type:varchar(20);not null;default:pending;index"`
+	Catatan      string    `json:"catatan,omitempty" gorm:"type:text"`
+	// StatusReason dan StatusChangedAt mencatat perpindahan status terakhir.
+	StatusReason    string     `json:"status_reason,omitempty" gorm:"type:text"`
+	StatusChangedAt *time.Time `json:"status_changed_at,omitempty" gorm:"type:timestamptz"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	// Riwayat konsultasi tidak boleh ikut terhapus ketika akun dihapus.
+	Klien    User `json:"-" gorm:"foreignKey:KlienID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+	Psikolog User `json:"-" gorm:"foreignKey:PsikologID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+}
+
+// TableName mengembalikan nama tabel untuk model Konsultasi.
+func (Konsultasi) TableName() string {
+	return "konsultasi"
+}
+
+// KonsultasiStatusHistory mencatat setiap perpindahan status konsultasi.
+type KonsultasiStatusHistory struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	KonsultasiID uint      `json:"konsultasi_id" gorm:"not null;index"`
+	FromStatus   string    `json:"from_status" gorm:"type:varchar(20);not null"`
+	ToStatus     string    `json:"to_status" gorm:"type:varchar(20);not null"`
+	Reason       string    `json:"reason,omitempty" gorm:"type:text"`
+	ChangedBy    uint      `json:"changed_by" gorm:"not null"`
+	CreatedAt    time.Time `json:"created_at"`
+
+	Konsultasi Konsultasi `json:"-" gorm:"foreignKey:KonsultasiID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName mengembalikan nama tabel untuk model KonsultasiStatusHistory.
+func (KonsultasiStatusHistory) TableName() string {
+	return "konsultasi_status_history"
+}
+
+// KonsultasiFilter adalah kriteria pencarian daftar konsultasi.
+// From dan To membatasi waktu mulai konsultasi (To bersifat eksklusif), Limit 0 berarti tanpa batas.
+// Statuses dipakai untuk mencocokkan beberapa status sekaligus. Preload ikut memuat data klien dan
+// psikolog, hanya untuk pemanggil yang menampilkannya.
+type KonsultasiFilter struct {
+	PsikologID uint
+	KlienID    uint
+	Status     string
+	Statuses   []string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+	Preload    bool
+}
+
+// KonsultasiHistoryItem adalah satu baris riwayat konsultasi klien beserta profil publik psikolog.
+type KonsultasiHistoryItem struct {
+	Konsultasi
+	Psikolog *PsychologistPublicProfile `json:"psikolog"`
+}
+
+// KonsultasiHistoryResult adalah hasil riwayat konsultasi klien yang terpaginasi.
+type KonsultasiHistoryResult struct {
+	Items      []KonsultasiHistoryItem `json:"items"`
+	Pagination Pagination              `json:"pagination"`
+}
+
+// ConsultationRequestQuery adalah filter daftar permintaan konsultasi psikolog dari query string.
+type ConsultationRequestQuery struct {
+	PaginationQuery
+	Status string `form:"status" validate:"omitempty,oneof=pending accepted rejected completed no_show cancelled"`
+}
+
+// KonsultasiRequestResult adalah daftar permintaan konsultasi psikolog yang terpaginasi.
+type KonsultasiRequestResult struct {
+	Items      []Konsultasi `json:"items"`
+	Pagination Pagination   `json:"pagination"`
+}
+
+// ConsultationHistoryQuery adalah filter riwayat konsultasi klien dari query string.
+type ConsultationHistoryQuery struct {
+	PaginationQuery
+	Status     string `form:"status" validate:"omitempty,oneof=pending accepted rejected completed no_show cancelled"`
+	PsikologID uint   `form:"psikolog_id"`
+	From       string `form:"from" validate:"omitempty,datetime=2006-01-02"`
+	To         string `form:"to" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// RequestKonsultasiPayload adalah payload klien untuk memesan sesi konsultasi.
+type RequestKonsultasiPayload struct {
+	PsikologID   uint   `json:"psikolog_id" validate:"required"`
+	Tanggal      string `json:"tanggal" validate:"required,datetime=2006-01-02"`
+	WaktuMulai   string `json:"waktu_mulai" validate:"required,datetime=15:04:05"`
+	WaktuSelesai string `json:"waktu_selesai" validate:"required,datetime=15:04:05"`
+	Catatan      string `json:"catatan" validate:"max=1000"`
+}
+
+// Validate melakukan validasi bisnis pada RequestKonsultasiPayload.
+func (p *RequestKonsultasiPayload) Validate() error {
+	if _, err := time.Parse("2006-01-02", p.Tanggal); err != nil {
+		return NewDomainError(http.StatusBadRequest, "Invalid date format")
+	}
+
+	// Aturan rentang waktu sama dengan slot ketersediaan
+	slot := SlotPayload{WaktuMulai: p.WaktuMulai, WaktuSelesai: p.WaktuSelesai}
+	return slot.Validate()
+}
+
+// Schedule menghitung waktu mulai dan selesai konsultasi pada zona waktu loc.
+func (p *RequestKonsultasiPayload) Schedule(loc *time.Location) (time.Time, time.Time, error) {
+	start, err := time.ParseInLocation("2006-01-02 15:04:05", p.Tanggal+" "+p.WaktuMulai, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, NewDomainError(http.StatusBadRequest, "Invalid start time format")
+	}
+	end, err := time.ParseInLocation("2006-01-02 15:04:05", p.Tanggal+" "+p.WaktuSelesai, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, NewDomainError(http.StatusBadRequest, "Invalid end time format")
+	}
+	return start, end, nil
+}
+
+// UpdateKonsultasiStatusPayload adalah payload psikolog untuk mengubah status konsultasi.
+type UpdateKonsultasiStatusPayload struct {
+	Status string `json:"status" validate:"required,oneof=accepted rejected completed no_show cancelled"`
+	Reason string `json:"reason" validate:"max=500"`
+}
+
+// CancelKonsultasiPayload adalah payload klien untuk membatalkan konsultasi.
+type CancelKonsultasiPayload struct {
+	Reason string `json:"reason" validate:"max=500"`
+}
+
+// ConsultationRepository mendefinisikan kontrak untuk interaksi database konsultasi.
+type ConsultationRepository interface {
+	Create(ctx context.Context, konsultasi *Konsultasi) error
+	GetByID(ctx context.Context, id uint) (*Konsultasi, error)
+	CountOverlapping(ctx context.Context, psikologID uint, start, end time.Time) (int64, error)
+	List(ctx context.Context, filter KonsultasiFilter) ([]Konsultasi, int64, error)
+	UpdateStatus(ctx context.Context, konsultasi *Konsultasi, fromStatus string, history *KonsultasiStatusHistory) error
+}
+
+// ConsultationUsecase mendefinisikan kontrak untuk logika bisnis konsultasi.
+type ConsultationUsecase interface {
+	RequestConsultation(ctx context.Context, klienID uint, payload *RequestKonsultasiPayload) (*Konsultasi, error)
+	GetConsultationRequests(ctx context.Context, psikologID uint, query *ConsultationRequestQuery) (*KonsultasiRequestResult, error)
+	UpdateConsultationStatus(ctx context.Context, psikologID, id uint, payload *UpdateKonsultasiStatusPayload) (*Konsultasi, error)
+	CancelConsultation(ctx context.Context, klienID, id uint, payload *CancelKonsultasiPayload) (*Konsultasi, error)
+	GetClientHistory(ctx context.Context, klienID uint, query *ConsultationHistoryQuery) (*KonsultasiHistoryResult, error)
+}
+
+var (
+	// ErrConsultationNotFound dikembalikan ketika konsultasi tidak ditemukan.
+	ErrConsultationNotFound = NewDomainError(http.StatusNotFound, "Consultation not found")
+	// ErrConsultationStatusChanged dikembalikan ketika status berubah oleh request lain.
+	ErrConsultationStatusChanged = NewDomainError(http.StatusConflict, "Consultation status was changed by another request")
+	// ErrConsultationSlotTaken dikembalikan ketika waktu yang diminta sudah dipesan, termasuk
+	// ketika dua permintaan berebut slot yang sama secara bersamaan.
+	ErrConsultationSlotTaken = NewDomainError(http.StatusConflict, "Requested time is already booked")
+)
@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Status ekspor data pribadi.
+const (
+	DataExportStatusPending    = "pending"
+	DataExportStatusProcessing = "processing"
+	DataExportStatusReady      = "ready"
+	DataExportStatusFailed     = "failed"
+	DataExportStatusExpired    = "expired"
+)
+
+// ErasedUsername adalah username pengganti untuk akun yang datanya sudah dihapus.
+const ErasedUsername = "Deleted user"
+
+// ErasedEmail membuat email pengganti yang unik untuk akun yang datanya sudah dihapus. Domain
+// .invalid menjamin alamat ini tidak pernah bisa menerima email.
+func ErasedEmail(userID uint) string {
+	return fmt.Sprintf("deleted-%d@erased.invalid", userID)
+}
+
+// DataExport adalah permintaan ekspor data pribadi user. Arsip dibuat di latar belakang dan
+// disimpan pada FileStorage sampai ExpiresAt.
+type DataExport struct {
+	ID          string     `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	UserID      uint       `json:"-" gorm:"not null;index"`
+	Status      string     `json:"status" gorm:"type:varchar(20);not null;index"`
+	FileKey     *string    `json:"-" gorm:"type:varchar(255)"`
+	SizeBytes   int64      `json:"size_bytes,omitempty" gorm:"not null;default:0"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" gorm:"type:timestamptz"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty" gorm:"type:timestamptz;index"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	User User `json:"-" gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName mengembalikan nama tabel untuk model DataExport.
+func (DataExport) TableName() string {
+	return "data_exports"
+}
+
+// IsActive mengembalikan true selama arsip masih dalam antrean atau sedang dibuat.
+func (e *DataExport) IsActive() bool {
+	return e.Status == DataExportStatusPending || e.Status == DataExportStatusProcessing
+}
+
+// DataExportResponse adalah status ekspor yang ditampilkan ke user. DownloadURL hanya diisi
+// ketika arsip siap dan berlaku singkat.
+type DataExportResponse struct {
+	*DataExport
+	DownloadURL string `json:"download_url,omitempty"`
+}
+
+// PersonalDataArchive adalah seluruh data pribadi yang disimpan aplikasi tentang satu user.
+// Aplikasi belum menyimpan pesan maupun catatan persetujuan, sehingga keduanya belum ada di arsip.
+type PersonalDataArchive struct {
+	Profile             *User
+	PsychologistProfile *PsychologistProfile
+	Consultations       []ExportedConsultation
+	Availability        *ExportedAvailability
+	Sessions            []Session
+	MFA                 *UserMFA
+}
+
+// ExportedConsultation adalah konsultasi beserta riwayat statusnya. Role menyatakan peran user
+// pada konsultasi tersebut, yaitu klien atau psikolog.
+type ExportedConsultation struct {
+	Konsultasi
+	Role          string                    `json:"role"`
+	StatusHistory []KonsultasiStatusHistory `json:"status_history"`
+}
+
+// ExportedAvailability adalah jadwal praktik psikolog.
+type ExportedAvailability struct {
+	Slots      []WaktuKonsultasi    `json:"slots"`
+	Exceptions []PengecualianJadwal `json:"exceptions"`
+	Settings   *PengaturanSesi      `json:"settings"`
+}
+
+// EraseAccountPayload adalah konfirmasi password untuk menghapus akun sendiri.
+type EraseAccountPayload struct {
+	Password string `json:"password" validate:"required"`
+}
+
+// DataPrivacyRepository mendefinisikan kontrak penyimpanan ekspor dan penghapusan data pribadi.
+type DataPrivacyRepository interface {
+	CreateExport(ctx context.Context, export *DataExport) error
+	GetExport(ctx context.Context, userID uint, id string) (*DataExport, error)
+	ListExports(ctx context.Context, userID uint) ([]DataExport, error)
+	// UpdateExport menyimpan status ekspor hanya jika statusnya di database masih expectedStatus.
+	// ErrDataExportStatusChanged berarti proses lain sudah mengubah atau menghapus ekspor tersebut.
+	UpdateExport(ctx context.Context, export *DataExport, expectedStatus string) error
+	ListExpiredExports(ctx context.Context, now time.Time, limit int) ([]DataExport, error)
+	// ListPendingExports mengambil ekspor yang belum mulai dibuat, terlama lebih dulu.
+	ListPendingExports(ctx context.Context, limit int) ([]DataExport, error)
+	// CollectPersonalData membaca semua data pribadi user dari satu snapshot database.
+	CollectPersonalData(ctx context.Context, userID uint) (*PersonalDataArchive, error)
+	// EraseUser menganonimkan akun, menghapus data yang tidak wajib disimpan, lalu menandai akun
+	// sebagai terhapus. Konsultasi dan riwayatnya tetap disimpan tanpa catatan dan alasan yang
+	// ditulis user tersebut. ErrAccountHasActiveConsultations dikembalikan jika klien masih memiliki
+	// konsultasi aktif setelah erasedAt.
+	EraseUser(ctx context.Context, userID uint, erasedAt time.Time) error
+}
+
+// DataPrivacyUsecase mendefinisikan kontrak hak akses dan penghapusan data pribadi user.
+type DataPrivacyUsecase interface {
+	RequestExport(ctx context.Context, userID uint) (*DataExportResponse, error)
+	ListExports(ctx context.Context, userID uint) ([]DataExportResponse, error)
+	GetExport(ctx context.Context, userID uint, id string) (*DataExportResponse, error)
+	// PurgeExpiredExports menghapus arsip yang sudah kedaluwarsa dan mengembalikan jumlahnya.
+	PurgeExpiredExports(ctx context.Context) (int, error)
+	// ResumePendingExports membuat arsip untuk ekspor yang masih pending, misalnya karena server
+	// berhenti sebelum arsipnya dibuat, dan mengembalikan jumlah ekspor yang diambil alih.
+	ResumePendingExports(ctx context.Context) (int, error)
+	EraseAccount(ctx context.Context, userID uint, payload *EraseAccountPayload) error
+}
+
+var (
+	// ErrDataExportNotFound dikembalikan ketika ekspor tidak ada atau bukan milik user tersebut.
+	ErrDataExportNotFound = NewDomainError(http.StatusNotFound, "Data export not found")
+	// ErrDataExportStatusChanged dikembalikan repository ketika status ekspor sudah diubah proses lain
+	// atau ekspornya sudah dihapus, sehingga pemanggil tidak lagi memegang ekspor tersebut.
+	ErrDataExportStatusChanged = NewDomainError(http.StatusConflict, "Data export has changed")
+	// ErrDataExportInProgress dikembalikan ketika user masih memiliki ekspor yang sedang dibuat.
+	ErrDataExportInProgress = NewDomainError(http.StatusConflict, "A data export is already being prepared")
+	// ErrAccountHasActiveConsultations dikembalikan ketika klien masih memiliki konsultasi yang akan datang.
+	ErrAccountHasActiveConsultations = NewDomainError(http.StatusConflict, "Cancel your upcoming consultations before deleting your account")
+)
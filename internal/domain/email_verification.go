@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// EmailVerificationToken adalah token verifikasi email yang tersimpan dalam bentuk hash.
+type EmailVerificationToken struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	UserID    uint       `json:"user_id" gorm:"not null;index"`
+	TokenHash string     `json:"-" gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+
+	User User `json:"-" gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName mengembalikan nama tabel untuk model EmailVerificationToken.
+func (EmailVerificationToken) TableName() string {
+	return "email_verification_tokens"
+}
+
+// VerifyEmailPayload adalah payload verifikasi email memakai token dari email.
+type VerifyEmailPayload struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// EmailVerificationRepository mendefinisikan kontrak penyimpanan token verifikasi email.
+type EmailVerificationRepository interface {
+	Create(ctx context.Context, token *EmailVerificationToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*EmailVerificationToken, error)
+	MarkUsed(ctx context.Context, id uint) error
+	InvalidateAllForUser(ctx context.Context, userID uint) error
+}
+
+// EmailVerificationUsecase mendefinisikan kontrak verifikasi alamat email.
+type EmailVerificationUsecase interface {
+	SendVerification(ctx context.Context, user *User) error
+	VerifyEmail(ctx context.Context, payload *VerifyEmailPayload) error
+	ResendVerification(ctx context.Context, userID uint) error
+}
+
+var (
+	// ErrEmailVerificationTokenNotFound dikembalikan repository ketika hash token verifikasi tidak dikenal.
+	ErrEmailVerificationTokenNotFound = NewDomainError(http.StatusBadRequest, "Invalid or expired verification token")
+	// ErrInvalidEmailVerificationToken dikembalikan ketika token verifikasi tidak dikenal, kedaluwarsa, atau sudah dipakai.
+	ErrInvalidEmailVerificationToken = NewDomainError(http.StatusBadRequest, "Invalid or expired verification token")
+	// ErrEmailAlreadyVerified dikembalikan ketika user meminta ulang verifikasi untuk email yang sudah terverifikasi.
+	ErrEmailAlreadyVerified = NewDomainError(http.StatusConflict, "Email address is already verified")
+	// ErrVerificationResendTooSoon dikembalikan ketika email verifikasi diminta ulang sebelum cooldown berakhir.
+	ErrVerificationResendTooSoon = NewDomainError(http.StatusTooManyRequests, "Please wait before requesting another verification email")
+)
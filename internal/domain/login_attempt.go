@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+)
+
+// LoginAttemptPolicy mengatur perlambatan dan penguncian login yang belum berhasil.
+// FreeAttempts percobaan pertama tidak diperlambat; setelah itu jeda berlipat dua mulai dari
+// BaseDelay hingga MaxDelay. Setelah MaxAttempts percobaan, email dikunci selama Lockout.
+// Hitungan percobaan direset jika tidak ada percobaan baru selama Window.
+type LoginAttemptPolicy struct {
+	FreeAttempts int
+	MaxAttempts  int
+	BaseDelay    time.Duration
+	MaxDelay     time.Duration
+	Lockout      time.Duration
+	Window       time.Duration
+}
+
+// LoginAttemptUsecase mendefinisikan kontrak pelacakan percobaan login per email. Setiap
+// percobaan dicatat lewat Reserve sebelum password diperiksa, dan hitungannya dihapus lewat Reset
+// hanya setelah login berhasil. Email yang tidak terdaftar dilacak dengan cara yang sama agar
+// keberadaan akun tidak bisa ditebak.
+type LoginAttemptUsecase interface {
+	Reserve(ctx context.Context, email string) error
+	Reset(ctx context.Context, email string) error
+}
+
+// LoginThrottledError dikembalikan ketika login untuk suatu email sedang diperlambat atau dikunci.
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return e.domainError().Error()
+}
+
+// Unwrap mengembalikan DomainError 429 sehingga handler bisa memetakannya seperti error domain lain.
+func (e *LoginThrottledError) Unwrap() error {
+	return e.domainError()
+}
+
+// RetryAfterSeconds mengembalikan RetryAfter yang dibulatkan ke atas dalam detik.
+func (e *LoginThrottledError) RetryAfterSeconds() int {
+	return int(math.Ceil(e.RetryAfter.Seconds()))
+}
+
+func (e *LoginThrottledError) domainError() *DomainError {
+	return NewDomainError(http.StatusTooManyRequests,
+		fmt.Sprintf("Too many failed login attempts, try again in %d seconds", e.RetryAfterSeconds()))
+}
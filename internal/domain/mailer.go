@@ -0,0 +1,15 @@
+package domain
+
+import "context"
+
+// EmailMessage adalah email teks biasa yang dikirim ke satu penerima.
+type EmailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer mendefinisikan kontrak pengiriman email.
+type Mailer interface {
+	Send(ctx context.Context, message *EmailMessage) error
+}
@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// MFARoles adalah role yang boleh dan bisa diwajibkan memakai MFA.
+var MFARoles = []string{RoleAdmin, RolePsikolog}
+
+// IsMFARole mengembalikan true jika role termasuk MFARoles.
+func IsMFARole(role string) bool {
+	for _, r := range MFARoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// UserMFA menyimpan secret TOTP milik user dalam bentuk terenkripsi. EnabledAt bernilai nil selama
+// enrollment belum dikonfirmasi dengan kode yang valid.
+type UserMFA struct {
+	UserID          uint       `json:"user_id" gorm:"primaryKey;autoIncrement:false"`
+	SecretEncrypted string     `json:"-" gorm:"type:text;not null"`
+	EnabledAt       *time.Time `json:"enabled_at"`
+	LastUsedStep    int64      `json:"-" gorm:"not null;default:0"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	User User `json:"-" gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName mengembalikan nama tabel untuk model UserMFA.
+func (UserMFA) TableName() string {
+	return "user_mfa"
+}
+
+// IsEnabled mengembalikan true jika enrollment MFA sudah dikonfirmasi.
+func (m *UserMFA) IsEnabled() bool {
+	return m.EnabledAt != nil
+}
+
+// MFARecoveryCode adalah kode pemulihan sekali pakai yang tersimpan dalam bentuk hash.
+type MFARecoveryCode struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	UserID    uint       `json:"user_id" gorm:"not null;index"`
+	CodeHash  string     `json:"-" gorm:"type:varchar(64);not null"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+
+	User User `json:"-" gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName mengembalikan nama tabel untuk model MFARecoveryCode.
+func (MFARecoveryCode) TableName() string {
+	return "mfa_recovery_codes"
+}
+
+// MFARolePolicy menentukan apakah MFA wajib untuk suatu role.
+type MFARolePolicy struct {
+	Role      string    `json:"role" gorm:"primaryKey;type:varchar(20)"`
+	Required  bool      `json:"required" gorm:"not null;default:false"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName mengembalikan nama tabel untuk model MFARolePolicy.
+func (MFARolePolicy) TableName() string {
+	return "mfa_role_policies"
+}
+
+// MFAEnrollment adalah secret TOTP baru beserta URI provisioning untuk aplikasi authenticator.
+type MFAEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// MFARecoveryCodes adalah daftar kode pemulihan yang hanya ditampilkan sekali.
+type MFARecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// MFAChallenge adalah tantangan MFA yang diterbitkan setelah password benar.
+type MFAChallenge struct {
+	Token              string
+	ExpiresIn          int64
+	EnrollmentRequired bool
+}
+
+// MFACodePayload adalah payload berisi kode TOTP dari aplikasi authenticator.
+type MFACodePayload struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+// MFAChallengePayload adalah payload yang hanya berisi token tantangan MFA.
+type MFAChallengePayload struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+}
+
+// MFAVerifyPayload adalah payload langkah kedua login. Code berisi kode TOTP atau kode pemulihan.
+type MFAVerifyPayload struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+	Code     string `json:"code" validate:"required,max=32"`
+}
+
+// MFAPolicyPayload adalah payload admin untuk mewajibkan MFA pada suatu role.
+type MFAPolicyPayload struct {
+	Required *bool `json:"required" validate:"required"`
+}
+
+// MFARepository mendefinisikan kontrak penyimpanan data MFA.
+type MFARepository interface {
+	GetByUserID(ctx context.Context, userID uint) (*UserMFA, error)
+	Save(ctx context.Context, mfa *UserMFA) error
+	Delete(ctx context.Context, userID uint) error
+	UpdateLastUsedStep(ctx context.Context, userID uint, step int64) error
+	ReplaceRecoveryCodes(ctx context.Context, userID uint, codeHashes []string) error
+	UseRecoveryCode(ctx context.Context, userID uint, codeHash string) error
+	GetPolicy(ctx context.Context, role string) (*MFARolePolicy, error)
+	GetPolicies(ctx context.Context) ([]MFARolePolicy, error)
+	UpsertPolicy(ctx context.Context, policy *MFARolePolicy) error
+}
+
+// MFAUsecase mendefinisikan kontrak autentikasi dua langkah berbasis TOTP.
+type MFAUsecase interface {
+	BeginChallenge(ctx context.Context, user *User) (*MFAChallenge, error)
+	EnrollWithChallenge(ctx context.Context, payload *MFAChallengePayload) (*MFAEnrollment, error)
+	VerifyChallenge(ctx context.Context, payload *MFAVerifyPayload, client *ClientInfo) (*LoginResponse, error)
+	Enroll(ctx context.Context, userID uint) (*MFAEnrollment, error)
+	ConfirmEnrollment(ctx context.Context, userID uint, payload *MFACodePayload) (*MFARecoveryCodes, error)
+	RegenerateRecoveryCodes(ctx context.Context, userID uint, payload *MFACodePayload) (*MFARecoveryCodes, error)
+	Disable(ctx context.Context, userID uint, payload *MFACodePayload) error
+	GetPolicies(ctx context.Context) ([]MFARolePolicy, error)
+	SetPolicy(ctx context.Context, role string, payload *MFAPolicyPayload) (*MFARolePolicy, error)
+	// ResetFailures menghapus hitungan kode MFA salah dan penguncian MFA user.
+	ResetFailures(ctx context.Context, userID uint) error
+}
+
+var (
+	// ErrMFANotFound dikembalikan repository ketika user belum pernah melakukan enrollment MFA.
+	ErrMFANotFound = NewDomainError(http.StatusNotFound, "MFA is not configured")
+	// ErrMFANotAllowed dikembalikan ketika role user tidak mendukung MFA.
+	ErrMFANotAllowed = NewDomainError(http.StatusForbidden, "MFA is only available for psychologists and admins")
+	// ErrMFAAlreadyEnabled dikembalikan ketika user memulai enrollment padahal MFA sudah aktif.
+	ErrMFAAlreadyEnabled = NewDomainError(http.StatusConflict, "MFA is already enabled")
+	// ErrMFANotEnabled dikembalikan ketika operasi membutuhkan MFA yang sudah aktif.
+	ErrMFANotEnabled = NewDomainError(http.StatusConflict, "MFA is not enabled")
+	// ErrMFARequiredByPolicy dikembalikan ketika user mencoba menonaktifkan MFA yang diwajibkan untuk role-nya.
+	ErrMFARequiredByPolicy = NewDomainError(http.StatusForbidden, "MFA is required for your role")
+	// ErrInvalidMFACode dikembalikan ketika kode TOTP atau kode pemulihan salah atau sudah dipakai.
+	ErrInvalidMFACode = NewDomainError(http.StatusUnauthorized, "Invalid MFA code")
+	// ErrInvalidMFAChallenge dikembalikan ketika token tantangan MFA tidak dikenal atau kedaluwarsa.
+	ErrInvalidMFAChallenge = NewDomainError(http.StatusUnauthorized, "Invalid or expired MFA challenge")
+	// ErrMFAPolicyNotFound dikembalikan repository ketika belum ada kebijakan untuk role tersebut.
+	ErrMFAPolicyNotFound = NewDomainError(http.StatusNotFound, "MFA policy not found")
+)
@@ -0,0 +1,53 @@
+package domain
+
+// Batas default paginasi.
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// PaginationQuery adalah parameter paginasi dari query string.
+type PaginationQuery struct {
+	Page  int `form:"page" validate:"omitempty,min=1"`
+	Limit int `form:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// Normalize mengisi nilai default untuk page dan limit.
+func (q *PaginationQuery) Normalize() {
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.Limit < 1 {
+		q.Limit = DefaultPageLimit
+	}
+	if q.Limit > MaxPageLimit {
+		q.Limit = MaxPageLimit
+	}
+}
+
+// Offset menghitung offset query berdasarkan page dan limit.
+func (q PaginationQuery) Offset() int {
+	return (q.Page - 1) * q.Limit
+}
+
+// Pagination adalah metadata paginasi pada response.
+type Pagination struct {
+	Page       int   `json:"page"`
+	Limit      int   `json:"limit"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+}
+
+// NewPagination membuat metadata paginasi dari query dan jumlah total data.
+func NewPagination(q PaginationQuery, total int64) Pagination {
+	totalPages := 0
+	if q.Limit > 0 {
+		totalPages = int((total + int64(q.Limit) - 1) / int64(q.Limit))
+	}
+	return Pagination{
+		Page:       q.Page,
+		Limit:      q.Limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// PasswordResetToken adalah token reset password yang tersimpan dalam bentuk hash.
+// Token hanya bisa dipakai sekali dan berlaku sampai ExpiresAt.
+type PasswordResetToken struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	UserID    uint       `json:"user_id" gorm:"not null;index"`
+	TokenHash string     `json:"-" gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+
+	User User `json:"-" gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName mengembalikan nama tabel untuk model PasswordResetToken.
+func (PasswordResetToken) TableName() string {
+	return "password_reset_tokens"
+}
+
+// ForgotPasswordPayload adalah payload permintaan link reset password.
+type ForgotPasswordPayload struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ResetPasswordPayload adalah payload untuk mengganti password memakai token reset.
+type ResetPasswordPayload struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required"`
+}
+
+// ChangePasswordPayload adalah payload untuk mengganti password oleh user yang sedang login.
+type ChangePasswordPayload struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required"`
+}
+
+// PasswordResetRepository mendefinisikan kontrak penyimpanan token reset password.
+type PasswordResetRepository interface {
+	Create(ctx context.Context, token *PasswordResetToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*PasswordResetToken, error)
+	MarkUsed(ctx context.Context, id uint) error
+	InvalidateAllForUser(ctx context.Context, userID uint) error
+}
+
+// PasswordUsecase mendefinisikan kontrak pemulihan dan penggantian password. ChangePassword
+// mencabut semua sesi user kecuali currentSessionID.
+type PasswordUsecase interface {
+	ForgotPassword(ctx context.Context, payload *ForgotPasswordPayload) error
+	ResetPassword(ctx context.Context, payload *ResetPasswordPayload) error
+	ChangePassword(ctx context.Context, userID uint, currentSessionID string, payload *ChangePasswordPayload) error
+}
+
+var (
+	// ErrInvalidPasswordResetToken dikembalikan ketika token reset tidak dikenal, kedaluwarsa, atau sudah dipakai.
+	ErrInvalidPasswordResetToken = NewDomainError(http.StatusBadRequest, "Invalid or expired reset token")
+	// ErrIncorrectCurrentPassword dikembalikan ketika password lama yang dikirim saat ganti password salah.
+	ErrIncorrectCurrentPassword = NewDomainError(http.StatusBadRequest, "Current password is incorrect")
+	// ErrPasswordUnchanged dikembalikan ketika password baru sama dengan password lama.
+	ErrPasswordUnchanged = NewDomainError(http.StatusBadRequest, "New password must be different from the current password")
+)
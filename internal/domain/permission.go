@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Role yang dikenal aplikasi. Role disimpan di tabel users dan dibawa access token sebagai klaim "role".
+const (
+	RoleAdmin    = "admin"
+	RolePsikolog = "psikolog"
+	RoleKlien    = "klien"
+)
+
+// Roles adalah semua role yang dikenal aplikasi.
+var Roles = []string{RoleAdmin, RolePsikolog, RoleKlien}
+
+// IsRole mengembalikan true jika role termasuk Roles.
+func IsRole(role string) bool {
+	for _, r := range Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Permission yang diperiksa oleh RequirePermission. Pemetaan role ke permission disimpan di database
+// dan bisa diubah admin.
+const (
+	PermissionPsychologistsRead        = "psychologists:read"
+	PermissionPsychologistProfileWrite = "psychologist-profile:write"
+	PermissionAvailabilityWrite        = "availability:write"
+	PermissionConsultationsBook        = "consultations:book"
+	PermissionConsultationsManage      = "consultations:manage"
+	PermissionMFAManage                = "mfa:manage"
+	PermissionAccountDelete            = "account:delete"
+	PermissionUsersManage              = "users:manage"
+	PermissionPsychologistsVerify      = "psychologists:verify"
+	PermissionRolesManage              = "roles:manage"
+)
+
+// Permission menjelaskan satu permission untuk ditampilkan ke admin.
+type Permission struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// PermissionCatalog adalah semua permission yang dikenal aplikasi, dengan urutan yang dipakai di respons.
+var PermissionCatalog = []Permission{
+	{Name: PermissionPsychologistsRead, Description: "Browse the psychologist directory, availability and bookable slots"},
+	{Name: PermissionPsychologistProfileWrite, Description: "Manage own professional profile and submit it for verification"},
+	{Name: PermissionAvailabilityWrite, Description: "Manage own availability, schedule exceptions and session settings"},
+	{Name: PermissionConsultationsBook, Description: "Book, cancel and view own consultations"},
+	{Name: PermissionConsultationsManage, Description: "Review and update consultation requests addressed to oneself"},
+	{Name: PermissionMFAManage, Description: "Enroll, confirm and disable own two-factor authentication"},
+	{Name: PermissionAccountDelete, Description: "Delete own account and erase its personal data"},
+	{Name: PermissionUsersManage, Description: "Register psychologists and manage other users' logins and sessions"},
+	{Name: PermissionPsychologistsVerify, Description: "Review psychologist profiles and verify or reject their credentials"},
+	{Name: PermissionRolesManage, Description: "Edit role permissions and MFA policies"},
+}
+
+// IsPermission mengembalikan true jika permission ada di PermissionCatalog.
+func IsPermission(permission string) bool {
+	for _, p := range PermissionCatalog {
+		if p.Name == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultRolePermissions adalah pemetaan awal yang diisi saat tabel role_permissions dibuat.
+var DefaultRolePermissions = map[string][]string{
+	RoleAdmin:    {PermissionUsersManage, PermissionPsychologistsVerify, PermissionRolesManage, PermissionMFAManage},
+	RolePsikolog: {PermissionPsychologistProfileWrite, PermissionAvailabilityWrite, PermissionConsultationsManage, PermissionMFAManage},
+	RoleKlien:    {PermissionPsychologistsRead, PermissionConsultationsBook, PermissionAccountDelete},
+}
+
+// RolePermission memberikan satu permission ke satu role.
+type RolePermission struct {
+	Role       string    `json:"role" gorm:"primaryKey;type:varchar(20)"`
+	Permission string    `json:"permission" gorm:"primaryKey;type:varchar(50)"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName mengembalikan nama tabel untuk model RolePermission.
+func (RolePermission) TableName() string {
+	return "role_permissions"
+}
+
+// RolePermissions adalah daftar permission milik satu role.
+type RolePermissions struct {
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+}
+
+// SetRolePermissionsPayload mengganti seluruh permission milik satu role. Daftar kosong mencabut
+// semua permission role tersebut.
+type SetRolePermissionsPayload struct {
+	Permissions []string `json:"permissions" validate:"required,dive,required"`
+}
+
+// PermissionRepository mendefinisikan kontrak penyimpanan pemetaan role ke permission.
+type PermissionRepository interface {
+	List(ctx context.Context) ([]RolePermission, error)
+	ReplaceForRole(ctx context.Context, role string, permissions []string) error
+}
+
+// PermissionChecker memeriksa apakah suatu role memiliki permission. Dipakai oleh middleware.
+type PermissionChecker interface {
+	HasPermission(ctx context.Context, role, permission string) (bool, error)
+}
+
+// PermissionUsecase mendefinisikan kontrak pengelolaan permission oleh admin.
+type PermissionUsecase interface {
+	PermissionChecker
+	ListPermissions(ctx context.Context) []Permission
+	ListRolePermissions(ctx context.Context) ([]RolePermissions, error)
+	SetRolePermissions(ctx context.Context, role string, payload *SetRolePermissionsPayload) (*RolePermissions, error)
+}
+
+var (
+	// ErrRoleNotFound dikembalikan ketika role pada path tidak dikenal.
+	ErrRoleNotFound = NewDomainError(http.StatusNotFound, "Role not found")
+	// ErrAdminMustManageRoles mencegah admin mengunci dirinya sendiri dari pengaturan permission.
+	ErrAdminMustManageRoles = NewDomainError(http.StatusBadRequest, "The admin role must keep the roles:manage permission")
+)
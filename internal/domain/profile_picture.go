@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+)
+
+// Ukuran foto profil yang dihasilkan server, dalam piksel sisi persegi.
+const (
+	ProfilePictureSmall  = 96
+	ProfilePictureMedium = 256
+	ProfilePictureLarge  = 512
+)
+
+// ProfilePictureSizes adalah ukuran foto profil dari yang terbesar, urutan yang dipakai saat
+// mengecilkan gambar.
+var ProfilePictureSizes = []int{ProfilePictureLarge, ProfilePictureMedium, ProfilePictureSmall}
+
+// ProfilePictureURLs adalah URL bertanda tangan untuk setiap ukuran foto profil.
+type ProfilePictureURLs struct {
+	Small  string `json:"small"`
+	Medium string `json:"medium"`
+	Large  string `json:"large"`
+}
+
+// ProfilePictureUsecase mendefinisikan kontrak unggah dan penyajian foto profil.
+type ProfilePictureUsecase interface {
+	Upload(ctx context.Context, userID uint, data []byte) (*User, error)
+	Delete(ctx context.Context, userID uint) (*User, error)
+	// URLs membuat URL bertanda tangan untuk foto profil dengan key tersebut; nil jika key kosong.
+	URLs(key *string) *ProfilePictureURLs
+	MaxUploadBytes() int64
+}
+
+var (
+	// ErrProfilePictureTooLarge dikembalikan ketika file yang diunggah melebihi batas ukuran.
+	ErrProfilePictureTooLarge = NewDomainError(http.StatusRequestEntityTooLarge, "Profile picture is too large")
+	// ErrUnsupportedProfilePicture dikembalikan ketika isi file bukan gambar JPEG atau PNG.
+	ErrUnsupportedProfilePicture = NewDomainError(http.StatusUnsupportedMediaType, "Profile picture must be a JPEG or PNG image")
+	// ErrInvalidProfilePicture dikembalikan ketika gambar rusak atau dimensinya terlalu besar.
+	ErrInvalidProfilePicture = NewDomainError(http.StatusUnprocessableEntity, "Profile picture could not be processed")
+	// ErrProfilePictureNotFound dikembalikan ketika user belum memiliki foto profil.
+	ErrProfilePictureNotFound = NewDomainError(http.StatusNotFound, "Profile picture not found")
+	// ErrProfilePictureChanged dikembalikan ketika foto profil diubah request lain selama diproses.
+	ErrProfilePictureChanged = NewDomainError(http.StatusConflict, "Profile picture has changed, reload it and try again")
+)
@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Status verifikasi profil profesional psikolog.
+const (
+	VerificationStatusPending  = "pending"
+	VerificationStatusVerified = "verified"
+	VerificationStatusRejected = "rejected"
+)
+
+// PsychologistProfile adalah profil profesional psikolog beserta izin praktiknya. Psikolog hanya
+// tampil di direktori dan bisa dipesan setelah profilnya diverifikasi admin.
+type PsychologistProfile struct {
+	UserID uint `json:"user_id" gorm:"primaryKey;autoIncrement:false"`
+	// SIPP (Surat Izin Praktik Psikolog) wajib dimiliki untuk berpraktik.
+	SIPPNumber    string    `json:"sipp_number" gorm:"type:varchar(50);not null"`
+	SIPPExpiresAt time.Time `json:"sipp_expires_at" gorm:"type:date;not null"`
+	// STR (Surat Tanda Registrasi) bersifat opsional; STRExpiresAt nil berarti berlaku seumur hidup.
+	STRNumber          *string    `json:"str_number" gorm:"type:varchar(50)"`
+	STRExpiresAt       *time.Time `json:"str_expires_at" gorm:"type:date"`
+	Specializations    []string   `json:"specializations" gorm:"type:jsonb;serializer:json;not null"`
+	Languages          []string   `json:"languages" gorm:"type:jsonb;serializer:json;not null"`
+	Bio                string     `json:"bio" gorm:"type:text;not null;default:''"`
+	YearsOfPractice    int        `json:"years_of_practice" gorm:"not null;default:0"`
+	SessionFee         int64      `json:"session_fee" gorm:"not null"`
+	VerificationStatus string     `json:"verification_status" gorm:"type:varchar(20);not null;default:pending;index"`
+	RejectionReason    *string    `json:"rejection_reason" gorm:"type:text"`
+	ReviewedBy         *uint      `json:"reviewed_by"`
+	ReviewedAt         *time.Time `json:"reviewed_at" gorm:"type:timestamptz"`
+	SubmittedAt        time.Time  `json:"submitted_at" gorm:"type:timestamptz;not null"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+
+	User *User `json:"user,omitempty" gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName mengembalikan nama tabel untuk model PsychologistProfile.
+func (PsychologistProfile) TableName() string {
+	return "psychologist_profiles"
+}
+
+// LicenseValid mengembalikan true jika SIPP, dan STR bila ada, masih berlaku pada tanggal now.
+// Izin berlaku hingga akhir tanggal kedaluwarsanya.
+func (p *PsychologistProfile) LicenseValid(now time.Time) bool {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if p.SIPPExpiresAt.Before(today) {
+		return false
+	}
+	return p.STRExpiresAt == nil || !p.STRExpiresAt.Before(today)
+}
+
+// IsBookable mengembalikan true jika psikolog sudah diverifikasi dan izinnya masih berlaku.
+func (p *PsychologistProfile) IsBookable(now time.Time) bool {
+	return p.VerificationStatus == VerificationStatusVerified && p.LicenseValid(now)
+}
+
+// PsychologistProfessionalInfo adalah bagian profil profesional yang ditampilkan kepada klien.
+type PsychologistProfessionalInfo struct {
+	Specializations []string `json:"specializations"`
+	Languages       []string `json:"languages"`
+	Bio             string   `json:"bio"`
+	YearsOfPractice int      `json:"years_of_practice"`
+	SessionFee      int64    `json:"session_fee"`
+}
+
+// NewPsychologistProfessionalInfo membuat info profesional publik dari PsychologistProfile.
+func NewPsychologistProfessionalInfo(profile *PsychologistProfile) *PsychologistProfessionalInfo {
+	return &PsychologistProfessionalInfo{
+		Specializations: profile.Specializations,
+		Languages:       profile.Languages,
+		Bio:             profile.Bio,
+		YearsOfPractice: profile.YearsOfPractice,
+		SessionFee:      profile.SessionFee,
+	}
+}
+
+// PsychologistProfilePayload menggantikan profil profesional milik psikolog. Tanggal memakai format
+// YYYY-MM-DD dan SessionFee dalam rupiah.
+type PsychologistProfilePayload struct {
+	SIPPNumber      string   `json:"sipp_number" validate:"required,max=50"`
+	SIPPExpiresAt   string   `json:"sipp_expires_at" validate:"required,datetime=2006-01-02"`
+	STRNumber       string   `json:"str_number" validate:"omitempty,max=50"`
+	STRExpiresAt    string   `json:"str_expires_at" validate:"omitempty,excluded_without=STRNumber,datetime=2006-01-02"`
+	Specializations []string `json:"specializations" validate:"required,min=1,max=10,dive,required,max=100"`
+	Languages       []string `json:"languages" validate:"required,min=1,max=10,dive,required,max=50"`
+	Bio             string   `json:"bio" validate:"max=2000"`
+	YearsOfPractice int      `json:"years_of_practice" validate:"min=0,max=70"`
+	SessionFee      int64    `json:"session_fee" validate:"required,min=1"`
+}
+
+// PsychologistVerificationPayload adalah keputusan admin atas profil psikolog. Alasan wajib diisi
+// ketika profil ditolak dan ditampilkan kepada psikolog.
+type PsychologistVerificationPayload struct {
+	Status string `json:"status" validate:"required,oneof=verified rejected"`
+	Reason string `json:"reason" validate:"required_if=Status rejected,max=500"`
+}
+
+// PsychologistProfileQuery adalah filter daftar profil psikolog untuk admin.
+type PsychologistProfileQuery struct {
+	PaginationQuery
+	Status string `form:"status" validate:"omitempty,oneof=pending verified rejected"`
+}
+
+// PsychologistProfileResult adalah daftar profil psikolog yang terpaginasi.
+type PsychologistProfileResult struct {
+	Items      []PsychologistProfile `json:"items"`
+	Pagination Pagination            `json:"pagination"`
+}
+
+// PsychologistProfileFilter adalah kriteria pencarian profil psikolog pada repository.
+type PsychologistProfileFilter struct {
+	Status string
+	Limit  int
+	Offset int
+	// Preload ikut memuat akun pemilik profil.
+	Preload bool
+}
+
+// PsychologistProfileRepository mendefinisikan kontrak penyimpanan profil profesional psikolog.
+type PsychologistProfileRepository interface {
+	GetByUserID(ctx context.Context, userID uint) (*PsychologistProfile, error)
+	GetByUserIDs(ctx context.Context, userIDs []uint) ([]PsychologistProfile, error)
+	// Save membuat profil baru atau memperbarui detail profil tanpa mengubah status verifikasinya.
+	Save(ctx context.Context, profile *PsychologistProfile) error
+	// Resubmit memperbarui detail profil dan mengajukannya ulang hanya jika status dan waktu
+	// pengajuannya masih sama dengan yang dibaca.
+	Resubmit(ctx context.Context, profile *PsychologistProfile, expectedStatus string, expectedSubmittedAt time.Time) error
+	List(ctx context.Context, filter PsychologistProfileFilter) ([]PsychologistProfile, int64, error)
+	// UpdateVerification menyimpan keputusan verifikasi hanya jika profil belum berubah sejak
+	// dibaca, yaitu status dan waktu pengajuannya masih sama.
+	UpdateVerification(ctx context.Context, profile *PsychologistProfile, expectedStatus string, expectedSubmittedAt time.Time) error
+}
+
+// PsychologistProfileUsecase mendefinisikan kontrak pengelolaan dan verifikasi profil psikolog.
+type PsychologistProfileUsecase interface {
+	GetOwnProfile(ctx context.Context, userID uint) (*PsychologistProfile, error)
+	UpdateOwnProfile(ctx context.Context, userID uint, payload *PsychologistProfilePayload) (*PsychologistProfile, error)
+	ListProfiles(ctx context.Context, query *PsychologistProfileQuery) (*PsychologistProfileResult, error)
+	GetProfile(ctx context.Context, userID uint) (*PsychologistProfile, error)
+	Review(ctx context.Context, reviewerID, userID uint, payload *PsychologistVerificationPayload) (*PsychologistProfile, error)
+}
+
+var (
+	// ErrPsychologistNotFound dikembalikan ketika psikolog tidak ada atau belum bisa ditampilkan kepada klien.
+	ErrPsychologistNotFound = NewDomainError(http.StatusNotFound, "Psychologist not found")
+	// ErrPsychologistProfileNotFound dikembalikan ketika psikolog belum mengisi profil profesional.
+	ErrPsychologistProfileNotFound = NewDomainError(http.StatusNotFound, "Psychologist profile not found")
+	// ErrPsychologistProfileChanged dikembalikan ketika profil berubah sejak dibaca, misalnya saat admin
+	// sedang meninjaunya atau psikolog mengajukan ulang tepat ketika admin meninjau.
+	ErrPsychologistProfileChanged = NewDomainError(http.StatusConflict, "Psychologist profile has changed, reload it and try again")
+	// ErrInvalidVerificationTransition dikembalikan ketika keputusan verifikasi tidak berlaku untuk status profil saat ini.
+	ErrInvalidVerificationTransition = NewDomainError(http.StatusConflict, "Psychologist profile cannot be reviewed in its current status")
+	// ErrLicenseExpired dikembalikan ketika izin praktik yang diajukan atau ditinjau sudah kedaluwarsa.
+	ErrLicenseExpired = NewDomainError(http.StatusUnprocessableEntity, "Practice license has expired")
+)
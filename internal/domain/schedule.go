@@ -0,0 +1,186 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// TimeRange adalah rentang waktu setengah terbuka [Start, End).
+type TimeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Overlaps memeriksa apakah dua rentang waktu beririsan.
+func (r TimeRange) Overlaps(other TimeRange) bool {
+	return r.Start.Before(other.End) && other.Start.Before(r.End)
+}
+
+// Duration mengembalikan panjang rentang waktu.
+func (r TimeRange) Duration() time.Duration {
+	return r.End.Sub(r.Start)
+}
+
+// BookableSlot adalah slot konsultasi bertanggal yang masih bisa dipesan klien.
+type BookableSlot struct {
+	Tanggal      string    `json:"tanggal"`
+	Hari         string    `json:"hari"`
+	WaktuMulai   time.Time `json:"waktu_mulai"`
+	WaktuSelesai time.Time `json:"waktu_selesai"`
+}
+
+// BookableSlotsQuery adalah parameter rentang kalender dari query string.
+type BookableSlotsQuery struct {
+	From string `form:"from" validate:"omitempty,datetime=2006-01-02"`
+	Days int    `form:"days" validate:"omitempty,min=1,max=90"`
+}
+
+// DefaultBookingHorizonDays adalah jumlah hari ke depan yang ditampilkan bila tidak ditentukan.
+const DefaultBookingHorizonDays = 28
+
+// ExpandWeeklyAvailability mengubah jadwal mingguan menjadi rentang waktu bertanggal
+// untuk setiap tanggal di [from, to) pada zona waktu loc.
+func ExpandWeeklyAvailability(slots []WaktuKonsultasi, from, to time.Time, loc *time.Location) []TimeRange {
+	byDay := make(map[string][]WaktuKonsultasi)
+	for _, slot := range slots {
+		byDay[slot.Hari] = append(byDay[slot.Hari], slot)
+	}
+
+	var ranges []TimeRange
+	start := from.In(loc)
+	for day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc); day.Before(to); day = day.AddDate(0, 0, 1) {
+		for _, slot := range byDay[HariFromWeekday(day.Weekday())] {
+			r, ok := slotOnDate(slot, day, loc)
+			if ok {
+				ranges = append(ranges, r)
+			}
+		}
+	}
+
+	sortRanges(ranges)
+	return ranges
+}
+
+// ComputeAvailability menghitung rentang waktu praktik bertanggal di [from, to) dari jadwal
+// mingguan yang sudah ditimpa oleh pengecualian jadwal.
+func ComputeAvailability(slots []WaktuKonsultasi, exceptions []PengecualianJadwal, from, to time.Time, loc *time.Location) []TimeRange {
+	ranges := ExpandWeeklyAvailability(slots, from, to, loc)
+
+	var blocked, extra []TimeRange
+	for _, exception := range exceptions {
+		date := exception.Tanggal.Format("2006-01-02")
+		switch exception.Jenis {
+		case PengecualianFullDay:
+			start, err := time.ParseInLocation("2006-01-02", date, loc)
+			if err != nil {
+				continue
+			}
+			blocked = append(blocked, TimeRange{Start: start, End: start.AddDate(0, 0, 1)})
+		case PengecualianPartialBlock, PengecualianExtraHours:
+			if exception.WaktuMulai == nil || exception.WaktuSelesai == nil {
+				continue
+			}
+			r, ok := slotOnDate(WaktuKonsultasi{WaktuMulai: *exception.WaktuMulai, WaktuSelesai: *exception.WaktuSelesai}, exception.Tanggal, loc)
+			if !ok {
+				continue
+			}
+			if exception.Jenis == PengecualianExtraHours {
+				extra = append(extra, r)
+			} else {
+				blocked = append(blocked, r)
+			}
+		}
+	}
+
+	// Tambahan jam ditambahkan terlebih dahulu lalu blok diterapkan, sehingga blok selalu menang
+	ranges = MergeRanges(append(ranges, extra...))
+	return MergeRanges(SubtractRanges(ranges, blocked))
+}
+
+// SliceIntoSessions memotong setiap rentang menjadi sesi sepanjang duration yang dipisahkan
+// oleh buffer. Sisa rentang yang tidak cukup untuk satu sesi penuh diabaikan.
+func SliceIntoSessions(ranges []TimeRange, duration, buffer time.Duration) []TimeRange {
+	if duration <= 0 {
+		return nil
+	}
+
+	var sessions []TimeRange
+	for _, r := range ranges {
+		for start := r.Start; !start.Add(duration).After(r.End); start = start.Add(duration + buffer) {
+			sessions = append(sessions, TimeRange{Start: start, End: start.Add(duration)})
+		}
+	}
+	return sessions
+}
+
+// MergeRanges menggabungkan rentang waktu yang beririsan atau bersambung.
+func MergeRanges(ranges []TimeRange) []TimeRange {
+	if len(ranges) == 0 {
+		return ranges
+	}
+
+	sorted := make([]TimeRange, len(ranges))
+	copy(sorted, ranges)
+	sortRanges(sorted)
+
+	merged := []TimeRange{sorted[0]}
+	for _, r := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if !r.Start.After(last.End) {
+			if r.End.After(last.End) {
+				last.End = r.End
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// SubtractRanges mengurangi setiap rentang pada base dengan rentang pada blocked.
+func SubtractRanges(base, blocked []TimeRange) []TimeRange {
+	result := make([]TimeRange, 0, len(base))
+	for _, r := range base {
+		pieces := []TimeRange{r}
+		for _, b := range blocked {
+			next := make([]TimeRange, 0, len(pieces))
+			for _, p := range pieces {
+				if !p.Overlaps(b) {
+					next = append(next, p)
+					continue
+				}
+				if p.Start.Before(b.Start) {
+					next = append(next, TimeRange{Start: p.Start, End: b.Start})
+				}
+				if b.End.Before(p.End) {
+					next = append(next, TimeRange{Start: b.End, End: p.End})
+				}
+			}
+			pieces = next
+		}
+		result = append(result, pieces...)
+	}
+
+	sortRanges(result)
+	return result
+}
+
+// slotOnDate menempatkan slot mingguan pada tanggal kalender dari day.
+func slotOnDate(slot WaktuKonsultasi, day time.Time, loc *time.Location) (TimeRange, bool) {
+	date := day.Format("2006-01-02")
+	start, err := time.ParseInLocation("2006-01-02 15:04:05", date+" "+slot.WaktuMulai, loc)
+	if err != nil {
+		return TimeRange{}, false
+	}
+	end, err := time.ParseInLocation("2006-01-02 15:04:05", date+" "+slot.WaktuSelesai, loc)
+	if err != nil || !start.Before(end) {
+		return TimeRange{}, false
+	}
+	return TimeRange{Start: start, End: end}, true
+}
+
+func sortRanges(ranges []TimeRange) {
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].Start.Before(ranges[j].Start)
+	})
+}
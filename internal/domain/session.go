@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Session adalah satu login aktif milik user. ID sesi sama dengan FamilyID refresh token yang
+// diterbitkan saat login dan dibawa oleh access token sebagai klaim "sid".
+type Session struct {
+	ID         string     `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	UserID     uint       `json:"user_id" gorm:"not null;index"`
+	DeviceName string     `json:"device_name" gorm:"type:varchar(100)"`
+	UserAgent  string     `json:"user_agent" gorm:"type:varchar(512)"`
+	IPAddress  string     `json:"ip_address" gorm:"type:varchar(45)"`
+	LastSeenAt time.Time  `json:"last_seen_at" gorm:"not null"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"not null"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+
+	User User `json:"-" gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName mengembalikan nama tabel untuk model Session.
+func (Session) TableName() string {
+	return "user_sessions"
+}
+
+// SessionResponse adalah sesi yang ditampilkan ke user. Current menandai sesi dari token yang
+// sedang dipakai.
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	DeviceName string    `json:"device_name"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	CreatedAt  time.Time `json:"created_at"`
+	Current    bool      `json:"current"`
+}
+
+// ClientInfo adalah informasi perangkat dari request login atau refresh.
+type ClientInfo struct {
+	UserAgent string
+	IPAddress string
+}
+
+// DeviceName membuat nama perangkat yang mudah dibaca dari user agent, misalnya "Chrome on Windows".
+func DeviceName(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+	if ua == "" {
+		return "Unknown device"
+	}
+
+	browser := "Unknown browser"
+	switch {
+	case strings.Contains(ua, "edg/"):
+		browser = "Edge"
+	case strings.Contains(ua, "opr/") || strings.Contains(ua, "opera"):
+		browser = "Opera"
+	case strings.Contains(ua, "firefox/"):
+		browser = "Firefox"
+	case strings.Contains(ua, "chrome/") || strings.Contains(ua, "crios/"):
+		browser = "Chrome"
+	case strings.Contains(ua, "safari/"):
+		browser = "Safari"
+	case strings.Contains(ua, "okhttp") || strings.Contains(ua, "dart"):
+		browser = "Mobile app"
+	}
+
+	os := "unknown OS"
+	switch {
+	case strings.Contains(ua, "android"):
+		os = "Android"
+	case strings.Contains(ua, "iphone") || strings.Contains(ua, "ipad"):
+		os = "iOS"
+	case strings.Contains(ua, "windows"):
+		os = "Windows"
+	case strings.Contains(ua, "mac os"):
+		os = "macOS"
+	case strings.Contains(ua, "linux"):
+		os = "Linux"
+	}
+
+	return browser + " on " + os
+}
+
+// SessionRepository mendefinisikan kontrak penyimpanan sesi login.
+type SessionRepository interface {
+	Create(ctx context.Context, session *Session) error
+	GetByID(ctx context.Context, id string) (*Session, error)
+	ListActiveByUser(ctx context.Context, userID uint) ([]Session, error)
+	Touch(ctx context.Context, id string, client *ClientInfo, expiresAt time.Time) error
+	Revoke(ctx context.Context, id string) error
+	RevokeAllForUser(ctx context.Context, userID uint, exceptID string) ([]string, error)
+}
+
+// SessionUsecase mendefinisikan kontrak pengelolaan sesi login. currentSessionID dipakai untuk
+// menandai sesi yang sedang dipakai dan dikecualikan saat mencabut sesi lain.
+type SessionUsecase interface {
+	ListSessions(ctx context.Context, userID uint, currentSessionID string) ([]SessionResponse, error)
+	RevokeSession(ctx context.Context, userID uint, sessionID string) error
+	RevokeOtherSessions(ctx context.Context, userID uint, currentSessionID string) error
+}
+
+var (
+	// ErrSessionNotFound dikembalikan ketika sesi tidak ada atau bukan milik user tersebut.
+	ErrSessionNotFound = NewDomainError(http.StatusNotFound, "Session not found")
+)
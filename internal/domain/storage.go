@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// FileStorage mendefinisikan kontrak penyimpanan file. Key adalah path relatif yang opak bagi
+// pemanggil; file hanya bisa diakses klien lewat URL bertanda tangan yang kedaluwarsa.
+type FileStorage interface {
+	Put(ctx context.Context, key, contentType string, data []byte) error
+	Delete(ctx context.Context, key string) error
+	SignedURL(key string, ttl time.Duration) (string, error)
+}
+
+// StoredFile adalah file yang dibuka dari penyimpanan untuk dikirim ke klien.
+type StoredFile struct {
+	Content     io.ReadSeekCloser
+	ContentType string
+	ModTime     time.Time
+}
+
+// SignedFileServer membuka file berdasarkan URL bertanda tangan. Hanya diimplementasikan oleh
+// penyimpanan yang file-nya dilayani aplikasi sendiri, bukan oleh layanan penyimpanan eksternal.
+type SignedFileServer interface {
+	Open(ctx context.Context, key, expires, signature string) (*StoredFile, error)
+}
+
+var (
+	// ErrFileNotFound dikembalikan ketika file pada key tidak ada.
+	ErrFileNotFound = NewDomainError(http.StatusNotFound, "File not found")
+	// ErrInvalidFileSignature dikembalikan ketika tanda tangan URL file salah atau sudah kedaluwarsa.
+	ErrInvalidFileSignature = NewDomainError(http.StatusForbidden, "Invalid or expired file URL")
+)
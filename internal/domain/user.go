@@ -3,32 +3,122 @@ package domain
 import (
 	"context"
 	"errors"
+	"net/http"
 	"time"
+
+	"gorm.io/gorm"
 )
 
+// User adalah akun aplikasi. Kolom mengikuti tabel users pada migrasi; PhoneNumber disimpan dalam
+// format E.164 dan ProfilePictureKey adalah key opak foto profil pada FileStorage. Akun yang
+// dinonaktifkan admin tidak bisa login; akun yang dihapus disembunyikan lewat soft delete agar
+// riwayat konsultasinya tetap utuh.
 type User struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	Username  string    `json:"username" gorm:"unique;not null"`
-	Email     string    `json:"email" gorm:"unique;not null"`
-	Password  string    `json:"-" gorm:"not null"`
-	Role      string    `json:"role" gorm:"not null"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID                uint           `json:"id" gorm:"primaryKey"`
+	Username          string         `json:"username" gorm:"type:varchar(100);not null"`
+	Email             string         `json:"email" gorm:"unique;not null"`
+	Password          string         `json:"-" gorm:"not null"`
+	PhoneNumber       *string        `json:"phone_number" gorm:"type:varchar(20)"`
+	Gender            *string        `json:"gender" gorm:"type:varchar(10)"`
+	ProfilePictureKey *string        `json:"-" gorm:"type:varchar(255)"`
+	Role              string         `json:"role" gorm:"not null"`
+	EmailVerifiedAt   *time.Time     `json:"email_verified_at"`
+	DeactivatedAt     *time.Time     `json:"deactivated_at,omitempty"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// IsDeactivated mengembalikan true jika akun dinonaktifkan admin.
+func (u *User) IsDeactivated() bool {
+	return u.DeactivatedAt != nil
+}
+
+// IsEmailVerified mengembalikan true jika user sudah memverifikasi alamat emailnya.
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
 }
 
 type UserResponse struct {
-	ID        uint      `json:"id"`
-	Username  string    `json:"username"`
-	Email     string    `json:"email"`
-	Role      string    `json:"role"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID             uint                `json:"id"`
+	Username       string              `json:"username"`
+	Email          string              `json:"email"`
+	PhoneNumber    *string             `json:"phone_number"`
+	Gender         *string             `json:"gender"`
+	ProfilePicture *ProfilePictureURLs `json:"profile_picture"`
+	// ProfilePictureKey dipakai handler untuk menandatangani ProfilePicture dan tidak dikirim ke klien.
+	ProfilePictureKey *string    `json:"-"`
+	Role              string     `json:"role"`
+	EmailVerifiedAt   *time.Time `json:"email_verified_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// PsychologistPublicProfile adalah data psikolog yang aman ditampilkan kepada klien.
+type PsychologistPublicProfile struct {
+	ID       uint   `json:"id"`
+	Username string `json:"username"`
+}
+
+// NewPsychologistPublicProfile membuat profil publik dari entitas User.
+func NewPsychologistPublicProfile(user *User) *PsychologistPublicProfile {
+	return &PsychologistPublicProfile{
+		ID:       user.ID,
+		Username: user.Username,
+	}
+}
+
+// PsychologistDirectoryEntry adalah satu psikolog pada direktori beserta profil profesional dan
+// ringkasan jadwalnya.
+type PsychologistDirectoryEntry struct {
+	PsychologistPublicProfile
+	Professional *PsychologistProfessionalInfo `json:"professional"`
+	Availability []AvailabilitySummary         `json:"availability"`
 }
 
+// PsychologistDirectoryResult adalah hasil pencarian direktori psikolog yang terpaginasi.
+type PsychologistDirectoryResult struct {
+	Items      []PsychologistDirectoryEntry `json:"items"`
+	Pagination Pagination                   `json:"pagination"`
+}
+
+// PsychologistDirectoryQuery adalah filter direktori psikolog dari query string.
+type PsychologistDirectoryQuery struct {
+	PaginationQuery
+	Hari         string `form:"hari" validate:"omitempty,oneof=Senin Selasa Rabu Kamis Jumat Sabtu Minggu"`
+	WaktuMulai   string `form:"waktu_mulai" validate:"omitempty,datetime=15:04:05"`
+	WaktuSelesai string `form:"waktu_selesai" validate:"omitempty,datetime=15:04:05"`
+	Q            string `form:"q" validate:"max=100"`
+}
+
+// PsychologistFilter adalah kriteria pencarian psikolog pada repository. Hanya psikolog terverifikasi
+// dengan izin yang masih berlaku pada VerifiedOn yang dikembalikan. Filter hari dan waktu hanya
+// meloloskan psikolog yang memiliki slot yang beririsan.
+type PsychologistFilter struct {
+	VerifiedOn   time.Time
+	Hari         string
+	WaktuMulai   string
+	WaktuSelesai string
+	Query        string
+	Limit        int
+	Offset       int
+}
+
+// RegisterPayload adalah payload registrasi. Kekuatan password diperiksa oleh kebijakan password
+// di usecase, bukan oleh tag validasi.
 type RegisterPayload struct {
 	Username string `json:"username" validate:"required,min=3,max=50"`
 	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=8"`
+	Password string `json:"password" validate:"required"`
+}
+
+// UpdateProfilePayload menggantikan data profil yang bisa diubah user. Field opsional yang
+// dikosongkan akan menghapus nilainya; nomor telepon diterima dalam format Indonesia yang umum
+// dan disimpan dalam format E.164. Foto profil diubah lewat endpoint unggah tersendiri.
+type UpdateProfilePayload struct {
+	Username    string `json:"username" validate:"required,min=3,max=50"`
+	PhoneNumber string `json:"phone_number" validate:"omitempty,max=25"`
+	Gender      string `json:"gender" validate:"omitempty,oneof=laki-laki perempuan"`
 }
 
 type LoginPayload struct {
@@ -36,24 +126,67 @@ type LoginPayload struct {
 	Password string `json:"password" validate:"required"`
 }
 
+// LoginResponse adalah hasil login. Jika MFA aktif atau diwajibkan, token belum diterbitkan dan
+// klien harus menyelesaikan tantangan MFA memakai MFAToken.
 type LoginResponse struct {
-	Token string        `json:"token"`
-	User  *UserResponse `json:"user"`
+	*TokenPair
+	User                  *UserResponse `json:"user,omitempty"`
+	MFARequired           bool          `json:"mfa_required,omitempty"`
+	MFAEnrollmentRequired bool          `json:"mfa_enrollment_required,omitempty"`
+	MFAToken              string        `json:"mfa_token,omitempty"`
+	MFATokenExpiresIn     int64         `json:"mfa_token_expires_in,omitempty"`
+	RecoveryCodes         []string      `json:"recovery_codes,omitempty"`
+}
+
+// NewUserResponse membuat UserResponse tanpa data sensitif dari entitas User. URL foto profil
+// diisi terpisah karena membutuhkan FileStorage untuk ditandatangani.
+func NewUserResponse(user *User) *UserResponse {
+	return &UserResponse{
+		ID:                user.ID,
+		Username:          user.Username,
+		Email:             user.Email,
+		PhoneNumber:       user.PhoneNumber,
+		Gender:            user.Gender,
+		ProfilePictureKey: user.ProfilePictureKey,
+		Role:              user.Role,
+		EmailVerifiedAt:   user.EmailVerifiedAt,
+		CreatedAt:         user.CreatedAt,
+		UpdatedAt:         user.UpdatedAt,
+	}
 }
 
 type UserRepository interface {
 	Create(ctx context.Context, user *User) error
 	GetByEmail(ctx context.Context, email string) (*User, error)
 	GetByID(ctx context.Context, id uint) (*User, error)
-	Update(ctx context.Context, user *User) error
+	// UpdateProfile hanya menyimpan username, nomor telepon, dan gender user yang belum dihapus.
+	// ErrUserNotFound dikembalikan jika user tidak ada atau sudah dihapus.
+	UpdateProfile(ctx context.Context, user *User) error
+	// UpdateProfilePictureKey mengganti key foto profil hanya jika key di database masih
+	// expectedKey. ErrProfilePictureChanged dikembalikan jika key sudah diubah request lain atau
+	// user sudah dihapus.
+	UpdateProfilePictureKey(ctx context.Context, id uint, expectedKey, key *string) error
+	// MarkEmailVerified mengisi email_verified_at hanya jika email belum terverifikasi. Tidak ada
+	// yang berubah jika email sudah terverifikasi atau user sudah dihapus.
+	MarkEmailVerified(ctx context.Context, id uint, verifiedAt time.Time) error
+	// UpdatePassword hanya mengganti hash password user yang belum dihapus. ErrUserNotFound
+	// dikembalikan jika user tidak ada atau sudah dihapus.
+	UpdatePassword(ctx context.Context, id uint, hashedPassword string) error
+	UpdateRole(ctx context.Context, id uint, role string) error
+	SetDeactivatedAt(ctx context.Context, id uint, deactivatedAt *time.Time) error
 	Delete(ctx context.Context, id uint) error
+	FindPsychologists(ctx context.Context, filter PsychologistFilter) ([]User, int64, error)
+	List(ctx context.Context, filter UserFilter) ([]User, int64, error)
 }
 
 type UserUsecase interface {
 	Register(ctx context.Context, payload *RegisterPayload) (*User, error)
 	RegisterPsychologist(ctx context.Context, payload *RegisterPayload) (*User, error)
-	Login(ctx context.Context, payload *LoginPayload) (*LoginResponse, error)
+	Login(ctx context.Context, payload *LoginPayload, client *ClientInfo) (*LoginResponse, error)
+	UnlockLogin(ctx context.Context, userID uint) error
 	GetProfile(ctx context.Context, userID uint) (*User, error)
+	UpdateProfile(ctx context.Context, userID uint, payload *UpdateProfilePayload) (*User, error)
+	GetAvailablePsychologists(ctx context.Context, query *PsychologistDirectoryQuery) (*PsychologistDirectoryResult, error)
 }
 
 // Common errors
@@ -62,4 +195,9 @@ var (
 	ErrEmailAlreadyExists = errors.New("email already exists")
 	ErrInvalidCredentials = errors.New("invalid credentials")
 	ErrInvalidInput       = errors.New("invalid input")
+	// ErrInvalidPhoneNumber dikembalikan ketika nomor telepon bukan nomor Indonesia yang valid.
+	ErrInvalidPhoneNumber = NewDomainError(http.StatusBadRequest, "Phone number must be a valid Indonesian number")
+	// ErrAccountDeactivated dikembalikan ketika akun yang dinonaktifkan admin mencoba login atau
+	// memperbarui token.
+	ErrAccountDeactivated = NewDomainError(http.StatusForbidden, "Account is deactivated")
 )
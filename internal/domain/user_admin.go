@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Status akun pada daftar user admin.
+const (
+	UserStatusActive      = "active"
+	UserStatusDeactivated = "deactivated"
+)
+
+// AdminUserQuery adalah filter daftar user untuk admin. Search mencocokkan username atau email.
+type AdminUserQuery struct {
+	PaginationQuery
+	Search string `form:"search" validate:"omitempty,max=100"`
+	Role   string `form:"role" validate:"omitempty,oneof=admin psikolog klien"`
+	Status string `form:"status" validate:"omitempty,oneof=active deactivated"`
+}
+
+// UserFilter adalah kriteria pencarian user pada repository.
+type UserFilter struct {
+	Search string
+	Role   string
+	Status string
+	Limit  int
+	Offset int
+}
+
+// AdminUserResponse adalah data user yang ditampilkan kepada admin.
+type AdminUserResponse struct {
+	*UserResponse
+	Status        string     `json:"status"`
+	DeactivatedAt *time.Time `json:"deactivated_at"`
+}
+
+// NewAdminUserResponse membuat AdminUserResponse dari entitas User.
+func NewAdminUserResponse(user *User) *AdminUserResponse {
+	status := UserStatusActive
+	if user.IsDeactivated() {
+		status = UserStatusDeactivated
+	}
+	return &AdminUserResponse{
+		UserResponse:  NewUserResponse(user),
+		Status:        status,
+		DeactivatedAt: user.DeactivatedAt,
+	}
+}
+
+// AdminUserResult adalah daftar user yang terpaginasi.
+type AdminUserResult struct {
+	Items      []*AdminUserResponse `json:"items"`
+	Pagination Pagination           `json:"pagination"`
+}
+
+// ChangeRolePayload adalah payload admin untuk mengganti role user.
+type ChangeRolePayload struct {
+	Role string `json:"role" validate:"required,oneof=admin psikolog klien"`
+}
+
+// UserAdminUsecase mendefinisikan kontrak pengelolaan akun oleh admin. adminID dipakai untuk
+// mencegah admin mengubah akunnya sendiri.
+type UserAdminUsecase interface {
+	ListUsers(ctx context.Context, query *AdminUserQuery) (*AdminUserResult, error)
+	GetUser(ctx context.Context, userID uint) (*User, error)
+	ChangeRole(ctx context.Context, adminID, userID uint, payload *ChangeRolePayload) (*User, error)
+	Deactivate(ctx context.Context, adminID, userID uint) (*User, error)
+	Reactivate(ctx context.Context, adminID, userID uint) (*User, error)
+	DeleteUser(ctx context.Context, adminID, userID uint) error
+}
+
+var (
+	// ErrManagedUserNotFound dikembalikan ketika user yang dikelola admin tidak ada atau sudah dihapus.
+	ErrManagedUserNotFound = NewDomainError(http.StatusNotFound, "User not found")
+	// ErrCannotManageOwnAccount mencegah admin mengunci dirinya sendiri dengan mengganti role,
+	// menonaktifkan, atau menghapus akunnya.
+	ErrCannotManageOwnAccount = NewDomainError(http.StatusBadRequest, "Admins cannot change the role of, deactivate or delete their own account")
+)
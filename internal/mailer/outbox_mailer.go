@@ -0,0 +1,56 @@
+package mailer
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"go.uber.org/zap"
+)
+
+type outboxMailer struct {
+	dir    string
+	from   string
+	logger *zap.Logger
+}
+
+// NewOutboxMailer membuat Mailer yang menulis setiap email sebagai file .eml di dir.
+// Dipakai untuk development dan test agar tidak perlu server SMTP.
+func NewOutboxMailer(dir, from string, logger *zap.Logger) domain.Mailer {
+	return &outboxMailer{
+		dir:    dir,
+		from:   from,
+		logger: logger,
+	}
+}
+
+// Send menulis email ke direktori outbox.
+func (m *outboxMailer) Send(ctx context.Context, message *domain.EmailMessage) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(m.dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create outbox directory: %w", err)
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return fmt.Errorf("failed to generate outbox file name: %w", err)
+	}
+
+	now := time.Now()
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405.000000000"), hex.EncodeToString(suffix))
+	path := filepath.Join(m.dir, name)
+	if err := os.WriteFile(path, buildMessage(m.from, message, now), 0o640); err != nil {
+		return fmt.Errorf("failed to write outbox email: %w", err)
+	}
+
+	m.logger.Info("Email written to outbox", zap.String("path", path), zap.String("subject", message.Subject))
+	return nil
+}
@@ -0,0 +1,41 @@
+package mailer_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/internal/mailer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestOutboxMailer_Send(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "outbox")
+	m := mailer.NewOutboxMailer(dir, "no-reply@gopsy.test", zap.NewNop())
+
+	err := m.Send(context.Background(), &domain.EmailMessage{
+		To:      "user@example.com\r\nBcc: attacker@example.com",
+		Subject: "Reset password",
+		Body:    "Line one\nLine two",
+	})
+	require.NoError(t, err)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	content, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	require.NoError(t, err)
+
+	email := string(content)
+	assert.Contains(t, email, "From: no-reply@gopsy.test\r\n")
+	assert.Contains(t, email, "Subject: Reset password\r\n")
+	assert.Contains(t, email, "Line one\r\nLine two")
+	// CR/LF pada header dibuang sehingga tidak bisa menyisipkan header baru
+	assert.Contains(t, email, "To: user@example.comBcc: attacker@example.com\r\n")
+	assert.NotContains(t, email, "\r\nBcc:")
+}
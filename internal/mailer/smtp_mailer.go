@@ -0,0 +1,55 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"go.uber.org/zap"
+)
+
+// SMTPConfig berisi konfigurasi server SMTP.
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+type smtpMailer struct {
+	cfg    SMTPConfig
+	logger *zap.Logger
+}
+
+// NewSMTPMailer membuat Mailer yang mengirim email lewat server SMTP. STARTTLS dipakai
+// otomatis jika didukung server.
+func NewSMTPMailer(cfg SMTPConfig, logger *zap.Logger) domain.Mailer {
+	return &smtpMailer{
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// Send mengirim email lewat server SMTP.
+func (m *smtpMailer) Send(ctx context.Context, message *domain.EmailMessage) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.cfg.Username != "" {
+		auth = smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+	}
+
+	addr := net.JoinHostPort(m.cfg.Host, m.cfg.Port)
+	body := buildMessage(m.cfg.From, message, time.Now())
+	if err := smtp.SendMail(addr, auth, m.cfg.From, []string{message.To}, body); err != nil {
+		m.logger.Error("Failed to send email", zap.Error(err), zap.String("subject", message.Subject))
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,327 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/auth.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/X3nonxe/gopsy-backend/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRefreshTokenRepository is a mock of RefreshTokenRepository interface.
+type MockRefreshTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefreshTokenRepositoryMockRecorder
+}
+
+// MockRefreshTokenRepositoryMockRecorder is the mock recorder for MockRefreshTokenRepository.
+type MockRefreshTokenRepositoryMockRecorder struct {
+	mock *MockRefreshTokenRepository
+}
+
+// NewMockRefreshTokenRepository creates a new mock instance.
+func NewMockRefreshTokenRepository(ctrl *gomock.Controller) *MockRefreshTokenRepository {
+	mock := &MockRefreshTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockRefreshTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefreshTokenRepository) EXPECT() *MockRefreshTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRefreshTokenRepository) Create(ctx context.Context, token *domain.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRefreshTokenRepositoryMockRecorder) Create(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRefreshTokenRepository)(nil).Create), ctx, token)
+}
+
+// GetByHash mocks base method.
+func (m *MockRefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*domain.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockRefreshTokenRepositoryMockRecorder) GetByHash(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockRefreshTokenRepository)(nil).GetByHash), ctx, tokenHash)
+}
+
+// MarkUsed mocks base method.
+func (m *MockRefreshTokenRepository) MarkUsed(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockRefreshTokenRepositoryMockRecorder) MarkUsed(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockRefreshTokenRepository)(nil).MarkUsed), ctx, id)
+}
+
+// RevokeAllForUser mocks base method.
+func (m *MockRefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllForUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAllForUser indicates an expected call of RevokeAllForUser.
+func (mr *MockRefreshTokenRepositoryMockRecorder) RevokeAllForUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllForUser", reflect.TypeOf((*MockRefreshTokenRepository)(nil).RevokeAllForUser), ctx, userID)
+}
+
+// RevokeFamily mocks base method.
+func (m *MockRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeFamily", ctx, familyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeFamily indicates an expected call of RevokeFamily.
+func (mr *MockRefreshTokenRepositoryMockRecorder) RevokeFamily(ctx, familyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeFamily", reflect.TypeOf((*MockRefreshTokenRepository)(nil).RevokeFamily), ctx, familyID)
+}
+
+// MockTokenRevocationStore is a mock of TokenRevocationStore interface.
+type MockTokenRevocationStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenRevocationStoreMockRecorder
+}
+
+// MockTokenRevocationStoreMockRecorder is the mock recorder for MockTokenRevocationStore.
+type MockTokenRevocationStoreMockRecorder struct {
+	mock *MockTokenRevocationStore
+}
+
+// NewMockTokenRevocationStore creates a new mock instance.
+func NewMockTokenRevocationStore(ctrl *gomock.Controller) *MockTokenRevocationStore {
+	mock := &MockTokenRevocationStore{ctrl: ctrl}
+	mock.recorder = &MockTokenRevocationStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenRevocationStore) EXPECT() *MockTokenRevocationStoreMockRecorder {
+	return m.recorder
+}
+
+// IsSessionRevoked mocks base method.
+func (m *MockTokenRevocationStore) IsSessionRevoked(ctx context.Context, sessionID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSessionRevoked", ctx, sessionID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsSessionRevoked indicates an expected call of IsSessionRevoked.
+func (mr *MockTokenRevocationStoreMockRecorder) IsSessionRevoked(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSessionRevoked", reflect.TypeOf((*MockTokenRevocationStore)(nil).IsSessionRevoked), ctx, sessionID)
+}
+
+// IsTokenRevoked mocks base method.
+func (m *MockTokenRevocationStore) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTokenRevoked", ctx, jti)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsTokenRevoked indicates an expected call of IsTokenRevoked.
+func (mr *MockTokenRevocationStoreMockRecorder) IsTokenRevoked(ctx, jti interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTokenRevoked", reflect.TypeOf((*MockTokenRevocationStore)(nil).IsTokenRevoked), ctx, jti)
+}
+
+// IsUserTokenRevoked mocks base method.
+func (m *MockTokenRevocationStore) IsUserTokenRevoked(ctx context.Context, userID uint, issuedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsUserTokenRevoked", ctx, userID, issuedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsUserTokenRevoked indicates an expected call of IsUserTokenRevoked.
+func (mr *MockTokenRevocationStoreMockRecorder) IsUserTokenRevoked(ctx, userID, issuedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUserTokenRevoked", reflect.TypeOf((*MockTokenRevocationStore)(nil).IsUserTokenRevoked), ctx, userID, issuedAt)
+}
+
+// RevokeSession mocks base method.
+func (m *MockTokenRevocationStore) RevokeSession(ctx context.Context, sessionID string, expiration time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, sessionID, expiration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockTokenRevocationStoreMockRecorder) RevokeSession(ctx, sessionID, expiration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockTokenRevocationStore)(nil).RevokeSession), ctx, sessionID, expiration)
+}
+
+// RevokeToken mocks base method.
+func (m *MockTokenRevocationStore) RevokeToken(ctx context.Context, jti string, expiration time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", ctx, jti, expiration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockTokenRevocationStoreMockRecorder) RevokeToken(ctx, jti, expiration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockTokenRevocationStore)(nil).RevokeToken), ctx, jti, expiration)
+}
+
+// RevokeUserTokens mocks base method.
+func (m *MockTokenRevocationStore) RevokeUserTokens(ctx context.Context, userID uint, issuedBefore time.Time, expiration time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserTokens", ctx, userID, issuedBefore, expiration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserTokens indicates an expected call of RevokeUserTokens.
+func (mr *MockTokenRevocationStoreMockRecorder) RevokeUserTokens(ctx, userID, issuedBefore, expiration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserTokens", reflect.TypeOf((*MockTokenRevocationStore)(nil).RevokeUserTokens), ctx, userID, issuedBefore, expiration)
+}
+
+// MockAuthUsecase is a mock of AuthUsecase interface.
+type MockAuthUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthUsecaseMockRecorder
+}
+
+// MockAuthUsecaseMockRecorder is the mock recorder for MockAuthUsecase.
+type MockAuthUsecaseMockRecorder struct {
+	mock *MockAuthUsecase
+}
+
+// NewMockAuthUsecase creates a new mock instance.
+func NewMockAuthUsecase(ctrl *gomock.Controller) *MockAuthUsecase {
+	mock := &MockAuthUsecase{ctrl: ctrl}
+	mock.recorder = &MockAuthUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthUsecase) EXPECT() *MockAuthUsecaseMockRecorder {
+	return m.recorder
+}
+
+// IssueTokens mocks base method.
+func (m *MockAuthUsecase) IssueTokens(ctx context.Context, user *domain.User, client *domain.ClientInfo) (*domain.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueTokens", ctx, user, client)
+	ret0, _ := ret[0].(*domain.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueTokens indicates an expected call of IssueTokens.
+func (mr *MockAuthUsecaseMockRecorder) IssueTokens(ctx, user, client interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueTokens", reflect.TypeOf((*MockAuthUsecase)(nil).IssueTokens), ctx, user, client)
+}
+
+// Logout mocks base method.
+func (m *MockAuthUsecase) Logout(ctx context.Context, claims *domain.AccessTokenClaims, payload *domain.LogoutPayload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, claims, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockAuthUsecaseMockRecorder) Logout(ctx, claims, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthUsecase)(nil).Logout), ctx, claims, payload)
+}
+
+// LogoutAll mocks base method.
+func (m *MockAuthUsecase) LogoutAll(ctx context.Context, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutAll", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogoutAll indicates an expected call of LogoutAll.
+func (mr *MockAuthUsecaseMockRecorder) LogoutAll(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutAll", reflect.TypeOf((*MockAuthUsecase)(nil).LogoutAll), ctx, userID)
+}
+
+// Refresh mocks base method.
+func (m *MockAuthUsecase) Refresh(ctx context.Context, payload *domain.RefreshTokenPayload, client *domain.ClientInfo) (*domain.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, payload, client)
+	ret0, _ := ret[0].(*domain.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockAuthUsecaseMockRecorder) Refresh(ctx, payload, client interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockAuthUsecase)(nil).Refresh), ctx, payload, client)
+}
+
+// RevokeOtherSessions mocks base method.
+func (m *MockAuthUsecase) RevokeOtherSessions(ctx context.Context, userID uint, exceptSessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOtherSessions", ctx, userID, exceptSessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeOtherSessions indicates an expected call of RevokeOtherSessions.
+func (mr *MockAuthUsecaseMockRecorder) RevokeOtherSessions(ctx, userID, exceptSessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOtherSessions", reflect.TypeOf((*MockAuthUsecase)(nil).RevokeOtherSessions), ctx, userID, exceptSessionID)
+}
+
+// RevokeSession mocks base method.
+func (m *MockAuthUsecase) RevokeSession(ctx context.Context, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockAuthUsecaseMockRecorder) RevokeSession(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockAuthUsecase)(nil).RevokeSession), ctx, sessionID)
+}
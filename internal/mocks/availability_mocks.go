@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/X3nonxe/gopsy-backend/internal/domain"
 	gomock "github.com/golang/mock/gomock"
@@ -18,16 +19,6 @@ type MockAvailabilityRepository struct {
 	recorder *MockAvailabilityRepositoryMockRecorder
 }
 
-// GetByPsikologID implements domain.AvailabilityRepository.
-func (m *MockAvailabilityRepository) GetByPsikologID(ctx context.Context, psikologID uint) ([]domain.WaktuKonsultasi, error) {
-	panic("unimplemented")
-}
-
-// GetByPsikologIDAndDay implements domain.AvailabilityRepository.
-func (m *MockAvailabilityRepository) GetByPsikologIDAndDay(ctx context.Context, psikologID uint, day string) ([]domain.WaktuKonsultasi, error) {
-	panic("unimplemented")
-}
-
 // MockAvailabilityRepositoryMockRecorder is the mock recorder for MockAvailabilityRepository.
 type MockAvailabilityRepositoryMockRecorder struct {
 	mock *MockAvailabilityRepository
@@ -45,6 +36,152 @@ func (m *MockAvailabilityRepository) EXPECT() *MockAvailabilityRepositoryMockRec
 	return m.recorder
 }
 
+// CreateException mocks base method.
+func (m *MockAvailabilityRepository) CreateException(ctx context.Context, exception *domain.PengecualianJadwal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateException", ctx, exception)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateException indicates an expected call of CreateException.
+func (mr *MockAvailabilityRepositoryMockRecorder) CreateException(ctx, exception interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateException", reflect.TypeOf((*MockAvailabilityRepository)(nil).CreateException), ctx, exception)
+}
+
+// CreateSlot mocks base method.
+func (m *MockAvailabilityRepository) CreateSlot(ctx context.Context, slot *domain.WaktuKonsultasi) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSlot", ctx, slot)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSlot indicates an expected call of CreateSlot.
+func (mr *MockAvailabilityRepositoryMockRecorder) CreateSlot(ctx, slot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSlot", reflect.TypeOf((*MockAvailabilityRepository)(nil).CreateSlot), ctx, slot)
+}
+
+// DeleteException mocks base method.
+func (m *MockAvailabilityRepository) DeleteException(ctx context.Context, psikologID, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteException", ctx, psikologID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteException indicates an expected call of DeleteException.
+func (mr *MockAvailabilityRepositoryMockRecorder) DeleteException(ctx, psikologID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteException", reflect.TypeOf((*MockAvailabilityRepository)(nil).DeleteException), ctx, psikologID, id)
+}
+
+// DeleteSlot mocks base method.
+func (m *MockAvailabilityRepository) DeleteSlot(ctx context.Context, psikologID, id, expectedVersion uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSlot", ctx, psikologID, id, expectedVersion)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSlot indicates an expected call of DeleteSlot.
+func (mr *MockAvailabilityRepositoryMockRecorder) DeleteSlot(ctx, psikologID, id, expectedVersion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSlot", reflect.TypeOf((*MockAvailabilityRepository)(nil).DeleteSlot), ctx, psikologID, id, expectedVersion)
+}
+
+// GetByPsikologID mocks base method.
+func (m *MockAvailabilityRepository) GetByPsikologID(ctx context.Context, psikologID uint) ([]domain.WaktuKonsultasi, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByPsikologID", ctx, psikologID)
+	ret0, _ := ret[0].([]domain.WaktuKonsultasi)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByPsikologID indicates an expected call of GetByPsikologID.
+func (mr *MockAvailabilityRepositoryMockRecorder) GetByPsikologID(ctx, psikologID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPsikologID", reflect.TypeOf((*MockAvailabilityRepository)(nil).GetByPsikologID), ctx, psikologID)
+}
+
+// GetByPsikologIDAndDay mocks base method.
+func (m *MockAvailabilityRepository) GetByPsikologIDAndDay(ctx context.Context, psikologID uint, day string) ([]domain.WaktuKonsultasi, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByPsikologIDAndDay", ctx, psikologID, day)
+	ret0, _ := ret[0].([]domain.WaktuKonsultasi)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByPsikologIDAndDay indicates an expected call of GetByPsikologIDAndDay.
+func (mr *MockAvailabilityRepositoryMockRecorder) GetByPsikologIDAndDay(ctx, psikologID, day interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPsikologIDAndDay", reflect.TypeOf((*MockAvailabilityRepository)(nil).GetByPsikologIDAndDay), ctx, psikologID, day)
+}
+
+// GetByPsikologIDs mocks base method.
+func (m *MockAvailabilityRepository) GetByPsikologIDs(ctx context.Context, psikologIDs []uint) ([]domain.WaktuKonsultasi, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByPsikologIDs", ctx, psikologIDs)
+	ret0, _ := ret[0].([]domain.WaktuKonsultasi)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByPsikologIDs indicates an expected call of GetByPsikologIDs.
+func (mr *MockAvailabilityRepositoryMockRecorder) GetByPsikologIDs(ctx, psikologIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPsikologIDs", reflect.TypeOf((*MockAvailabilityRepository)(nil).GetByPsikologIDs), ctx, psikologIDs)
+}
+
+// GetExceptions mocks base method.
+func (m *MockAvailabilityRepository) GetExceptions(ctx context.Context, psikologID uint, from, to time.Time) ([]domain.PengecualianJadwal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExceptions", ctx, psikologID, from, to)
+	ret0, _ := ret[0].([]domain.PengecualianJadwal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExceptions indicates an expected call of GetExceptions.
+func (mr *MockAvailabilityRepositoryMockRecorder) GetExceptions(ctx, psikologID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExceptions", reflect.TypeOf((*MockAvailabilityRepository)(nil).GetExceptions), ctx, psikologID, from, to)
+}
+
+// GetSessionSettings mocks base method.
+func (m *MockAvailabilityRepository) GetSessionSettings(ctx context.Context, psikologID uint) (*domain.PengaturanSesi, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionSettings", ctx, psikologID)
+	ret0, _ := ret[0].(*domain.PengaturanSesi)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionSettings indicates an expected call of GetSessionSettings.
+func (mr *MockAvailabilityRepositoryMockRecorder) GetSessionSettings(ctx, psikologID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionSettings", reflect.TypeOf((*MockAvailabilityRepository)(nil).GetSessionSettings), ctx, psikologID)
+}
+
+// GetSlotByID mocks base method.
+func (m *MockAvailabilityRepository) GetSlotByID(ctx context.Context, psikologID, id uint) (*domain.WaktuKonsultasi, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSlotByID", ctx, psikologID, id)
+	ret0, _ := ret[0].(*domain.WaktuKonsultasi)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSlotByID indicates an expected call of GetSlotByID.
+func (mr *MockAvailabilityRepositoryMockRecorder) GetSlotByID(ctx, psikologID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSlotByID", reflect.TypeOf((*MockAvailabilityRepository)(nil).GetSlotByID), ctx, psikologID, id)
+}
+
 // ReplaceAll mocks base method.
 func (m *MockAvailabilityRepository) ReplaceAll(ctx context.Context, psikologID uint, slots []domain.WaktuKonsultasi) error {
 	m.ctrl.T.Helper()
@@ -59,6 +196,34 @@ func (mr *MockAvailabilityRepositoryMockRecorder) ReplaceAll(ctx, psikologID, sl
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceAll", reflect.TypeOf((*MockAvailabilityRepository)(nil).ReplaceAll), ctx, psikologID, slots)
 }
 
+// UpdateSlot mocks base method.
+func (m *MockAvailabilityRepository) UpdateSlot(ctx context.Context, slot *domain.WaktuKonsultasi, expectedVersion uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSlot", ctx, slot, expectedVersion)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSlot indicates an expected call of UpdateSlot.
+func (mr *MockAvailabilityRepositoryMockRecorder) UpdateSlot(ctx, slot, expectedVersion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSlot", reflect.TypeOf((*MockAvailabilityRepository)(nil).UpdateSlot), ctx, slot, expectedVersion)
+}
+
+// UpsertSessionSettings mocks base method.
+func (m *MockAvailabilityRepository) UpsertSessionSettings(ctx context.Context, settings *domain.PengaturanSesi) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertSessionSettings", ctx, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertSessionSettings indicates an expected call of UpsertSessionSettings.
+func (mr *MockAvailabilityRepositoryMockRecorder) UpsertSessionSettings(ctx, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertSessionSettings", reflect.TypeOf((*MockAvailabilityRepository)(nil).UpsertSessionSettings), ctx, settings)
+}
+
 // MockAvailabilityUsecase is a mock of AvailabilityUsecase interface.
 type MockAvailabilityUsecase struct {
 	ctrl     *gomock.Controller
@@ -82,6 +247,169 @@ func (m *MockAvailabilityUsecase) EXPECT() *MockAvailabilityUsecaseMockRecorder
 	return m.recorder
 }
 
+// AddException mocks base method.
+func (m *MockAvailabilityUsecase) AddException(ctx context.Context, psikologID uint, payload *domain.AvailabilityExceptionPayload) (*domain.PengecualianJadwal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddException", ctx, psikologID, payload)
+	ret0, _ := ret[0].(*domain.PengecualianJadwal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddException indicates an expected call of AddException.
+func (mr *MockAvailabilityUsecaseMockRecorder) AddException(ctx, psikologID, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddException", reflect.TypeOf((*MockAvailabilityUsecase)(nil).AddException), ctx, psikologID, payload)
+}
+
+// AddSlot mocks base method.
+func (m *MockAvailabilityUsecase) AddSlot(ctx context.Context, psikologID uint, payload *domain.SlotPayload) (*domain.WaktuKonsultasi, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSlot", ctx, psikologID, payload)
+	ret0, _ := ret[0].(*domain.WaktuKonsultasi)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddSlot indicates an expected call of AddSlot.
+func (mr *MockAvailabilityUsecaseMockRecorder) AddSlot(ctx, psikologID, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSlot", reflect.TypeOf((*MockAvailabilityUsecase)(nil).AddSlot), ctx, psikologID, payload)
+}
+
+// DeleteException mocks base method.
+func (m *MockAvailabilityUsecase) DeleteException(ctx context.Context, psikologID, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteException", ctx, psikologID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteException indicates an expected call of DeleteException.
+func (mr *MockAvailabilityUsecaseMockRecorder) DeleteException(ctx, psikologID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteException", reflect.TypeOf((*MockAvailabilityUsecase)(nil).DeleteException), ctx, psikologID, id)
+}
+
+// DeleteSlot mocks base method.
+func (m *MockAvailabilityUsecase) DeleteSlot(ctx context.Context, psikologID, id, version uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSlot", ctx, psikologID, id, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSlot indicates an expected call of DeleteSlot.
+func (mr *MockAvailabilityUsecaseMockRecorder) DeleteSlot(ctx, psikologID, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSlot", reflect.TypeOf((*MockAvailabilityUsecase)(nil).DeleteSlot), ctx, psikologID, id, version)
+}
+
+// GetAvailability mocks base method.
+func (m *MockAvailabilityUsecase) GetAvailability(ctx context.Context, psikologID uint) ([]domain.WaktuKonsultasi, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvailability", ctx, psikologID)
+	ret0, _ := ret[0].([]domain.WaktuKonsultasi)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvailability indicates an expected call of GetAvailability.
+func (mr *MockAvailabilityUsecaseMockRecorder) GetAvailability(ctx, psikologID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailability", reflect.TypeOf((*MockAvailabilityUsecase)(nil).GetAvailability), ctx, psikologID)
+}
+
+// GetAvailabilityByDay mocks base method.
+func (m *MockAvailabilityUsecase) GetAvailabilityByDay(ctx context.Context, psikologID uint, day string) ([]domain.WaktuKonsultasi, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvailabilityByDay", ctx, psikologID, day)
+	ret0, _ := ret[0].([]domain.WaktuKonsultasi)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvailabilityByDay indicates an expected call of GetAvailabilityByDay.
+func (mr *MockAvailabilityUsecaseMockRecorder) GetAvailabilityByDay(ctx, psikologID, day interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailabilityByDay", reflect.TypeOf((*MockAvailabilityUsecase)(nil).GetAvailabilityByDay), ctx, psikologID, day)
+}
+
+// GetBookableSlots mocks base method.
+func (m *MockAvailabilityUsecase) GetBookableSlots(ctx context.Context, psikologID uint, query *domain.BookableSlotsQuery) ([]domain.BookableSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookableSlots", ctx, psikologID, query)
+	ret0, _ := ret[0].([]domain.BookableSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookableSlots indicates an expected call of GetBookableSlots.
+func (mr *MockAvailabilityUsecaseMockRecorder) GetBookableSlots(ctx, psikologID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookableSlots", reflect.TypeOf((*MockAvailabilityUsecase)(nil).GetBookableSlots), ctx, psikologID, query)
+}
+
+// GetExceptions mocks base method.
+func (m *MockAvailabilityUsecase) GetExceptions(ctx context.Context, psikologID uint, query *domain.AvailabilityExceptionQuery) ([]domain.PengecualianJadwal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExceptions", ctx, psikologID, query)
+	ret0, _ := ret[0].([]domain.PengecualianJadwal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExceptions indicates an expected call of GetExceptions.
+func (mr *MockAvailabilityUsecaseMockRecorder) GetExceptions(ctx, psikologID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExceptions", reflect.TypeOf((*MockAvailabilityUsecase)(nil).GetExceptions), ctx, psikologID, query)
+}
+
+// GetOwnWeeklySchedule mocks base method.
+func (m *MockAvailabilityUsecase) GetOwnWeeklySchedule(ctx context.Context, psikologID uint, hari string) (*domain.JadwalMingguan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnWeeklySchedule", ctx, psikologID, hari)
+	ret0, _ := ret[0].(*domain.JadwalMingguan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwnWeeklySchedule indicates an expected call of GetOwnWeeklySchedule.
+func (mr *MockAvailabilityUsecaseMockRecorder) GetOwnWeeklySchedule(ctx, psikologID, hari interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnWeeklySchedule", reflect.TypeOf((*MockAvailabilityUsecase)(nil).GetOwnWeeklySchedule), ctx, psikologID, hari)
+}
+
+// GetSessionSettings mocks base method.
+func (m *MockAvailabilityUsecase) GetSessionSettings(ctx context.Context, psikologID uint) (*domain.PengaturanSesi, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionSettings", ctx, psikologID)
+	ret0, _ := ret[0].(*domain.PengaturanSesi)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionSettings indicates an expected call of GetSessionSettings.
+func (mr *MockAvailabilityUsecaseMockRecorder) GetSessionSettings(ctx, psikologID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionSettings", reflect.TypeOf((*MockAvailabilityUsecase)(nil).GetSessionSettings), ctx, psikologID)
+}
+
+// GetWeeklySchedule mocks base method.
+func (m *MockAvailabilityUsecase) GetWeeklySchedule(ctx context.Context, psikologID uint, hari string) (*domain.JadwalMingguan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWeeklySchedule", ctx, psikologID, hari)
+	ret0, _ := ret[0].(*domain.JadwalMingguan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWeeklySchedule indicates an expected call of GetWeeklySchedule.
+func (mr *MockAvailabilityUsecaseMockRecorder) GetWeeklySchedule(ctx, psikologID, hari interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWeeklySchedule", reflect.TypeOf((*MockAvailabilityUsecase)(nil).GetWeeklySchedule), ctx, psikologID, hari)
+}
+
 // SetAvailability mocks base method.
 func (m *MockAvailabilityUsecase) SetAvailability(ctx context.Context, psikologID uint, payload *domain.SetAvailabilityPayload) error {
 	m.ctrl.T.Helper()
@@ -95,3 +423,33 @@ func (mr *MockAvailabilityUsecaseMockRecorder) SetAvailability(ctx, psikologID,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAvailability", reflect.TypeOf((*MockAvailabilityUsecase)(nil).SetAvailability), ctx, psikologID, payload)
 }
+
+// UpdateSessionSettings mocks base method.
+func (m *MockAvailabilityUsecase) UpdateSessionSettings(ctx context.Context, psikologID uint, payload *domain.SessionSettingsPayload) (*domain.PengaturanSesi, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSessionSettings", ctx, psikologID, payload)
+	ret0, _ := ret[0].(*domain.PengaturanSesi)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSessionSettings indicates an expected call of UpdateSessionSettings.
+func (mr *MockAvailabilityUsecaseMockRecorder) UpdateSessionSettings(ctx, psikologID, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSessionSettings", reflect.TypeOf((*MockAvailabilityUsecase)(nil).UpdateSessionSettings), ctx, psikologID, payload)
+}
+
+// UpdateSlot mocks base method.
+func (m *MockAvailabilityUsecase) UpdateSlot(ctx context.Context, psikologID, id, version uint, payload *domain.SlotPayload) (*domain.WaktuKonsultasi, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSlot", ctx, psikologID, id, version, payload)
+	ret0, _ := ret[0].(*domain.WaktuKonsultasi)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSlot indicates an expected call of UpdateSlot.
+func (mr *MockAvailabilityUsecaseMockRecorder) UpdateSlot(ctx, psikologID, id, version, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSlot", reflect.TypeOf((*MockAvailabilityUsecase)(nil).UpdateSlot), ctx, psikologID, id, version, payload)
+}
@@ -0,0 +1,209 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/consultation.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/X3nonxe/gopsy-backend/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockConsultationRepository is a mock of ConsultationRepository interface.
+type MockConsultationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockConsultationRepositoryMockRecorder
+}
+
+// MockConsultationRepositoryMockRecorder is the mock recorder for MockConsultationRepository.
+type MockConsultationRepositoryMockRecorder struct {
+	mock *MockConsultationRepository
+}
+
+// NewMockConsultationRepository creates a new mock instance.
+func NewMockConsultationRepository(ctrl *gomock.Controller) *MockConsultationRepository {
+	mock := &MockConsultationRepository{ctrl: ctrl}
+	mock.recorder = &MockConsultationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConsultationRepository) EXPECT() *MockConsultationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountOverlapping mocks base method.
+func (m *MockConsultationRepository) CountOverlapping(ctx context.Context, psikologID uint, start, end time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOverlapping", ctx, psikologID, start, end)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOverlapping indicates an expected call of CountOverlapping.
+func (mr *MockConsultationRepositoryMockRecorder) CountOverlapping(ctx, psikologID, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOverlapping", reflect.TypeOf((*MockConsultationRepository)(nil).CountOverlapping), ctx, psikologID, start, end)
+}
+
+// Create mocks base method.
+func (m *MockConsultationRepository) Create(ctx context.Context, konsultasi *domain.Konsultasi) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, konsultasi)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockConsultationRepositoryMockRecorder) Create(ctx, konsultasi interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockConsultationRepository)(nil).Create), ctx, konsultasi)
+}
+
+// GetByID mocks base method.
+func (m *MockConsultationRepository) GetByID(ctx context.Context, id uint) (*domain.Konsultasi, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Konsultasi)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockConsultationRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockConsultationRepository)(nil).GetByID), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockConsultationRepository) List(ctx context.Context, filter domain.KonsultasiFilter) ([]domain.Konsultasi, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]domain.Konsultasi)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockConsultationRepositoryMockRecorder) List(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockConsultationRepository)(nil).List), ctx, filter)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockConsultationRepository) UpdateStatus(ctx context.Context, konsultasi *domain.Konsultasi, fromStatus string, history *domain.KonsultasiStatusHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, konsultasi, fromStatus, history)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockConsultationRepositoryMockRecorder) UpdateStatus(ctx, konsultasi, fromStatus, history interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockConsultationRepository)(nil).UpdateStatus), ctx, konsultasi, fromStatus, history)
+}
+
+// MockConsultationUsecase is a mock of ConsultationUsecase interface.
+type MockConsultationUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockConsultationUsecaseMockRecorder
+}
+
+// MockConsultationUsecaseMockRecorder is the mock recorder for MockConsultationUsecase.
+type MockConsultationUsecaseMockRecorder struct {
+	mock *MockConsultationUsecase
+}
+
+// NewMockConsultationUsecase creates a new mock instance.
+func NewMockConsultationUsecase(ctrl *gomock.Controller) *MockConsultationUsecase {
+	mock := &MockConsultationUsecase{ctrl: ctrl}
+	mock.recorder = &MockConsultationUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConsultationUsecase) EXPECT() *MockConsultationUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CancelConsultation mocks base method.
+func (m *MockConsultationUsecase) CancelConsultation(ctx context.Context, klienID, id uint, payload *domain.CancelKonsultasiPayload) (*domain.Konsultasi, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelConsultation", ctx, klienID, id, payload)
+	ret0, _ := ret[0].(*domain.Konsultasi)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelConsultation indicates an expected call of CancelConsultation.
+func (mr *MockConsultationUsecaseMockRecorder) CancelConsultation(ctx, klienID, id, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelConsultation", reflect.TypeOf((*MockConsultationUsecase)(nil).CancelConsultation), ctx, klienID, id, payload)
+}
+
+// GetClientHistory mocks base method.
+func (m *MockConsultationUsecase) GetClientHistory(ctx context.Context, klienID uint, query *domain.ConsultationHistoryQuery) (*domain.KonsultasiHistoryResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientHistory", ctx, klienID, query)
+	ret0, _ := ret[0].(*domain.KonsultasiHistoryResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientHistory indicates an expected call of GetClientHistory.
+func (mr *MockConsultationUsecaseMockRecorder) GetClientHistory(ctx, klienID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientHistory", reflect.TypeOf((*MockConsultationUsecase)(nil).GetClientHistory), ctx, klienID, query)
+}
+
+// GetConsultationRequests mocks base method.
+func (m *MockConsultationUsecase) GetConsultationRequests(ctx context.Context, psikologID uint, query *domain.ConsultationRequestQuery) (*domain.KonsultasiRequestResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConsultationRequests", ctx, psikologID, query)
+	ret0, _ := ret[0].(*domain.KonsultasiRequestResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConsultationRequests indicates an expected call of GetConsultationRequests.
+func (mr *MockConsultationUsecaseMockRecorder) GetConsultationRequests(ctx, psikologID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConsultationRequests", reflect.TypeOf((*MockConsultationUsecase)(nil).GetConsultationRequests), ctx, psikologID, query)
+}
+
+// RequestConsultation mocks base method.
+func (m *MockConsultationUsecase) RequestConsultation(ctx context.Context, klienID uint, payload *domain.RequestKonsultasiPayload) (*domain.Konsultasi, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestConsultation", ctx, klienID, payload)
+	ret0, _ := ret[0].(*domain.Konsultasi)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestConsultation indicates an expected call of RequestConsultation.
+func (mr *MockConsultationUsecaseMockRecorder) RequestConsultation(ctx, klienID, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestConsultation", reflect.TypeOf((*MockConsultationUsecase)(nil).RequestConsultation), ctx, klienID, payload)
+}
+
+// UpdateConsultationStatus mocks base method.
+func (m *MockConsultationUsecase) UpdateConsultationStatus(ctx context.Context, psikologID, id uint, payload *domain.UpdateKonsultasiStatusPayload) (*domain.Konsultasi, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateConsultationStatus", ctx, psikologID, id, payload)
+	ret0, _ := ret[0].(*domain.Konsultasi)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateConsultationStatus indicates an expected call of UpdateConsultationStatus.
+func (mr *MockConsultationUsecaseMockRecorder) UpdateConsultationStatus(ctx, psikologID, id, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateConsultationStatus", reflect.TypeOf((*MockConsultationUsecase)(nil).UpdateConsultationStatus), ctx, psikologID, id, payload)
+}
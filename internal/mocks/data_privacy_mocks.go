@@ -0,0 +1,266 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/data_privacy.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/X3nonxe/gopsy-backend/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDataPrivacyRepository is a mock of DataPrivacyRepository interface.
+type MockDataPrivacyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDataPrivacyRepositoryMockRecorder
+}
+
+// MockDataPrivacyRepositoryMockRecorder is the mock recorder for MockDataPrivacyRepository.
+type MockDataPrivacyRepositoryMockRecorder struct {
+	mock *MockDataPrivacyRepository
+}
+
+// NewMockDataPrivacyRepository creates a new mock instance.
+func NewMockDataPrivacyRepository(ctrl *gomock.Controller) *MockDataPrivacyRepository {
+	mock := &MockDataPrivacyRepository{ctrl: ctrl}
+	mock.recorder = &MockDataPrivacyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDataPrivacyRepository) EXPECT() *MockDataPrivacyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CollectPersonalData mocks base method.
+func (m *MockDataPrivacyRepository) CollectPersonalData(ctx context.Context, userID uint) (*domain.PersonalDataArchive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CollectPersonalData", ctx, userID)
+	ret0, _ := ret[0].(*domain.PersonalDataArchive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CollectPersonalData indicates an expected call of CollectPersonalData.
+func (mr *MockDataPrivacyRepositoryMockRecorder) CollectPersonalData(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectPersonalData", reflect.TypeOf((*MockDataPrivacyRepository)(nil).CollectPersonalData), ctx, userID)
+}
+
+// CreateExport mocks base method.
+func (m *MockDataPrivacyRepository) CreateExport(ctx context.Context, export *domain.DataExport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExport", ctx, export)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateExport indicates an expected call of CreateExport.
+func (mr *MockDataPrivacyRepositoryMockRecorder) CreateExport(ctx, export interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExport", reflect.TypeOf((*MockDataPrivacyRepository)(nil).CreateExport), ctx, export)
+}
+
+// EraseUser mocks base method.
+func (m *MockDataPrivacyRepository) EraseUser(ctx context.Context, userID uint, erasedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseUser", ctx, userID, erasedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EraseUser indicates an expected call of EraseUser.
+func (mr *MockDataPrivacyRepositoryMockRecorder) EraseUser(ctx, userID, erasedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseUser", reflect.TypeOf((*MockDataPrivacyRepository)(nil).EraseUser), ctx, userID, erasedAt)
+}
+
+// GetExport mocks base method.
+func (m *MockDataPrivacyRepository) GetExport(ctx context.Context, userID uint, id string) (*domain.DataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExport", ctx, userID, id)
+	ret0, _ := ret[0].(*domain.DataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExport indicates an expected call of GetExport.
+func (mr *MockDataPrivacyRepositoryMockRecorder) GetExport(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExport", reflect.TypeOf((*MockDataPrivacyRepository)(nil).GetExport), ctx, userID, id)
+}
+
+// ListExpiredExports mocks base method.
+func (m *MockDataPrivacyRepository) ListExpiredExports(ctx context.Context, now time.Time, limit int) ([]domain.DataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredExports", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.DataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredExports indicates an expected call of ListExpiredExports.
+func (mr *MockDataPrivacyRepositoryMockRecorder) ListExpiredExports(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredExports", reflect.TypeOf((*MockDataPrivacyRepository)(nil).ListExpiredExports), ctx, now, limit)
+}
+
+// ListExports mocks base method.
+func (m *MockDataPrivacyRepository) ListExports(ctx context.Context, userID uint) ([]domain.DataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExports", ctx, userID)
+	ret0, _ := ret[0].([]domain.DataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExports indicates an expected call of ListExports.
+func (mr *MockDataPrivacyRepositoryMockRecorder) ListExports(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExports", reflect.TypeOf((*MockDataPrivacyRepository)(nil).ListExports), ctx, userID)
+}
+
+// ListPendingExports mocks base method.
+func (m *MockDataPrivacyRepository) ListPendingExports(ctx context.Context, limit int) ([]domain.DataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingExports", ctx, limit)
+	ret0, _ := ret[0].([]domain.DataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingExports indicates an expected call of ListPendingExports.
+func (mr *MockDataPrivacyRepositoryMockRecorder) ListPendingExports(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingExports", reflect.TypeOf((*MockDataPrivacyRepository)(nil).ListPendingExports), ctx, limit)
+}
+
+// UpdateExport mocks base method.
+func (m *MockDataPrivacyRepository) UpdateExport(ctx context.Context, export *domain.DataExport, expectedStatus string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateExport", ctx, export, expectedStatus)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateExport indicates an expected call of UpdateExport.
+func (mr *MockDataPrivacyRepositoryMockRecorder) UpdateExport(ctx, export, expectedStatus interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExport", reflect.TypeOf((*MockDataPrivacyRepository)(nil).UpdateExport), ctx, export, expectedStatus)
+}
+
+// MockDataPrivacyUsecase is a mock of DataPrivacyUsecase interface.
+type MockDataPrivacyUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockDataPrivacyUsecaseMockRecorder
+}
+
+// MockDataPrivacyUsecaseMockRecorder is the mock recorder for MockDataPrivacyUsecase.
+type MockDataPrivacyUsecaseMockRecorder struct {
+	mock *MockDataPrivacyUsecase
+}
+
+// NewMockDataPrivacyUsecase creates a new mock instance.
+func NewMockDataPrivacyUsecase(ctrl *gomock.Controller) *MockDataPrivacyUsecase {
+	mock := &MockDataPrivacyUsecase{ctrl: ctrl}
+	mock.recorder = &MockDataPrivacyUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDataPrivacyUsecase) EXPECT() *MockDataPrivacyUsecaseMockRecorder {
+	return m.recorder
+}
+
+// EraseAccount mocks base method.
+func (m *MockDataPrivacyUsecase) EraseAccount(ctx context.Context, userID uint, payload *domain.EraseAccountPayload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseAccount", ctx, userID, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EraseAccount indicates an expected call of EraseAccount.
+func (mr *MockDataPrivacyUsecaseMockRecorder) EraseAccount(ctx, userID, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseAccount", reflect.TypeOf((*MockDataPrivacyUsecase)(nil).EraseAccount), ctx, userID, payload)
+}
+
+// GetExport mocks base method.
+func (m *MockDataPrivacyUsecase) GetExport(ctx context.Context, userID uint, id string) (*domain.DataExportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExport", ctx, userID, id)
+	ret0, _ := ret[0].(*domain.DataExportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExport indicates an expected call of GetExport.
+func (mr *MockDataPrivacyUsecaseMockRecorder) GetExport(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExport", reflect.TypeOf((*MockDataPrivacyUsecase)(nil).GetExport), ctx, userID, id)
+}
+
+// ListExports mocks base method.
+func (m *MockDataPrivacyUsecase) ListExports(ctx context.Context, userID uint) ([]domain.DataExportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExports", ctx, userID)
+	ret0, _ := ret[0].([]domain.DataExportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExports indicates an expected call of ListExports.
+func (mr *MockDataPrivacyUsecaseMockRecorder) ListExports(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExports", reflect.TypeOf((*MockDataPrivacyUsecase)(nil).ListExports), ctx, userID)
+}
+
+// PurgeExpiredExports mocks base method.
+func (m *MockDataPrivacyUsecase) PurgeExpiredExports(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpiredExports", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpiredExports indicates an expected call of PurgeExpiredExports.
+func (mr *MockDataPrivacyUsecaseMockRecorder) PurgeExpiredExports(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpiredExports", reflect.TypeOf((*MockDataPrivacyUsecase)(nil).PurgeExpiredExports), ctx)
+}
+
+// RequestExport mocks base method.
+func (m *MockDataPrivacyUsecase) RequestExport(ctx context.Context, userID uint) (*domain.DataExportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestExport", ctx, userID)
+	ret0, _ := ret[0].(*domain.DataExportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestExport indicates an expected call of RequestExport.
+func (mr *MockDataPrivacyUsecaseMockRecorder) RequestExport(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestExport", reflect.TypeOf((*MockDataPrivacyUsecase)(nil).RequestExport), ctx, userID)
+}
+
+// ResumePendingExports mocks base method.
+func (m *MockDataPrivacyUsecase) ResumePendingExports(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumePendingExports", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResumePendingExports indicates an expected call of ResumePendingExports.
+func (mr *MockDataPrivacyUsecaseMockRecorder) ResumePendingExports(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumePendingExports", reflect.TypeOf((*MockDataPrivacyUsecase)(nil).ResumePendingExports), ctx)
+}
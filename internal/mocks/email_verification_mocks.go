@@ -0,0 +1,158 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/email_verification.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/X3nonxe/gopsy-backend/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEmailVerificationRepository is a mock of EmailVerificationRepository interface.
+type MockEmailVerificationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailVerificationRepositoryMockRecorder
+}
+
+// MockEmailVerificationRepositoryMockRecorder is the mock recorder for MockEmailVerificationRepository.
+type MockEmailVerificationRepositoryMockRecorder struct {
+	mock *MockEmailVerificationRepository
+}
+
+// NewMockEmailVerificationRepository creates a new mock instance.
+func NewMockEmailVerificationRepository(ctrl *gomock.Controller) *MockEmailVerificationRepository {
+	mock := &MockEmailVerificationRepository{ctrl: ctrl}
+	mock.recorder = &MockEmailVerificationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailVerificationRepository) EXPECT() *MockEmailVerificationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockEmailVerificationRepository) Create(ctx context.Context, token *domain.EmailVerificationToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockEmailVerificationRepositoryMockRecorder) Create(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEmailVerificationRepository)(nil).Create), ctx, token)
+}
+
+// GetByHash mocks base method.
+func (m *MockEmailVerificationRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.EmailVerificationToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*domain.EmailVerificationToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockEmailVerificationRepositoryMockRecorder) GetByHash(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockEmailVerificationRepository)(nil).GetByHash), ctx, tokenHash)
+}
+
+// InvalidateAllForUser mocks base method.
+func (m *MockEmailVerificationRepository) InvalidateAllForUser(ctx context.Context, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateAllForUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateAllForUser indicates an expected call of InvalidateAllForUser.
+func (mr *MockEmailVerificationRepositoryMockRecorder) InvalidateAllForUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateAllForUser", reflect.TypeOf((*MockEmailVerificationRepository)(nil).InvalidateAllForUser), ctx, userID)
+}
+
+// MarkUsed mocks base method.
+func (m *MockEmailVerificationRepository) MarkUsed(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockEmailVerificationRepositoryMockRecorder) MarkUsed(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockEmailVerificationRepository)(nil).MarkUsed), ctx, id)
+}
+
+// MockEmailVerificationUsecase is a mock of EmailVerificationUsecase interface.
+type MockEmailVerificationUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailVerificationUsecaseMockRecorder
+}
+
+// MockEmailVerificationUsecaseMockRecorder is the mock recorder for MockEmailVerificationUsecase.
+type MockEmailVerificationUsecaseMockRecorder struct {
+	mock *MockEmailVerificationUsecase
+}
+
+// NewMockEmailVerificationUsecase creates a new mock instance.
+func NewMockEmailVerificationUsecase(ctrl *gomock.Controller) *MockEmailVerificationUsecase {
+	mock := &MockEmailVerificationUsecase{ctrl: ctrl}
+	mock.recorder = &MockEmailVerificationUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailVerificationUsecase) EXPECT() *MockEmailVerificationUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ResendVerification mocks base method.
+func (m *MockEmailVerificationUsecase) ResendVerification(ctx context.Context, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerification", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendVerification indicates an expected call of ResendVerification.
+func (mr *MockEmailVerificationUsecaseMockRecorder) ResendVerification(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerification", reflect.TypeOf((*MockEmailVerificationUsecase)(nil).ResendVerification), ctx, userID)
+}
+
+// SendVerification mocks base method.
+func (m *MockEmailVerificationUsecase) SendVerification(ctx context.Context, user *domain.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendVerification", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendVerification indicates an expected call of SendVerification.
+func (mr *MockEmailVerificationUsecaseMockRecorder) SendVerification(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendVerification", reflect.TypeOf((*MockEmailVerificationUsecase)(nil).SendVerification), ctx, user)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockEmailVerificationUsecase) VerifyEmail(ctx context.Context, payload *domain.VerifyEmailPayload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockEmailVerificationUsecaseMockRecorder) VerifyEmail(ctx, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockEmailVerificationUsecase)(nil).VerifyEmail), ctx, payload)
+}
@@ -0,0 +1,63 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/login_attempt.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLoginAttemptUsecase is a mock of LoginAttemptUsecase interface.
+type MockLoginAttemptUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginAttemptUsecaseMockRecorder
+}
+
+// MockLoginAttemptUsecaseMockRecorder is the mock recorder for MockLoginAttemptUsecase.
+type MockLoginAttemptUsecaseMockRecorder struct {
+	mock *MockLoginAttemptUsecase
+}
+
+// NewMockLoginAttemptUsecase creates a new mock instance.
+func NewMockLoginAttemptUsecase(ctrl *gomock.Controller) *MockLoginAttemptUsecase {
+	mock := &MockLoginAttemptUsecase{ctrl: ctrl}
+	mock.recorder = &MockLoginAttemptUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginAttemptUsecase) EXPECT() *MockLoginAttemptUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Reserve mocks base method.
+func (m *MockLoginAttemptUsecase) Reserve(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockLoginAttemptUsecaseMockRecorder) Reserve(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockLoginAttemptUsecase)(nil).Reserve), ctx, email)
+}
+
+// Reset mocks base method.
+func (m *MockLoginAttemptUsecase) Reset(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockLoginAttemptUsecaseMockRecorder) Reset(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockLoginAttemptUsecase)(nil).Reset), ctx, email)
+}
@@ -0,0 +1,336 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/mfa.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/X3nonxe/gopsy-backend/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMFARepository is a mock of MFARepository interface.
+type MockMFARepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMFARepositoryMockRecorder
+}
+
+// MockMFARepositoryMockRecorder is the mock recorder for MockMFARepository.
+type MockMFARepositoryMockRecorder struct {
+	mock *MockMFARepository
+}
+
+// NewMockMFARepository creates a new mock instance.
+func NewMockMFARepository(ctrl *gomock.Controller) *MockMFARepository {
+	mock := &MockMFARepository{ctrl: ctrl}
+	mock.recorder = &MockMFARepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMFARepository) EXPECT() *MockMFARepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockMFARepository) Delete(ctx context.Context, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMFARepositoryMockRecorder) Delete(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMFARepository)(nil).Delete), ctx, userID)
+}
+
+// GetByUserID mocks base method.
+func (m *MockMFARepository) GetByUserID(ctx context.Context, userID uint) (*domain.UserMFA, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", ctx, userID)
+	ret0, _ := ret[0].(*domain.UserMFA)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockMFARepositoryMockRecorder) GetByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockMFARepository)(nil).GetByUserID), ctx, userID)
+}
+
+// GetPolicies mocks base method.
+func (m *MockMFARepository) GetPolicies(ctx context.Context) ([]domain.MFARolePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicies", ctx)
+	ret0, _ := ret[0].([]domain.MFARolePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicies indicates an expected call of GetPolicies.
+func (mr *MockMFARepositoryMockRecorder) GetPolicies(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicies", reflect.TypeOf((*MockMFARepository)(nil).GetPolicies), ctx)
+}
+
+// GetPolicy mocks base method.
+func (m *MockMFARepository) GetPolicy(ctx context.Context, role string) (*domain.MFARolePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicy", ctx, role)
+	ret0, _ := ret[0].(*domain.MFARolePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicy indicates an expected call of GetPolicy.
+func (mr *MockMFARepositoryMockRecorder) GetPolicy(ctx, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicy", reflect.TypeOf((*MockMFARepository)(nil).GetPolicy), ctx, role)
+}
+
+// ReplaceRecoveryCodes mocks base method.
+func (m *MockMFARepository) ReplaceRecoveryCodes(ctx context.Context, userID uint, codeHashes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceRecoveryCodes", ctx, userID, codeHashes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceRecoveryCodes indicates an expected call of ReplaceRecoveryCodes.
+func (mr *MockMFARepositoryMockRecorder) ReplaceRecoveryCodes(ctx, userID, codeHashes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceRecoveryCodes", reflect.TypeOf((*MockMFARepository)(nil).ReplaceRecoveryCodes), ctx, userID, codeHashes)
+}
+
+// Save mocks base method.
+func (m *MockMFARepository) Save(ctx context.Context, mfa *domain.UserMFA) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, mfa)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockMFARepositoryMockRecorder) Save(ctx, mfa interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockMFARepository)(nil).Save), ctx, mfa)
+}
+
+// UpdateLastUsedStep mocks base method.
+func (m *MockMFARepository) UpdateLastUsedStep(ctx context.Context, userID uint, step int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastUsedStep", ctx, userID, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastUsedStep indicates an expected call of UpdateLastUsedStep.
+func (mr *MockMFARepositoryMockRecorder) UpdateLastUsedStep(ctx, userID, step interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastUsedStep", reflect.TypeOf((*MockMFARepository)(nil).UpdateLastUsedStep), ctx, userID, step)
+}
+
+// UpsertPolicy mocks base method.
+func (m *MockMFARepository) UpsertPolicy(ctx context.Context, policy *domain.MFARolePolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertPolicy", ctx, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertPolicy indicates an expected call of UpsertPolicy.
+func (mr *MockMFARepositoryMockRecorder) UpsertPolicy(ctx, policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertPolicy", reflect.TypeOf((*MockMFARepository)(nil).UpsertPolicy), ctx, policy)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockMFARepository) UseRecoveryCode(ctx context.Context, userID uint, codeHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", ctx, userID, codeHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockMFARepositoryMockRecorder) UseRecoveryCode(ctx, userID, codeHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockMFARepository)(nil).UseRecoveryCode), ctx, userID, codeHash)
+}
+
+// MockMFAUsecase is a mock of MFAUsecase interface.
+type MockMFAUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockMFAUsecaseMockRecorder
+}
+
+// MockMFAUsecaseMockRecorder is the mock recorder for MockMFAUsecase.
+type MockMFAUsecaseMockRecorder struct {
+	mock *MockMFAUsecase
+}
+
+// NewMockMFAUsecase creates a new mock instance.
+func NewMockMFAUsecase(ctrl *gomock.Controller) *MockMFAUsecase {
+	mock := &MockMFAUsecase{ctrl: ctrl}
+	mock.recorder = &MockMFAUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMFAUsecase) EXPECT() *MockMFAUsecaseMockRecorder {
+	return m.recorder
+}
+
+// BeginChallenge mocks base method.
+func (m *MockMFAUsecase) BeginChallenge(ctx context.Context, user *domain.User) (*domain.MFAChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginChallenge", ctx, user)
+	ret0, _ := ret[0].(*domain.MFAChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginChallenge indicates an expected call of BeginChallenge.
+func (mr *MockMFAUsecaseMockRecorder) BeginChallenge(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginChallenge", reflect.TypeOf((*MockMFAUsecase)(nil).BeginChallenge), ctx, user)
+}
+
+// ConfirmEnrollment mocks base method.
+func (m *MockMFAUsecase) ConfirmEnrollment(ctx context.Context, userID uint, payload *domain.MFACodePayload) (*domain.MFARecoveryCodes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmEnrollment", ctx, userID, payload)
+	ret0, _ := ret[0].(*domain.MFARecoveryCodes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmEnrollment indicates an expected call of ConfirmEnrollment.
+func (mr *MockMFAUsecaseMockRecorder) ConfirmEnrollment(ctx, userID, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEnrollment", reflect.TypeOf((*MockMFAUsecase)(nil).ConfirmEnrollment), ctx, userID, payload)
+}
+
+// Disable mocks base method.
+func (m *MockMFAUsecase) Disable(ctx context.Context, userID uint, payload *domain.MFACodePayload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disable", ctx, userID, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disable indicates an expected call of Disable.
+func (mr *MockMFAUsecaseMockRecorder) Disable(ctx, userID, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disable", reflect.TypeOf((*MockMFAUsecase)(nil).Disable), ctx, userID, payload)
+}
+
+// Enroll mocks base method.
+func (m *MockMFAUsecase) Enroll(ctx context.Context, userID uint) (*domain.MFAEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enroll", ctx, userID)
+	ret0, _ := ret[0].(*domain.MFAEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enroll indicates an expected call of Enroll.
+func (mr *MockMFAUsecaseMockRecorder) Enroll(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enroll", reflect.TypeOf((*MockMFAUsecase)(nil).Enroll), ctx, userID)
+}
+
+// EnrollWithChallenge mocks base method.
+func (m *MockMFAUsecase) EnrollWithChallenge(ctx context.Context, payload *domain.MFAChallengePayload) (*domain.MFAEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollWithChallenge", ctx, payload)
+	ret0, _ := ret[0].(*domain.MFAEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollWithChallenge indicates an expected call of EnrollWithChallenge.
+func (mr *MockMFAUsecaseMockRecorder) EnrollWithChallenge(ctx, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollWithChallenge", reflect.TypeOf((*MockMFAUsecase)(nil).EnrollWithChallenge), ctx, payload)
+}
+
+// GetPolicies mocks base method.
+func (m *MockMFAUsecase) GetPolicies(ctx context.Context) ([]domain.MFARolePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicies", ctx)
+	ret0, _ := ret[0].([]domain.MFARolePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicies indicates an expected call of GetPolicies.
+func (mr *MockMFAUsecaseMockRecorder) GetPolicies(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicies", reflect.TypeOf((*MockMFAUsecase)(nil).GetPolicies), ctx)
+}
+
+// RegenerateRecoveryCodes mocks base method.
+func (m *MockMFAUsecase) RegenerateRecoveryCodes(ctx context.Context, userID uint, payload *domain.MFACodePayload) (*domain.MFARecoveryCodes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegenerateRecoveryCodes", ctx, userID, payload)
+	ret0, _ := ret[0].(*domain.MFARecoveryCodes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegenerateRecoveryCodes indicates an expected call of RegenerateRecoveryCodes.
+func (mr *MockMFAUsecaseMockRecorder) RegenerateRecoveryCodes(ctx, userID, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegenerateRecoveryCodes", reflect.TypeOf((*MockMFAUsecase)(nil).RegenerateRecoveryCodes), ctx, userID, payload)
+}
+
+// ResetFailures mocks base method.
+func (m *MockMFAUsecase) ResetFailures(ctx context.Context, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetFailures", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetFailures indicates an expected call of ResetFailures.
+func (mr *MockMFAUsecaseMockRecorder) ResetFailures(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetFailures", reflect.TypeOf((*MockMFAUsecase)(nil).ResetFailures), ctx, userID)
+}
+
+// SetPolicy mocks base method.
+func (m *MockMFAUsecase) SetPolicy(ctx context.Context, role string, payload *domain.MFAPolicyPayload) (*domain.MFARolePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPolicy", ctx, role, payload)
+	ret0, _ := ret[0].(*domain.MFARolePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPolicy indicates an expected call of SetPolicy.
+func (mr *MockMFAUsecaseMockRecorder) SetPolicy(ctx, role, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPolicy", reflect.TypeOf((*MockMFAUsecase)(nil).SetPolicy), ctx, role, payload)
+}
+
+// VerifyChallenge mocks base method.
+func (m *MockMFAUsecase) VerifyChallenge(ctx context.Context, payload *domain.MFAVerifyPayload, client *domain.ClientInfo) (*domain.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyChallenge", ctx, payload, client)
+	ret0, _ := ret[0].(*domain.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyChallenge indicates an expected call of VerifyChallenge.
+func (mr *MockMFAUsecaseMockRecorder) VerifyChallenge(ctx, payload, client interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyChallenge", reflect.TypeOf((*MockMFAUsecase)(nil).VerifyChallenge), ctx, payload, client)
+}
@@ -0,0 +1,158 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/password.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/X3nonxe/gopsy-backend/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPasswordResetRepository is a mock of PasswordResetRepository interface.
+type MockPasswordResetRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordResetRepositoryMockRecorder
+}
+
+// MockPasswordResetRepositoryMockRecorder is the mock recorder for MockPasswordResetRepository.
+type MockPasswordResetRepositoryMockRecorder struct {
+	mock *MockPasswordResetRepository
+}
+
+// NewMockPasswordResetRepository creates a new mock instance.
+func NewMockPasswordResetRepository(ctrl *gomock.Controller) *MockPasswordResetRepository {
+	mock := &MockPasswordResetRepository{ctrl: ctrl}
+	mock.recorder = &MockPasswordResetRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordResetRepository) EXPECT() *MockPasswordResetRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPasswordResetRepository) Create(ctx context.Context, token *domain.PasswordResetToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPasswordResetRepositoryMockRecorder) Create(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPasswordResetRepository)(nil).Create), ctx, token)
+}
+
+// GetByHash mocks base method.
+func (m *MockPasswordResetRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*domain.PasswordResetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockPasswordResetRepositoryMockRecorder) GetByHash(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockPasswordResetRepository)(nil).GetByHash), ctx, tokenHash)
+}
+
+// InvalidateAllForUser mocks base method.
+func (m *MockPasswordResetRepository) InvalidateAllForUser(ctx context.Context, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateAllForUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateAllForUser indicates an expected call of InvalidateAllForUser.
+func (mr *MockPasswordResetRepositoryMockRecorder) InvalidateAllForUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateAllForUser", reflect.TypeOf((*MockPasswordResetRepository)(nil).InvalidateAllForUser), ctx, userID)
+}
+
+// MarkUsed mocks base method.
+func (m *MockPasswordResetRepository) MarkUsed(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockPasswordResetRepositoryMockRecorder) MarkUsed(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockPasswordResetRepository)(nil).MarkUsed), ctx, id)
+}
+
+// MockPasswordUsecase is a mock of PasswordUsecase interface.
+type MockPasswordUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordUsecaseMockRecorder
+}
+
+// MockPasswordUsecaseMockRecorder is the mock recorder for MockPasswordUsecase.
+type MockPasswordUsecaseMockRecorder struct {
+	mock *MockPasswordUsecase
+}
+
+// NewMockPasswordUsecase creates a new mock instance.
+func NewMockPasswordUsecase(ctrl *gomock.Controller) *MockPasswordUsecase {
+	mock := &MockPasswordUsecase{ctrl: ctrl}
+	mock.recorder = &MockPasswordUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordUsecase) EXPECT() *MockPasswordUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ChangePassword mocks base method.
+func (m *MockPasswordUsecase) ChangePassword(ctx context.Context, userID uint, currentSessionID string, payload *domain.ChangePasswordPayload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, userID, currentSessionID, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockPasswordUsecaseMockRecorder) ChangePassword(ctx, userID, currentSessionID, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockPasswordUsecase)(nil).ChangePassword), ctx, userID, currentSessionID, payload)
+}
+
+// ForgotPassword mocks base method.
+func (m *MockPasswordUsecase) ForgotPassword(ctx context.Context, payload *domain.ForgotPasswordPayload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", ctx, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockPasswordUsecaseMockRecorder) ForgotPassword(ctx, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockPasswordUsecase)(nil).ForgotPassword), ctx, payload)
+}
+
+// ResetPassword mocks base method.
+func (m *MockPasswordUsecase) ResetPassword(ctx context.Context, payload *domain.ResetPasswordPayload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockPasswordUsecaseMockRecorder) ResetPassword(ctx, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockPasswordUsecase)(nil).ResetPassword), ctx, payload)
+}
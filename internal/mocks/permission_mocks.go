@@ -0,0 +1,185 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/permission.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/X3nonxe/gopsy-backend/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPermissionRepository is a mock of PermissionRepository interface.
+type MockPermissionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPermissionRepositoryMockRecorder
+}
+
+// MockPermissionRepositoryMockRecorder is the mock recorder for MockPermissionRepository.
+type MockPermissionRepositoryMockRecorder struct {
+	mock *MockPermissionRepository
+}
+
+// NewMockPermissionRepository creates a new mock instance.
+func NewMockPermissionRepository(ctrl *gomock.Controller) *MockPermissionRepository {
+	mock := &MockPermissionRepository{ctrl: ctrl}
+	mock.recorder = &MockPermissionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPermissionRepository) EXPECT() *MockPermissionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockPermissionRepository) List(ctx context.Context) ([]domain.RolePermission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]domain.RolePermission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockPermissionRepositoryMockRecorder) List(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPermissionRepository)(nil).List), ctx)
+}
+
+// ReplaceForRole mocks base method.
+func (m *MockPermissionRepository) ReplaceForRole(ctx context.Context, role string, permissions []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceForRole", ctx, role, permissions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceForRole indicates an expected call of ReplaceForRole.
+func (mr *MockPermissionRepositoryMockRecorder) ReplaceForRole(ctx, role, permissions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceForRole", reflect.TypeOf((*MockPermissionRepository)(nil).ReplaceForRole), ctx, role, permissions)
+}
+
+// MockPermissionChecker is a mock of PermissionChecker interface.
+type MockPermissionChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockPermissionCheckerMockRecorder
+}
+
+// MockPermissionCheckerMockRecorder is the mock recorder for MockPermissionChecker.
+type MockPermissionCheckerMockRecorder struct {
+	mock *MockPermissionChecker
+}
+
+// NewMockPermissionChecker creates a new mock instance.
+func NewMockPermissionChecker(ctrl *gomock.Controller) *MockPermissionChecker {
+	mock := &MockPermissionChecker{ctrl: ctrl}
+	mock.recorder = &MockPermissionCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPermissionChecker) EXPECT() *MockPermissionCheckerMockRecorder {
+	return m.recorder
+}
+
+// HasPermission mocks base method.
+func (m *MockPermissionChecker) HasPermission(ctx context.Context, role, permission string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPermission", ctx, role, permission)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasPermission indicates an expected call of HasPermission.
+func (mr *MockPermissionCheckerMockRecorder) HasPermission(ctx, role, permission interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPermission", reflect.TypeOf((*MockPermissionChecker)(nil).HasPermission), ctx, role, permission)
+}
+
+// MockPermissionUsecase is a mock of PermissionUsecase interface.
+type MockPermissionUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPermissionUsecaseMockRecorder
+}
+
+// MockPermissionUsecaseMockRecorder is the mock recorder for MockPermissionUsecase.
+type MockPermissionUsecaseMockRecorder struct {
+	mock *MockPermissionUsecase
+}
+
+// NewMockPermissionUsecase creates a new mock instance.
+func NewMockPermissionUsecase(ctrl *gomock.Controller) *MockPermissionUsecase {
+	mock := &MockPermissionUsecase{ctrl: ctrl}
+	mock.recorder = &MockPermissionUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPermissionUsecase) EXPECT() *MockPermissionUsecaseMockRecorder {
+	return m.recorder
+}
+
+// HasPermission mocks base method.
+func (m *MockPermissionUsecase) HasPermission(ctx context.Context, role, permission string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPermission", ctx, role, permission)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasPermission indicates an expected call of HasPermission.
+func (mr *MockPermissionUsecaseMockRecorder) HasPermission(ctx, role, permission interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPermission", reflect.TypeOf((*MockPermissionUsecase)(nil).HasPermission), ctx, role, permission)
+}
+
+// ListPermissions mocks base method.
+func (m *MockPermissionUsecase) ListPermissions(ctx context.Context) []domain.Permission {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPermissions", ctx)
+	ret0, _ := ret[0].([]domain.Permission)
+	return ret0
+}
+
+// ListPermissions indicates an expected call of ListPermissions.
+func (mr *MockPermissionUsecaseMockRecorder) ListPermissions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPermissions", reflect.TypeOf((*MockPermissionUsecase)(nil).ListPermissions), ctx)
+}
+
+// ListRolePermissions mocks base method.
+func (m *MockPermissionUsecase) ListRolePermissions(ctx context.Context) ([]domain.RolePermissions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRolePermissions", ctx)
+	ret0, _ := ret[0].([]domain.RolePermissions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRolePermissions indicates an expected call of ListRolePermissions.
+func (mr *MockPermissionUsecaseMockRecorder) ListRolePermissions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRolePermissions", reflect.TypeOf((*MockPermissionUsecase)(nil).ListRolePermissions), ctx)
+}
+
+// SetRolePermissions mocks base method.
+func (m *MockPermissionUsecase) SetRolePermissions(ctx context.Context, role string, payload *domain.SetRolePermissionsPayload) (*domain.RolePermissions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRolePermissions", ctx, role, payload)
+	ret0, _ := ret[0].(*domain.RolePermissions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRolePermissions indicates an expected call of SetRolePermissions.
+func (mr *MockPermissionUsecaseMockRecorder) SetRolePermissions(ctx, role, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRolePermissions", reflect.TypeOf((*MockPermissionUsecase)(nil).SetRolePermissions), ctx, role, payload)
+}
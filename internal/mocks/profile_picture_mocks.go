@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/profile_picture.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/X3nonxe/gopsy-backend/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockProfilePictureUsecase is a mock of ProfilePictureUsecase interface.
+type MockProfilePictureUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfilePictureUsecaseMockRecorder
+}
+
+// MockProfilePictureUsecaseMockRecorder is the mock recorder for MockProfilePictureUsecase.
+type MockProfilePictureUsecaseMockRecorder struct {
+	mock *MockProfilePictureUsecase
+}
+
+// NewMockProfilePictureUsecase creates a new mock instance.
+func NewMockProfilePictureUsecase(ctrl *gomock.Controller) *MockProfilePictureUsecase {
+	mock := &MockProfilePictureUsecase{ctrl: ctrl}
+	mock.recorder = &MockProfilePictureUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfilePictureUsecase) EXPECT() *MockProfilePictureUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockProfilePictureUsecase) Delete(ctx context.Context, userID uint) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProfilePictureUsecaseMockRecorder) Delete(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProfilePictureUsecase)(nil).Delete), ctx, userID)
+}
+
+// MaxUploadBytes mocks base method.
+func (m *MockProfilePictureUsecase) MaxUploadBytes() int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaxUploadBytes")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// MaxUploadBytes indicates an expected call of MaxUploadBytes.
+func (mr *MockProfilePictureUsecaseMockRecorder) MaxUploadBytes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaxUploadBytes", reflect.TypeOf((*MockProfilePictureUsecase)(nil).MaxUploadBytes))
+}
+
+// URLs mocks base method.
+func (m *MockProfilePictureUsecase) URLs(key *string) *domain.ProfilePictureURLs {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "URLs", key)
+	ret0, _ := ret[0].(*domain.ProfilePictureURLs)
+	return ret0
+}
+
+// URLs indicates an expected call of URLs.
+func (mr *MockProfilePictureUsecaseMockRecorder) URLs(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "URLs", reflect.TypeOf((*MockProfilePictureUsecase)(nil).URLs), key)
+}
+
+// Upload mocks base method.
+func (m *MockProfilePictureUsecase) Upload(ctx context.Context, userID uint, data []byte) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", ctx, userID, data)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockProfilePictureUsecaseMockRecorder) Upload(ctx, userID, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockProfilePictureUsecase)(nil).Upload), ctx, userID, data)
+}
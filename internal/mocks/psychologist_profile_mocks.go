@@ -0,0 +1,223 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/psychologist_profile.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/X3nonxe/gopsy-backend/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPsychologistProfileRepository is a mock of PsychologistProfileRepository interface.
+type MockPsychologistProfileRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPsychologistProfileRepositoryMockRecorder
+}
+
+// MockPsychologistProfileRepositoryMockRecorder is the mock recorder for MockPsychologistProfileRepository.
+type MockPsychologistProfileRepositoryMockRecorder struct {
+	mock *MockPsychologistProfileRepository
+}
+
+// NewMockPsychologistProfileRepository creates a new mock instance.
+func NewMockPsychologistProfileRepository(ctrl *gomock.Controller) *MockPsychologistProfileRepository {
+	mock := &MockPsychologistProfileRepository{ctrl: ctrl}
+	mock.recorder = &MockPsychologistProfileRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPsychologistProfileRepository) EXPECT() *MockPsychologistProfileRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByUserID mocks base method.
+func (m *MockPsychologistProfileRepository) GetByUserID(ctx context.Context, userID uint) (*domain.PsychologistProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", ctx, userID)
+	ret0, _ := ret[0].(*domain.PsychologistProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockPsychologistProfileRepositoryMockRecorder) GetByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockPsychologistProfileRepository)(nil).GetByUserID), ctx, userID)
+}
+
+// GetByUserIDs mocks base method.
+func (m *MockPsychologistProfileRepository) GetByUserIDs(ctx context.Context, userIDs []uint) ([]domain.PsychologistProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserIDs", ctx, userIDs)
+	ret0, _ := ret[0].([]domain.PsychologistProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserIDs indicates an expected call of GetByUserIDs.
+func (mr *MockPsychologistProfileRepositoryMockRecorder) GetByUserIDs(ctx, userIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserIDs", reflect.TypeOf((*MockPsychologistProfileRepository)(nil).GetByUserIDs), ctx, userIDs)
+}
+
+// List mocks base method.
+func (m *MockPsychologistProfileRepository) List(ctx context.Context, filter domain.PsychologistProfileFilter) ([]domain.PsychologistProfile, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]domain.PsychologistProfile)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockPsychologistProfileRepositoryMockRecorder) List(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPsychologistProfileRepository)(nil).List), ctx, filter)
+}
+
+// Resubmit mocks base method.
+func (m *MockPsychologistProfileRepository) Resubmit(ctx context.Context, profile *domain.PsychologistProfile, expectedStatus string, expectedSubmittedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resubmit", ctx, profile, expectedStatus, expectedSubmittedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resubmit indicates an expected call of Resubmit.
+func (mr *MockPsychologistProfileRepositoryMockRecorder) Resubmit(ctx, profile, expectedStatus, expectedSubmittedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resubmit", reflect.TypeOf((*MockPsychologistProfileRepository)(nil).Resubmit), ctx, profile, expectedStatus, expectedSubmittedAt)
+}
+
+// Save mocks base method.
+func (m *MockPsychologistProfileRepository) Save(ctx context.Context, profile *domain.PsychologistProfile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, profile)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockPsychologistProfileRepositoryMockRecorder) Save(ctx, profile interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockPsychologistProfileRepository)(nil).Save), ctx, profile)
+}
+
+// UpdateVerification mocks base method.
+func (m *MockPsychologistProfileRepository) UpdateVerification(ctx context.Context, profile *domain.PsychologistProfile, expectedStatus string, expectedSubmittedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVerification", ctx, profile, expectedStatus, expectedSubmittedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateVerification indicates an expected call of UpdateVerification.
+func (mr *MockPsychologistProfileRepositoryMockRecorder) UpdateVerification(ctx, profile, expectedStatus, expectedSubmittedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVerification", reflect.TypeOf((*MockPsychologistProfileRepository)(nil).UpdateVerification), ctx, profile, expectedStatus, expectedSubmittedAt)
+}
+
+// MockPsychologistProfileUsecase is a mock of PsychologistProfileUsecase interface.
+type MockPsychologistProfileUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPsychologistProfileUsecaseMockRecorder
+}
+
+// MockPsychologistProfileUsecaseMockRecorder is the mock recorder for MockPsychologistProfileUsecase.
+type MockPsychologistProfileUsecaseMockRecorder struct {
+	mock *MockPsychologistProfileUsecase
+}
+
+// NewMockPsychologistProfileUsecase creates a new mock instance.
+func NewMockPsychologistProfileUsecase(ctrl *gomock.Controller) *MockPsychologistProfileUsecase {
+	mock := &MockPsychologistProfileUsecase{ctrl: ctrl}
+	mock.recorder = &MockPsychologistProfileUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPsychologistProfileUsecase) EXPECT() *MockPsychologistProfileUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetOwnProfile mocks base method.
+func (m *MockPsychologistProfileUsecase) GetOwnProfile(ctx context.Context, userID uint) (*domain.PsychologistProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnProfile", ctx, userID)
+	ret0, _ := ret[0].(*domain.PsychologistProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwnProfile indicates an expected call of GetOwnProfile.
+func (mr *MockPsychologistProfileUsecaseMockRecorder) GetOwnProfile(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnProfile", reflect.TypeOf((*MockPsychologistProfileUsecase)(nil).GetOwnProfile), ctx, userID)
+}
+
+// GetProfile mocks base method.
+func (m *MockPsychologistProfileUsecase) GetProfile(ctx context.Context, userID uint) (*domain.PsychologistProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfile", ctx, userID)
+	ret0, _ := ret[0].(*domain.PsychologistProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfile indicates an expected call of GetProfile.
+func (mr *MockPsychologistProfileUsecaseMockRecorder) GetProfile(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*MockPsychologistProfileUsecase)(nil).GetProfile), ctx, userID)
+}
+
+// ListProfiles mocks base method.
+func (m *MockPsychologistProfileUsecase) ListProfiles(ctx context.Context, query *domain.PsychologistProfileQuery) (*domain.PsychologistProfileResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProfiles", ctx, query)
+	ret0, _ := ret[0].(*domain.PsychologistProfileResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProfiles indicates an expected call of ListProfiles.
+func (mr *MockPsychologistProfileUsecaseMockRecorder) ListProfiles(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProfiles", reflect.TypeOf((*MockPsychologistProfileUsecase)(nil).ListProfiles), ctx, query)
+}
+
+// Review mocks base method.
+func (m *MockPsychologistProfileUsecase) Review(ctx context.Context, reviewerID, userID uint, payload *domain.PsychologistVerificationPayload) (*domain.PsychologistProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Review", ctx, reviewerID, userID, payload)
+	ret0, _ := ret[0].(*domain.PsychologistProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Review indicates an expected call of Review.
+func (mr *MockPsychologistProfileUsecaseMockRecorder) Review(ctx, reviewerID, userID, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Review", reflect.TypeOf((*MockPsychologistProfileUsecase)(nil).Review), ctx, reviewerID, userID, payload)
+}
+
+// UpdateOwnProfile mocks base method.
+func (m *MockPsychologistProfileUsecase) UpdateOwnProfile(ctx context.Context, userID uint, payload *domain.PsychologistProfilePayload) (*domain.PsychologistProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOwnProfile", ctx, userID, payload)
+	ret0, _ := ret[0].(*domain.PsychologistProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOwnProfile indicates an expected call of UpdateOwnProfile.
+func (mr *MockPsychologistProfileUsecaseMockRecorder) UpdateOwnProfile(ctx, userID, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOwnProfile", reflect.TypeOf((*MockPsychologistProfileUsecase)(nil).UpdateOwnProfile), ctx, userID, payload)
+}
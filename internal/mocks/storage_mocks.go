@@ -0,0 +1,118 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/storage.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/X3nonxe/gopsy-backend/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockFileStorage is a mock of FileStorage interface.
+type MockFileStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockFileStorageMockRecorder
+}
+
+// MockFileStorageMockRecorder is the mock recorder for MockFileStorage.
+type MockFileStorageMockRecorder struct {
+	mock *MockFileStorage
+}
+
+// NewMockFileStorage creates a new mock instance.
+func NewMockFileStorage(ctrl *gomock.Controller) *MockFileStorage {
+	mock := &MockFileStorage{ctrl: ctrl}
+	mock.recorder = &MockFileStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFileStorage) EXPECT() *MockFileStorageMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockFileStorage) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockFileStorageMockRecorder) Delete(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFileStorage)(nil).Delete), ctx, key)
+}
+
+// Put mocks base method.
+func (m *MockFileStorage) Put(ctx context.Context, key, contentType string, data []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", ctx, key, contentType, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockFileStorageMockRecorder) Put(ctx, key, contentType, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockFileStorage)(nil).Put), ctx, key, contentType, data)
+}
+
+// SignedURL mocks base method.
+func (m *MockFileStorage) SignedURL(key string, ttl time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignedURL", key, ttl)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignedURL indicates an expected call of SignedURL.
+func (mr *MockFileStorageMockRecorder) SignedURL(key, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignedURL", reflect.TypeOf((*MockFileStorage)(nil).SignedURL), key, ttl)
+}
+
+// MockSignedFileServer is a mock of SignedFileServer interface.
+type MockSignedFileServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockSignedFileServerMockRecorder
+}
+
+// MockSignedFileServerMockRecorder is the mock recorder for MockSignedFileServer.
+type MockSignedFileServerMockRecorder struct {
+	mock *MockSignedFileServer
+}
+
+// NewMockSignedFileServer creates a new mock instance.
+func NewMockSignedFileServer(ctrl *gomock.Controller) *MockSignedFileServer {
+	mock := &MockSignedFileServer{ctrl: ctrl}
+	mock.recorder = &MockSignedFileServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSignedFileServer) EXPECT() *MockSignedFileServerMockRecorder {
+	return m.recorder
+}
+
+// Open mocks base method.
+func (m *MockSignedFileServer) Open(ctx context.Context, key, expires, signature string) (*domain.StoredFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", ctx, key, expires, signature)
+	ret0, _ := ret[0].(*domain.StoredFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockSignedFileServerMockRecorder) Open(ctx, key, expires, signature interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockSignedFileServer)(nil).Open), ctx, key, expires, signature)
+}
@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/user_admin.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/X3nonxe/gopsy-backend/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserAdminUsecase is a mock of UserAdminUsecase interface.
+type MockUserAdminUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserAdminUsecaseMockRecorder
+}
+
+// MockUserAdminUsecaseMockRecorder is the mock recorder for MockUserAdminUsecase.
+type MockUserAdminUsecaseMockRecorder struct {
+	mock *MockUserAdminUsecase
+}
+
+// NewMockUserAdminUsecase creates a new mock instance.
+func NewMockUserAdminUsecase(ctrl *gomock.Controller) *MockUserAdminUsecase {
+	mock := &MockUserAdminUsecase{ctrl: ctrl}
+	mock.recorder = &MockUserAdminUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserAdminUsecase) EXPECT() *MockUserAdminUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ChangeRole mocks base method.
+func (m *MockUserAdminUsecase) ChangeRole(ctx context.Context, adminID, userID uint, payload *domain.ChangeRolePayload) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeRole", ctx, adminID, userID, payload)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeRole indicates an expected call of ChangeRole.
+func (mr *MockUserAdminUsecaseMockRecorder) ChangeRole(ctx, adminID, userID, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeRole", reflect.TypeOf((*MockUserAdminUsecase)(nil).ChangeRole), ctx, adminID, userID, payload)
+}
+
+// Deactivate mocks base method.
+func (m *MockUserAdminUsecase) Deactivate(ctx context.Context, adminID, userID uint) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deactivate", ctx, adminID, userID)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deactivate indicates an expected call of Deactivate.
+func (mr *MockUserAdminUsecaseMockRecorder) Deactivate(ctx, adminID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deactivate", reflect.TypeOf((*MockUserAdminUsecase)(nil).Deactivate), ctx, adminID, userID)
+}
+
+// DeleteUser mocks base method.
+func (m *MockUserAdminUsecase) DeleteUser(ctx context.Context, adminID, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, adminID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockUserAdminUsecaseMockRecorder) DeleteUser(ctx, adminID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserAdminUsecase)(nil).DeleteUser), ctx, adminID, userID)
+}
+
+// GetUser mocks base method.
+func (m *MockUserAdminUsecase) GetUser(ctx context.Context, userID uint) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, userID)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockUserAdminUsecaseMockRecorder) GetUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUserAdminUsecase)(nil).GetUser), ctx, userID)
+}
+
+// ListUsers mocks base method.
+func (m *MockUserAdminUsecase) ListUsers(ctx context.Context, query *domain.AdminUserQuery) (*domain.AdminUserResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx, query)
+	ret0, _ := ret[0].(*domain.AdminUserResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockUserAdminUsecaseMockRecorder) ListUsers(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserAdminUsecase)(nil).ListUsers), ctx, query)
+}
+
+// Reactivate mocks base method.
+func (m *MockUserAdminUsecase) Reactivate(ctx context.Context, adminID, userID uint) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reactivate", ctx, adminID, userID)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reactivate indicates an expected call of Reactivate.
+func (mr *MockUserAdminUsecaseMockRecorder) Reactivate(ctx, adminID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reactivate", reflect.TypeOf((*MockUserAdminUsecase)(nil).Reactivate), ctx, adminID, userID)
+}
@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/X3nonxe/gopsy-backend/internal/domain"
 	gomock "github.com/golang/mock/gomock"
@@ -63,6 +64,22 @@ func (mr *MockUserRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepository)(nil).Delete), ctx, id)
 }
 
+// FindPsychologists mocks base method.
+func (m *MockUserRepository) FindPsychologists(ctx context.Context, filter domain.PsychologistFilter) ([]domain.User, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPsychologists", ctx, filter)
+	ret0, _ := ret[0].([]domain.User)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindPsychologists indicates an expected call of FindPsychologists.
+func (mr *MockUserRepositoryMockRecorder) FindPsychologists(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPsychologists", reflect.TypeOf((*MockUserRepository)(nil).FindPsychologists), ctx, filter)
+}
+
 // GetByEmail mocks base method.
 func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	m.ctrl.T.Helper()
@@ -93,18 +110,104 @@ func (mr *MockUserRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserRepository)(nil).GetByID), ctx, id)
 }
 
-// Update mocks base method.
-func (m *MockUserRepository) Update(ctx context.Context, user *domain.User) error {
+// List mocks base method.
+func (m *MockUserRepository) List(ctx context.Context, filter domain.UserFilter) ([]domain.User, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]domain.User)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockUserRepositoryMockRecorder) List(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserRepository)(nil).List), ctx, filter)
+}
+
+// MarkEmailVerified mocks base method.
+func (m *MockUserRepository) MarkEmailVerified(ctx context.Context, id uint, verifiedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEmailVerified", ctx, id, verifiedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEmailVerified indicates an expected call of MarkEmailVerified.
+func (mr *MockUserRepositoryMockRecorder) MarkEmailVerified(ctx, id, verifiedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEmailVerified", reflect.TypeOf((*MockUserRepository)(nil).MarkEmailVerified), ctx, id, verifiedAt)
+}
+
+// SetDeactivatedAt mocks base method.
+func (m *MockUserRepository) SetDeactivatedAt(ctx context.Context, id uint, deactivatedAt *time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, user)
+	ret := m.ctrl.Call(m, "SetDeactivatedAt", ctx, id, deactivatedAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Update indicates an expected call of Update.
-func (mr *MockUserRepositoryMockRecorder) Update(ctx, user interface{}) *gomock.Call {
+// SetDeactivatedAt indicates an expected call of SetDeactivatedAt.
+func (mr *MockUserRepositoryMockRecorder) SetDeactivatedAt(ctx, id, deactivatedAt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserRepository)(nil).Update), ctx, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDeactivatedAt", reflect.TypeOf((*MockUserRepository)(nil).SetDeactivatedAt), ctx, id, deactivatedAt)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockUserRepository) UpdatePassword(ctx context.Context, id uint, hashedPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, id, hashedPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockUserRepositoryMockRecorder) UpdatePassword(ctx, id, hashedPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockUserRepository)(nil).UpdatePassword), ctx, id, hashedPassword)
+}
+
+// UpdateProfile mocks base method.
+func (m *MockUserRepository) UpdateProfile(ctx context.Context, user *domain.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockUserRepositoryMockRecorder) UpdateProfile(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockUserRepository)(nil).UpdateProfile), ctx, user)
+}
+
+// UpdateProfilePictureKey mocks base method.
+func (m *MockUserRepository) UpdateProfilePictureKey(ctx context.Context, id uint, expectedKey, key *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfilePictureKey", ctx, id, expectedKey, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProfilePictureKey indicates an expected call of UpdateProfilePictureKey.
+func (mr *MockUserRepositoryMockRecorder) UpdateProfilePictureKey(ctx, id, expectedKey, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfilePictureKey", reflect.TypeOf((*MockUserRepository)(nil).UpdateProfilePictureKey), ctx, id, expectedKey, key)
+}
+
+// UpdateRole mocks base method.
+func (m *MockUserRepository) UpdateRole(ctx context.Context, id uint, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", ctx, id, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockUserRepositoryMockRecorder) UpdateRole(ctx, id, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockUserRepository)(nil).UpdateRole), ctx, id, role)
 }
 
 // MockUserUsecase is a mock of UserUsecase interface.
@@ -130,6 +233,21 @@ func (m *MockUserUsecase) EXPECT() *MockUserUsecaseMockRecorder {
 	return m.recorder
 }
 
+// GetAvailablePsychologists mocks base method.
+func (m *MockUserUsecase) GetAvailablePsychologists(ctx context.Context, query *domain.PsychologistDirectoryQuery) (*domain.PsychologistDirectoryResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvailablePsychologists", ctx, query)
+	ret0, _ := ret[0].(*domain.PsychologistDirectoryResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvailablePsychologists indicates an expected call of GetAvailablePsychologists.
+func (mr *MockUserUsecaseMockRecorder) GetAvailablePsychologists(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailablePsychologists", reflect.TypeOf((*MockUserUsecase)(nil).GetAvailablePsychologists), ctx, query)
+}
+
 // GetProfile mocks base method.
 func (m *MockUserUsecase) GetProfile(ctx context.Context, userID uint) (*domain.User, error) {
 	m.ctrl.T.Helper()
@@ -146,18 +264,18 @@ func (mr *MockUserUsecaseMockRecorder) GetProfile(ctx, userID interface{}) *gomo
 }
 
 // Login mocks base method.
-func (m *MockUserUsecase) Login(ctx context.Context, payload *domain.LoginPayload) (*domain.LoginResponse, error) {
+func (m *MockUserUsecase) Login(ctx context.Context, payload *domain.LoginPayload, client *domain.ClientInfo) (*domain.LoginResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Login", ctx, payload)
+	ret := m.ctrl.Call(m, "Login", ctx, payload, client)
 	ret0, _ := ret[0].(*domain.LoginResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Login indicates an expected call of Login.
-func (mr *MockUserUsecaseMockRecorder) Login(ctx, payload interface{}) *gomock.Call {
+func (mr *MockUserUsecaseMockRecorder) Login(ctx, payload, client interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUserUsecase)(nil).Login), ctx, payload)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUserUsecase)(nil).Login), ctx, payload, client)
 }
 
 // Register mocks base method.
@@ -189,3 +307,32 @@ func (mr *MockUserUsecaseMockRecorder) RegisterPsychologist(ctx, payload interfa
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterPsychologist", reflect.TypeOf((*MockUserUsecase)(nil).RegisterPsychologist), ctx, payload)
 }
+
+// UnlockLogin mocks base method.
+func (m *MockUserUsecase) UnlockLogin(ctx context.Context, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockLogin", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockLogin indicates an expected call of UnlockLogin.
+func (mr *MockUserUsecaseMockRecorder) UnlockLogin(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockLogin", reflect.TypeOf((*MockUserUsecase)(nil).UnlockLogin), ctx, userID)
+}
+
+// UpdateProfile mocks base method.
+func (m *MockUserUsecase) UpdateProfile(ctx context.Context, userID uint, payload *domain.UpdateProfilePayload) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", ctx, userID, payload)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockUserUsecaseMockRecorder) UpdateProfile(ctx, userID, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockUserUsecase)(nil).UpdateProfile), ctx, userID, payload)
+}
@@ -2,11 +2,15 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/jackc/pgx/v5/pgconn"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type availabilityRepository struct {
@@ -22,6 +26,36 @@ func NewAvailabilityRepository(db *gorm.DB, logger *zap.Logger) domain.Availabil
 	}
 }
 
+// waktuKonsultasiOverlapConstraint adalah nama exclusion constraint yang mencegah dua slot
+// ketersediaan milik psikolog yang sama beririsan pada hari yang sama.
+const waktuKonsultasiOverlapConstraint = "excl_waktu_konsultasi_psikolog_hari"
+
+// EnsureAvailabilityConstraints memasang exclusion constraint anti slot beririsan pada tabel
+// waktu_konsultasi bila belum ada. Dipakai setelah AutoMigrate karena GORM tidak bisa
+// mendeklarasikan constraint ini. Kolom time diubah menjadi timestamp pada tanggal tetap karena
+// Postgres tidak memiliki tipe range untuk time.
+func EnsureAvailabilityConstraints(db *gorm.DB) error {
+	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS btree_gist").Error; err != nil {
+		return fmt.Errorf("failed to create btree_gist extension: %w", err)
+	}
+
+	err := db.Exec(`DO $$ BEGIN
+  IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = '` + waktuKonsultasiOverlapConstraint + `') THEN
+    ALTER TABLE "waktu_konsultasi" ADD CONSTRAINT ` + waktuKonsultasiOverlapConstraint + `
+      EXCLUDE USING gist (
+        "psikolog_id" WITH =,
+        "hari" WITH =,
+        tsrange(DATE '2000-01-01' + "waktu_mulai", DATE '2000-01-01' + "waktu_selesai", '[)') WITH &&
+      );
+  END IF;
+END $$`).Error
+	if err != nil {
+		return fmt.Errorf("failed to add availability overlap constraint: %w", err)
+	}
+
+	return nil
+}
+
 // ReplaceAll menghapus semua jadwal lama dan menyisipkan yang baru dalam satu transaksi.
 func (r *availabilityRepository) ReplaceAll(ctx context.Context, psikologID uint, slots []domain.WaktuKonsultasi) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
@@ -82,3 +116,186 @@ func (r *availabilityRepository) GetByPsikologIDAndDay(ctx context.Context, psik
 
 	return slots, nil
 }
+
+// GetByPsikologIDs mengambil jadwal ketersediaan untuk sekumpulan psikolog sekaligus.
+func (r *availabilityRepository) GetByPsikologIDs(ctx context.Context, psikologIDs []uint) ([]domain.WaktuKonsultasi, error) {
+	var slots []domain.WaktuKonsultasi
+
+	if len(psikologIDs) == 0 {
+		return slots, nil
+	}
+
+	err := r.db.WithContext(ctx).
+		Where("psikolog_id IN ?", psikologIDs).
+		Order("psikolog_id ASC, waktu_mulai ASC").
+		Find(&slots).Error
+
+	if err != nil {
+		r.logger.Error("Failed to get availability by psikolog IDs",
+			zap.Error(err), zap.Int("psikolog_count", len(psikologIDs)))
+		return nil, fmt.Errorf("failed to get availability: %w", err)
+	}
+
+	return slots, nil
+}
+
+// CreateException menyimpan pengecualian jadwal baru.
+func (r *availabilityRepository) CreateException(ctx context.Context, exception *domain.PengecualianJadwal) error {
+	if err := r.db.WithContext(ctx).Create(exception).Error; err != nil {
+		r.logger.Error("Failed to create availability exception",
+			zap.Error(err), zap.Uint("psikolog_id", exception.PsikologID))
+		return fmt.Errorf("failed to create availability exception: %w", err)
+	}
+	return nil
+}
+
+// DeleteException menghapus pengecualian jadwal milik psikolog.
+func (r *availabilityRepository) DeleteException(ctx context.Context, psikologID, id uint) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND psikolog_id = ?", id, psikologID).
+		Delete(&domain.PengecualianJadwal{})
+	if result.Error != nil {
+		r.logger.Error("Failed to delete availability exception",
+			zap.Error(result.Error), zap.Uint("psikolog_id", psikologID), zap.Uint("id", id))
+		return fmt.Errorf("failed to delete availability exception: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrAvailabilityExceptionNotFound
+	}
+	return nil
+}
+
+// GetExceptions mengambil pengecualian jadwal psikolog untuk tanggal di [from, to).
+func (r *availabilityRepository) GetExceptions(ctx context.Context, psikologID uint, from, to time.Time) ([]domain.PengecualianJadwal, error) {
+	var exceptions []domain.PengecualianJadwal
+
+	err := r.db.WithContext(ctx).
+		Where("psikolog_id = ? AND tanggal >= ? AND tanggal < ?",
+			psikologID, from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("tanggal ASC, waktu_mulai ASC").
+		Find(&exceptions).Error
+
+	if err != nil {
+		r.logger.Error("Failed to get availability exceptions",
+			zap.Error(err), zap.Uint("psikolog_id", psikologID))
+		return nil, fmt.Errorf("failed to get availability exceptions: %w", err)
+	}
+
+	return exceptions, nil
+}
+
+// GetSessionSettings mengambil pengaturan sesi milik psikolog.
+func (r *availabilityRepository) GetSessionSettings(ctx context.Context, psikologID uint) (*domain.PengaturanSesi, error) {
+	var settings domain.PengaturanSesi
+
+	err := r.db.WithContext(ctx).Where("psikolog_id = ?", psikologID).First(&settings).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrSessionSettingsNotFound
+		}
+		r.logger.Error("Failed to get session settings", zap.Error(err), zap.Uint("psikolog_id", psikologID))
+		return nil, fmt.Errorf("failed to get session settings: %w", err)
+	}
+
+	return &settings, nil
+}
+
+// UpsertSessionSettings menyimpan pengaturan sesi psikolog, membuat baris baru bila belum ada.
+func (r *availabilityRepository) UpsertSessionSettings(ctx context.Context, settings *domain.PengaturanSesi) error {
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "psikolog_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"durasi_sesi_menit", "buffer_menit", "updated_at"}),
+		}).
+		Create(settings).Error
+	if err != nil {
+		r.logger.Error("Failed to upsert session settings", zap.Error(err), zap.Uint("psikolog_id", settings.PsikologID))
+		return fmt.Errorf("failed to save session settings: %w", err)
+	}
+	return nil
+}
+
+// GetSlotByID mengambil satu slot ketersediaan milik psikolog.
+func (r *availabilityRepository) GetSlotByID(ctx context.Context, psikologID, id uint) (*domain.WaktuKonsultasi, error) {
+	var slot domain.WaktuKonsultasi
+
+	err := r.db.WithContext(ctx).Where("id = ? AND psikolog_id = ?", id, psikologID).First(&slot).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrAvailabilitySlotNotFound
+		}
+		r.logger.Error("Failed to get availability slot", zap.Error(err), zap.Uint("id", id))
+		return nil, fmt.Errorf("failed to get availability slot: %w", err)
+	}
+
+	return &slot, nil
+}
+
+// CreateSlot menyimpan satu slot ketersediaan baru. Database menolak slot yang beririsan dengan
+// slot lain milik psikolog yang sama pada hari yang sama, sehingga dua request yang bersamaan tidak
+// bisa sama-sama lolos pemeriksaan di usecase.
+func (r *availabilityRepository) CreateSlot(ctx context.Context, slot *domain.WaktuKonsultasi) error {
+	slot.Version = 1
+	if err := r.db.WithContext(ctx).Create(slot).Error; err != nil {
+		if isExclusionViolation(err) {
+			return domain.ErrAvailabilitySlotOverlap
+		}
+		r.logger.Error("Failed to create availability slot",
+			zap.Error(err), zap.Uint("psikolog_id", slot.PsikologID))
+		return fmt.Errorf("failed to create availability slot: %w", err)
+	}
+	return nil
+}
+
+// UpdateSlot mengubah slot ketersediaan hanya jika versinya di database masih expectedVersion,
+// lalu menaikkan versinya. Slot baru yang beririsan ditolak database seperti pada CreateSlot.
+func (r *availabilityRepository) UpdateSlot(ctx context.Context, slot *domain.WaktuKonsultasi, expectedVersion uint) error {
+	now := time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&domain.WaktuKonsultasi{}).
+		Where("id = ? AND psikolog_id = ? AND version = ?", slot.ID, slot.PsikologID, expectedVersion).
+		Updates(map[string]interface{}{
+			"hari":          slot.Hari,
+			"waktu_mulai":   slot.WaktuMulai,
+			"waktu_selesai": slot.WaktuSelesai,
+			"version":       gorm.Expr("version + 1"),
+			"updated_at":    now,
+		})
+	if result.Error != nil {
+		if isExclusionViolation(result.Error) {
+			return domain.ErrAvailabilitySlotOverlap
+		}
+		r.logger.Error("Failed to update availability slot",
+			zap.Error(result.Error), zap.Uint("id", slot.ID))
+		return fmt.Errorf("failed to update availability slot: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrAvailabilitySlotVersionMismatch
+	}
+
+	slot.Version = expectedVersion + 1
+	slot.UpdatedAt = now
+	return nil
+}
+
+// DeleteSlot menghapus slot ketersediaan hanya jika versinya di database masih expectedVersion.
+func (r *availabilityRepository) DeleteSlot(ctx context.Context, psikologID, id, expectedVersion uint) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND psikolog_id = ? AND version = ?", id, psikologID, expectedVersion).
+		Delete(&domain.WaktuKonsultasi{})
+	if result.Error != nil {
+		r.logger.Error("Failed to delete availability slot",
+			zap.Error(result.Error), zap.Uint("id", id))
+		return fmt.Errorf("failed to delete availability slot: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrAvailabilitySlotVersionMismatch
+	}
+	return nil
+}
+
+// isExclusionViolation mengembalikan true jika err berasal dari pelanggaran exclusion constraint.
+func isExclusionViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgExclusionViolation
+}
@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type consultationRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewConsultationRepository membuat instance baru dari consultationRepository.
+func NewConsultationRepository(db *gorm.DB, logger *zap.Logger) domain.ConsultationRepository {
+	return &consultationRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// pgExclusionViolation adalah kode SQLSTATE Postgres untuk pelanggaran exclusion constraint.
+const pgExclusionViolation = "23P01"
+
+// konsultasiOverlapConstraint adalah nama exclusion constraint yang mencegah dua konsultasi aktif
+// milik psikolog yang sama beririsan waktunya.
+const konsultasiOverlapConstraint = "excl_konsultasi_psikolog_waktu"
+
+// EnsureKonsultasiConstraints memasang exclusion constraint anti double-booking pada tabel konsultasi
+// bila belum ada. Dipakai setelah AutoMigrate karena GORM tidak bisa mendeklarasikan constraint ini.
+// Status yang dicakup diambil dari domain.ActiveKonsultasiStatuses. Constraint hanya mencegah waktu
+// konsultasi beririsan; jeda antar sesi diatur per psikolog sehingga hanya ditegakkan oleh aplikasi.
+func EnsureKonsultasiConstraints(db *gorm.DB) error {
+	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS btree_gist").Error; err != nil {
+		return fmt.Errorf("failed to create btree_gist extension: %w", err)
+	}
+
+	err := db.Exec(`DO $$ BEGIN
+  IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = '` + konsultasiOverlapConstraint + `') THEN
+    ALTER TABLE "konsultasi" ADD CONSTRAINT ` + konsultasiOverlapConstraint + `
+      EXCLUDE USING gist (
+        "psikolog_id" WITH =,
+        tstzrange("waktu_mulai", "waktu_selesai", '[)') WITH &&
+      ) WHERE ("status" IN (` + sqlStringList(domain.ActiveKonsultasiStatuses) + `));
+  END IF;
+END $$`).Error
+	if err != nil {
+		return fmt.Errorf("failed to add consultation overlap constraint: %w", err)
+	}
+
+	return nil
+}
+
+// sqlStringList menuliskan values sebagai daftar literal string SQL yang dipisahkan koma. Dipakai
+// untuk DDL yang tidak mendukung parameter.
+func sqlStringList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// Create menyimpan konsultasi baru. Database menolak konsultasi aktif yang beririsan dengan
+// konsultasi aktif lain milik psikolog yang sama, sehingga hanya satu dari permintaan yang
+// berebut slot yang sama akan berhasil.
+func (r *consultationRepository) Create(ctx context.Context, konsultasi *domain.Konsultasi) error {
+	if err := r.db.WithContext(ctx).Create(konsultasi).Error; err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgExclusionViolation {
+			r.logger.Warn("Consultation slot already taken",
+				zap.Uint("psikolog_id", konsultasi.PsikologID), zap.Time("waktu_mulai", konsultasi.WaktuMulai))
+			return domain.ErrConsultationSlotTaken
+		}
+		r.logger.Error("Failed to create consultation",
+			zap.Error(err), zap.Uint("klien_id", konsultasi.KlienID), zap.Uint("psikolog_id", konsultasi.PsikologID))
+		return fmt.Errorf("failed to create consultation: %w", err)
+	}
+	return nil
+}
+
+// GetByID mengambil konsultasi berdasarkan ID.
+func (r *consultationRepository) GetByID(ctx context.Context, id uint) (*domain.Konsultasi, error) {
+	var konsultasi domain.Konsultasi
+
+	err := r.db.WithContext(ctx).First(&konsultasi, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrConsultationNotFound
+		}
+		r.logger.Error("Failed to get consultation by ID", zap.Error(err), zap.Uint("id", id))
+		return nil, fmt.Errorf("failed to get consultation: %w", err)
+	}
+
+	return &konsultasi, nil
+}
+
+// CountOverlapping menghitung konsultasi aktif milik psikolog yang beririsan dengan rentang waktu.
+func (r *consultationRepository) CountOverlapping(ctx context.Context, psikologID uint, start, end time.Time) (int64, error) {
+	var count int64
+
+	err := r.db.WithContext(ctx).
+		Model(&domain.Konsultasi{}).
+		Where("psikolog_id = ? AND status IN ?", psikologID, domain.ActiveKonsultasiStatuses).
+		Where("waktu_mulai < ? AND waktu_selesai > ?", end, start).
+		Count(&count).Error
+
+	if err != nil {
+		r.logger.Error("Failed to count overlapping consultations",
+			zap.Error(err), zap.Uint("psikolog_id", psikologID))
+		return 0, fmt.Errorf("failed to count overlapping consultations: %w", err)
+	}
+
+	return count, nil
+}
+
+// List mengambil daftar konsultasi sesuai filter beserta jumlah totalnya, diurutkan berdasarkan waktu mulai.
+// Data klien dan psikolog hanya dimuat jika filter.Preload diisi.
+func (r *consultationRepository) List(ctx context.Context, filter domain.KonsultasiFilter) ([]domain.Konsultasi, int64, error) {
+	var (
+		konsultasi []domain.Konsultasi
+		total      int64
+	)
+
+	query := r.db.WithContext(ctx).Model(&domain.Konsultasi{})
+	if filter.PsikologID != 0 {
+		query = query.Where("psikolog_id = ?", filter.PsikologID)
+	}
+	if filter.KlienID != 0 {
+		query = query.Where("klien_id = ?", filter.KlienID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if filter.From != nil {
+		query = query.Where("waktu_mulai >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("waktu_mulai < ?", *filter.To)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error("Failed to count consultations", zap.Error(err), zap.Any("filter", filter))
+		return nil, 0, fmt.Errorf("failed to count consultations: %w", err)
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit).Offset(filter.Offset)
+	}
+
+	if filter.Preload {
+		query = query.Preload("Klien").Preload("Psikolog")
+	}
+
+	err := query.Order("waktu_mulai ASC").Find(&konsultasi).Error
+	if err != nil {
+		r.logger.Error("Failed to list consultations", zap.Error(err), zap.Any("filter", filter))
+		return nil, 0, fmt.Errorf("failed to list consultations: %w", err)
+	}
+
+	return konsultasi, total, nil
+}
+
+// UpdateStatus memindahkan status konsultasi dan mencatat riwayatnya dalam satu transaksi.
+// Update hanya berhasil jika status di database masih sama dengan fromStatus.
+func (r *consultationRepository) UpdateStatus(ctx context.Context, konsultasi *domain.Konsultasi, fromStatus string, history *domain.KonsultasiStatusHistory) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Konsultasi{}).
+			Where("id = ? AND status = ?", konsultasi.ID, fromStatus).
+			Updates(map[string]interface{}{
+				"status":            konsultasi.Status,
+				"status_reason":     konsultasi.StatusReason,
+				"status_changed_at": konsultasi.StatusChangedAt,
+				"updated_at":        time.Now(),
+			})
+		if result.Error != nil {
+			r.logger.Error("Failed to update consultation status",
+				zap.Error(result.Error), zap.Uint("id", konsultasi.ID))
+			return fmt.Errorf("failed to update consultation status: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrConsultationStatusChanged
+		}
+
+		if err := tx.Create(history).Error; err != nil {
+			r.logger.Error("Failed to create consultation status history",
+				zap.Error(err), zap.Uint("id", konsultasi.ID))
+			return fmt.Errorf("failed to create consultation status history: %w", err)
+		}
+
+		return nil
+	})
+}
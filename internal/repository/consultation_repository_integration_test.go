@@ -0,0 +1,178 @@
+//go:build integration
+
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/internal/repository"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// setupTestDBForConsultation adalah helper untuk koneksi ke DB, memasang constraint konsultasi, dan membersihkannya
+func setupTestDBForConsultation(t *testing.T) (*gorm.DB, func()) {
+	// Muat .env untuk mendapatkan credential DB
+	if err := godotenv.Load("../../.env"); err != nil {
+		log.Fatalf("Error loading .env file for integration tests: %v", err)
+	}
+
+	if os.Getenv("DB_HOST") != "localhost" {
+		t.Fatalf("DB_HOST must be 'localhost' for integration tests, but got '%s'", os.Getenv("DB_HOST"))
+	}
+
+	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
+		os.Getenv("DB_HOST"), os.Getenv("DB_USER"), os.Getenv("DB_PASS"), os.Getenv("DB_NAME"), os.Getenv("DB_PORT"), os.Getenv("DB_SSL_MODE"),
+	)
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database for integration test: %v", err)
+	}
+
+	// Migrasi tabel yang diperlukan beserta exclusion constraint anti double-booking
+	db.AutoMigrate(&domain.User{}, &domain.Konsultasi{}, &domain.KonsultasiStatusHistory{})
+	if err := repository.EnsureKonsultasiConstraints(db); err != nil {
+		t.Fatalf("Failed to add consultation constraints: %v", err)
+	}
+
+	// Fungsi teardown
+	teardown := func() {
+		db.Exec("TRUNCATE TABLE users, konsultasi, konsultasi_status_history RESTART IDENTITY CASCADE")
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+	}
+
+	// Bersihkan tabel sebelum setiap test run
+	db.Exec("TRUNCATE TABLE users, konsultasi, konsultasi_status_history RESTART IDENTITY CASCADE")
+
+	return db, teardown
+}
+
+func TestConsultationRepository_Integration_DoubleBooking(t *testing.T) {
+	db, teardown := setupTestDBForConsultation(t)
+	defer teardown()
+
+	consultationRepo := repository.NewConsultationRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	psikolog := &domain.User{Username: "dr.budi", Email: "budi@test.com", Password: "pwd", Role: "psikolog"}
+	db.Create(psikolog)
+	assert.NotZero(t, psikolog.ID)
+
+	const racers = 20
+	klien := make([]*domain.User, racers)
+	for i := range klien {
+		klien[i] = &domain.User{
+			Username: fmt.Sprintf("klien_%d", i),
+			Email:    fmt.Sprintf("klien%d@test.com", i),
+			Password: "pwd",
+			Role:     "klien",
+		}
+		db.Create(klien[i])
+	}
+
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	end := start.Add(time.Hour)
+
+	t.Run("Concurrent Requests For One Slot", func(t *testing.T) {
+		// Arrange
+		var (
+			wg    sync.WaitGroup
+			ready = make(chan struct{})
+		)
+		results := make(chan error, racers)
+
+		// Act - semua goroutine dilepas bersamaan untuk slot yang sama
+		for i := 0; i < racers; i++ {
+			wg.Add(1)
+			go func(index int) {
+				defer wg.Done()
+				<-ready
+				results <- consultationRepo.Create(ctx, &domain.Konsultasi{
+					KlienID:      klien[index].ID,
+					PsikologID:   psikolog.ID,
+					WaktuMulai:   start,
+					WaktuSelesai: end,
+					Status:       domain.KonsultasiStatusPending,
+				})
+			}(i)
+		}
+		close(ready)
+		wg.Wait()
+		close(results)
+
+		// Assert - tepat satu pemesanan yang menang, sisanya ditolak sebagai slot terisi
+		var wins, taken int
+		for err := range results {
+			switch {
+			case err == nil:
+				wins++
+			case errors.Is(err, domain.ErrConsultationSlotTaken):
+				taken++
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		}
+		assert.Equal(t, 1, wins)
+		assert.Equal(t, racers-1, taken)
+
+		var count int64
+		db.Model(&domain.Konsultasi{}).Where("psikolog_id = ?", psikolog.ID).Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Partially Overlapping Slot Is Rejected", func(t *testing.T) {
+		err := consultationRepo.Create(ctx, &domain.Konsultasi{
+			KlienID:      klien[0].ID,
+			PsikologID:   psikolog.ID,
+			WaktuMulai:   start.Add(30 * time.Minute),
+			WaktuSelesai: end.Add(30 * time.Minute),
+			Status:       domain.KonsultasiStatusPending,
+		})
+
+		assert.True(t, errors.Is(err, domain.ErrConsultationSlotTaken))
+	})
+
+	t.Run("Adjacent Slot Is Allowed", func(t *testing.T) {
+		err := consultationRepo.Create(ctx, &domain.Konsultasi{
+			KlienID:      klien[1].ID,
+			PsikologID:   psikolog.ID,
+			WaktuMulai:   end,
+			WaktuSelesai: end.Add(time.Hour),
+			Status:       domain.KonsultasiStatusPending,
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Cancelled Consultation Frees The Slot", func(t *testing.T) {
+		// Arrange - batalkan konsultasi pemenang
+		db.Model(&domain.Konsultasi{}).
+			Where("psikolog_id = ? AND waktu_mulai = ?", psikolog.ID, start).
+			Update("status", domain.KonsultasiStatusCancelled)
+
+		// Act
+		err := consultationRepo.Create(ctx, &domain.Konsultasi{
+			KlienID:      klien[2].ID,
+			PsikologID:   psikolog.ID,
+			WaktuMulai:   start,
+			WaktuSelesai: end,
+			Status:       domain.KonsultasiStatusPending,
+		})
+
+		// Assert
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,365 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type dataPrivacyRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewDataPrivacyRepository membuat instance baru dari dataPrivacyRepository.
+func NewDataPrivacyRepository(db *gorm.DB, logger *zap.Logger) domain.DataPrivacyRepository {
+	return &dataPrivacyRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// pgUniqueViolation adalah kode SQLSTATE Postgres untuk pelanggaran unique constraint.
+const pgUniqueViolation = "23505"
+
+// EnsureDataExportConstraints memasang unique index parsial yang membatasi satu ekspor aktif per
+// user. Dipakai setelah AutoMigrate karena tag GORM tidak bisa menuliskan klausa WHERE ini.
+// Daftar status harus sama dengan DataExport.IsActive.
+func EnsureDataExportConstraints(db *gorm.DB) error {
+	err := db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS "idx_data_exports_active_user"
+  ON "data_exports" ("user_id") WHERE ("status" IN ('pending', 'processing'))`).Error
+	if err != nil {
+		return fmt.Errorf("failed to add active data export index: %w", err)
+	}
+	return nil
+}
+
+// CreateExport menyimpan permintaan ekspor baru. Database menolak ekspor kedua selama ekspor
+// sebelumnya masih aktif, sehingga permintaan yang bersamaan hanya menghasilkan satu arsip.
+func (r *dataPrivacyRepository) CreateExport(ctx context.Context, export *domain.DataExport) error {
+	if err := r.db.WithContext(ctx).Create(export).Error; err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return domain.ErrDataExportInProgress
+		}
+		r.logger.Error("Failed to create data export", zap.Error(err), zap.Uint("user_id", export.UserID))
+		return fmt.Errorf("failed to create data export: %w", err)
+	}
+	return nil
+}
+
+// GetExport mengambil ekspor milik user berdasarkan ID.
+func (r *dataPrivacyRepository) GetExport(ctx context.Context, userID uint, id string) (*domain.DataExport, error) {
+	var export domain.DataExport
+
+	err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&export).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrDataExportNotFound
+		}
+		r.logger.Error("Failed to get data export", zap.Error(err), zap.String("export_id", id))
+		return nil, fmt.Errorf("failed to get data export: %w", err)
+	}
+
+	return &export, nil
+}
+
+// ListExports mengambil semua ekspor milik user, terbaru lebih dulu.
+func (r *dataPrivacyRepository) ListExports(ctx context.Context, userID uint) ([]domain.DataExport, error) {
+	var exports []domain.DataExport
+
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Find(&exports).Error
+	if err != nil {
+		r.logger.Error("Failed to list data exports", zap.Error(err), zap.Uint("user_id", userID))
+		return nil, fmt.Errorf("failed to list data exports: %w", err)
+	}
+
+	return exports, nil
+}
+
+// UpdateExport menyimpan status, file, dan masa berlaku ekspor jika statusnya masih
+// expectedStatus. Perubahan status yang bersamaan, misalnya ekspor yang ditandai gagal karena
+// terlalu lama sementara arsipnya baru selesai, tidak saling menimpa. Ekspor yang sudah terhapus,
+// misalnya karena akunnya dihapus selama arsip dibuat, tidak dibuat ulang.
+func (r *dataPrivacyRepository) UpdateExport(ctx context.Context, export *domain.DataExport, expectedStatus string) error {
+	result := r.db.WithContext(ctx).Model(export).
+		Where("status = ?", expectedStatus).
+		Select("status", "file_key", "size_bytes", "completed_at", "expires_at", "updated_at").
+		Updates(export)
+	if result.Error != nil {
+		r.logger.Error("Failed to update data export", zap.Error(result.Error), zap.String("export_id", export.ID))
+		return fmt.Errorf("failed to update data export: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrDataExportStatusChanged
+	}
+	return nil
+}
+
+// ListExpiredExports mengambil ekspor siap unduh yang masa berlakunya sudah lewat.
+func (r *dataPrivacyRepository) ListExpiredExports(ctx context.Context, now time.Time, limit int) ([]domain.DataExport, error) {
+	var exports []domain.DataExport
+
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND expires_at <= ?", domain.DataExportStatusReady, now).
+		Order("expires_at").
+		Limit(limit).
+		Find(&exports).Error
+	if err != nil {
+		r.logger.Error("Failed to list expired data exports", zap.Error(err))
+		return nil, fmt.Errorf("failed to list expired data exports: %w", err)
+	}
+
+	return exports, nil
+}
+
+// ListPendingExports mengambil ekspor yang belum diambil alih proses pembuat arsip.
+func (r *dataPrivacyRepository) ListPendingExports(ctx context.Context, limit int) ([]domain.DataExport, error) {
+	var exports []domain.DataExport
+
+	err := r.db.WithContext(ctx).
+		Where("status = ?", domain.DataExportStatusPending).
+		Order("created_at").
+		Limit(limit).
+		Find(&exports).Error
+	if err != nil {
+		r.logger.Error("Failed to list pending data exports", zap.Error(err))
+		return nil, fmt.Errorf("failed to list pending data exports: %w", err)
+	}
+
+	return exports, nil
+}
+
+// CollectPersonalData membaca data user dalam satu transaksi read-only REPEATABLE READ agar semua
+// bagian arsip berasal dari snapshot yang sama.
+func (r *dataPrivacyRepository) CollectPersonalData(ctx context.Context, userID uint) (*domain.PersonalDataArchive, error) {
+	archive := &domain.PersonalDataArchive{Sessions: []domain.Session{}}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user domain.User
+		if err := tx.First(&user, userID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrUserNotFound
+			}
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		archive.Profile = &user
+
+		var profiles []domain.PsychologistProfile
+		if err := tx.Where("user_id = ?", userID).Limit(1).Find(&profiles).Error; err != nil {
+			return fmt.Errorf("failed to get psychologist profile: %w", err)
+		}
+		if len(profiles) > 0 {
+			archive.PsychologistProfile = &profiles[0]
+		}
+
+		consultations, err := r.collectConsultations(tx, userID)
+		if err != nil {
+			return err
+		}
+		archive.Consultations = consultations
+
+		if user.Role == domain.RolePsikolog {
+			availability, err := r.collectAvailability(tx, userID)
+			if err != nil {
+				return err
+			}
+			archive.Availability = availability
+		}
+
+		if err := tx.Where("user_id = ?", userID).Order("created_at").Find(&archive.Sessions).Error; err != nil {
+			return fmt.Errorf("failed to get sessions: %w", err)
+		}
+
+		var mfa []domain.UserMFA
+		if err := tx.Where("user_id = ?", userID).Limit(1).Find(&mfa).Error; err != nil {
+			return fmt.Errorf("failed to get MFA settings: %w", err)
+		}
+		if len(mfa) > 0 {
+			archive.MFA = &mfa[0]
+		}
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, err
+		}
+		r.logger.Error("Failed to collect personal data", zap.Error(err), zap.Uint("user_id", userID))
+		return nil, fmt.Errorf("failed to collect personal data: %w", err)
+	}
+
+	return archive, nil
+}
+
+// collectConsultations mengambil konsultasi tempat user menjadi klien atau psikolog beserta
+// riwayat statusnya.
+func (r *dataPrivacyRepository) collectConsultations(tx *gorm.DB, userID uint) ([]domain.ExportedConsultation, error) {
+	var consultations []domain.Konsultasi
+	err := tx.Where("klien_id = ? OR psikolog_id = ?", userID, userID).
+		Order("waktu_mulai, id").
+		Find(&consultations).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get consultations: %w", err)
+	}
+
+	exported := make([]domain.ExportedConsultation, 0, len(consultations))
+	if len(consultations) == 0 {
+		return exported, nil
+	}
+
+	ids := make([]uint, 0, len(consultations))
+	for _, konsultasi := range consultations {
+		ids = append(ids, konsultasi.ID)
+	}
+
+	var history []domain.KonsultasiStatusHistory
+	if err := tx.Where("konsultasi_id IN ?", ids).Order("created_at, id").Find(&history).Error; err != nil {
+		return nil, fmt.Errorf("failed to get consultation status history: %w", err)
+	}
+	historyByID := make(map[uint][]domain.KonsultasiStatusHistory, len(consultations))
+	for _, entry := range history {
+		historyByID[entry.KonsultasiID] = append(historyByID[entry.KonsultasiID], entry)
+	}
+
+	for _, konsultasi := range consultations {
+		role := domain.RolePsikolog
+		if konsultasi.KlienID == userID {
+			role = domain.RoleKlien
+		}
+		statusHistory := historyByID[konsultasi.ID]
+		if statusHistory == nil {
+			statusHistory = []domain.KonsultasiStatusHistory{}
+		}
+		exported = append(exported, domain.ExportedConsultation{
+			Konsultasi:    konsultasi,
+			Role:          role,
+			StatusHistory: statusHistory,
+		})
+	}
+	return exported, nil
+}
+
+// collectAvailability mengambil jadwal praktik, pengecualian jadwal, dan pengaturan sesi psikolog.
+func (r *dataPrivacyRepository) collectAvailability(tx *gorm.DB, psikologID uint) (*domain.ExportedAvailability, error) {
+	availability := &domain.ExportedAvailability{
+		Slots:      []domain.WaktuKonsultasi{},
+		Exceptions: []domain.PengecualianJadwal{},
+	}
+
+	if err := tx.Where("psikolog_id = ?", psikologID).Order("id").Find(&availability.Slots).Error; err != nil {
+		return nil, fmt.Errorf("failed to get availability slots: %w", err)
+	}
+	if err := tx.Where("psikolog_id = ?", psikologID).Order("tanggal, id").Find(&availability.Exceptions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get availability exceptions: %w", err)
+	}
+
+	var settings []domain.PengaturanSesi
+	if err := tx.Where("psikolog_id = ?", psikologID).Limit(1).Find(&settings).Error; err != nil {
+		return nil, fmt.Errorf("failed to get session settings: %w", err)
+	}
+	if len(settings) > 0 {
+		availability.Settings = &settings[0]
+	}
+	return availability, nil
+}
+
+// EraseUser menghapus sesi, token, MFA, dan ekspor milik user, lalu mengganti data identitas
+// akun dengan nilai anonim dan menandainya terhapus. Baris users tetap ada karena konsultasi
+// dan riwayat statusnya wajib disimpan dan mereferensikan akun tersebut.
+//
+// Baris user dikunci FOR UPDATE sebelum konsultasi mendatang diperiksa ulang. Konsultasi baru
+// membutuhkan kunci KEY SHARE pada user yang sama karena foreign key klien_id, sehingga booking
+// yang bersamaan menunggu transaksi ini selesai dan pemeriksaan melihat booking yang sudah commit.
+func (r *dataPrivacyRepository) EraseUser(ctx context.Context, userID uint, erasedAt time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user domain.User
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&user, userID).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrUserNotFound
+			}
+			return fmt.Errorf("failed to lock user: %w", err)
+		}
+
+		var upcoming int64
+		err = tx.Model(&domain.Konsultasi{}).
+			Where("klien_id = ? AND status IN ? AND waktu_mulai >= ?", userID, domain.ActiveKonsultasiStatuses, erasedAt).
+			Count(&upcoming).Error
+		if err != nil {
+			return fmt.Errorf("failed to check upcoming consultations: %w", err)
+		}
+		if upcoming > 0 {
+			return domain.ErrAccountHasActiveConsultations
+		}
+
+		userData := []interface{}{
+			&domain.Session{},
+			&domain.RefreshToken{},
+			&domain.PasswordResetToken{},
+			&domain.EmailVerificationToken{},
+			&domain.MFARecoveryCode{},
+			&domain.UserMFA{},
+			&domain.DataExport{},
+		}
+		for _, model := range userData {
+			if err := tx.Where("user_id = ?", userID).Delete(model).Error; err != nil {
+				r.logger.Error("Failed to delete user data", zap.Error(err),
+					zap.String("model", fmt.Sprintf("%T", model)), zap.Uint("user_id", userID))
+				return fmt.Errorf("failed to delete %T: %w", model, err)
+			}
+		}
+
+		// Teks bebas yang ditulis klien bukan bagian dari catatan konsultasi yang wajib disimpan,
+		// sehingga ikut dihapus. Alasan yang ditulis psikolog tetap disimpan sebagai catatan praktiknya.
+		err = tx.Model(&domain.Konsultasi{}).
+			Where("klien_id = ? AND status_reason <> ''", userID).
+			Where("EXISTS (?)", tx.Model(&domain.KonsultasiStatusHistory{}).Select("1").
+				Where("konsultasi_status_history.konsultasi_id = konsultasi.id").
+				Where("konsultasi_status_history.changed_by = ?", userID).
+				Where("konsultasi_status_history.reason = konsultasi.status_reason")).
+			Update("status_reason", "").Error
+		if err != nil {
+			return fmt.Errorf("failed to clear consultation status reasons: %w", err)
+		}
+		err = tx.Model(&domain.Konsultasi{}).Where("klien_id = ? AND catatan <> ''", userID).
+			Update("catatan", "").Error
+		if err != nil {
+			return fmt.Errorf("failed to clear consultation notes: %w", err)
+		}
+		err = tx.Model(&domain.KonsultasiStatusHistory{}).Where("changed_by = ? AND reason <> ''", userID).
+			Update("reason", "").Error
+		if err != nil {
+			return fmt.Errorf("failed to clear consultation status history reasons: %w", err)
+		}
+
+		result := tx.Model(&domain.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"username":            domain.ErasedUsername,
+			"email":               domain.ErasedEmail(userID),
+			"password":            "",
+			"phone_number":        nil,
+			"gender":              nil,
+			"profile_picture_key": nil,
+			"email_verified_at":   nil,
+			"updated_at":          erasedAt,
+			"deleted_at":          erasedAt,
+		})
+		if result.Error != nil {
+			r.logger.Error("Failed to anonymize user", zap.Error(result.Error), zap.Uint("user_id", userID))
+			return fmt.Errorf("failed to anonymize user: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrUserNotFound
+		}
+
+		r.logger.Info("User personal data erased", zap.Uint("user_id", userID))
+		return nil
+	})
+}
@@ -0,0 +1,161 @@
+//go:build integration
+
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/internal/repository"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// setupTestDBForDataPrivacy adalah helper untuk koneksi ke DB, migrasi tabel data pribadi, dan membersihkannya
+func setupTestDBForDataPrivacy(t *testing.T) (*gorm.DB, func()) {
+	// Muat .env untuk mendapatkan credential DB
+	if err := godotenv.Load("../../.env"); err != nil {
+		log.Fatalf("Error loading .env file for integration tests: %v", err)
+	}
+
+	if os.Getenv("DB_HOST") != "localhost" {
+		t.Fatalf("DB_HOST must be 'localhost' for integration tests, but got '%s'", os.Getenv("DB_HOST"))
+	}
+
+	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
+		os.Getenv("DB_HOST"), os.Getenv("DB_USER"), os.Getenv("DB_PASS"), os.Getenv("DB_NAME"), os.Getenv("DB_PORT"), os.Getenv("DB_SSL_MODE"),
+	)
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database for integration test: %v", err)
+	}
+
+	// Migrasi semua tabel yang disentuh EraseUser
+	db.AutoMigrate(
+		&domain.User{},
+		&domain.Konsultasi{},
+		&domain.KonsultasiStatusHistory{},
+		&domain.Session{},
+		&domain.RefreshToken{},
+		&domain.PasswordResetToken{},
+		&domain.EmailVerificationToken{},
+		&domain.UserMFA{},
+		&domain.MFARecoveryCode{},
+		&domain.DataExport{},
+	)
+
+	// Fungsi teardown
+	teardown := func() {
+		db.Exec("TRUNCATE TABLE users, konsultasi, konsultasi_status_history RESTART IDENTITY CASCADE")
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+	}
+
+	// Bersihkan tabel sebelum setiap test run
+	db.Exec("TRUNCATE TABLE users, konsultasi, konsultasi_status_history RESTART IDENTITY CASCADE")
+
+	return db, teardown
+}
+
+func TestDataPrivacyRepository_Integration_EraseUser(t *testing.T) {
+	db, teardown := setupTestDBForDataPrivacy(t)
+	defer teardown()
+
+	privacyRepo := repository.NewDataPrivacyRepository(db, zap.NewNop())
+	userRepo := repository.NewUserRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	phone := "+6281234567890"
+	klien := &domain.User{Username: "klien_budi", Email: "budi@test.com", Password: "pwd", Role: domain.RoleKlien, PhoneNumber: &phone}
+	db.Create(klien)
+	psikolog := &domain.User{Username: "dr.ani", Email: "ani@test.com", Password: "pwd", Role: domain.RolePsikolog}
+	db.Create(psikolog)
+
+	start := time.Now().Add(-48 * time.Hour).Truncate(time.Hour)
+	cancelled := &domain.Konsultasi{
+		KlienID:      klien.ID,
+		PsikologID:   psikolog.ID,
+		WaktuMulai:   start,
+		WaktuSelesai: start.Add(time.Hour),
+		Status:       domain.KonsultasiStatusCancelled,
+		Catatan:      "Saya ingin membahas masalah keluarga",
+		StatusReason: "Ada keperluan mendadak",
+	}
+	db.Create(cancelled)
+	db.Create(&domain.KonsultasiStatusHistory{
+		KonsultasiID: cancelled.ID, FromStatus: domain.KonsultasiStatusPending, ToStatus: domain.KonsultasiStatusCancelled,
+		Reason: "Ada keperluan mendadak", ChangedBy: klien.ID,
+	})
+	rejected := &domain.Konsultasi{
+		KlienID:      klien.ID,
+		PsikologID:   psikolog.ID,
+		WaktuMulai:   start.Add(24 * time.Hour),
+		WaktuSelesai: start.Add(25 * time.Hour),
+		Status:       domain.KonsultasiStatusRejected,
+		StatusReason: "Jadwal penuh",
+	}
+	db.Create(rejected)
+	db.Create(&domain.KonsultasiStatusHistory{
+		KonsultasiID: rejected.ID, FromStatus: domain.KonsultasiStatusPending, ToStatus: domain.KonsultasiStatusRejected,
+		Reason: "Jadwal penuh", ChangedBy: psikolog.ID,
+	})
+
+	// Salinan user dibaca sebelum penghapusan, seperti update profil yang sedang berjalan
+	stale, err := userRepo.GetByID(ctx, klien.ID)
+	assert.NoError(t, err)
+
+	t.Run("Erases Personal Data And Client Written Text", func(t *testing.T) {
+		err := privacyRepo.EraseUser(ctx, klien.ID, time.Now())
+		assert.NoError(t, err)
+
+		var erased domain.User
+		db.Unscoped().First(&erased, klien.ID)
+		assert.Equal(t, domain.ErasedUsername, erased.Username)
+		assert.Equal(t, domain.ErasedEmail(klien.ID), erased.Email)
+		assert.Nil(t, erased.PhoneNumber)
+		assert.True(t, erased.DeletedAt.Valid)
+
+		var reloaded domain.Konsultasi
+		db.First(&reloaded, cancelled.ID)
+		assert.Empty(t, reloaded.Catatan)
+		assert.Empty(t, reloaded.StatusReason)
+
+		// Alasan yang ditulis psikolog tetap disimpan
+		db.First(&reloaded, rejected.ID)
+		assert.Equal(t, "Jadwal penuh", reloaded.StatusReason)
+
+		var histories []domain.KonsultasiStatusHistory
+		db.Order("id ASC").Find(&histories)
+		assert.Len(t, histories, 2)
+		assert.Empty(t, histories[0].Reason)
+		assert.Equal(t, "Jadwal penuh", histories[1].Reason)
+	})
+
+	t.Run("Stale Update Does Not Restore Erased Data", func(t *testing.T) {
+		stale.Username = "klien_budi_baru"
+		err := userRepo.UpdateProfile(ctx, stale)
+		assert.True(t, errors.Is(err, domain.ErrUserNotFound))
+
+		var erased domain.User
+		db.Unscoped().First(&erased, klien.ID)
+		assert.Equal(t, domain.ErasedUsername, erased.Username)
+		assert.Equal(t, domain.ErasedEmail(klien.ID), erased.Email)
+		assert.Empty(t, erased.Password)
+		assert.Nil(t, erased.PhoneNumber)
+		assert.True(t, erased.DeletedAt.Valid)
+
+		var count int64
+		db.Unscoped().Model(&domain.User{}).Where("email = ?", "budi@test.com").Count(&count)
+		assert.Zero(t, count)
+	})
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type emailVerificationRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewEmailVerificationRepository membuat instance baru dari emailVerificationRepository.
+func NewEmailVerificationRepository(db *gorm.DB, logger *zap.Logger) domain.EmailVerificationRepository {
+	return &emailVerificationRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Create menyimpan token verifikasi email baru.
+func (r *emailVerificationRepository) Create(ctx context.Context, token *domain.EmailVerificationToken) error {
+	if err := r.db.WithContext(ctx).Create(token).Error; err != nil {
+		r.logger.Error("Failed to create email verification token", zap.Error(err), zap.Uint("user_id", token.UserID))
+		return fmt.Errorf("failed to create email verification token: %w", err)
+	}
+	return nil
+}
+
+// GetByHash mengambil token verifikasi email berdasarkan hash-nya.
+func (r *emailVerificationRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.EmailVerificationToken, error) {
+	var token domain.EmailVerificationToken
+
+	err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrEmailVerificationTokenNotFound
+		}
+		r.logger.Error("Failed to get email verification token", zap.Error(err))
+		return nil, fmt.Errorf("failed to get email verification token: %w", err)
+	}
+
+	return &token, nil
+}
+
+// MarkUsed menandai token verifikasi sudah dipakai. Hanya satu pemanggil yang berhasil untuk token
+// yang sama; pemanggil lain mendapat ErrInvalidEmailVerificationToken.
+func (r *emailVerificationRepository) MarkUsed(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).
+		Model(&domain.EmailVerificationToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		r.logger.Error("Failed to mark email verification token as used", zap.Error(result.Error), zap.Uint("id", id))
+		return fmt.Errorf("failed to mark email verification token as used: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrInvalidEmailVerificationToken
+	}
+	return nil
+}
+
+// InvalidateAllForUser menandai semua token verifikasi user yang belum dipakai sebagai sudah dipakai.
+func (r *emailVerificationRepository) InvalidateAllForUser(ctx context.Context, userID uint) error {
+	err := r.db.WithContext(ctx).
+		Model(&domain.EmailVerificationToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", time.Now()).Error
+	if err != nil {
+		r.logger.Error("Failed to invalidate email verification tokens", zap.Error(err), zap.Uint("user_id", userID))
+		return fmt.Errorf("failed to invalidate email verification tokens: %w", err)
+	}
+	return nil
+}
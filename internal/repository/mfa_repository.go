@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type mfaRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewMFARepository membuat instance baru dari mfaRepository.
+func NewMFARepository(db *gorm.DB, logger *zap.Logger) domain.MFARepository {
+	return &mfaRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetByUserID mengambil data MFA milik user.
+func (r *mfaRepository) GetByUserID(ctx context.Context, userID uint) (*domain.UserMFA, error) {
+	var mfa domain.UserMFA
+
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&mfa).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrMFANotFound
+		}
+		r.logger.Error("Failed to get user MFA", zap.Error(err), zap.Uint("user_id", userID))
+		return nil, fmt.Errorf("failed to get user MFA: %w", err)
+	}
+
+	return &mfa, nil
+}
+
+// Save menyimpan data MFA user, menimpa data sebelumnya jika ada.
+func (r *mfaRepository) Save(ctx context.Context, mfa *domain.UserMFA) error {
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"secret_encrypted", "enabled_at", "last_used_step", "updated_at"}),
+		}).
+		Create(mfa).Error
+	if err != nil {
+		r.logger.Error("Failed to save user MFA", zap.Error(err), zap.Uint("user_id", mfa.UserID))
+		return fmt.Errorf("failed to save user MFA: %w", err)
+	}
+	return nil
+}
+
+// Delete menghapus data MFA beserta kode pemulihan milik user.
+func (r *mfaRepository) Delete(ctx context.Context, userID uint) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&domain.MFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&domain.UserMFA{}).Error
+	})
+	if err != nil {
+		r.logger.Error("Failed to delete user MFA", zap.Error(err), zap.Uint("user_id", userID))
+		return fmt.Errorf("failed to delete user MFA: %w", err)
+	}
+	return nil
+}
+
+// UpdateLastUsedStep mencatat time step kode TOTP terakhir yang dipakai. Kode dari step yang sama
+// atau lebih lama ditolak dengan ErrInvalidMFACode agar kode tidak bisa dipakai ulang.
+func (r *mfaRepository) UpdateLastUsedStep(ctx context.Context, userID uint, step int64) error {
+	result := r.db.WithContext(ctx).
+		Model(&domain.UserMFA{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		r.logger.Error("Failed to update MFA last used step", zap.Error(result.Error), zap.Uint("user_id", userID))
+		return fmt.Errorf("failed to update MFA last used step: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrInvalidMFACode
+	}
+	return nil
+}
+
+// ReplaceRecoveryCodes mengganti semua kode pemulihan milik user.
+func (r *mfaRepository) ReplaceRecoveryCodes(ctx context.Context, userID uint, codeHashes []string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&domain.MFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		codes := make([]domain.MFARecoveryCode, 0, len(codeHashes))
+		for _, hash := range codeHashes {
+			codes = append(codes, domain.MFARecoveryCode{UserID: userID, CodeHash: hash})
+		}
+		return tx.Create(&codes).Error
+	})
+	if err != nil {
+		r.logger.Error("Failed to replace MFA recovery codes", zap.Error(err), zap.Uint("user_id", userID))
+		return fmt.Errorf("failed to replace MFA recovery codes: %w", err)
+	}
+	return nil
+}
+
+// UseRecoveryCode menandai kode pemulihan sudah dipakai. Kode yang tidak dikenal atau sudah
+// dipakai menghasilkan ErrInvalidMFACode.
+func (r *mfaRepository) UseRecoveryCode(ctx context.Context, userID uint, codeHash string) error {
+	result := r.db.WithContext(ctx).
+		Model(&domain.MFARecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		r.logger.Error("Failed to use MFA recovery code", zap.Error(result.Error), zap.Uint("user_id", userID))
+		return fmt.Errorf("failed to use MFA recovery code: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrInvalidMFACode
+	}
+	return nil
+}
+
+// GetPolicy mengambil kebijakan MFA untuk satu role.
+func (r *mfaRepository) GetPolicy(ctx context.Context, role string) (*domain.MFARolePolicy, error) {
+	var policy domain.MFARolePolicy
+
+	err := r.db.WithContext(ctx).Where("role = ?", role).First(&policy).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrMFAPolicyNotFound
+		}
+		r.logger.Error("Failed to get MFA policy", zap.Error(err), zap.String("role", role))
+		return nil, fmt.Errorf("failed to get MFA policy: %w", err)
+	}
+
+	return &policy, nil
+}
+
+// GetPolicies mengambil semua kebijakan MFA yang tersimpan.
+func (r *mfaRepository) GetPolicies(ctx context.Context) ([]domain.MFARolePolicy, error) {
+	var policies []domain.MFARolePolicy
+
+	if err := r.db.WithContext(ctx).Order("role ASC").Find(&policies).Error; err != nil {
+		r.logger.Error("Failed to get MFA policies", zap.Error(err))
+		return nil, fmt.Errorf("failed to get MFA policies: %w", err)
+	}
+
+	return policies, nil
+}
+
+// UpsertPolicy menyimpan kebijakan MFA untuk satu role.
+func (r *mfaRepository) UpsertPolicy(ctx context.Context, policy *domain.MFARolePolicy) error {
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "role"}},
+			DoUpdates: clause.AssignmentColumns([]string{"required", "updated_at"}),
+		}).
+		Create(policy).Error
+	if err != nil {
+		r.logger.Error("Failed to save MFA policy", zap.Error(err), zap.String("role", policy.Role))
+		return fmt.Errorf("failed to save MFA policy: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type passwordResetRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewPasswordResetRepository membuat instance baru dari passwordResetRepository.
+func NewPasswordResetRepository(db *gorm.DB, logger *zap.Logger) domain.PasswordResetRepository {
+	return &passwordResetRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Create menyimpan token reset password baru.
+func (r *passwordResetRepository) Create(ctx context.Context, token *domain.PasswordResetToken) error {
+	if err := r.db.WithContext(ctx).Create(token).Error; err != nil {
+		r.logger.Error("Failed to create password reset token", zap.Error(err), zap.Uint("user_id", token.UserID))
+		return fmt.Errorf("failed to create password reset token: %w", err)
+	}
+	return nil
+}
+
+// GetByHash mengambil token reset password berdasarkan hash-nya. Hash yang tidak dikenal
+// menghasilkan ErrInvalidPasswordResetToken.
+func (r *passwordResetRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	var token domain.PasswordResetToken
+
+	err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrInvalidPasswordResetToken
+		}
+		r.logger.Error("Failed to get password reset token", zap.Error(err))
+		return nil, fmt.Errorf("failed to get password reset token: %w", err)
+	}
+
+	return &token, nil
+}
+
+// MarkUsed menandai token reset sudah dipakai. Hanya satu pemanggil yang berhasil untuk token
+// yang sama; pemanggil lain mendapat ErrInvalidPasswordResetToken.
+func (r *passwordResetRepository) MarkUsed(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).
+		Model(&domain.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		r.logger.Error("Failed to mark password reset token as used", zap.Error(result.Error), zap.Uint("id", id))
+		return fmt.Errorf("failed to mark password reset token as used: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrInvalidPasswordResetToken
+	}
+	return nil
+}
+
+// InvalidateAllForUser menandai semua token reset user yang belum dipakai sebagai sudah dipakai.
+func (r *passwordResetRepository) InvalidateAllForUser(ctx context.Context, userID uint) error {
+	err := r.db.WithContext(ctx).
+		Model(&domain.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", time.Now()).Error
+	if err != nil {
+		r.logger.Error("Failed to invalidate password reset tokens", zap.Error(err), zap.Uint("user_id", userID))
+		return fmt.Errorf("failed to invalidate password reset tokens: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type permissionRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewPermissionRepository membuat instance baru dari permissionRepository.
+func NewPermissionRepository(db *gorm.DB, logger *zap.Logger) domain.PermissionRepository {
+	return &permissionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// List mengambil seluruh pemetaan role ke permission.
+func (r *permissionRepository) List(ctx context.Context) ([]domain.RolePermission, error) {
+	var rolePermissions []domain.RolePermission
+
+	err := r.db.WithContext(ctx).Order("role ASC, permission ASC").Find(&rolePermissions).Error
+	if err != nil {
+		r.logger.Error("Failed to list role permissions", zap.Error(err))
+		return nil, fmt.Errorf("failed to list role permissions: %w", err)
+	}
+
+	return rolePermissions, nil
+}
+
+// ReplaceForRole mengganti seluruh permission milik role dalam satu transaksi.
+func (r *permissionRepository) ReplaceForRole(ctx context.Context, role string, permissions []string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("role = ?", role).Delete(&domain.RolePermission{}).Error; err != nil {
+			return err
+		}
+		if len(permissions) == 0 {
+			return nil
+		}
+
+		rows := make([]domain.RolePermission, 0, len(permissions))
+		for _, permission := range permissions {
+			rows = append(rows, domain.RolePermission{Role: role, Permission: permission})
+		}
+		return tx.Create(&rows).Error
+	})
+	if err != nil {
+		r.logger.Error("Failed to replace role permissions", zap.Error(err), zap.String("role", role))
+		return fmt.Errorf("failed to replace role permissions: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type psychologistProfileRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewPsychologistProfileRepository membuat instance baru dari psychologistProfileRepository.
+func NewPsychologistProfileRepository(db *gorm.DB, logger *zap.Logger) domain.PsychologistProfileRepository {
+	return &psychologistProfileRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetByUserID mengambil profil profesional milik psikolog.
+func (r *psychologistProfileRepository) GetByUserID(ctx context.Context, userID uint) (*domain.PsychologistProfile, error) {
+	var profile domain.PsychologistProfile
+
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&profile).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrPsychologistProfileNotFound
+		}
+		r.logger.Error("Failed to get psychologist profile", zap.Error(err), zap.Uint("user_id", userID))
+		return nil, fmt.Errorf("failed to get psychologist profile: %w", err)
+	}
+
+	return &profile, nil
+}
+
+// GetByUserIDs mengambil profil profesional milik beberapa psikolog sekaligus.
+func (r *psychologistProfileRepository) GetByUserIDs(ctx context.Context, userIDs []uint) ([]domain.PsychologistProfile, error) {
+	var profiles []domain.PsychologistProfile
+	if len(userIDs) == 0 {
+		return profiles, nil
+	}
+
+	err := r.db.WithContext(ctx).Where("user_id IN ?", userIDs).Find(&profiles).Error
+	if err != nil {
+		r.logger.Error("Failed to get psychologist profiles", zap.Error(err))
+		return nil, fmt.Errorf("failed to get psychologist profiles: %w", err)
+	}
+
+	return profiles, nil
+}
+
+// psychologistProfileDetailColumns adalah kolom profil yang diisi psikolog sendiri. Kolom status
+// verifikasi sengaja tidak ikut agar keputusan admin tidak tertimpa oleh data yang dibaca sebelumnya.
+var psychologistProfileDetailColumns = []string{
+	"sipp_number", "sipp_expires_at", "str_number", "str_expires_at",
+	"specializations", "languages", "bio", "years_of_practice", "session_fee", "updated_at",
+}
+
+// Save membuat profil profesional baru atau memperbarui detail profil yang sudah ada tanpa
+// mengubah status verifikasinya.
+func (r *psychologistProfileRepository) Save(ctx context.Context, profile *domain.PsychologistProfile) error {
+	err := r.db.WithContext(ctx).
+		Omit("User").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns(psychologistProfileDetailColumns),
+		}).
+		Create(profile).Error
+	if err != nil {
+		r.logger.Error("Failed to save psychologist profile", zap.Error(err), zap.Uint("user_id", profile.UserID))
+		return fmt.Errorf("failed to save psychologist profile: %w", err)
+	}
+	return nil
+}
+
+// Resubmit menyimpan detail profil dan mengajukannya ulang untuk diverifikasi jika status dan waktu
+// pengajuan profil belum berubah. ErrPsychologistProfileChanged dikembalikan jika admin meninjau
+// profil di antaranya.
+func (r *psychologistProfileRepository) Resubmit(ctx context.Context, profile *domain.PsychologistProfile, expectedStatus string, expectedSubmittedAt time.Time) error {
+	columns := append([]string{
+		"verification_status", "rejection_reason", "reviewed_by", "reviewed_at", "submitted_at",
+	}, psychologistProfileDetailColumns...)
+
+	result := r.db.WithContext(ctx).
+		Model(&domain.PsychologistProfile{}).
+		Where("user_id = ? AND verification_status = ? AND submitted_at = ?", profile.UserID, expectedStatus, expectedSubmittedAt).
+		Select(columns).
+		Updates(profile)
+	if result.Error != nil {
+		r.logger.Error("Failed to resubmit psychologist profile", zap.Error(result.Error), zap.Uint("user_id", profile.UserID))
+		return fmt.Errorf("failed to resubmit psychologist profile: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrPsychologistProfileChanged
+	}
+	return nil
+}
+
+// List mengambil profil psikolog, pengajuan terlama lebih dulu agar antrean verifikasi diproses
+// berurutan. Akun pemilik profil hanya dimuat jika filter.Preload diisi.
+func (r *psychologistProfileRepository) List(ctx context.Context, filter domain.PsychologistProfileFilter) ([]domain.PsychologistProfile, int64, error) {
+	var (
+		profiles []domain.PsychologistProfile
+		total    int64
+	)
+
+	query := r.db.WithContext(ctx).Model(&domain.PsychologistProfile{})
+	if filter.Status != "" {
+		query = query.Where("verification_status = ?", filter.Status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error("Failed to count psychologist profiles", zap.Error(err))
+		return nil, 0, fmt.Errorf("failed to count psychologist profiles: %w", err)
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit).Offset(filter.Offset)
+	}
+
+	if filter.Preload {
+		query = query.Preload("User")
+	}
+
+	if err := query.Order("submitted_at ASC, user_id ASC").Find(&profiles).Error; err != nil {
+		r.logger.Error("Failed to list psychologist profiles", zap.Error(err))
+		return nil, 0, fmt.Errorf("failed to list psychologist profiles: %w", err)
+	}
+
+	return profiles, total, nil
+}
+
+// UpdateVerification menyimpan keputusan verifikasi jika status dan waktu pengajuan profil belum
+// berubah. ErrPsychologistProfileChanged dikembalikan jika psikolog mengubah profilnya di antaranya.
+func (r *psychologistProfileRepository) UpdateVerification(ctx context.Context, profile *domain.PsychologistProfile, expectedStatus string, expectedSubmittedAt time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&domain.PsychologistProfile{}).
+		Where("user_id = ? AND verification_status = ? AND submitted_at = ?", profile.UserID, expectedStatus, expectedSubmittedAt).
+		Updates(map[string]interface{}{
+			"verification_status": profile.VerificationStatus,
+			"rejection_reason":    profile.RejectionReason,
+			"reviewed_by":         profile.ReviewedBy,
+			"reviewed_at":         profile.ReviewedAt,
+			"updated_at":          time.Now(),
+		})
+	if result.Error != nil {
+		r.logger.Error("Failed to update psychologist verification", zap.Error(result.Error), zap.Uint("user_id", profile.UserID))
+		return fmt.Errorf("failed to update psychologist verification: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrPsychologistProfileChanged
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type refreshTokenRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewRefreshTokenRepository membuat instance baru dari refreshTokenRepository.
+func NewRefreshTokenRepository(db *gorm.DB, logger *zap.Logger) domain.RefreshTokenRepository {
+	return &refreshTokenRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Create menyimpan refresh token baru.
+func (r *refreshTokenRepository) Create(ctx context.Context, token *domain.RefreshToken) error {
+	if err := r.db.WithContext(ctx).Create(token).Error; err != nil {
+		r.logger.Error("Failed to create refresh token", zap.Error(err), zap.Uint("user_id", token.UserID))
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+	return nil
+}
+
+// GetByHash mengambil refresh token berdasarkan hash-nya.
+func (r *refreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	var token domain.RefreshToken
+
+	err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrRefreshTokenNotFound
+		}
+		r.logger.Error("Failed to get refresh token", zap.Error(err))
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	return &token, nil
+}
+
+// MarkUsed menandai refresh token sudah dipakai. Hanya satu pemanggil yang berhasil untuk token
+// yang sama; pemanggil lain mendapat ErrRefreshTokenReused.
+func (r *refreshTokenRepository) MarkUsed(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).
+		Model(&domain.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		r.logger.Error("Failed to mark refresh token as used", zap.Error(result.Error), zap.Uint("id", id))
+		return fmt.Errorf("failed to mark refresh token as used: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrRefreshTokenReused
+	}
+	return nil
+}
+
+// RevokeFamily mencabut semua refresh token dalam satu keluarga rotasi.
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	err := r.db.WithContext(ctx).
+		Model(&domain.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		r.logger.Error("Failed to revoke refresh token family", zap.Error(err), zap.String("family_id", familyID))
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+	return nil
+}
+
+// RevokeAllForUser mencabut semua refresh token milik user.
+func (r *refreshTokenRepository) RevokeAllForUser(ctx context.Context, userID uint) error {
+	err := r.db.WithContext(ctx).
+		Model(&domain.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		r.logger.Error("Failed to revoke user refresh tokens", zap.Error(err), zap.Uint("user_id", userID))
+		return fmt.Errorf("failed to revoke user refresh tokens: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type sessionRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewSessionRepository membuat instance baru dari sessionRepository.
+func NewSessionRepository(db *gorm.DB, logger *zap.Logger) domain.SessionRepository {
+	return &sessionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Create menyimpan sesi login baru.
+func (r *sessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	if err := r.db.WithContext(ctx).Create(session).Error; err != nil {
+		r.logger.Error("Failed to create session", zap.Error(err), zap.Uint("user_id", session.UserID))
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	return nil
+}
+
+// GetByID mengambil sesi berdasarkan ID.
+func (r *sessionRepository) GetByID(ctx context.Context, id string) (*domain.Session, error) {
+	var session domain.Session
+
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&session).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrSessionNotFound
+		}
+		r.logger.Error("Failed to get session", zap.Error(err), zap.String("session_id", id))
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return &session, nil
+}
+
+// ListActiveByUser mengambil sesi user yang belum dicabut dan belum kedaluwarsa, terbaru lebih dulu.
+func (r *sessionRepository) ListActiveByUser(ctx context.Context, userID uint) ([]domain.Session, error) {
+	var sessions []domain.Session
+
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error
+	if err != nil {
+		r.logger.Error("Failed to list sessions", zap.Error(err), zap.Uint("user_id", userID))
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// Touch memperbarui waktu terakhir aktif, perangkat, dan masa berlaku sesi.
+func (r *sessionRepository) Touch(ctx context.Context, id string, client *domain.ClientInfo, expiresAt time.Time) error {
+	updates := map[string]interface{}{
+		"last_seen_at": time.Now(),
+		"expires_at":   expiresAt,
+	}
+	if client != nil {
+		updates["ip_address"] = client.IPAddress
+		updates["user_agent"] = client.UserAgent
+		updates["device_name"] = domain.DeviceName(client.UserAgent)
+	}
+
+	err := r.db.WithContext(ctx).
+		Model(&domain.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(updates).Error
+	if err != nil {
+		r.logger.Error("Failed to touch session", zap.Error(err), zap.String("session_id", id))
+		return fmt.Errorf("failed to touch session: %w", err)
+	}
+	return nil
+}
+
+// Revoke menandai satu sesi sudah dicabut.
+func (r *sessionRepository) Revoke(ctx context.Context, id string) error {
+	err := r.db.WithContext(ctx).
+		Model(&domain.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		r.logger.Error("Failed to revoke session", zap.Error(err), zap.String("session_id", id))
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return nil
+}
+
+// RevokeAllForUser mencabut semua sesi aktif user kecuali exceptID dan mengembalikan ID sesi
+// yang dicabut. exceptID kosong berarti semua sesi dicabut.
+func (r *sessionRepository) RevokeAllForUser(ctx context.Context, userID uint, exceptID string) ([]string, error) {
+	var ids []string
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&domain.Session{}).Where("user_id = ? AND revoked_at IS NULL", userID)
+		if exceptID != "" {
+			query = query.Where("id <> ?", exceptID)
+		}
+		if err := query.Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		return tx.Model(&domain.Session{}).
+			Where("id IN ?", ids).
+			Update("revoked_at", time.Now()).Error
+	})
+	if err != nil {
+		r.logger.Error("Failed to revoke user sessions", zap.Error(err), zap.Uint("user_id", userID))
+		return nil, fmt.Errorf("failed to revoke user sessions: %w", err)
+	}
+
+	return ids, nil
+}
@@ -3,7 +3,9 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/X3nonxe/gopsy-backend/internal/domain"
 	"go.uber.org/zap"
@@ -52,12 +54,186 @@ func (r *userRepository) GetByID(ctx context.Context, id uint) (*domain.User, er
 	return &user, nil
 }
 
-func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
-	// Normalize email to lowercase before updating
-	user.Email = strings.ToLower(strings.TrimSpace(user.Email))
-	return r.db.WithContext(ctx).Save(user).Error
+// UpdateProfile hanya menyimpan kolom profil yang boleh diubah user sendiri, sehingga salinan user
+// yang sudah basi tidak menimpa password, status verifikasi email, atau foto profil.
+func (r *userRepository) UpdateProfile(ctx context.Context, user *domain.User) error {
+	user.UpdatedAt = time.Now()
+
+	result := r.db.WithContext(ctx).Model(&domain.User{}).
+		Where("id = ? AND deleted_at IS NULL", user.ID).
+		Updates(map[string]interface{}{
+			"username":     user.Username,
+			"phone_number": user.PhoneNumber,
+			"gender":       user.Gender,
+			"updated_at":   user.UpdatedAt,
+		})
+	if result.Error != nil {
+		r.logger.Error("Failed to update user profile", zap.Uint("user_id", user.ID), zap.Error(result.Error))
+		return fmt.Errorf("failed to update user profile: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *userRepository) UpdateProfilePictureKey(ctx context.Context, id uint, expectedKey, key *string) error {
+	query := r.db.WithContext(ctx).Model(&domain.User{}).Where("id = ? AND deleted_at IS NULL", id)
+	if expectedKey == nil {
+		query = query.Where("profile_picture_key IS NULL")
+	} else {
+		query = query.Where("profile_picture_key = ?", *expectedKey)
+	}
+
+	result := query.Updates(map[string]interface{}{"profile_picture_key": key, "updated_at": time.Now()})
+	if result.Error != nil {
+		r.logger.Error("Failed to update profile picture key", zap.Uint("user_id", id), zap.Error(result.Error))
+		return fmt.Errorf("failed to update profile picture key: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrProfilePictureChanged
+	}
+	return nil
+}
+
+func (r *userRepository) UpdateRole(ctx context.Context, id uint, role string) error {
+	return r.updateColumn(ctx, id, "role", role)
+}
+
+func (r *userRepository) SetDeactivatedAt(ctx context.Context, id uint, deactivatedAt *time.Time) error {
+	return r.updateColumn(ctx, id, "deactivated_at", deactivatedAt)
+}
+
+// MarkEmailVerified bersyarat pada email_verified_at IS NULL agar waktu verifikasi pertama tidak
+// tertimpa oleh verifikasi yang berjalan bersamaan.
+func (r *userRepository) MarkEmailVerified(ctx context.Context, id uint, verifiedAt time.Time) error {
+	result := r.db.WithContext(ctx).Model(&domain.User{}).
+		Where("id = ? AND deleted_at IS NULL AND email_verified_at IS NULL", id).
+		Updates(map[string]interface{}{"email_verified_at": verifiedAt, "updated_at": time.Now()})
+	if result.Error != nil {
+		r.logger.Error("Failed to mark email verified", zap.Uint("user_id", id), zap.Error(result.Error))
+		return fmt.Errorf("failed to mark email verified: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *userRepository) UpdatePassword(ctx context.Context, id uint, hashedPassword string) error {
+	return r.updateColumn(ctx, id, "password", hashedPassword)
+}
+
+// updateColumn mengubah satu kolom tanpa menyentuh kolom lain. ErrUserNotFound dikembalikan jika
+// user tidak ada atau sudah dihapus.
+func (r *userRepository) updateColumn(ctx context.Context, id uint, column string, value interface{}) error {
+	result := r.db.WithContext(ctx).Model(&domain.User{}).Where("id = ? AND deleted_at IS NULL", id).
+		Updates(map[string]interface{}{column: value, "updated_at": time.Now()})
+	if result.Error != nil {
+		r.logger.Error("Failed to update user", zap.String("column", column), zap.Error(result.Error))
+		return fmt.Errorf("failed to update user %s: %w", column, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
 }
 
 func (r *userRepository) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&domain.User{}, id).Error
 }
+
+func (r *userRepository) FindPsychologists(ctx context.Context, filter domain.PsychologistFilter) ([]domain.User, int64, error) {
+	var (
+		users []domain.User
+		total int64
+	)
+
+	query := r.db.WithContext(ctx).Model(&domain.User{}).
+		Where("role = ? AND deactivated_at IS NULL", domain.RolePsikolog)
+
+	// Hanya psikolog terverifikasi yang izin praktiknya masih berlaku
+	verifiedOn := filter.VerifiedOn.Format("2006-01-02")
+	profileQuery := r.db.Table("psychologist_profiles").Select("1").
+		Where("psychologist_profiles.user_id = users.id").
+		Where("psychologist_profiles.verification_status = ?", domain.VerificationStatusVerified).
+		Where("psychologist_profiles.sipp_expires_at >= ?", verifiedOn).
+		Where("(psychologist_profiles.str_expires_at IS NULL OR psychologist_profiles.str_expires_at >= ?)", verifiedOn)
+	query = query.Where("EXISTS (?)", profileQuery)
+
+	if q := strings.TrimSpace(filter.Query); q != "" {
+		query = query.Where("username ILIKE ?", "%"+escapeLike(q)+"%")
+	}
+
+	// Hanya psikolog yang memiliki slot pada hari/jam yang diminta
+	if filter.Hari != "" || filter.WaktuMulai != "" || filter.WaktuSelesai != "" {
+		slotQuery := r.db.Table("waktu_konsultasi").Select("1").Where("waktu_konsultasi.psikolog_id = users.id")
+		if filter.Hari != "" {
+			slotQuery = slotQuery.Where("waktu_konsultasi.hari = ?", filter.Hari)
+		}
+		if filter.WaktuSelesai != "" {
+			slotQuery = slotQuery.Where("waktu_konsultasi.waktu_mulai < ?", filter.WaktuSelesai)
+		}
+		if filter.WaktuMulai != "" {
+			slotQuery = slotQuery.Where("waktu_konsultasi.waktu_selesai > ?", filter.WaktuMulai)
+		}
+		query = query.Where("EXISTS (?)", slotQuery)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error("Failed to count psychologists", zap.Error(err))
+		return nil, 0, err
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit).Offset(filter.Offset)
+	}
+
+	if err := query.Order("username ASC").Find(&users).Error; err != nil {
+		r.logger.Error("Failed to find psychologists", zap.Error(err))
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// List mencari user untuk admin. User yang dihapus tidak pernah ikut karena soft delete.
+func (r *userRepository) List(ctx context.Context, filter domain.UserFilter) ([]domain.User, int64, error) {
+	var (
+		users []domain.User
+		total int64
+	)
+
+	query := r.db.WithContext(ctx).Model(&domain.User{})
+	if q := strings.TrimSpace(filter.Search); q != "" {
+		pattern := "%" + escapeLike(q) + "%"
+		query = query.Where("(username ILIKE ? OR email ILIKE ?)", pattern, pattern)
+	}
+	if filter.Role != "" {
+		query = query.Where("role = ?", filter.Role)
+	}
+	switch filter.Status {
+	case domain.UserStatusActive:
+		query = query.Where("deactivated_at IS NULL")
+	case domain.UserStatusDeactivated:
+		query = query.Where("deactivated_at IS NOT NULL")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error("Failed to count users", zap.Error(err))
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit).Offset(filter.Offset)
+	}
+
+	if err := query.Order("created_at DESC, id DESC").Find(&users).Error; err != nil {
+		r.logger.Error("Failed to list users", zap.Error(err))
+		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	return users, total, nil
+}
+
+// escapeLike meng-escape karakter wildcard agar input pencarian diperlakukan sebagai teks biasa.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,166 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"go.uber.org/zap"
+)
+
+// LocalStorage menyimpan file di filesystem lokal dan melayaninya sendiri lewat URL yang
+// ditandatangani dengan HMAC. Dipakai untuk development dan deployment satu instance.
+type LocalStorage struct {
+	dir        string
+	baseURL    string
+	signingKey []byte
+	logger     *zap.Logger
+}
+
+var (
+	_ domain.FileStorage      = (*LocalStorage)(nil)
+	_ domain.SignedFileServer = (*LocalStorage)(nil)
+)
+
+// NewLocalStorage membuat penyimpanan di dir. baseURL adalah URL publik tempat MediaHandler
+// dipasang, misalnya http://localhost:8080/media.
+func NewLocalStorage(dir, baseURL string, signingKey []byte, logger *zap.Logger) *LocalStorage {
+	return &LocalStorage{
+		dir:        dir,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		signingKey: signingKey,
+		logger:     logger,
+	}
+}
+
+// Put menulis file secara atomik: isi ditulis ke file sementara lalu di-rename.
+func (s *LocalStorage) Put(ctx context.Context, key, contentType string, data []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	target := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o640); err != nil {
+		return fmt.Errorf("failed to set file permissions: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return fmt.Errorf("failed to move file into place: %w", err)
+	}
+
+	s.logger.Debug("File stored", zap.String("key", key), zap.String("content_type", contentType), zap.Int("size", len(data)))
+	return nil
+}
+
+// Delete menghapus file; file yang sudah tidak ada tidak dianggap error.
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	return nil
+}
+
+// SignedURL membuat URL ke MediaHandler yang berlaku selama ttl.
+func (s *LocalStorage) SignedURL(key string, ttl time.Duration) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+
+	expires := strconv.FormatInt(time.Now().Add(ttl).Unix(), 10)
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", s.sign(key, expires))
+	return s.baseURL + "/" + key + "?" + query.Encode(), nil
+}
+
+// Open memverifikasi tanda tangan dan masa berlaku URL lalu membuka file-nya.
+func (s *LocalStorage) Open(ctx context.Context, key, expires, signature string) (*domain.StoredFile, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if validateKey(key) != nil {
+		return nil, domain.ErrFileNotFound
+	}
+
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return nil, domain.ErrInvalidFileSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.sign(key, expires))) {
+		return nil, domain.ErrInvalidFileSignature
+	}
+
+	file, err := os.Open(s.path(key))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, domain.ErrFileNotFound
+		}
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return &domain.StoredFile{
+		Content:     file,
+		ContentType: contentType,
+		ModTime:     info.ModTime(),
+	}, nil
+}
+
+func (s *LocalStorage) path(key string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(key))
+}
+
+// sign menandatangani key beserta waktu kedaluwarsanya sehingga keduanya tidak bisa diubah.
+func (s *LocalStorage) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,95 @@
+package storage_test
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// parseSignedURL memisahkan key, expires dan signature dari URL LocalStorage.
+func parseSignedURL(t *testing.T, baseURL, signedURL string) (string, string, string) {
+	t.Helper()
+	require.True(t, strings.HasPrefix(signedURL, baseURL+"/"))
+	u, err := url.Parse(signedURL)
+	require.NoError(t, err)
+	key := strings.TrimPrefix(strings.SplitN(signedURL, "?", 2)[0], baseURL+"/")
+	return key, u.Query().Get("expires"), u.Query().Get("signature")
+}
+
+func TestLocalStorage(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	baseURL := "http://localhost:8080/media"
+	s := storage.NewLocalStorage(dir, baseURL+"/", []byte("signing-key"), zap.NewNop())
+
+	require.NoError(t, s.Put(ctx, "profile-pictures/abc/96.jpg", "image/jpeg", []byte("jpeg-bytes")))
+
+	t.Run("Signed URL Roundtrip", func(t *testing.T) {
+		signedURL, err := s.SignedURL("profile-pictures/abc/96.jpg", time.Minute)
+		require.NoError(t, err)
+
+		key, expires, signature := parseSignedURL(t, baseURL, signedURL)
+		file, err := s.Open(ctx, key, expires, signature)
+		require.NoError(t, err)
+		defer file.Content.Close()
+
+		content, err := io.ReadAll(file.Content)
+		require.NoError(t, err)
+		assert.Equal(t, "jpeg-bytes", string(content))
+		assert.Equal(t, "image/jpeg", file.ContentType)
+	})
+
+	t.Run("Tampered Signature", func(t *testing.T) {
+		signedURL, err := s.SignedURL("profile-pictures/abc/96.jpg", time.Minute)
+		require.NoError(t, err)
+		_, expires, signature := parseSignedURL(t, baseURL, signedURL)
+
+		// Tanda tangan tidak berlaku untuk key lain maupun masa berlaku yang diperpanjang
+		_, err = s.Open(ctx, "profile-pictures/abc/512.jpg", expires, signature)
+		assert.ErrorIs(t, err, domain.ErrInvalidFileSignature)
+		_, err = s.Open(ctx, "profile-pictures/abc/96.jpg", expires+"0", signature)
+		assert.ErrorIs(t, err, domain.ErrInvalidFileSignature)
+	})
+
+	t.Run("Expired URL", func(t *testing.T) {
+		signedURL, err := s.SignedURL("profile-pictures/abc/96.jpg", -time.Minute)
+		require.NoError(t, err)
+
+		key, expires, signature := parseSignedURL(t, baseURL, signedURL)
+		_, err = s.Open(ctx, key, expires, signature)
+		assert.ErrorIs(t, err, domain.ErrInvalidFileSignature)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, s.Put(ctx, "tmp/delete-me.jpg", "image/jpeg", []byte("x")))
+		require.NoError(t, s.Delete(ctx, "tmp/delete-me.jpg"))
+		require.NoError(t, s.Delete(ctx, "tmp/delete-me.jpg"))
+
+		signedURL, err := s.SignedURL("tmp/delete-me.jpg", time.Minute)
+		require.NoError(t, err)
+		key, expires, signature := parseSignedURL(t, baseURL, signedURL)
+		_, err = s.Open(ctx, key, expires, signature)
+		assert.ErrorIs(t, err, domain.ErrFileNotFound)
+	})
+
+	t.Run("Rejects Path Traversal", func(t *testing.T) {
+		for _, key := range []string{"../outside.jpg", "/etc/passwd", "a/../../b.jpg", "a//b.jpg", "a/b c.jpg", ""} {
+			assert.Error(t, s.Put(ctx, key, "image/jpeg", []byte("x")), key)
+			_, err := s.SignedURL(key, time.Minute)
+			assert.Error(t, err, key)
+		}
+		_, err := os.Stat(filepath.Join(filepath.Dir(dir), "outside.jpg"))
+		assert.True(t, os.IsNotExist(err))
+	})
+}
@@ -0,0 +1,158 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_sigv4"
+	"go.uber.org/zap"
+)
+
+// S3Config berisi koneksi ke layanan penyimpanan yang kompatibel dengan S3, termasuk MinIO
+// yang dipakai sebagai pengganti lokal.
+type S3Config struct {
+	// Endpoint dipakai aplikasi untuk mengunggah dan menghapus objek, misalnya http://minio:9000.
+	Endpoint string
+	// PublicEndpoint dipakai di URL bertanda tangan yang diberikan ke klien. Kosong berarti
+	// sama dengan Endpoint.
+	PublicEndpoint  string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// UsePathStyle menaruh bucket di path (endpoint/bucket/key) alih-alih di subdomain.
+	// MinIO dan kebanyakan pengganti lokal membutuhkannya.
+	UsePathStyle bool
+}
+
+type s3Storage struct {
+	cfg    S3Config
+	client *http.Client
+	logger *zap.Logger
+}
+
+// NewS3Storage membuat FileStorage yang menyimpan objek di bucket S3 dengan request yang
+// ditandatangani AWS Signature Version 4. URL file adalah presigned URL langsung ke bucket.
+func NewS3Storage(cfg S3Config, client *http.Client, logger *zap.Logger) domain.FileStorage {
+	if cfg.PublicEndpoint == "" {
+		cfg.PublicEndpoint = cfg.Endpoint
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &s3Storage{
+		cfg:    cfg,
+		client: client,
+		logger: logger,
+	}
+}
+
+// Put mengunggah objek dengan PUT.
+func (s *s3Storage) Put(ctx context.Context, key, contentType string, data []byte) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	objectURL, err := s.objectURL(s.cfg.Endpoint, key)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, objectURL.String(), bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to build upload request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	app_sigv4.SignRequest(req, app_sigv4.PayloadHash(data), s.credentials(), time.Now())
+
+	if err := s.do(req, http.StatusOK); err != nil {
+		return fmt.Errorf("failed to upload object: %w", err)
+	}
+
+	s.logger.Debug("Object uploaded", zap.String("bucket", s.cfg.Bucket), zap.String("key", key), zap.Int("size", len(data)))
+	return nil
+}
+
+// Delete menghapus objek; S3 sudah mengembalikan 204 untuk objek yang tidak ada.
+func (s *s3Storage) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	objectURL, err := s.objectURL(s.cfg.Endpoint, key)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, objectURL.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to build delete request: %w", err)
+	}
+	app_sigv4.SignRequest(req, app_sigv4.PayloadHash(nil), s.credentials(), time.Now())
+
+	if err := s.do(req, http.StatusNoContent, http.StatusOK, http.StatusNotFound); err != nil {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	return nil
+}
+
+// SignedURL membuat presigned GET URL pada PublicEndpoint yang berlaku selama ttl.
+func (s *s3Storage) SignedURL(key string, ttl time.Duration) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+
+	objectURL, err := s.objectURL(s.cfg.PublicEndpoint, key)
+	if err != nil {
+		return "", err
+	}
+	return app_sigv4.PresignURL(http.MethodGet, objectURL, s.credentials(), ttl, time.Now())
+}
+
+func (s *s3Storage) credentials() app_sigv4.Credentials {
+	return app_sigv4.Credentials{
+		AccessKeyID:     s.cfg.AccessKeyID,
+		SecretAccessKey: s.cfg.SecretAccessKey,
+		Region:          s.cfg.Region,
+		Service:         "s3",
+	}
+}
+
+func (s *s3Storage) objectURL(endpoint, key string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimRight(endpoint, "/"))
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", endpoint)
+	}
+	if s.cfg.UsePathStyle {
+		u.Path += "/" + s.cfg.Bucket + "/" + key
+	} else {
+		u.Host = s.cfg.Bucket + "." + u.Host
+		u.Path += "/" + key
+	}
+	return u, nil
+}
+
+func (s *s3Storage) do(req *http.Request, expected ...int) error {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	for _, status := range expected {
+		if resp.StatusCode == status {
+			io.Copy(io.Discard, resp.Body)
+			return nil
+		}
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
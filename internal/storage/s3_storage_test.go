@@ -0,0 +1,96 @@
+package storage_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/storage"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_sigv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestS3Storage(t *testing.T) {
+	type received struct {
+		method, path, contentType, authorization, payloadHash string
+		body                                                  []byte
+	}
+	var requests []received
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, received{
+			method:        r.Method,
+			path:          r.URL.Path,
+			contentType:   r.Header.Get("Content-Type"),
+			authorization: r.Header.Get("Authorization"),
+			payloadHash:   r.Header.Get("X-Amz-Content-Sha256"),
+			body:          body,
+		})
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	s := storage.NewS3Storage(storage.S3Config{
+		Endpoint:        server.URL,
+		PublicEndpoint:  "http://localhost:9000",
+		Region:          "us-east-1",
+		Bucket:          "gopsy",
+		AccessKeyID:     "minio",
+		SecretAccessKey: "minio-secret",
+		UsePathStyle:    true,
+	}, server.Client(), zap.NewNop())
+
+	ctx := context.Background()
+	require.NoError(t, s.Put(ctx, "profile-pictures/abc/96.jpg", "image/jpeg", []byte("jpeg-bytes")))
+	require.NoError(t, s.Delete(ctx, "profile-pictures/abc/96.jpg"))
+	require.Len(t, requests, 2)
+
+	put := requests[0]
+	assert.Equal(t, http.MethodPut, put.method)
+	assert.Equal(t, "/gopsy/profile-pictures/abc/96.jpg", put.path)
+	assert.Equal(t, "image/jpeg", put.contentType)
+	assert.Equal(t, "jpeg-bytes", string(put.body))
+	assert.Equal(t, app_sigv4.PayloadHash([]byte("jpeg-bytes")), put.payloadHash)
+	assert.True(t, strings.HasPrefix(put.authorization, "AWS4-HMAC-SHA256 Credential=minio/"))
+	assert.Contains(t, put.authorization, "SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date")
+
+	assert.Equal(t, http.MethodDelete, requests[1].method)
+
+	signedURL, err := s.SignedURL("profile-pictures/abc/96.jpg", 15*time.Minute)
+	require.NoError(t, err)
+	u, err := url.Parse(signedURL)
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:9000", u.Host)
+	assert.Equal(t, "/gopsy/profile-pictures/abc/96.jpg", u.Path)
+	assert.Equal(t, "900", u.Query().Get("X-Amz-Expires"))
+	assert.NotEmpty(t, u.Query().Get("X-Amz-Signature"))
+}
+
+func TestS3Storage_UploadFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("<Error><Code>SignatureDoesNotMatch</Code></Error>"))
+	}))
+	defer server.Close()
+
+	s := storage.NewS3Storage(storage.S3Config{
+		Endpoint:     server.URL,
+		Region:       "us-east-1",
+		Bucket:       "gopsy",
+		UsePathStyle: true,
+	}, server.Client(), zap.NewNop())
+
+	err := s.Put(context.Background(), "profile-pictures/abc/96.jpg", "image/jpeg", []byte("x"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SignatureDoesNotMatch")
+}
@@ -0,0 +1,28 @@
+// Package storage berisi implementasi domain.FileStorage.
+package storage
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// validateKey memastikan key adalah path relatif yang aman dipakai sebagai nama file maupun
+// nama objek: tidak kosong, tidak absolut, tanpa segmen "." atau "..", dan hanya memakai huruf,
+// angka, "-", "_", "." dan "/".
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key {
+		return fmt.Errorf("invalid storage key %q", key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "." || segment == ".." {
+			return fmt.Errorf("invalid storage key %q", key)
+		}
+	}
+	for _, c := range key {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.ContainsRune("-_./", c)) {
+			return fmt.Errorf("invalid storage key %q", key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,332 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_jwt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// opaqueTokenBytes adalah panjang entropi refresh token dan token reset sebelum di-encode.
+const opaqueTokenBytes = 32
+
+type authUsecase struct {
+	refreshTokenRepo domain.RefreshTokenRepository
+	sessionRepo      domain.SessionRepository
+	userRepo         domain.UserRepository
+	revocations      domain.TokenRevocationStore
+	keys             *app_jwt.KeySet
+	accessTokenTTL   time.Duration
+	refreshTokenTTL  time.Duration
+	logger           *zap.Logger
+}
+
+// NewAuthUsecase membuat instance baru dari authUsecase.
+func NewAuthUsecase(
+	rr domain.RefreshTokenRepository,
+	sr domain.SessionRepository,
+	ur domain.UserRepository,
+	revocations domain.TokenRevocationStore,
+	keys *app_jwt.KeySet,
+	accessTokenTTL time.Duration,
+	refreshTokenTTL time.Duration,
+	logger *zap.Logger,
+) domain.AuthUsecase {
+	return &authUsecase{
+		refreshTokenRepo: rr,
+		sessionRepo:      sr,
+		userRepo:         ur,
+		revocations:      revocations,
+		keys:             keys,
+		accessTokenTTL:   accessTokenTTL,
+		refreshTokenTTL:  refreshTokenTTL,
+		logger:           logger,
+	}
+}
+
+// IssueTokens membuka sesi baru dan menerbitkan access token serta refresh token dari keluarga
+// rotasi baru. ID sesi sama dengan ID keluarga refresh token. Status akun dibaca ulang dari
+// database karena akun bisa dinonaktifkan atau dihapus setelah pemanggil membacanya, misalnya
+// selama tantangan MFA berlangsung; akun yang dinonaktifkan atau dihapus ditolak.
+func (uc *authUsecase) IssueTokens(ctx context.Context, user *domain.User, client *domain.ClientInfo) (*domain.TokenPair, error) {
+	current, err := uc.userRepo.GetByID(ctx, user.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.NewDomainError(http.StatusNotFound, "User not found")
+		}
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to issue token", err)
+	}
+	if current.IsDeactivated() {
+		return nil, domain.ErrAccountDeactivated
+	}
+
+	sessionID := uuid.NewString()
+	if err := uc.createSession(ctx, current.ID, sessionID, client); err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to issue token", err)
+	}
+	return uc.issue(ctx, current, sessionID)
+}
+
+// Refresh menukar refresh token yang masih berlaku dengan pasangan token baru. Setiap refresh token
+// hanya bisa dipakai sekali; pemakaian ulang mencabut seluruh keluarga token tersebut.
+func (uc *authUsecase) Refresh(ctx context.Context, payload *domain.RefreshTokenPayload, client *domain.ClientInfo) (*domain.TokenPair, error) {
+	stored, err := uc.refreshTokenRepo.GetByHash(ctx, hashToken(payload.RefreshToken))
+	if err != nil {
+		if errors.Is(err, domain.ErrRefreshTokenNotFound) {
+			return nil, domain.ErrInvalidRefreshToken
+		}
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to refresh token", err)
+	}
+
+	if stored.RevokedAt != nil {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	if stored.UsedAt != nil {
+		return nil, uc.revokeReusedFamily(ctx, stored)
+	}
+
+	if !time.Now().Before(stored.ExpiresAt) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	// Update bersyarat memastikan hanya satu request yang bisa merotasi token ini
+	if err := uc.refreshTokenRepo.MarkUsed(ctx, stored.ID); err != nil {
+		if errors.Is(err, domain.ErrRefreshTokenReused) {
+			return nil, uc.revokeReusedFamily(ctx, stored)
+		}
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to refresh token", err)
+	}
+
+	// Ambil ulang data user agar perubahan role ikut masuk ke access token baru
+	user, err := uc.userRepo.GetByID(ctx, stored.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.ErrInvalidRefreshToken
+		}
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to refresh token", err)
+	}
+	if user.IsDeactivated() {
+		return nil, domain.ErrAccountDeactivated
+	}
+
+	if err := uc.touchSession(ctx, stored, client); err != nil {
+		return nil, err
+	}
+
+	return uc.issue(ctx, user, stored.FamilyID)
+}
+
+// Logout mencabut access token dan sesi yang sedang dipakai, serta keluarga refresh token yang
+// diberikan jika ada.
+func (uc *authUsecase) Logout(ctx context.Context, claims *domain.AccessTokenClaims, payload *domain.LogoutPayload) error {
+	if err := uc.revocations.RevokeToken(ctx, claims.JTI, time.Until(claims.ExpiresAt)); err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to logout", err)
+	}
+
+	if claims.SessionID != "" {
+		if err := uc.RevokeSession(ctx, claims.SessionID); err != nil {
+			return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to logout", err)
+		}
+	}
+
+	if payload == nil || payload.RefreshToken == "" {
+		return nil
+	}
+
+	stored, err := uc.refreshTokenRepo.GetByHash(ctx, hashToken(payload.RefreshToken))
+	if err != nil {
+		// Refresh token yang tidak dikenal tidak perlu dicabut; logout tetap dianggap berhasil
+		if errors.Is(err, domain.ErrRefreshTokenNotFound) {
+			return nil
+		}
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to logout", err)
+	}
+
+	// Refresh token milik user lain diabaikan agar logout tidak bisa mencabut sesi orang lain
+	if stored.UserID != claims.UserID {
+		return nil
+	}
+
+	if err := uc.refreshTokenRepo.RevokeFamily(ctx, stored.FamilyID); err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to logout", err)
+	}
+	return nil
+}
+
+// LogoutAll mencabut semua sesi dan refresh token user serta semua access token yang sudah
+// diterbitkan untuknya.
+func (uc *authUsecase) LogoutAll(ctx context.Context, userID uint) error {
+	if _, err := uc.sessionRepo.RevokeAllForUser(ctx, userID, ""); err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to logout from all devices", err)
+	}
+
+	if err := uc.refreshTokenRepo.RevokeAllForUser(ctx, userID); err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to logout from all devices", err)
+	}
+
+	// Access token berumur paling lama accessTokenTTL, sehingga penanda ini cukup disimpan selama itu
+	if err := uc.revocations.RevokeUserTokens(ctx, userID, time.Now(), uc.accessTokenTTL); err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to logout from all devices", err)
+	}
+	return nil
+}
+
+// RevokeSession mencabut satu sesi beserta keluarga refresh token dan access token-nya.
+func (uc *authUsecase) RevokeSession(ctx context.Context, sessionID string) error {
+	if err := uc.sessionRepo.Revoke(ctx, sessionID); err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to revoke session", err)
+	}
+	return uc.revokeSessionTokens(ctx, sessionID)
+}
+
+// RevokeOtherSessions mencabut semua sesi user kecuali exceptSessionID. exceptSessionID kosong
+// berarti semua sesi dicabut.
+func (uc *authUsecase) RevokeOtherSessions(ctx context.Context, userID uint, exceptSessionID string) error {
+	sessionIDs, err := uc.sessionRepo.RevokeAllForUser(ctx, userID, exceptSessionID)
+	if err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to revoke sessions", err)
+	}
+
+	for _, sessionID := range sessionIDs {
+		if err := uc.revokeSessionTokens(ctx, sessionID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// revokeSessionTokens mencabut keluarga refresh token sesi dan memasukkan sid ke denylist selama
+// umur access token agar access token sesi itu langsung ditolak.
+func (uc *authUsecase) revokeSessionTokens(ctx context.Context, sessionID string) error {
+	if err := uc.refreshTokenRepo.RevokeFamily(ctx, sessionID); err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to revoke session", err)
+	}
+	if err := uc.revocations.RevokeSession(ctx, sessionID, uc.accessTokenTTL); err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to revoke session", err)
+	}
+	return nil
+}
+
+func (uc *authUsecase) createSession(ctx context.Context, userID uint, sessionID string, client *domain.ClientInfo) error {
+	now := time.Now()
+	session := &domain.Session{
+		ID:         sessionID,
+		UserID:     userID,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(uc.refreshTokenTTL),
+	}
+	if client != nil {
+		session.UserAgent = client.UserAgent
+		session.IPAddress = client.IPAddress
+		session.DeviceName = domain.DeviceName(client.UserAgent)
+	}
+	return uc.sessionRepo.Create(ctx, session)
+}
+
+// touchSession memperbarui waktu terakhir aktif sesi saat refresh. Keluarga refresh token yang
+// dibuat sebelum ada pencatatan sesi dibuatkan sesi baru.
+func (uc *authUsecase) touchSession(ctx context.Context, stored *domain.RefreshToken, client *domain.ClientInfo) error {
+	session, err := uc.sessionRepo.GetByID(ctx, stored.FamilyID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			if err := uc.createSession(ctx, stored.UserID, stored.FamilyID, client); err != nil {
+				return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to refresh token", err)
+			}
+			return nil
+		}
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to refresh token", err)
+	}
+
+	if session.RevokedAt != nil {
+		return domain.ErrInvalidRefreshToken
+	}
+
+	if err := uc.sessionRepo.Touch(ctx, session.ID, client, time.Now().Add(uc.refreshTokenTTL)); err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to refresh token", err)
+	}
+	return nil
+}
+
+// revokeReusedFamily mencabut sesi yang refresh token-nya dipakai ulang.
+func (uc *authUsecase) revokeReusedFamily(ctx context.Context, stored *domain.RefreshToken) error {
+	uc.logger.Warn("Refresh token reuse detected, revoking token family",
+		zap.Uint("user_id", stored.UserID), zap.String("family_id", stored.FamilyID))
+
+	if err := uc.RevokeSession(ctx, stored.FamilyID); err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to refresh token", err)
+	}
+	return domain.ErrRefreshTokenReused
+}
+
+// issue menerbitkan pasangan token untuk user pada sesi dan keluarga rotasi familyID.
+func (uc *authUsecase) issue(ctx context.Context, user *domain.User, familyID string) (*domain.TokenPair, error) {
+	accessToken, err := uc.generateAccessToken(user, familyID)
+	if err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to issue token", err)
+	}
+
+	rawRefreshToken, err := generateOpaqueToken()
+	if err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to issue token", err)
+	}
+
+	refreshToken := &domain.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(rawRefreshToken),
+		ExpiresAt: time.Now().Add(uc.refreshTokenTTL),
+	}
+	if err := uc.refreshTokenRepo.Create(ctx, refreshToken); err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to issue token", err)
+	}
+
+	return &domain.TokenPair{
+		Token:        accessToken,
+		RefreshToken: rawRefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(uc.accessTokenTTL.Seconds()),
+	}, nil
+}
+
+func (uc *authUsecase) generateAccessToken(user *domain.User, sessionID string) (string, error) {
+	now := time.Now()
+	// iat memakai pecahan milidetik agar login ulang tepat setelah logout dari semua perangkat,
+	// pada detik yang sama, tidak ikut dicabut
+	claims := jwt.MapClaims{
+		"user_id":        user.ID,
+		"role":           user.Role,
+		"email_verified": user.IsEmailVerified(),
+		"jti":            uuid.NewString(),
+		"sid":            sessionID,
+		"exp":            now.Add(uc.accessTokenTTL).Unix(),
+		"iat":            app_jwt.NumericDate(now),
+	}
+	return uc.keys.Sign(claims)
+}
+
+// generateOpaqueToken membuat token acak yang aman dipakai di URL.
+func generateOpaqueToken() (string, error) {
+	b := make([]byte, opaqueTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken mengembalikan hash SHA-256 dari token dalam format hex. Hanya hash yang disimpan
+// di database sehingga kebocoran tabel tidak membocorkan token yang masih berlaku.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
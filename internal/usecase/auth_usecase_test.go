@@ -0,0 +1,416 @@
+package usecase_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/internal/mocks"
+	"github.com/X3nonxe/gopsy-backend/internal/usecase"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func testKeySet(t *testing.T) *app_jwt.KeySet {
+	keys, err := app_jwt.NewKeySet(app_jwt.NewHMACKey([]byte("test-secret")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestAuthUsecase_IssueTokens(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRefreshTokenRepo := mocks.NewMockRefreshTokenRepository(mockCtrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(mockCtrl)
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	keys := testKeySet(t)
+	authUsecase := usecase.NewAuthUsecase(mockRefreshTokenRepo, mockSessionRepo, mockUserRepo, mocks.NewMockTokenRevocationStore(mockCtrl), keys, 15*time.Minute, 720*time.Hour, zap.NewNop())
+
+	user := &domain.User{ID: 1, Role: "klien"}
+	client := &domain.ClientInfo{
+		UserAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Safari/537.36",
+		IPAddress: "203.0.113.7",
+	}
+
+	mockUserRepo.EXPECT().GetByID(gomock.Any(), user.ID).Return(user, nil).Times(1)
+
+	var session *domain.Session
+	mockSessionRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		Do(func(ctx context.Context, s *domain.Session) {
+			session = s
+		}).
+		Return(nil).
+		Times(1)
+
+	var stored *domain.RefreshToken
+	mockRefreshTokenRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		Do(func(ctx context.Context, token *domain.RefreshToken) {
+			stored = token
+		}).
+		Return(nil).
+		Times(1)
+
+	tokens, err := authUsecase.IssueTokens(context.Background(), user, client)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, tokens.Token)
+	assert.NotEmpty(t, tokens.RefreshToken)
+	assert.Equal(t, "Bearer", tokens.TokenType)
+	assert.Equal(t, int64(900), tokens.ExpiresIn)
+
+	_, claims, err := keys.Parse(tokens.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(user.ID), claims["user_id"])
+	assert.NotEmpty(t, claims["jti"])
+	assert.Equal(t, false, claims["email_verified"])
+
+	// Sesi baru memakai ID yang sama dengan keluarga refresh token dan klaim sid
+	assert.Equal(t, user.ID, session.UserID)
+	assert.Equal(t, "Chrome on Windows", session.DeviceName)
+	assert.Equal(t, "203.0.113.7", session.IPAddress)
+	assert.Equal(t, session.ID, claims["sid"])
+	assert.Equal(t, session.ID, stored.FamilyID)
+
+	// Refresh token hanya disimpan dalam bentuk hash
+	assert.Equal(t, user.ID, stored.UserID)
+	assert.NotEmpty(t, stored.FamilyID)
+	assert.Equal(t, sha256Hex(tokens.RefreshToken), stored.TokenHash)
+	assert.NotEqual(t, tokens.RefreshToken, stored.TokenHash)
+}
+
+func TestAuthUsecase_IssueTokensDeactivated(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	authUsecase := usecase.NewAuthUsecase(mocks.NewMockRefreshTokenRepository(mockCtrl), mocks.NewMockSessionRepository(mockCtrl), mockUserRepo, mocks.NewMockTokenRevocationStore(mockCtrl), testKeySet(t), 15*time.Minute, 720*time.Hour, zap.NewNop())
+
+	// Pemanggil membaca user sebelum admin menonaktifkannya, misalnya saat tantangan MFA berlangsung
+	caller := &domain.User{ID: 1, Role: "klien"}
+
+	t.Run("Deactivated", func(t *testing.T) {
+		deactivatedAt := time.Now().Add(-time.Minute)
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), uint(1)).Return(&domain.User{ID: 1, Role: "klien", DeactivatedAt: &deactivatedAt}, nil).Times(1)
+
+		tokens, err := authUsecase.IssueTokens(context.Background(), caller, nil)
+
+		assert.True(t, errors.Is(err, domain.ErrAccountDeactivated))
+		assert.Nil(t, tokens)
+	})
+
+	t.Run("Deleted", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), uint(1)).Return(nil, domain.ErrUserNotFound).Times(1)
+
+		tokens, err := authUsecase.IssueTokens(context.Background(), caller, nil)
+
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusNotFound, domainErr.HTTPStatus)
+		assert.Nil(t, tokens)
+	})
+}
+
+func TestAuthUsecase_Refresh(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRefreshTokenRepo := mocks.NewMockRefreshTokenRepository(mockCtrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(mockCtrl)
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(mockCtrl)
+	authUsecase := usecase.NewAuthUsecase(mockRefreshTokenRepo, mockSessionRepo, mockUserRepo, mockRevocations, testKeySet(t), 15*time.Minute, 720*time.Hour, zap.NewNop())
+
+	ctx := context.Background()
+	user := &domain.User{ID: 1, Role: "klien"}
+	rawToken := "raw-refresh-token"
+	payload := &domain.RefreshTokenPayload{RefreshToken: rawToken}
+	client := &domain.ClientInfo{UserAgent: "okhttp/4.12", IPAddress: "198.51.100.2"}
+
+	expectSessionRevoked := func(sessionID string) {
+		mockSessionRepo.EXPECT().Revoke(ctx, sessionID).Return(nil).Times(1)
+		mockRefreshTokenRepo.EXPECT().RevokeFamily(ctx, sessionID).Return(nil).Times(1)
+		mockRevocations.EXPECT().RevokeSession(ctx, sessionID, 15*time.Minute).Return(nil).Times(1)
+	}
+
+	validToken := func() *domain.RefreshToken {
+		return &domain.RefreshToken{
+			ID:        10,
+			UserID:    user.ID,
+			FamilyID:  "family-1",
+			TokenHash: sha256Hex(rawToken),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+	}
+
+	t.Run("Success Rotates Within Family", func(t *testing.T) {
+		mockRefreshTokenRepo.EXPECT().GetByHash(ctx, sha256Hex(rawToken)).Return(validToken(), nil).Times(1)
+		mockRefreshTokenRepo.EXPECT().MarkUsed(ctx, uint(10)).Return(nil).Times(1)
+		mockUserRepo.EXPECT().GetByID(ctx, user.ID).Return(user, nil).Times(1)
+		mockSessionRepo.EXPECT().GetByID(ctx, "family-1").Return(&domain.Session{ID: "family-1", UserID: user.ID}, nil).Times(1)
+		mockSessionRepo.EXPECT().Touch(ctx, "family-1", client, gomock.Any()).Return(nil).Times(1)
+		mockRefreshTokenRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			Do(func(ctx context.Context, token *domain.RefreshToken) {
+				assert.Equal(t, "family-1", token.FamilyID)
+				assert.NotEqual(t, sha256Hex(rawToken), token.TokenHash)
+			}).
+			Return(nil).
+			Times(1)
+
+		tokens, err := authUsecase.Refresh(ctx, payload, client)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, tokens.Token)
+		assert.NotEqual(t, rawToken, tokens.RefreshToken)
+	})
+
+	t.Run("Reused Token Revokes Family", func(t *testing.T) {
+		used := validToken()
+		usedAt := time.Now().Add(-time.Minute)
+		used.UsedAt = &usedAt
+
+		mockRefreshTokenRepo.EXPECT().GetByHash(ctx, sha256Hex(rawToken)).Return(used, nil).Times(1)
+		expectSessionRevoked("family-1")
+
+		tokens, err := authUsecase.Refresh(ctx, payload, client)
+
+		assert.True(t, errors.Is(err, domain.ErrRefreshTokenReused))
+		assert.Nil(t, tokens)
+	})
+
+	t.Run("Concurrent Rotation Revokes Family", func(t *testing.T) {
+		mockRefreshTokenRepo.EXPECT().GetByHash(ctx, sha256Hex(rawToken)).Return(validToken(), nil).Times(1)
+		mockRefreshTokenRepo.EXPECT().MarkUsed(ctx, uint(10)).Return(domain.ErrRefreshTokenReused).Times(1)
+		expectSessionRevoked("family-1")
+
+		tokens, err := authUsecase.Refresh(ctx, payload, client)
+
+		assert.True(t, errors.Is(err, domain.ErrRefreshTokenReused))
+		assert.Nil(t, tokens)
+	})
+
+	t.Run("Revoked Session", func(t *testing.T) {
+		revokedAt := time.Now().Add(-time.Minute)
+		mockRefreshTokenRepo.EXPECT().GetByHash(ctx, sha256Hex(rawToken)).Return(validToken(), nil).Times(1)
+		mockRefreshTokenRepo.EXPECT().MarkUsed(ctx, uint(10)).Return(nil).Times(1)
+		mockUserRepo.EXPECT().GetByID(ctx, user.ID).Return(user, nil).Times(1)
+		mockSessionRepo.EXPECT().
+			GetByID(ctx, "family-1").
+			Return(&domain.Session{ID: "family-1", UserID: user.ID, RevokedAt: &revokedAt}, nil).
+			Times(1)
+
+		tokens, err := authUsecase.Refresh(ctx, payload, client)
+
+		assert.True(t, errors.Is(err, domain.ErrInvalidRefreshToken))
+		assert.Nil(t, tokens)
+	})
+
+	t.Run("Legacy Family Gets A Session", func(t *testing.T) {
+		mockRefreshTokenRepo.EXPECT().GetByHash(ctx, sha256Hex(rawToken)).Return(validToken(), nil).Times(1)
+		mockRefreshTokenRepo.EXPECT().MarkUsed(ctx, uint(10)).Return(nil).Times(1)
+		mockUserRepo.EXPECT().GetByID(ctx, user.ID).Return(user, nil).Times(1)
+		mockSessionRepo.EXPECT().GetByID(ctx, "family-1").Return(nil, domain.ErrSessionNotFound).Times(1)
+		mockSessionRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			Do(func(ctx context.Context, session *domain.Session) {
+				assert.Equal(t, "family-1", session.ID)
+				assert.Equal(t, "Mobile app on unknown OS", session.DeviceName)
+			}).
+			Return(nil).
+			Times(1)
+		mockRefreshTokenRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil).Times(1)
+
+		tokens, err := authUsecase.Refresh(ctx, payload, client)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, tokens.Token)
+	})
+
+	t.Run("Deactivated Account", func(t *testing.T) {
+		deactivatedAt := time.Now().Add(-time.Minute)
+		mockRefreshTokenRepo.EXPECT().GetByHash(ctx, sha256Hex(rawToken)).Return(validToken(), nil).Times(1)
+		mockRefreshTokenRepo.EXPECT().MarkUsed(ctx, uint(10)).Return(nil).Times(1)
+		mockUserRepo.EXPECT().GetByID(ctx, user.ID).Return(&domain.User{ID: user.ID, Role: "klien", DeactivatedAt: &deactivatedAt}, nil).Times(1)
+
+		tokens, err := authUsecase.Refresh(ctx, payload, client)
+
+		assert.True(t, errors.Is(err, domain.ErrAccountDeactivated))
+		assert.Nil(t, tokens)
+	})
+
+	t.Run("Expired Token", func(t *testing.T) {
+		expired := validToken()
+		expired.ExpiresAt = time.Now().Add(-time.Minute)
+
+		mockRefreshTokenRepo.EXPECT().GetByHash(ctx, sha256Hex(rawToken)).Return(expired, nil).Times(1)
+
+		tokens, err := authUsecase.Refresh(ctx, payload, client)
+
+		assert.True(t, errors.Is(err, domain.ErrInvalidRefreshToken))
+		assert.Nil(t, tokens)
+	})
+
+	t.Run("Revoked Token", func(t *testing.T) {
+		revoked := validToken()
+		revokedAt := time.Now().Add(-time.Minute)
+		revoked.RevokedAt = &revokedAt
+
+		mockRefreshTokenRepo.EXPECT().GetByHash(ctx, sha256Hex(rawToken)).Return(revoked, nil).Times(1)
+
+		tokens, err := authUsecase.Refresh(ctx, payload, client)
+
+		assert.True(t, errors.Is(err, domain.ErrInvalidRefreshToken))
+		assert.Nil(t, tokens)
+	})
+
+	t.Run("Unknown Token", func(t *testing.T) {
+		mockRefreshTokenRepo.EXPECT().GetByHash(ctx, sha256Hex(rawToken)).Return(nil, domain.ErrRefreshTokenNotFound).Times(1)
+
+		tokens, err := authUsecase.Refresh(ctx, payload, client)
+
+		assert.True(t, errors.Is(err, domain.ErrInvalidRefreshToken))
+		assert.Nil(t, tokens)
+	})
+}
+
+func TestAuthUsecase_Logout(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRefreshTokenRepo := mocks.NewMockRefreshTokenRepository(mockCtrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(mockCtrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(mockCtrl)
+	authUsecase := usecase.NewAuthUsecase(mockRefreshTokenRepo, mockSessionRepo, mocks.NewMockUserRepository(mockCtrl), mockRevocations, testKeySet(t), 15*time.Minute, 720*time.Hour, zap.NewNop())
+
+	ctx := context.Background()
+	claims := &domain.AccessTokenClaims{
+		UserID:    1,
+		Role:      "klien",
+		JTI:       "jti-1",
+		IssuedAt:  time.Now().Add(-time.Minute),
+		ExpiresAt: time.Now().Add(14 * time.Minute),
+	}
+	rawToken := "raw-refresh-token"
+
+	t.Run("Revokes Access Token Only", func(t *testing.T) {
+		mockRevocations.EXPECT().
+			RevokeToken(ctx, "jti-1", gomock.Any()).
+			Do(func(ctx context.Context, jti string, expiration time.Duration) {
+				assert.True(t, expiration > 13*time.Minute && expiration <= 14*time.Minute)
+			}).
+			Return(nil).
+			Times(1)
+
+		err := authUsecase.Logout(ctx, claims, &domain.LogoutPayload{})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Revokes Current Session", func(t *testing.T) {
+		withSession := *claims
+		withSession.SessionID = "session-1"
+
+		mockRevocations.EXPECT().RevokeToken(ctx, "jti-1", gomock.Any()).Return(nil).Times(1)
+		mockSessionRepo.EXPECT().Revoke(ctx, "session-1").Return(nil).Times(1)
+		mockRefreshTokenRepo.EXPECT().RevokeFamily(ctx, "session-1").Return(nil).Times(1)
+		mockRevocations.EXPECT().RevokeSession(ctx, "session-1", 15*time.Minute).Return(nil).Times(1)
+
+		err := authUsecase.Logout(ctx, &withSession, &domain.LogoutPayload{})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Revokes Refresh Token Family", func(t *testing.T) {
+		mockRevocations.EXPECT().RevokeToken(ctx, "jti-1", gomock.Any()).Return(nil).Times(1)
+		mockRefreshTokenRepo.EXPECT().
+			GetByHash(ctx, sha256Hex(rawToken)).
+			Return(&domain.RefreshToken{ID: 10, UserID: 1, FamilyID: "family-1"}, nil).
+			Times(1)
+		mockRefreshTokenRepo.EXPECT().RevokeFamily(ctx, "family-1").Return(nil).Times(1)
+
+		err := authUsecase.Logout(ctx, claims, &domain.LogoutPayload{RefreshToken: rawToken})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Ignores Refresh Token Of Another User", func(t *testing.T) {
+		mockRevocations.EXPECT().RevokeToken(ctx, "jti-1", gomock.Any()).Return(nil).Times(1)
+		mockRefreshTokenRepo.EXPECT().
+			GetByHash(ctx, sha256Hex(rawToken)).
+			Return(&domain.RefreshToken{ID: 11, UserID: 2, FamilyID: "family-2"}, nil).
+			Times(1)
+
+		err := authUsecase.Logout(ctx, claims, &domain.LogoutPayload{RefreshToken: rawToken})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Denylist Error", func(t *testing.T) {
+		mockRevocations.EXPECT().RevokeToken(ctx, "jti-1", gomock.Any()).Return(errors.New("redis down")).Times(1)
+
+		err := authUsecase.Logout(ctx, claims, &domain.LogoutPayload{})
+
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusInternalServerError, domainErr.HTTPStatus)
+	})
+}
+
+func TestAuthUsecase_LogoutAll(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRefreshTokenRepo := mocks.NewMockRefreshTokenRepository(mockCtrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(mockCtrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(mockCtrl)
+	authUsecase := usecase.NewAuthUsecase(mockRefreshTokenRepo, mockSessionRepo, mocks.NewMockUserRepository(mockCtrl), mockRevocations, testKeySet(t), 15*time.Minute, 720*time.Hour, zap.NewNop())
+
+	ctx := context.Background()
+
+	mockSessionRepo.EXPECT().RevokeAllForUser(ctx, uint(1), "").Return([]string{"session-1", "session-2"}, nil).Times(1)
+	mockRefreshTokenRepo.EXPECT().RevokeAllForUser(ctx, uint(1)).Return(nil).Times(1)
+	mockRevocations.EXPECT().RevokeUserTokens(ctx, uint(1), gomock.Any(), 15*time.Minute).Return(nil).Times(1)
+
+	err := authUsecase.LogoutAll(ctx, 1)
+
+	assert.NoError(t, err)
+}
+
+func TestAuthUsecase_RevokeOtherSessions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRefreshTokenRepo := mocks.NewMockRefreshTokenRepository(mockCtrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(mockCtrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(mockCtrl)
+	authUsecase := usecase.NewAuthUsecase(mockRefreshTokenRepo, mockSessionRepo, mocks.NewMockUserRepository(mockCtrl), mockRevocations, testKeySet(t), 15*time.Minute, 720*time.Hour, zap.NewNop())
+
+	ctx := context.Background()
+
+	mockSessionRepo.EXPECT().RevokeAllForUser(ctx, uint(1), "current").Return([]string{"other-1", "other-2"}, nil).Times(1)
+	for _, sessionID := range []string{"other-1", "other-2"} {
+		mockRefreshTokenRepo.EXPECT().RevokeFamily(ctx, sessionID).Return(nil).Times(1)
+		mockRevocations.EXPECT().RevokeSession(ctx, sessionID, 15*time.Minute).Return(nil).Times(1)
+	}
+
+	err := authUsecase.RevokeOtherSessions(ctx, 1, "current")
+
+	assert.NoError(t, err)
+}
@@ -2,8 +2,10 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/X3nonxe/gopsy-backend/internal/domain"
 	"go.uber.org/zap"
@@ -11,16 +13,28 @@ import (
 
 type availabilityUsecase struct {
 	availabilityRepo domain.AvailabilityRepository
+	consultationRepo domain.ConsultationRepository
+	userRepo         domain.UserRepository
+	profileRepo      domain.PsychologistProfileRepository
+	location         *time.Location
 	logger           *zap.Logger
 }
 
 // NewAvailabilityUsecase membuat instance baru dari availabilityUsecase.
 func NewAvailabilityUsecase(
 	ar domain.AvailabilityRepository,
+	cr domain.ConsultationRepository,
+	ur domain.UserRepository,
+	pr domain.PsychologistProfileRepository,
+	location *time.Location,
 	logger *zap.Logger,
 ) domain.AvailabilityUsecase {
 	return &availabilityUsecase{
 		availabilityRepo: ar,
+		consultationRepo: cr,
+		userRepo:         ur,
+		profileRepo:      pr,
+		location:         location,
 		logger:           logger,
 	}
 }
@@ -33,6 +47,17 @@ func (uc *availabilityUsecase) SetAvailability(ctx context.Context, psikologID u
 		return err
 	}
 
+	// Setiap slot harus cukup untuk minimal satu sesi sesuai pengaturan psikolog
+	settings, err := loadSessionSettings(ctx, uc.availabilityRepo, psikologID)
+	if err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to update availability schedule", err)
+	}
+	for _, slot := range payload.Slots {
+		if err := validateFitsSession(slot.WaktuMulai, slot.WaktuSelesai, settings); err != nil {
+			return err
+		}
+	}
+
 	// Konversi payload ke entitas domain
 	newSlots := make([]domain.WaktuKonsultasi, 0, len(payload.Slots))
 	for _, slot := range payload.Slots {
@@ -82,16 +107,7 @@ func (uc *availabilityUsecase) GetAvailability(ctx context.Context, psikologID u
 // GetAvailabilityByDay mengambil jadwal ketersediaan psikolog berdasarkan hari.
 func (uc *availabilityUsecase) GetAvailabilityByDay(ctx context.Context, psikologID uint, day string) ([]domain.WaktuKonsultasi, error) {
 	// Validasi hari
-	validDays := []string{"Senin", "Selasa", "Rabu", "Kamis", "Jumat", "Sabtu", "Minggu"}
-	isValidDay := false
-	for _, validDay := range validDays {
-		if day == validDay {
-			isValidDay = true
-			break
-		}
-	}
-
-	if !isValidDay {
+	if !domain.IsValidHari(day) {
 		return nil, domain.NewDomainError(
 			http.StatusBadRequest,
 			fmt.Sprintf("Invalid day: %s", day),
@@ -111,3 +127,456 @@ func (uc *availabilityUsecase) GetAvailabilityByDay(ctx context.Context, psikolo
 
 	return slots, nil
 }
+
+// GetWeeklySchedule mengambil jadwal ketersediaan psikolog yang dikelompokkan per hari dari Senin
+// hingga Minggu untuk klien. Jika hari diisi, hanya hari tersebut yang dikembalikan. Psikolog yang
+// belum terverifikasi dianggap tidak ada.
+func (uc *availabilityUsecase) GetWeeklySchedule(ctx context.Context, psikologID uint, hari string) (*domain.JadwalMingguan, error) {
+	if _, err := loadBookablePsychologist(ctx, uc.userRepo, uc.profileRepo, psikologID, time.Now().In(uc.location)); err != nil {
+		if errors.Is(err, domain.ErrPsychologistNotFound) {
+			return nil, err
+		}
+		uc.logger.Error("Failed to get psychologist", zap.Error(err), zap.Uint("psikolog_id", psikologID))
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to retrieve availability schedule", err)
+	}
+
+	return uc.weeklySchedule(ctx, psikologID, hari)
+}
+
+// GetOwnWeeklySchedule mengambil jadwal mingguan milik psikolog sendiri, termasuk sebelum profilnya
+// diverifikasi.
+func (uc *availabilityUsecase) GetOwnWeeklySchedule(ctx context.Context, psikologID uint, hari string) (*domain.JadwalMingguan, error) {
+	return uc.weeklySchedule(ctx, psikologID, hari)
+}
+
+func (uc *availabilityUsecase) weeklySchedule(ctx context.Context, psikologID uint, hari string) (*domain.JadwalMingguan, error) {
+	var (
+		slots []domain.WaktuKonsultasi
+		err   error
+	)
+	days := domain.DaftarHariMingguan()
+	if hari != "" {
+		slots, err = uc.GetAvailabilityByDay(ctx, psikologID, hari)
+		days = []string{hari}
+	} else {
+		slots, err = uc.GetAvailability(ctx, psikologID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := loadSessionSettings(ctx, uc.availabilityRepo, psikologID)
+	if err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to retrieve availability schedule", err)
+	}
+
+	return domain.NewJadwalMingguan(settings, slots, days), nil
+}
+
+// GetBookableSlots menjabarkan jadwal mingguan psikolog menjadi slot bertanggal dalam horizon
+// tertentu pada zona waktu aplikasi, menerapkan pengecualian jadwal, memotongnya menjadi sesi
+// sesuai pengaturan psikolog, lalu membuang sesi yang sudah dipesan.
+func (uc *availabilityUsecase) GetBookableSlots(ctx context.Context, psikologID uint, query *domain.BookableSlotsQuery) ([]domain.BookableSlot, error) {
+	now := time.Now().In(uc.location)
+
+	if _, err := loadBookablePsychologist(ctx, uc.userRepo, uc.profileRepo, psikologID, now); err != nil {
+		if errors.Is(err, domain.ErrPsychologistNotFound) {
+			return nil, err
+		}
+		uc.logger.Error("Failed to get psychologist", zap.Error(err), zap.Uint("psikolog_id", psikologID))
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to retrieve bookable slots", err)
+	}
+
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, uc.location)
+	if query.From != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", query.From, uc.location)
+		if err != nil {
+			return nil, domain.NewDomainError(http.StatusBadRequest, "Invalid from date format")
+		}
+		if parsed.After(from) {
+			from = parsed
+		}
+	}
+
+	days := query.Days
+	if days <= 0 {
+		days = domain.DefaultBookingHorizonDays
+	}
+	to := from.AddDate(0, 0, days)
+
+	templates, err := uc.availabilityRepo.GetByPsikologID(ctx, psikologID)
+	if err != nil {
+		uc.logger.Error("Failed to get availability",
+			zap.Error(err), zap.Uint("psikolog_id", psikologID))
+		return nil, domain.NewDomainErrorWithCause(
+			http.StatusInternalServerError,
+			"Failed to retrieve bookable slots",
+			err,
+		)
+	}
+
+	exceptions, err := uc.availabilityRepo.GetExceptions(ctx, psikologID, from, to)
+	if err != nil {
+		return nil, domain.NewDomainErrorWithCause(
+			http.StatusInternalServerError,
+			"Failed to retrieve bookable slots",
+			err,
+		)
+	}
+
+	// Konsultasi yang masih aktif tidak bisa dipesan ulang
+	bookedFrom := from.AddDate(0, 0, -1)
+	booked, _, err := uc.consultationRepo.List(ctx, domain.KonsultasiFilter{
+		PsikologID: psikologID,
+		Statuses:   domain.ActiveKonsultasiStatuses,
+		From:       &bookedFrom,
+		To:         &to,
+	})
+	if err != nil {
+		return nil, domain.NewDomainErrorWithCause(
+			http.StatusInternalServerError,
+			"Failed to retrieve bookable slots",
+			err,
+		)
+	}
+
+	settings, err := loadSessionSettings(ctx, uc.availabilityRepo, psikologID)
+	if err != nil {
+		return nil, domain.NewDomainErrorWithCause(
+			http.StatusInternalServerError,
+			"Failed to retrieve bookable slots",
+			err,
+		)
+	}
+
+	// Konsultasi aktif beserta jeda setelah dan sebelumnya tidak bisa dipesan
+	blocked := make([]domain.TimeRange, 0, len(booked))
+	for _, k := range booked {
+		blocked = append(blocked, domain.TimeRange{
+			Start: k.WaktuMulai.Add(-settings.Buffer()),
+			End:   k.WaktuSelesai.Add(settings.Buffer()),
+		})
+	}
+
+	windows := domain.ComputeAvailability(templates, exceptions, from, to, uc.location)
+	sessions := domain.SliceIntoSessions(windows, settings.SessionDuration(), settings.Buffer())
+
+	slots := make([]domain.BookableSlot, 0, len(sessions))
+	for _, session := range sessions {
+		// Sesi yang sudah dimulai tidak bisa dipesan
+		if session.Start.Before(now) {
+			continue
+		}
+		if overlapsAny(session, blocked) {
+			continue
+		}
+		start := session.Start.In(uc.location)
+		slots = append(slots, domain.BookableSlot{
+			Tanggal:      start.Format("2006-01-02"),
+			Hari:         domain.HariFromWeekday(start.Weekday()),
+			WaktuMulai:   start,
+			WaktuSelesai: session.End.In(uc.location),
+		})
+	}
+
+	return slots, nil
+}
+
+// AddException menambahkan pengecualian jadwal bertanggal untuk psikolog.
+func (uc *availabilityUsecase) AddException(ctx context.Context, psikologID uint, payload *domain.AvailabilityExceptionPayload) (*domain.PengecualianJadwal, error) {
+	if err := payload.Validate(); err != nil {
+		uc.logger.Warn("Payload validation failed", zap.Error(err))
+		return nil, err
+	}
+
+	tanggal, err := time.ParseInLocation("2006-01-02", payload.Tanggal, uc.location)
+	if err != nil {
+		return nil, domain.NewDomainError(http.StatusBadRequest, "Invalid date format")
+	}
+
+	now := time.Now().In(uc.location)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, uc.location)
+	if tanggal.Before(today) {
+		return nil, domain.NewDomainError(http.StatusBadRequest, "Exception date must not be in the past")
+	}
+
+	// Pengecualian pada tanggal yang sama tidak boleh saling bertabrakan
+	existing, err := uc.availabilityRepo.GetExceptions(ctx, psikologID, tanggal, tanggal.AddDate(0, 0, 1))
+	if err != nil {
+		uc.logger.Error("Failed to get availability exceptions",
+			zap.Error(err), zap.Uint("psikolog_id", psikologID))
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to add availability exception", err)
+	}
+	for _, e := range existing {
+		if exceptionsConflict(e, payload) {
+			return nil, domain.NewDomainError(http.StatusConflict,
+				fmt.Sprintf("Exception conflicts with an existing exception on %s", payload.Tanggal))
+		}
+	}
+
+	if payload.Jenis == domain.PengecualianExtraHours {
+		settings, err := loadSessionSettings(ctx, uc.availabilityRepo, psikologID)
+		if err != nil {
+			return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to add availability exception", err)
+		}
+		if err := validateFitsSession(payload.WaktuMulai, payload.WaktuSelesai, settings); err != nil {
+			return nil, err
+		}
+	}
+
+	exception := &domain.PengecualianJadwal{
+		PsikologID: psikologID,
+		Tanggal:    tanggal,
+		Jenis:      payload.Jenis,
+		Alasan:     payload.Alasan,
+	}
+	if payload.Jenis != domain.PengecualianFullDay {
+		exception.WaktuMulai = &payload.WaktuMulai
+		exception.WaktuSelesai = &payload.WaktuSelesai
+	}
+
+	if err := uc.availabilityRepo.CreateException(ctx, exception); err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to add availability exception", err)
+	}
+
+	uc.logger.Info("Availability exception added",
+		zap.Uint("id", exception.ID), zap.Uint("psikolog_id", psikologID), zap.String("jenis", exception.Jenis))
+	return exception, nil
+}
+
+// GetExceptions mengambil pengecualian jadwal psikolog. Tanpa filter, yang ditampilkan adalah
+// pengecualian mulai hari ini sepanjang horizon pemesanan.
+func (uc *availabilityUsecase) GetExceptions(ctx context.Context, psikologID uint, query *domain.AvailabilityExceptionQuery) ([]domain.PengecualianJadwal, error) {
+	now := time.Now().In(uc.location)
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, uc.location)
+	if query.From != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", query.From, uc.location)
+		if err != nil {
+			return nil, domain.NewDomainError(http.StatusBadRequest, "Invalid from date format")
+		}
+		from = parsed
+	}
+
+	to := from.AddDate(0, 0, domain.DefaultBookingHorizonDays)
+	if query.To != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", query.To, uc.location)
+		if err != nil {
+			return nil, domain.NewDomainError(http.StatusBadRequest, "Invalid to date format")
+		}
+		to = parsed.AddDate(0, 0, 1)
+	}
+
+	if !from.Before(to) {
+		return nil, domain.NewDomainError(http.StatusBadRequest, "From date must not be after to date")
+	}
+
+	exceptions, err := uc.availabilityRepo.GetExceptions(ctx, psikologID, from, to)
+	if err != nil {
+		uc.logger.Error("Failed to get availability exceptions",
+			zap.Error(err), zap.Uint("psikolog_id", psikologID))
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to retrieve availability exceptions", err)
+	}
+
+	return exceptions, nil
+}
+
+// DeleteException menghapus pengecualian jadwal milik psikolog.
+func (uc *availabilityUsecase) DeleteException(ctx context.Context, psikologID, id uint) error {
+	if err := uc.availabilityRepo.DeleteException(ctx, psikologID, id); err != nil {
+		if errors.Is(err, domain.ErrAvailabilityExceptionNotFound) {
+			return err
+		}
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to delete availability exception", err)
+	}
+
+	uc.logger.Info("Availability exception deleted", zap.Uint("id", id), zap.Uint("psikolog_id", psikologID))
+	return nil
+}
+
+// GetSessionSettings mengambil pengaturan sesi psikolog, atau pengaturan bawaan bila belum diatur.
+func (uc *availabilityUsecase) GetSessionSettings(ctx context.Context, psikologID uint) (*domain.PengaturanSesi, error) {
+	settings, err := loadSessionSettings(ctx, uc.availabilityRepo, psikologID)
+	if err != nil {
+		uc.logger.Error("Failed to get session settings", zap.Error(err), zap.Uint("psikolog_id", psikologID))
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to retrieve session settings", err)
+	}
+	return settings, nil
+}
+
+// UpdateSessionSettings mengubah durasi sesi dan jeda antar sesi psikolog.
+func (uc *availabilityUsecase) UpdateSessionSettings(ctx context.Context, psikologID uint, payload *domain.SessionSettingsPayload) (*domain.PengaturanSesi, error) {
+	if payload.DurasiSesiMenit < domain.MinDurasiSesiMenit || payload.DurasiSesiMenit > domain.MaxDurasiSesiMenit {
+		return nil, domain.NewDomainError(http.StatusBadRequest,
+			fmt.Sprintf("Session length must be between %d and %d minutes", domain.MinDurasiSesiMenit, domain.MaxDurasiSesiMenit))
+	}
+	if payload.BufferMenit < 0 || payload.BufferMenit > domain.MaxBufferMenit {
+		return nil, domain.NewDomainError(http.StatusBadRequest,
+			fmt.Sprintf("Buffer must be between 0 and %d minutes", domain.MaxBufferMenit))
+	}
+
+	settings := &domain.PengaturanSesi{
+		PsikologID:      psikologID,
+		DurasiSesiMenit: payload.DurasiSesiMenit,
+		BufferMenit:     payload.BufferMenit,
+	}
+	if err := uc.availabilityRepo.UpsertSessionSettings(ctx, settings); err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to update session settings", err)
+	}
+
+	uc.logger.Info("Session settings updated",
+		zap.Uint("psikolog_id", psikologID), zap.Int("durasi_sesi_menit", settings.DurasiSesiMenit), zap.Int("buffer_menit", settings.BufferMenit))
+	return settings, nil
+}
+
+// AddSlot menambahkan satu slot ketersediaan tanpa mengganti slot lain yang sudah ada. Pemeriksaan
+// irisan di sini memberi pesan yang jelas; irisan dengan slot yang disimpan bersamaan ditolak oleh
+// constraint database.
+func (uc *availabilityUsecase) AddSlot(ctx context.Context, psikologID uint, payload *domain.SlotPayload) (*domain.WaktuKonsultasi, error) {
+	if err := uc.validateSlot(ctx, psikologID, payload, 0); err != nil {
+		return nil, err
+	}
+
+	slot := &domain.WaktuKonsultasi{
+		PsikologID:   psikologID,
+		Hari:         payload.Hari,
+		WaktuMulai:   payload.WaktuMulai,
+		WaktuSelesai: payload.WaktuSelesai,
+	}
+	if err := uc.availabilityRepo.CreateSlot(ctx, slot); err != nil {
+		return nil, uc.slotError(err, "Failed to add availability slot")
+	}
+
+	uc.logger.Info("Availability slot added", zap.Uint("id", slot.ID), zap.Uint("psikolog_id", psikologID))
+	return slot, nil
+}
+
+// UpdateSlot mengubah satu slot ketersediaan. Perubahan ditolak bila version tidak lagi sama
+// dengan versi slot di database.
+func (uc *availabilityUsecase) UpdateSlot(ctx context.Context, psikologID, id, version uint, payload *domain.SlotPayload) (*domain.WaktuKonsultasi, error) {
+	slot, err := uc.availabilityRepo.GetSlotByID(ctx, psikologID, id)
+	if err != nil {
+		return nil, uc.slotError(err, "Failed to update availability slot")
+	}
+	if slot.Version != version {
+		return nil, domain.ErrAvailabilitySlotVersionMismatch
+	}
+
+	if err := uc.validateSlot(ctx, psikologID, payload, id); err != nil {
+		return nil, err
+	}
+
+	slot.Hari = payload.Hari
+	slot.WaktuMulai = payload.WaktuMulai
+	slot.WaktuSelesai = payload.WaktuSelesai
+	if err := uc.availabilityRepo.UpdateSlot(ctx, slot, version); err != nil {
+		return nil, uc.slotError(err, "Failed to update availability slot")
+	}
+
+	uc.logger.Info("Availability slot updated",
+		zap.Uint("id", id), zap.Uint("psikolog_id", psikologID), zap.Uint("version", slot.Version))
+	return slot, nil
+}
+
+// DeleteSlot menghapus satu slot ketersediaan bila version masih sama dengan versi di database.
+func (uc *availabilityUsecase) DeleteSlot(ctx context.Context, psikologID, id, version uint) error {
+	slot, err := uc.availabilityRepo.GetSlotByID(ctx, psikologID, id)
+	if err != nil {
+		return uc.slotError(err, "Failed to delete availability slot")
+	}
+	if slot.Version != version {
+		return domain.ErrAvailabilitySlotVersionMismatch
+	}
+
+	if err := uc.availabilityRepo.DeleteSlot(ctx, psikologID, id, version); err != nil {
+		return uc.slotError(err, "Failed to delete availability slot")
+	}
+
+	uc.logger.Info("Availability slot deleted", zap.Uint("id", id), zap.Uint("psikolog_id", psikologID))
+	return nil
+}
+
+// validateSlot menerapkan validasi yang sama dengan SetAvailability terhadap slot yang sudah tersimpan.
+func (uc *availabilityUsecase) validateSlot(ctx context.Context, psikologID uint, payload *domain.SlotPayload, excludeID uint) error {
+	if err := payload.Validate(); err != nil {
+		uc.logger.Warn("Payload validation failed", zap.Error(err))
+		return err
+	}
+
+	settings, err := loadSessionSettings(ctx, uc.availabilityRepo, psikologID)
+	if err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to validate availability slot", err)
+	}
+	if err := validateFitsSession(payload.WaktuMulai, payload.WaktuSelesai, settings); err != nil {
+		return err
+	}
+
+	existing, err := uc.availabilityRepo.GetByPsikologIDAndDay(ctx, psikologID, payload.Hari)
+	if err != nil {
+		uc.logger.Error("Failed to get availability by day",
+			zap.Error(err), zap.Uint("psikolog_id", psikologID), zap.String("day", payload.Hari))
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to validate availability slot", err)
+	}
+
+	return payload.ValidateAgainstExisting(existing, excludeID)
+}
+
+// slotError meneruskan error domain dari repository dan membungkus error lain sebagai 500.
+func (uc *availabilityUsecase) slotError(err error, message string) error {
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) {
+		return err
+	}
+	return domain.NewDomainErrorWithCause(http.StatusInternalServerError, message, err)
+}
+
+// loadSessionSettings mengambil pengaturan sesi psikolog dan jatuh ke pengaturan bawaan bila belum ada.
+func loadSessionSettings(ctx context.Context, repo domain.AvailabilityRepository, psikologID uint) (*domain.PengaturanSesi, error) {
+	settings, err := repo.GetSessionSettings(ctx, psikologID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionSettingsNotFound) {
+			return domain.DefaultPengaturanSesi(psikologID), nil
+		}
+		return nil, err
+	}
+	return settings, nil
+}
+
+// validateFitsSession memastikan jendela waktu (format 15:04:05) cukup untuk minimal satu sesi.
+func validateFitsSession(waktuMulai, waktuSelesai string, settings *domain.PengaturanSesi) error {
+	start, err := time.Parse("15:04:05", waktuMulai)
+	if err != nil {
+		return domain.NewDomainError(http.StatusBadRequest, "Invalid start time format")
+	}
+	end, err := time.Parse("15:04:05", waktuSelesai)
+	if err != nil {
+		return domain.NewDomainError(http.StatusBadRequest, "Invalid end time format")
+	}
+	if end.Sub(start) < settings.SessionDuration() {
+		return domain.NewDomainError(http.StatusBadRequest,
+			fmt.Sprintf("Time window %s-%s is shorter than the session length of %d minutes",
+				waktuMulai, waktuSelesai, settings.DurasiSesiMenit))
+	}
+	return nil
+}
+
+// overlapsAny memeriksa apakah rentang beririsan dengan salah satu rentang lain.
+func overlapsAny(r domain.TimeRange, others []domain.TimeRange) bool {
+	for _, other := range others {
+		if r.Overlaps(other) {
+			return true
+		}
+	}
+	return false
+}
+
+// exceptionsConflict memeriksa apakah pengecualian baru bertabrakan dengan pengecualian yang sudah ada
+// pada tanggal yang sama. Libur sehari penuh bertabrakan dengan pengecualian apa pun.
+func exceptionsConflict(existing domain.PengecualianJadwal, payload *domain.AvailabilityExceptionPayload) bool {
+	if existing.Jenis == domain.PengecualianFullDay || payload.Jenis == domain.PengecualianFullDay {
+		return true
+	}
+	if existing.WaktuMulai == nil || existing.WaktuSelesai == nil {
+		return false
+	}
+	return payload.WaktuMulai < *existing.WaktuSelesai && *existing.WaktuMulai < payload.WaktuSelesai
+}
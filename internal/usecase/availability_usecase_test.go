@@ -2,7 +2,10 @@ package usecase_test
 
 import (
 	"context"
+	"errors"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/X3nonxe/gopsy-backend/internal/domain"
 	"github.com/X3nonxe/gopsy-backend/internal/mocks" // Pastikan mock sudah di-generate
@@ -18,7 +21,10 @@ func TestAvailabilityUsecase_SetAvailability(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	mockAvailabilityRepo := mocks.NewMockAvailabilityRepository(mockCtrl)
-	availabilityUsecase := usecase.NewAvailabilityUsecase(mockAvailabilityRepo, zap.NewNop()) // Logger bisa diisi sesuai kebutuhan
+	mockConsultationRepo := mocks.NewMockConsultationRepository(mockCtrl)
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	mockProfileRepo := mocks.NewMockPsychologistProfileRepository(mockCtrl)
+	availabilityUsecase := usecase.NewAvailabilityUsecase(mockAvailabilityRepo, mockConsultationRepo, mockUserRepo, mockProfileRepo, time.UTC, zap.NewNop()) // Logger bisa diisi sesuai kebutuhan
 
 	ctx := context.Background()
 	psikologID := uint(1)
@@ -38,6 +44,12 @@ func TestAvailabilityUsecase_SetAvailability(t *testing.T) {
 		},
 	}
 
+	// Psikolog belum mengatur sesi, sehingga pengaturan bawaan (60 menit) yang dipakai
+	mockAvailabilityRepo.EXPECT().
+		GetSessionSettings(ctx, psikologID).
+		Return(nil, domain.ErrSessionSettingsNotFound).
+		AnyTimes()
+
 	t.Run("Success", func(t *testing.T) {
 		// Arrange (Persiapan)
 		// Kita harapkan metode ReplaceAll dipanggil sekali dengan psikologID
@@ -75,6 +87,20 @@ func TestAvailabilityUsecase_SetAvailability(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("Slot Shorter Than Session", func(t *testing.T) {
+		shortPayload := &domain.SetAvailabilityPayload{
+			Slots: []domain.SlotPayload{
+				{Hari: "Senin", WaktuMulai: "09:00:00", WaktuSelesai: "09:45:00"},
+			},
+		}
+
+		err := availabilityUsecase.SetAvailability(ctx, psikologID, shortPayload)
+
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusBadRequest, domainErr.HTTPStatus)
+	})
+
 	t.Run("Repository Failure", func(t *testing.T) {
 		// Arrange
 		expectedError := assert.AnError // Error dummy
@@ -90,4 +116,509 @@ func TestAvailabilityUsecase_SetAvailability(t *testing.T) {
 		assert.Error(t, err)
 		assert.Equal(t, expectedError, err)
 	})
+
+	t.Run("Repository Failure Is Wrapped As Domain Error", func(t *testing.T) {
+		// Arrange
+		expectedError := assert.AnError
+		mockAvailabilityRepo.EXPECT().
+			ReplaceAll(ctx, psikologID, gomock.Any()).
+			Return(expectedError).
+			Times(1)
+
+		// Act
+		err := availabilityUsecase.SetAvailability(ctx, psikologID, payload)
+
+		// Assert: error repository dibungkus menjadi DomainError 500
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusInternalServerError, domainErr.HTTPStatus)
+		assert.True(t, errors.Is(err, expectedError))
+	})
+}
+
+func TestAvailabilityUsecase_GetBookableSlots(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockAvailabilityRepo := mocks.NewMockAvailabilityRepository(mockCtrl)
+	mockConsultationRepo := mocks.NewMockConsultationRepository(mockCtrl)
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	mockProfileRepo := mocks.NewMockPsychologistProfileRepository(mockCtrl)
+	location, _ := time.LoadLocation("Asia/Jakarta")
+	availabilityUsecase := usecase.NewAvailabilityUsecase(mockAvailabilityRepo, mockConsultationRepo, mockUserRepo, mockProfileRepo, location, zap.NewNop())
+
+	ctx := context.Background()
+	psikologID := uint(1)
+
+	mockUserRepo.EXPECT().GetByID(ctx, psikologID).Return(&domain.User{ID: psikologID, Role: "psikolog"}, nil).AnyTimes()
+	mockProfileRepo.EXPECT().GetByUserID(ctx, psikologID).Return(verifiedProfile(psikologID), nil).AnyTimes()
+
+	// Senin minggu depan, agar seluruh slot berada di masa depan
+	now := time.Now().In(location)
+	monday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location).AddDate(0, 0, 7)
+	for monday.Weekday() != time.Monday {
+		monday = monday.AddDate(0, 0, 1)
+	}
+
+	templates := []domain.WaktuKonsultasi{
+		{PsikologID: psikologID, Hari: "Senin", WaktuMulai: "09:00:00", WaktuSelesai: "12:00:00"},
+		{PsikologID: psikologID, Hari: "Rabu", WaktuMulai: "13:00:00", WaktuSelesai: "15:00:00"},
+	}
+
+	t.Run("Expands Template And Subtracts Bookings", func(t *testing.T) {
+		booked := []domain.Konsultasi{
+			{
+				PsikologID:   psikologID,
+				WaktuMulai:   monday.Add(10 * time.Hour),
+				WaktuSelesai: monday.Add(11 * time.Hour),
+				Status:       domain.KonsultasiStatusAccepted,
+			},
+		}
+
+		mockAvailabilityRepo.EXPECT().GetByPsikologID(ctx, psikologID).Return(templates, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetExceptions(ctx, psikologID, gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetSessionSettings(ctx, psikologID).Return(nil, domain.ErrSessionSettingsNotFound).Times(1)
+		mockConsultationRepo.EXPECT().
+			List(ctx, gomock.Any()).
+			Do(func(ctx context.Context, filter domain.KonsultasiFilter) {
+				assert.Equal(t, psikologID, filter.PsikologID)
+				assert.Equal(t, domain.ActiveKonsultasiStatuses, filter.Statuses)
+				assert.False(t, filter.Preload)
+			}).
+			Return(booked, int64(1), nil).
+			Times(1)
+
+		query := &domain.BookableSlotsQuery{From: monday.Format("2006-01-02"), Days: 7}
+		slots, err := availabilityUsecase.GetBookableSlots(ctx, psikologID, query)
+
+		assert.NoError(t, err)
+		assert.Len(t, slots, 4)
+		assert.Equal(t, monday.Add(9*time.Hour), slots[0].WaktuMulai)
+		assert.Equal(t, monday.Add(10*time.Hour), slots[0].WaktuSelesai)
+		assert.Equal(t, monday.Add(11*time.Hour), slots[1].WaktuMulai)
+		assert.Equal(t, "Rabu", slots[2].Hari)
+		assert.Equal(t, monday.AddDate(0, 0, 2).Format("2006-01-02"), slots[2].Tanggal)
+	})
+
+	t.Run("Slices By Session Length And Buffer", func(t *testing.T) {
+		settings := &domain.PengaturanSesi{PsikologID: psikologID, DurasiSesiMenit: 50, BufferMenit: 10}
+		booked := []domain.Konsultasi{
+			{
+				PsikologID:   psikologID,
+				WaktuMulai:   monday.Add(10 * time.Hour),
+				WaktuSelesai: monday.Add(10*time.Hour + 50*time.Minute),
+				Status:       domain.KonsultasiStatusPending,
+			},
+		}
+
+		mockAvailabilityRepo.EXPECT().GetByPsikologID(ctx, psikologID).Return(templates[:1], nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetExceptions(ctx, psikologID, gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetSessionSettings(ctx, psikologID).Return(settings, nil).Times(1)
+		mockConsultationRepo.EXPECT().List(ctx, gomock.Any()).Return(booked, int64(1), nil).Times(1)
+
+		query := &domain.BookableSlotsQuery{From: monday.Format("2006-01-02"), Days: 1}
+		slots, err := availabilityUsecase.GetBookableSlots(ctx, psikologID, query)
+
+		assert.NoError(t, err)
+		assert.Len(t, slots, 2)
+		assert.Equal(t, monday.Add(9*time.Hour), slots[0].WaktuMulai)
+		assert.Equal(t, monday.Add(9*time.Hour+50*time.Minute), slots[0].WaktuSelesai)
+		assert.Equal(t, monday.Add(11*time.Hour), slots[1].WaktuMulai)
+		assert.Equal(t, monday.Add(11*time.Hour+50*time.Minute), slots[1].WaktuSelesai)
+	})
+
+	t.Run("Applies Exceptions", func(t *testing.T) {
+		mulai, selesai := "09:00:00", "10:00:00"
+		exceptions := []domain.PengecualianJadwal{
+			{PsikologID: psikologID, Tanggal: monday, Jenis: domain.PengecualianPartialBlock, WaktuMulai: &mulai, WaktuSelesai: &selesai},
+			{PsikologID: psikologID, Tanggal: monday.AddDate(0, 0, 2), Jenis: domain.PengecualianFullDay},
+		}
+
+		mockAvailabilityRepo.EXPECT().GetByPsikologID(ctx, psikologID).Return(templates, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetExceptions(ctx, psikologID, gomock.Any(), gomock.Any()).Return(exceptions, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetSessionSettings(ctx, psikologID).Return(nil, domain.ErrSessionSettingsNotFound).Times(1)
+		mockConsultationRepo.EXPECT().List(ctx, gomock.Any()).Return(nil, int64(0), nil).Times(1)
+
+		query := &domain.BookableSlotsQuery{From: monday.Format("2006-01-02"), Days: 7}
+		slots, err := availabilityUsecase.GetBookableSlots(ctx, psikologID, query)
+
+		assert.NoError(t, err)
+		assert.Len(t, slots, 2)
+		assert.Equal(t, monday.Add(10*time.Hour), slots[0].WaktuMulai)
+		assert.Equal(t, monday.Add(12*time.Hour), slots[1].WaktuSelesai)
+	})
+
+	t.Run("Repository Failure", func(t *testing.T) {
+		mockAvailabilityRepo.EXPECT().GetByPsikologID(ctx, psikologID).Return(nil, assert.AnError).Times(1)
+
+		slots, err := availabilityUsecase.GetBookableSlots(ctx, psikologID, &domain.BookableSlotsQuery{})
+
+		assert.True(t, errors.Is(err, assert.AnError))
+		assert.Nil(t, slots)
+	})
+}
+
+func TestAvailabilityUsecase_AddException(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockAvailabilityRepo := mocks.NewMockAvailabilityRepository(mockCtrl)
+	mockConsultationRepo := mocks.NewMockConsultationRepository(mockCtrl)
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	mockProfileRepo := mocks.NewMockPsychologistProfileRepository(mockCtrl)
+	location, _ := time.LoadLocation("Asia/Jakarta")
+	availabilityUsecase := usecase.NewAvailabilityUsecase(mockAvailabilityRepo, mockConsultationRepo, mockUserRepo, mockProfileRepo, location, zap.NewNop())
+
+	ctx := context.Background()
+	psikologID := uint(1)
+	tanggal := time.Now().In(location).AddDate(0, 0, 3).Format("2006-01-02")
+
+	t.Run("Success Partial Block", func(t *testing.T) {
+		payload := &domain.AvailabilityExceptionPayload{
+			Tanggal:      tanggal,
+			Jenis:        domain.PengecualianPartialBlock,
+			WaktuMulai:   "09:00:00",
+			WaktuSelesai: "10:00:00",
+			Alasan:       "Seminar",
+		}
+
+		mockAvailabilityRepo.EXPECT().GetExceptions(ctx, psikologID, gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().
+			CreateException(ctx, gomock.Any()).
+			Do(func(ctx context.Context, e *domain.PengecualianJadwal) {
+				assert.Equal(t, psikologID, e.PsikologID)
+				assert.Equal(t, tanggal, e.Tanggal.Format("2006-01-02"))
+				assert.Equal(t, "09:00:00", *e.WaktuMulai)
+			}).
+			Return(nil).
+			Times(1)
+
+		exception, err := availabilityUsecase.AddException(ctx, psikologID, payload)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, exception)
+	})
+
+	t.Run("Full Day With Time Range", func(t *testing.T) {
+		payload := &domain.AvailabilityExceptionPayload{
+			Tanggal:    tanggal,
+			Jenis:      domain.PengecualianFullDay,
+			WaktuMulai: "09:00:00",
+		}
+
+		exception, err := availabilityUsecase.AddException(ctx, psikologID, payload)
+
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusBadRequest, domainErr.HTTPStatus)
+		assert.Nil(t, exception)
+	})
+
+	t.Run("Conflicts With Full Day Exception", func(t *testing.T) {
+		payload := &domain.AvailabilityExceptionPayload{
+			Tanggal:      tanggal,
+			Jenis:        domain.PengecualianExtraHours,
+			WaktuMulai:   "18:00:00",
+			WaktuSelesai: "20:00:00",
+		}
+		existing := []domain.PengecualianJadwal{{ID: 5, PsikologID: psikologID, Jenis: domain.PengecualianFullDay}}
+
+		mockAvailabilityRepo.EXPECT().GetExceptions(ctx, psikologID, gomock.Any(), gomock.Any()).Return(existing, nil).Times(1)
+
+		exception, err := availabilityUsecase.AddException(ctx, psikologID, payload)
+
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusConflict, domainErr.HTTPStatus)
+		assert.Nil(t, exception)
+	})
+
+	t.Run("Date In The Past", func(t *testing.T) {
+		payload := &domain.AvailabilityExceptionPayload{
+			Tanggal: time.Now().In(location).AddDate(0, 0, -1).Format("2006-01-02"),
+			Jenis:   domain.PengecualianFullDay,
+		}
+
+		exception, err := availabilityUsecase.AddException(ctx, psikologID, payload)
+
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusBadRequest, domainErr.HTTPStatus)
+		assert.Nil(t, exception)
+	})
+}
+
+func TestAvailabilityUsecase_UpdateSessionSettings(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockAvailabilityRepo := mocks.NewMockAvailabilityRepository(mockCtrl)
+	mockConsultationRepo := mocks.NewMockConsultationRepository(mockCtrl)
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	mockProfileRepo := mocks.NewMockPsychologistProfileRepository(mockCtrl)
+	availabilityUsecase := usecase.NewAvailabilityUsecase(mockAvailabilityRepo, mockConsultationRepo, mockUserRepo, mockProfileRepo, time.UTC, zap.NewNop())
+
+	ctx := context.Background()
+	psikologID := uint(1)
+
+	t.Run("Success", func(t *testing.T) {
+		mockAvailabilityRepo.EXPECT().
+			UpsertSessionSettings(ctx, gomock.Any()).
+			Do(func(ctx context.Context, settings *domain.PengaturanSesi) {
+				assert.Equal(t, psikologID, settings.PsikologID)
+				assert.Equal(t, 50, settings.DurasiSesiMenit)
+				assert.Equal(t, 10, settings.BufferMenit)
+			}).
+			Return(nil).
+			Times(1)
+
+		settings, err := availabilityUsecase.UpdateSessionSettings(ctx, psikologID, &domain.SessionSettingsPayload{DurasiSesiMenit: 50, BufferMenit: 10})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 50*time.Minute, settings.SessionDuration())
+	})
+
+	t.Run("Session Too Short", func(t *testing.T) {
+		settings, err := availabilityUsecase.UpdateSessionSettings(ctx, psikologID, &domain.SessionSettingsPayload{DurasiSesiMenit: 5})
+
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusBadRequest, domainErr.HTTPStatus)
+		assert.Nil(t, settings)
+	})
+
+	t.Run("Defaults When Not Configured", func(t *testing.T) {
+		mockAvailabilityRepo.EXPECT().GetSessionSettings(ctx, psikologID).Return(nil, domain.ErrSessionSettingsNotFound).Times(1)
+
+		settings, err := availabilityUsecase.GetSessionSettings(ctx, psikologID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.DefaultDurasiSesiMenit, settings.DurasiSesiMenit)
+		assert.Equal(t, domain.DefaultBufferMenit, settings.BufferMenit)
+	})
+}
+
+func TestAvailabilityUsecase_UpdateSlot(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockAvailabilityRepo := mocks.NewMockAvailabilityRepository(mockCtrl)
+	mockConsultationRepo := mocks.NewMockConsultationRepository(mockCtrl)
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	mockProfileRepo := mocks.NewMockPsychologistProfileRepository(mockCtrl)
+	availabilityUsecase := usecase.NewAvailabilityUsecase(mockAvailabilityRepo, mockConsultationRepo, mockUserRepo, mockProfileRepo, time.UTC, zap.NewNop())
+
+	ctx := context.Background()
+	psikologID := uint(1)
+
+	existing := []domain.WaktuKonsultasi{
+		{ID: 1, PsikologID: psikologID, Hari: "Senin", WaktuMulai: "09:00:00", WaktuSelesai: "12:00:00", Version: 2},
+		{ID: 2, PsikologID: psikologID, Hari: "Senin", WaktuMulai: "13:00:00", WaktuSelesai: "15:00:00", Version: 1},
+	}
+
+	mockAvailabilityRepo.EXPECT().
+		GetSessionSettings(ctx, psikologID).
+		Return(nil, domain.ErrSessionSettingsNotFound).
+		AnyTimes()
+
+	t.Run("Success", func(t *testing.T) {
+		slot := existing[0]
+		payload := &domain.SlotPayload{Hari: "Senin", WaktuMulai: "08:00:00", WaktuSelesai: "12:00:00"}
+
+		mockAvailabilityRepo.EXPECT().GetSlotByID(ctx, psikologID, uint(1)).Return(&slot, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetByPsikologIDAndDay(ctx, psikologID, "Senin").Return(existing, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().
+			UpdateSlot(ctx, gomock.Any(), uint(2)).
+			DoAndReturn(func(ctx context.Context, s *domain.WaktuKonsultasi, expectedVersion uint) error {
+				s.Version = expectedVersion + 1
+				return nil
+			}).
+			Times(1)
+
+		updated, err := availabilityUsecase.UpdateSlot(ctx, psikologID, 1, 2, payload)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "08:00:00", updated.WaktuMulai)
+		assert.Equal(t, uint(3), updated.Version)
+	})
+
+	t.Run("Stale Version", func(t *testing.T) {
+		slot := existing[0]
+		payload := &domain.SlotPayload{Hari: "Senin", WaktuMulai: "08:00:00", WaktuSelesai: "12:00:00"}
+
+		mockAvailabilityRepo.EXPECT().GetSlotByID(ctx, psikologID, uint(1)).Return(&slot, nil).Times(1)
+
+		updated, err := availabilityUsecase.UpdateSlot(ctx, psikologID, 1, 1, payload)
+
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusPreconditionFailed, domainErr.HTTPStatus)
+		assert.Nil(t, updated)
+	})
+
+	t.Run("Concurrent Write Wins Race", func(t *testing.T) {
+		slot := existing[0]
+		payload := &domain.SlotPayload{Hari: "Senin", WaktuMulai: "08:00:00", WaktuSelesai: "12:00:00"}
+
+		mockAvailabilityRepo.EXPECT().GetSlotByID(ctx, psikologID, uint(1)).Return(&slot, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetByPsikologIDAndDay(ctx, psikologID, "Senin").Return(existing, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().UpdateSlot(ctx, gomock.Any(), uint(2)).Return(domain.ErrAvailabilitySlotVersionMismatch).Times(1)
+
+		updated, err := availabilityUsecase.UpdateSlot(ctx, psikologID, 1, 2, payload)
+
+		assert.True(t, errors.Is(err, domain.ErrAvailabilitySlotVersionMismatch))
+		assert.Nil(t, updated)
+	})
+
+	t.Run("Overlaps Existing Slot", func(t *testing.T) {
+		slot := existing[0]
+		payload := &domain.SlotPayload{Hari: "Senin", WaktuMulai: "10:00:00", WaktuSelesai: "14:00:00"}
+
+		mockAvailabilityRepo.EXPECT().GetSlotByID(ctx, psikologID, uint(1)).Return(&slot, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetByPsikologIDAndDay(ctx, psikologID, "Senin").Return(existing, nil).Times(1)
+
+		updated, err := availabilityUsecase.UpdateSlot(ctx, psikologID, 1, 2, payload)
+
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusBadRequest, domainErr.HTTPStatus)
+		assert.Nil(t, updated)
+	})
+
+	t.Run("Add Slot Overlapping Existing", func(t *testing.T) {
+		payload := &domain.SlotPayload{Hari: "Senin", WaktuMulai: "14:00:00", WaktuSelesai: "16:00:00"}
+
+		mockAvailabilityRepo.EXPECT().GetByPsikologIDAndDay(ctx, psikologID, "Senin").Return(existing, nil).Times(1)
+
+		slot, err := availabilityUsecase.AddSlot(ctx, psikologID, payload)
+
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusBadRequest, domainErr.HTTPStatus)
+		assert.Nil(t, slot)
+	})
+
+	t.Run("Add Slot Overlapping Concurrent Slot", func(t *testing.T) {
+		payload := &domain.SlotPayload{Hari: "Senin", WaktuMulai: "15:00:00", WaktuSelesai: "17:00:00"}
+
+		mockAvailabilityRepo.EXPECT().GetByPsikologIDAndDay(ctx, psikologID, "Senin").Return(existing, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().CreateSlot(ctx, gomock.Any()).Return(domain.ErrAvailabilitySlotOverlap).Times(1)
+
+		slot, err := availabilityUsecase.AddSlot(ctx, psikologID, payload)
+
+		assert.True(t, errors.Is(err, domain.ErrAvailabilitySlotOverlap))
+		assert.Nil(t, slot)
+	})
+
+	t.Run("Delete Not Found", func(t *testing.T) {
+		mockAvailabilityRepo.EXPECT().GetSlotByID(ctx, psikologID, uint(99)).Return(nil, domain.ErrAvailabilitySlotNotFound).Times(1)
+
+		err := availabilityUsecase.DeleteSlot(ctx, psikologID, 99, 1)
+
+		assert.True(t, errors.Is(err, domain.ErrAvailabilitySlotNotFound))
+	})
+}
+
+func TestAvailabilityUsecase_GetWeeklySchedule(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockAvailabilityRepo := mocks.NewMockAvailabilityRepository(mockCtrl)
+	mockConsultationRepo := mocks.NewMockConsultationRepository(mockCtrl)
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	mockProfileRepo := mocks.NewMockPsychologistProfileRepository(mockCtrl)
+	availabilityUsecase := usecase.NewAvailabilityUsecase(mockAvailabilityRepo, mockConsultationRepo, mockUserRepo, mockProfileRepo, time.UTC, zap.NewNop())
+
+	ctx := context.Background()
+	psikolog := &domain.User{ID: 1, Username: "dr.budi", Role: "psikolog"}
+
+	slots := []domain.WaktuKonsultasi{
+		{ID: 3, PsikologID: psikolog.ID, Hari: "Rabu", WaktuMulai: "13:00:00", WaktuSelesai: "15:00:00"},
+		{ID: 2, PsikologID: psikolog.ID, Hari: "Senin", WaktuMulai: "13:00:00", WaktuSelesai: "15:00:00"},
+		{ID: 1, PsikologID: psikolog.ID, Hari: "Senin", WaktuMulai: "09:00:00", WaktuSelesai: "12:00:00"},
+	}
+
+	mockProfileRepo.EXPECT().GetByUserID(ctx, psikolog.ID).Return(verifiedProfile(psikolog.ID), nil).AnyTimes()
+
+	t.Run("Grouped By Day", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(ctx, psikolog.ID).Return(psikolog, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetByPsikologID(ctx, psikolog.ID).Return(slots, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetSessionSettings(ctx, psikolog.ID).Return(nil, domain.ErrSessionSettingsNotFound).Times(1)
+
+		schedule, err := availabilityUsecase.GetWeeklySchedule(ctx, psikolog.ID, "")
+
+		assert.NoError(t, err)
+		assert.Len(t, schedule.Jadwal, 7)
+		assert.Equal(t, "Senin", schedule.Jadwal[0].Hari)
+		assert.Equal(t, "Minggu", schedule.Jadwal[6].Hari)
+		assert.Len(t, schedule.Jadwal[0].Slots, 2)
+		assert.Equal(t, "09:00:00", schedule.Jadwal[0].Slots[0].WaktuMulai)
+		assert.Empty(t, schedule.Jadwal[1].Slots)
+		assert.Len(t, schedule.Jadwal[2].Slots, 1)
+		assert.Equal(t, domain.DefaultDurasiSesiMenit, schedule.DurasiSesiMenit)
+	})
+
+	t.Run("Filtered By Day", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(ctx, psikolog.ID).Return(psikolog, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetByPsikologIDAndDay(ctx, psikolog.ID, "Rabu").Return(slots[:1], nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetSessionSettings(ctx, psikolog.ID).Return(nil, domain.ErrSessionSettingsNotFound).Times(1)
+
+		schedule, err := availabilityUsecase.GetWeeklySchedule(ctx, psikolog.ID, "Rabu")
+
+		assert.NoError(t, err)
+		assert.Len(t, schedule.Jadwal, 1)
+		assert.Equal(t, "Rabu", schedule.Jadwal[0].Hari)
+	})
+
+	t.Run("Invalid Day", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(ctx, psikolog.ID).Return(psikolog, nil).Times(1)
+
+		schedule, err := availabilityUsecase.GetWeeklySchedule(ctx, psikolog.ID, "Funday")
+
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusBadRequest, domainErr.HTTPStatus)
+		assert.Nil(t, schedule)
+	})
+
+	t.Run("Not A Psychologist", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(ctx, uint(5)).Return(&domain.User{ID: 5, Role: "klien"}, nil).Times(1)
+
+		schedule, err := availabilityUsecase.GetWeeklySchedule(ctx, 5, "")
+
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusNotFound, domainErr.HTTPStatus)
+		assert.Nil(t, schedule)
+	})
+
+	t.Run("Psychologist Not Verified", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(ctx, uint(6)).Return(&domain.User{ID: 6, Role: "psikolog"}, nil).Times(1)
+		mockProfileRepo.EXPECT().GetByUserID(ctx, uint(6)).Return(nil, domain.ErrPsychologistProfileNotFound).Times(1)
+
+		schedule, err := availabilityUsecase.GetWeeklySchedule(ctx, 6, "")
+
+		assert.True(t, errors.Is(err, domain.ErrPsychologistNotFound))
+		assert.Nil(t, schedule)
+	})
+
+	t.Run("Psychologist Deactivated", func(t *testing.T) {
+		deactivatedAt := time.Now().Add(-time.Hour)
+		mockUserRepo.EXPECT().GetByID(ctx, uint(7)).Return(&domain.User{ID: 7, Role: "psikolog", DeactivatedAt: &deactivatedAt}, nil).Times(1)
+
+		schedule, err := availabilityUsecase.GetWeeklySchedule(ctx, 7, "")
+
+		assert.True(t, errors.Is(err, domain.ErrPsychologistNotFound))
+		assert.Nil(t, schedule)
+	})
+
+	t.Run("Own Schedule Before Verification", func(t *testing.T) {
+		mockAvailabilityRepo.EXPECT().GetByPsikologID(ctx, uint(6)).Return(nil, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetSessionSettings(ctx, uint(6)).Return(nil, domain.ErrSessionSettingsNotFound).Times(1)
+
+		schedule, err := availabilityUsecase.GetOwnWeeklySchedule(ctx, 6, "")
+
+		assert.NoError(t, err)
+		assert.Len(t, schedule.Jadwal, 7)
+	})
 }
@@ -0,0 +1,285 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"go.uber.org/zap"
+)
+
+type consultationUsecase struct {
+	consultationRepo domain.ConsultationRepository
+	availabilityRepo domain.AvailabilityRepository
+	userRepo         domain.UserRepository
+	profileRepo      domain.PsychologistProfileRepository
+	location         *time.Location
+	logger           *zap.Logger
+}
+
+// NewConsultationUsecase membuat instance baru dari consultationUsecase.
+func NewConsultationUsecase(
+	cr domain.ConsultationRepository,
+	ar domain.AvailabilityRepository,
+	ur domain.UserRepository,
+	pr domain.PsychologistProfileRepository,
+	location *time.Location,
+	logger *zap.Logger,
+) domain.ConsultationUsecase {
+	return &consultationUsecase{
+		consultationRepo: cr,
+		availabilityRepo: ar,
+		userRepo:         ur,
+		profileRepo:      pr,
+		location:         location,
+		logger:           logger,
+	}
+}
+
+// RequestConsultation memproses permintaan konsultasi dari klien.
+func (uc *consultationUsecase) RequestConsultation(ctx context.Context, klienID uint, payload *domain.RequestKonsultasiPayload) (*domain.Konsultasi, error) {
+	// 1. Validasi bisnis payload
+	if err := payload.Validate(); err != nil {
+		uc.logger.Warn("Payload validation failed", zap.Error(err))
+		return nil, err
+	}
+
+	start, end, err := payload.Schedule(uc.location)
+	if err != nil {
+		return nil, err
+	}
+
+	if !start.After(time.Now()) {
+		return nil, domain.NewDomainError(http.StatusBadRequest, "Consultation must be scheduled in the future")
+	}
+
+	// 2. Pastikan psikolog tujuan ada, terverifikasi, dan izin praktiknya masih berlaku
+	if _, err := loadBookablePsychologist(ctx, uc.userRepo, uc.profileRepo, payload.PsikologID, time.Now().In(uc.location)); err != nil {
+		if errors.Is(err, domain.ErrPsychologistNotFound) {
+			return nil, err
+		}
+		uc.logger.Error("Failed to get psychologist", zap.Error(err), zap.Uint("psikolog_id", payload.PsikologID))
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to request consultation", err)
+	}
+
+	// 3. Waktu yang diminta harus berada di dalam jadwal psikolog setelah pengecualian diterapkan
+	hari := domain.HariFromWeekday(start.Weekday())
+	slots, err := uc.availabilityRepo.GetByPsikologIDAndDay(ctx, payload.PsikologID, hari)
+	if err != nil {
+		uc.logger.Error("Failed to get availability", zap.Error(err), zap.Uint("psikolog_id", payload.PsikologID))
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to request consultation", err)
+	}
+
+	dayStart := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, uc.location)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+	exceptions, err := uc.availabilityRepo.GetExceptions(ctx, payload.PsikologID, dayStart, dayEnd)
+	if err != nil {
+		uc.logger.Error("Failed to get availability exceptions", zap.Error(err), zap.Uint("psikolog_id", payload.PsikologID))
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to request consultation", err)
+	}
+
+	settings, err := loadSessionSettings(ctx, uc.availabilityRepo, payload.PsikologID)
+	if err != nil {
+		uc.logger.Error("Failed to get session settings", zap.Error(err), zap.Uint("psikolog_id", payload.PsikologID))
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to request consultation", err)
+	}
+
+	if end.Sub(start) != settings.SessionDuration() {
+		return nil, domain.NewDomainError(http.StatusUnprocessableEntity,
+			fmt.Sprintf("Consultation must last exactly %d minutes", settings.DurasiSesiMenit))
+	}
+
+	// Waktu yang diminta harus tepat sama dengan salah satu sesi hasil pemotongan jadwal
+	requested := domain.TimeRange{Start: start, End: end}
+	available := domain.ComputeAvailability(slots, exceptions, dayStart, dayEnd, uc.location)
+	matchesSession := false
+	for _, session := range domain.SliceIntoSessions(available, settings.SessionDuration(), settings.Buffer()) {
+		if session.Start.Equal(requested.Start) && session.End.Equal(requested.End) {
+			matchesSession = true
+			break
+		}
+	}
+	if !matchesSession {
+		return nil, domain.NewDomainError(http.StatusUnprocessableEntity,
+			"Requested time is outside the psychologist's availability")
+	}
+
+	// 4. Tidak boleh bertabrakan dengan konsultasi lain, termasuk jeda antar sesi
+	overlapping, err := uc.consultationRepo.CountOverlapping(ctx, payload.PsikologID,
+		start.Add(-settings.Buffer()), end.Add(settings.Buffer()))
+	if err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to request consultation", err)
+	}
+	if overlapping > 0 {
+		return nil, domain.ErrConsultationSlotTaken
+	}
+
+	// 5. Simpan konsultasi dengan status pending
+	konsultasi := &domain.Konsultasi{
+		KlienID:      klienID,
+		PsikologID:   payload.PsikologID,
+		WaktuMulai:   start,
+		WaktuSelesai: end,
+		Status:       domain.KonsultasiStatusPending,
+		Catatan:      payload.Catatan,
+	}
+	// Pemeriksaan di atas bisa kalah cepat dari permintaan lain; constraint di database yang menjadi penentu akhir
+	if err := uc.consultationRepo.Create(ctx, konsultasi); err != nil {
+		if errors.Is(err, domain.ErrConsultationSlotTaken) {
+			return nil, err
+		}
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to request consultation", err)
+	}
+
+	uc.logger.Info("Consultation requested",
+		zap.Uint("id", konsultasi.ID), zap.Uint("klien_id", klienID), zap.Uint("psikolog_id", payload.PsikologID))
+	return konsultasi, nil
+}
+
+// GetConsultationRequests mengambil daftar permintaan konsultasi yang masuk ke psikolog dengan paginasi.
+func (uc *consultationUsecase) GetConsultationRequests(ctx context.Context, psikologID uint, query *domain.ConsultationRequestQuery) (*domain.KonsultasiRequestResult, error) {
+	query.Normalize()
+
+	if query.Status != "" && !domain.IsValidKonsultasiStatus(query.Status) {
+		return nil, domain.NewDomainError(http.StatusBadRequest, fmt.Sprintf("Invalid status: %s", query.Status))
+	}
+
+	konsultasi, total, err := uc.consultationRepo.List(ctx, domain.KonsultasiFilter{
+		PsikologID: psikologID,
+		Status:     query.Status,
+		Limit:      query.Limit,
+		Offset:     query.Offset(),
+	})
+	if err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to retrieve consultation requests", err)
+	}
+
+	return &domain.KonsultasiRequestResult{
+		Items:      konsultasi,
+		Pagination: domain.NewPagination(query.PaginationQuery, total),
+	}, nil
+}
+
+// UpdateConsultationStatus memindahkan status konsultasi milik psikolog sesuai state machine.
+func (uc *consultationUsecase) UpdateConsultationStatus(ctx context.Context, psikologID, id uint, payload *domain.UpdateKonsultasiStatusPayload) (*domain.Konsultasi, error) {
+	konsultasi, err := uc.consultationRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Psikolog hanya boleh mengubah konsultasi miliknya sendiri
+	if konsultasi.PsikologID != psikologID {
+		return nil, domain.ErrConsultationNotFound
+	}
+
+	// Sesi baru bisa dinyatakan selesai atau tidak hadir setelah waktunya dimulai
+	if (payload.Status == domain.KonsultasiStatusCompleted || payload.Status == domain.KonsultasiStatusNoShow) &&
+		time.Now().Before(konsultasi.WaktuMulai) {
+		return nil, domain.NewDomainError(http.StatusConflict,
+			fmt.Sprintf("Consultation cannot be marked as %s before it starts", payload.Status))
+	}
+
+	return uc.transition(ctx, konsultasi, payload.Status, payload.Reason, psikologID)
+}
+
+// CancelConsultation membatalkan konsultasi milik klien yang masih aktif.
+func (uc *consultationUsecase) CancelConsultation(ctx context.Context, klienID, id uint, payload *domain.CancelKonsultasiPayload) (*domain.Konsultasi, error) {
+	konsultasi, err := uc.consultationRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if konsultasi.KlienID != klienID {
+		return nil, domain.ErrConsultationNotFound
+	}
+
+	return uc.transition(ctx, konsultasi, domain.KonsultasiStatusCancelled, payload.Reason, klienID)
+}
+
+// GetClientHistory mengambil riwayat konsultasi klien (lampau maupun mendatang) dengan filter dan paginasi.
+func (uc *consultationUsecase) GetClientHistory(ctx context.Context, klienID uint, query *domain.ConsultationHistoryQuery) (*domain.KonsultasiHistoryResult, error) {
+	query.Normalize()
+
+	filter := domain.KonsultasiFilter{
+		KlienID:    klienID,
+		PsikologID: query.PsikologID,
+		Status:     query.Status,
+		Limit:      query.Limit,
+		Offset:     query.Offset(),
+		Preload:    true,
+	}
+
+	// Rentang tanggal dihitung per hari penuh pada zona waktu aplikasi
+	if query.From != "" {
+		from, err := time.ParseInLocation("2006-01-02", query.From, uc.location)
+		if err != nil {
+			return nil, domain.NewDomainError(http.StatusBadRequest, "Invalid from date format")
+		}
+		filter.From = &from
+	}
+	if query.To != "" {
+		to, err := time.ParseInLocation("2006-01-02", query.To, uc.location)
+		if err != nil {
+			return nil, domain.NewDomainError(http.StatusBadRequest, "Invalid to date format")
+		}
+		to = to.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, domain.NewDomainError(http.StatusBadRequest, "From date must not be after to date")
+	}
+
+	konsultasi, total, err := uc.consultationRepo.List(ctx, filter)
+	if err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to retrieve consultation history", err)
+	}
+
+	items := make([]domain.KonsultasiHistoryItem, 0, len(konsultasi))
+	for i := range konsultasi {
+		items = append(items, domain.KonsultasiHistoryItem{
+			Konsultasi: konsultasi[i],
+			Psikolog:   domain.NewPsychologistPublicProfile(&konsultasi[i].Psikolog),
+		})
+	}
+
+	return &domain.KonsultasiHistoryResult{
+		Items:      items,
+		Pagination: domain.NewPagination(query.PaginationQuery, total),
+	}, nil
+}
+
+// transition menerapkan perpindahan status beserta timestamp dan alasannya.
+func (uc *consultationUsecase) transition(ctx context.Context, konsultasi *domain.Konsultasi, to, reason string, actorID uint) (*domain.Konsultasi, error) {
+	from := konsultasi.Status
+	if !domain.CanTransitionKonsultasi(from, to) {
+		return nil, domain.NewInvalidTransitionError(from, to)
+	}
+
+	now := time.Now()
+	konsultasi.Status = to
+	konsultasi.StatusReason = reason
+	konsultasi.StatusChangedAt = &now
+
+	history := &domain.KonsultasiStatusHistory{
+		KonsultasiID: konsultasi.ID,
+		FromStatus:   from,
+		ToStatus:     to,
+		Reason:       reason,
+		ChangedBy:    actorID,
+	}
+
+	if err := uc.consultationRepo.UpdateStatus(ctx, konsultasi, from, history); err != nil {
+		var domainErr *domain.DomainError
+		if errors.As(err, &domainErr) {
+			return nil, err
+		}
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to update consultation status", err)
+	}
+
+	uc.logger.Info("Consultation status changed",
+		zap.Uint("id", konsultasi.ID), zap.String("from", from), zap.String("to", to), zap.Uint("actor_id", actorID))
+	return konsultasi, nil
+}
@@ -0,0 +1,474 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/internal/mocks"
+	"github.com/X3nonxe/gopsy-backend/internal/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestConsultationUsecase_RequestConsultation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockConsultationRepo := mocks.NewMockConsultationRepository(mockCtrl)
+	mockAvailabilityRepo := mocks.NewMockAvailabilityRepository(mockCtrl)
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	mockProfileRepo := mocks.NewMockPsychologistProfileRepository(mockCtrl)
+
+	location, _ := time.LoadLocation("Asia/Jakarta")
+	consultationUsecase := usecase.NewConsultationUsecase(
+		mockConsultationRepo, mockAvailabilityRepo, mockUserRepo, mockProfileRepo, location, zap.NewNop(),
+	)
+
+	ctx := context.Background()
+	klienID := uint(10)
+	psikolog := &domain.User{ID: 2, Username: "dr.budi", Role: "psikolog"}
+
+	// Gunakan tanggal satu minggu ke depan agar selalu di masa depan
+	tanggal := time.Now().In(location).AddDate(0, 0, 7)
+	hari := domain.HariFromWeekday(tanggal.Weekday())
+
+	payload := &domain.RequestKonsultasiPayload{
+		PsikologID:   psikolog.ID,
+		Tanggal:      tanggal.Format("2006-01-02"),
+		WaktuMulai:   "10:00:00",
+		WaktuSelesai: "11:00:00",
+	}
+	slots := []domain.WaktuKonsultasi{
+		{PsikologID: psikolog.ID, Hari: hari, WaktuMulai: "09:00:00", WaktuSelesai: "12:00:00"},
+	}
+
+	// Psikolog belum mengatur sesi, sehingga durasi sesi bawaan 60 menit yang dipakai
+	mockAvailabilityRepo.EXPECT().
+		GetSessionSettings(ctx, psikolog.ID).
+		Return(nil, domain.ErrSessionSettingsNotFound).
+		AnyTimes()
+	// Profil psikolog tujuan sudah terverifikasi
+	mockProfileRepo.EXPECT().GetByUserID(ctx, psikolog.ID).Return(verifiedProfile(psikolog.ID), nil).AnyTimes()
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(ctx, psikolog.ID).Return(psikolog, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetByPsikologIDAndDay(ctx, psikolog.ID, hari).Return(slots, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetExceptions(ctx, psikolog.ID, gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+		mockConsultationRepo.EXPECT().CountOverlapping(ctx, psikolog.ID, gomock.Any(), gomock.Any()).Return(int64(0), nil).Times(1)
+		mockConsultationRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			Do(func(ctx context.Context, k *domain.Konsultasi) {
+				assert.Equal(t, klienID, k.KlienID)
+				assert.Equal(t, domain.KonsultasiStatusPending, k.Status)
+				assert.Equal(t, time.Hour, k.WaktuSelesai.Sub(k.WaktuMulai))
+			}).
+			Return(nil).
+			Times(1)
+
+		konsultasi, err := consultationUsecase.RequestConsultation(ctx, klienID, payload)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, konsultasi)
+		assert.Equal(t, location, konsultasi.WaktuMulai.Location())
+	})
+
+	t.Run("Outside Availability", func(t *testing.T) {
+		outsidePayload := *payload
+		outsidePayload.WaktuMulai = "11:30:00"
+		outsidePayload.WaktuSelesai = "12:30:00"
+
+		mockUserRepo.EXPECT().GetByID(ctx, psikolog.ID).Return(psikolog, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetByPsikologIDAndDay(ctx, psikolog.ID, hari).Return(slots, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetExceptions(ctx, psikolog.ID, gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+
+		konsultasi, err := consultationUsecase.RequestConsultation(ctx, klienID, &outsidePayload)
+
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusUnprocessableEntity, domainErr.HTTPStatus)
+		assert.Nil(t, konsultasi)
+	})
+
+	t.Run("Does Not Match Session Length", func(t *testing.T) {
+		longPayload := *payload
+		longPayload.WaktuSelesai = "11:30:00"
+
+		mockUserRepo.EXPECT().GetByID(ctx, psikolog.ID).Return(psikolog, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetByPsikologIDAndDay(ctx, psikolog.ID, hari).Return(slots, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetExceptions(ctx, psikolog.ID, gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+
+		konsultasi, err := consultationUsecase.RequestConsultation(ctx, klienID, &longPayload)
+
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusUnprocessableEntity, domainErr.HTTPStatus)
+		assert.Nil(t, konsultasi)
+	})
+
+	t.Run("Not Aligned To Session Grid", func(t *testing.T) {
+		offGridPayload := *payload
+		offGridPayload.WaktuMulai = "09:30:00"
+		offGridPayload.WaktuSelesai = "10:30:00"
+
+		mockUserRepo.EXPECT().GetByID(ctx, psikolog.ID).Return(psikolog, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetByPsikologIDAndDay(ctx, psikolog.ID, hari).Return(slots, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetExceptions(ctx, psikolog.ID, gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+
+		konsultasi, err := consultationUsecase.RequestConsultation(ctx, klienID, &offGridPayload)
+
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusUnprocessableEntity, domainErr.HTTPStatus)
+		assert.Nil(t, konsultasi)
+	})
+
+	t.Run("Blocked By Exception", func(t *testing.T) {
+		blocked := []domain.PengecualianJadwal{
+			{PsikologID: psikolog.ID, Tanggal: tanggal, Jenis: domain.PengecualianFullDay},
+		}
+
+		mockUserRepo.EXPECT().GetByID(ctx, psikolog.ID).Return(psikolog, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetByPsikologIDAndDay(ctx, psikolog.ID, hari).Return(slots, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetExceptions(ctx, psikolog.ID, gomock.Any(), gomock.Any()).Return(blocked, nil).Times(1)
+
+		konsultasi, err := consultationUsecase.RequestConsultation(ctx, klienID, payload)
+
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusUnprocessableEntity, domainErr.HTTPStatus)
+		assert.Nil(t, konsultasi)
+	})
+
+	t.Run("Extra Hours Exception", func(t *testing.T) {
+		extraPayload := *payload
+		extraPayload.WaktuMulai = "18:00:00"
+		extraPayload.WaktuSelesai = "19:00:00"
+
+		mulai, selesai := "17:00:00", "20:00:00"
+		extra := []domain.PengecualianJadwal{
+			{PsikologID: psikolog.ID, Tanggal: tanggal, Jenis: domain.PengecualianExtraHours, WaktuMulai: &mulai, WaktuSelesai: &selesai},
+		}
+
+		mockUserRepo.EXPECT().GetByID(ctx, psikolog.ID).Return(psikolog, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetByPsikologIDAndDay(ctx, psikolog.ID, hari).Return(slots, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetExceptions(ctx, psikolog.ID, gomock.Any(), gomock.Any()).Return(extra, nil).Times(1)
+		mockConsultationRepo.EXPECT().CountOverlapping(ctx, psikolog.ID, gomock.Any(), gomock.Any()).Return(int64(0), nil).Times(1)
+		mockConsultationRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil).Times(1)
+
+		konsultasi, err := consultationUsecase.RequestConsultation(ctx, klienID, &extraPayload)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, konsultasi)
+	})
+
+	t.Run("Collides With Existing Booking", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(ctx, psikolog.ID).Return(psikolog, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetByPsikologIDAndDay(ctx, psikolog.ID, hari).Return(slots, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetExceptions(ctx, psikolog.ID, gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+		mockConsultationRepo.EXPECT().CountOverlapping(ctx, psikolog.ID, gomock.Any(), gomock.Any()).Return(int64(1), nil).Times(1)
+
+		konsultasi, err := consultationUsecase.RequestConsultation(ctx, klienID, payload)
+
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusConflict, domainErr.HTTPStatus)
+		assert.Nil(t, konsultasi)
+	})
+
+	t.Run("Loses Race To Concurrent Booking", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(ctx, psikolog.ID).Return(psikolog, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetByPsikologIDAndDay(ctx, psikolog.ID, hari).Return(slots, nil).Times(1)
+		mockAvailabilityRepo.EXPECT().GetExceptions(ctx, psikolog.ID, gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+		mockConsultationRepo.EXPECT().CountOverlapping(ctx, psikolog.ID, gomock.Any(), gomock.Any()).Return(int64(0), nil).Times(1)
+		mockConsultationRepo.EXPECT().Create(ctx, gomock.Any()).Return(domain.ErrConsultationSlotTaken).Times(1)
+
+		konsultasi, err := consultationUsecase.RequestConsultation(ctx, klienID, payload)
+
+		assert.True(t, errors.Is(err, domain.ErrConsultationSlotTaken))
+		assert.Nil(t, konsultasi)
+	})
+
+	t.Run("Target Is Not A Psychologist", func(t *testing.T) {
+		mockUserRepo.EXPECT().
+			GetByID(ctx, psikolog.ID).
+			Return(&domain.User{ID: psikolog.ID, Role: "klien"}, nil).
+			Times(1)
+
+		konsultasi, err := consultationUsecase.RequestConsultation(ctx, klienID, payload)
+
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusNotFound, domainErr.HTTPStatus)
+		assert.Nil(t, konsultasi)
+	})
+
+	t.Run("Psychologist Not Verified", func(t *testing.T) {
+		unverified := verifiedProfile(3)
+		unverified.VerificationStatus = domain.VerificationStatusPending
+		unverifiedPayload := *payload
+		unverifiedPayload.PsikologID = 3
+
+		mockUserRepo.EXPECT().GetByID(ctx, uint(3)).Return(&domain.User{ID: 3, Role: "psikolog"}, nil).Times(1)
+		mockProfileRepo.EXPECT().GetByUserID(ctx, uint(3)).Return(unverified, nil).Times(1)
+
+		konsultasi, err := consultationUsecase.RequestConsultation(ctx, klienID, &unverifiedPayload)
+
+		assert.True(t, errors.Is(err, domain.ErrPsychologistNotFound))
+		assert.Nil(t, konsultasi)
+	})
+
+	t.Run("Date In The Past", func(t *testing.T) {
+		pastPayload := *payload
+		pastPayload.Tanggal = time.Now().In(location).AddDate(0, 0, -1).Format("2006-01-02")
+
+		konsultasi, err := consultationUsecase.RequestConsultation(ctx, klienID, &pastPayload)
+
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusBadRequest, domainErr.HTTPStatus)
+		assert.Nil(t, konsultasi)
+	})
+}
+
+func TestConsultationUsecase_UpdateConsultationStatus(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockConsultationRepo := mocks.NewMockConsultationRepository(mockCtrl)
+	consultationUsecase := usecase.NewConsultationUsecase(
+		mockConsultationRepo, mocks.NewMockAvailabilityRepository(mockCtrl), mocks.NewMockUserRepository(mockCtrl),
+		mocks.NewMockPsychologistProfileRepository(mockCtrl),
+		time.UTC, zap.NewNop(),
+	)
+
+	ctx := context.Background()
+	psikologID := uint(2)
+
+	newKonsultasi := func(status string, start time.Time) *domain.Konsultasi {
+		return &domain.Konsultasi{
+			ID:           7,
+			KlienID:      10,
+			PsikologID:   psikologID,
+			WaktuMulai:   start,
+			WaktuSelesai: start.Add(time.Hour),
+			Status:       status,
+		}
+	}
+
+	t.Run("Accept Pending Request", func(t *testing.T) {
+		mockConsultationRepo.EXPECT().
+			GetByID(ctx, uint(7)).
+			Return(newKonsultasi(domain.KonsultasiStatusPending, time.Now().Add(24*time.Hour)), nil).
+			Times(1)
+		mockConsultationRepo.EXPECT().
+			UpdateStatus(ctx, gomock.Any(), domain.KonsultasiStatusPending, gomock.Any()).
+			Do(func(ctx context.Context, k *domain.Konsultasi, from string, h *domain.KonsultasiStatusHistory) {
+				assert.Equal(t, domain.KonsultasiStatusAccepted, k.Status)
+				assert.NotNil(t, k.StatusChangedAt)
+				assert.Equal(t, "See you", h.Reason)
+				assert.Equal(t, psikologID, h.ChangedBy)
+			}).
+			Return(nil).
+			Times(1)
+
+		payload := &domain.UpdateKonsultasiStatusPayload{Status: domain.KonsultasiStatusAccepted, Reason: "See you"}
+		konsultasi, err := consultationUsecase.UpdateConsultationStatus(ctx, psikologID, 7, payload)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.KonsultasiStatusAccepted, konsultasi.Status)
+	})
+
+	t.Run("Illegal Transition Returns Conflict", func(t *testing.T) {
+		mockConsultationRepo.EXPECT().
+			GetByID(ctx, uint(7)).
+			Return(newKonsultasi(domain.KonsultasiStatusRejected, time.Now().Add(24*time.Hour)), nil).
+			Times(1)
+
+		payload := &domain.UpdateKonsultasiStatusPayload{Status: domain.KonsultasiStatusAccepted}
+		konsultasi, err := consultationUsecase.UpdateConsultationStatus(ctx, psikologID, 7, payload)
+
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusConflict, domainErr.HTTPStatus)
+		assert.Nil(t, konsultasi)
+	})
+
+	t.Run("Complete Before Session Starts", func(t *testing.T) {
+		mockConsultationRepo.EXPECT().
+			GetByID(ctx, uint(7)).
+			Return(newKonsultasi(domain.KonsultasiStatusAccepted, time.Now().Add(24*time.Hour)), nil).
+			Times(1)
+
+		payload := &domain.UpdateKonsultasiStatusPayload{Status: domain.KonsultasiStatusCompleted}
+		_, err := consultationUsecase.UpdateConsultationStatus(ctx, psikologID, 7, payload)
+
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusConflict, domainErr.HTTPStatus)
+	})
+
+	t.Run("Other Psychologist's Consultation", func(t *testing.T) {
+		mockConsultationRepo.EXPECT().
+			GetByID(ctx, uint(7)).
+			Return(newKonsultasi(domain.KonsultasiStatusPending, time.Now().Add(24*time.Hour)), nil).
+			Times(1)
+
+		payload := &domain.UpdateKonsultasiStatusPayload{Status: domain.KonsultasiStatusAccepted}
+		_, err := consultationUsecase.UpdateConsultationStatus(ctx, uint(99), 7, payload)
+
+		assert.True(t, errors.Is(err, domain.ErrConsultationNotFound))
+	})
+}
+
+func TestCanTransitionKonsultasi(t *testing.T) {
+	assert.True(t, domain.CanTransitionKonsultasi(domain.KonsultasiStatusPending, domain.KonsultasiStatusAccepted))
+	assert.True(t, domain.CanTransitionKonsultasi(domain.KonsultasiStatusPending, domain.KonsultasiStatusRejected))
+	assert.True(t, domain.CanTransitionKonsultasi(domain.KonsultasiStatusAccepted, domain.KonsultasiStatusNoShow))
+	assert.True(t, domain.CanTransitionKonsultasi(domain.KonsultasiStatusAccepted, domain.KonsultasiStatusCancelled))
+	assert.False(t, domain.CanTransitionKonsultasi(domain.KonsultasiStatusPending, domain.KonsultasiStatusCompleted))
+	assert.False(t, domain.CanTransitionKonsultasi(domain.KonsultasiStatusCompleted, domain.KonsultasiStatusCancelled))
+	assert.False(t, domain.CanTransitionKonsultasi(domain.KonsultasiStatusCancelled, domain.KonsultasiStatusPending))
+}
+
+func TestConsultationUsecase_GetConsultationRequests(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockConsultationRepo := mocks.NewMockConsultationRepository(mockCtrl)
+	consultationUsecase := usecase.NewConsultationUsecase(
+		mockConsultationRepo, mocks.NewMockAvailabilityRepository(mockCtrl), mocks.NewMockUserRepository(mockCtrl),
+		mocks.NewMockPsychologistProfileRepository(mockCtrl),
+		time.UTC, zap.NewNop(),
+	)
+
+	ctx := context.Background()
+	psikologID := uint(2)
+
+	t.Run("Filters And Paginates", func(t *testing.T) {
+		query := &domain.ConsultationRequestQuery{
+			PaginationQuery: domain.PaginationQuery{Page: 3, Limit: 10},
+			Status:          domain.KonsultasiStatusPending,
+		}
+
+		rows := []domain.Konsultasi{{ID: 21, KlienID: 10, PsikologID: psikologID, Status: domain.KonsultasiStatusPending}}
+
+		mockConsultationRepo.EXPECT().
+			List(ctx, gomock.Any()).
+			Do(func(ctx context.Context, filter domain.KonsultasiFilter) {
+				assert.Equal(t, psikologID, filter.PsikologID)
+				assert.Equal(t, domain.KonsultasiStatusPending, filter.Status)
+				assert.Equal(t, 10, filter.Limit)
+				assert.Equal(t, 20, filter.Offset)
+			}).
+			Return(rows, int64(21), nil).
+			Times(1)
+
+		result, err := consultationUsecase.GetConsultationRequests(ctx, psikologID, query)
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Items, 1)
+		assert.Equal(t, int64(21), result.Pagination.Total)
+		assert.Equal(t, 3, result.Pagination.TotalPages)
+	})
+
+	t.Run("Default Page", func(t *testing.T) {
+		mockConsultationRepo.EXPECT().
+			List(ctx, gomock.Any()).
+			Do(func(ctx context.Context, filter domain.KonsultasiFilter) {
+				assert.Equal(t, domain.DefaultPageLimit, filter.Limit)
+				assert.Equal(t, 0, filter.Offset)
+			}).
+			Return(nil, int64(0), nil).
+			Times(1)
+
+		result, err := consultationUsecase.GetConsultationRequests(ctx, psikologID, &domain.ConsultationRequestQuery{})
+
+		assert.NoError(t, err)
+		assert.Empty(t, result.Items)
+		assert.Equal(t, 1, result.Pagination.Page)
+	})
+
+	t.Run("Invalid Status", func(t *testing.T) {
+		result, err := consultationUsecase.GetConsultationRequests(ctx, psikologID, &domain.ConsultationRequestQuery{Status: "unknown"})
+
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusBadRequest, domainErr.HTTPStatus)
+		assert.Nil(t, result)
+	})
+}
+
+func TestConsultationUsecase_GetClientHistory(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockConsultationRepo := mocks.NewMockConsultationRepository(mockCtrl)
+	location, _ := time.LoadLocation("Asia/Jakarta")
+	consultationUsecase := usecase.NewConsultationUsecase(
+		mockConsultationRepo, mocks.NewMockAvailabilityRepository(mockCtrl), mocks.NewMockUserRepository(mockCtrl),
+		mocks.NewMockPsychologistProfileRepository(mockCtrl),
+		location, zap.NewNop(),
+	)
+
+	ctx := context.Background()
+	klienID := uint(10)
+
+	t.Run("Filters And Paginates", func(t *testing.T) {
+		query := &domain.ConsultationHistoryQuery{
+			PaginationQuery: domain.PaginationQuery{Page: 2, Limit: 5},
+			Status:          domain.KonsultasiStatusCompleted,
+			PsikologID:      2,
+			From:            "2025-01-01",
+			To:              "2025-01-31",
+		}
+
+		rows := []domain.Konsultasi{
+			{
+				ID:         1,
+				KlienID:    klienID,
+				PsikologID: 2,
+				Status:     domain.KonsultasiStatusCompleted,
+				Psikolog:   domain.User{ID: 2, Username: "dr.budi", Email: "budi@test.com", Password: "hash"},
+			},
+		}
+
+		mockConsultationRepo.EXPECT().
+			List(ctx, gomock.Any()).
+			Do(func(ctx context.Context, filter domain.KonsultasiFilter) {
+				assert.Equal(t, klienID, filter.KlienID)
+				assert.Equal(t, uint(2), filter.PsikologID)
+				assert.Equal(t, domain.KonsultasiStatusCompleted, filter.Status)
+				assert.Equal(t, 5, filter.Limit)
+				assert.Equal(t, 5, filter.Offset)
+				assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, location), *filter.From)
+				assert.Equal(t, time.Date(2025, 2, 1, 0, 0, 0, 0, location), *filter.To)
+				assert.True(t, filter.Preload)
+			}).
+			Return(rows, int64(6), nil).
+			Times(1)
+
+		result, err := consultationUsecase.GetClientHistory(ctx, klienID, query)
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Items, 1)
+		assert.Equal(t, "dr.budi", result.Items[0].Psikolog.Username)
+		assert.Equal(t, int64(6), result.Pagination.Total)
+		assert.Equal(t, 2, result.Pagination.TotalPages)
+	})
+
+	t.Run("Invalid Date Range", func(t *testing.T) {
+		query := &domain.ConsultationHistoryQuery{From: "2025-02-01", To: "2025-01-01"}
+
+		result, err := consultationUsecase.GetClientHistory(ctx, klienID, query)
+
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusBadRequest, domainErr.HTTPStatus)
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,472 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// dataExportTimeout membatasi lama pembuatan arsip. Ekspor yang masih diproses setelah itu
+	// dianggap gagal, misalnya karena server berhenti saat arsip sedang dibuat.
+	dataExportTimeout = 30 * time.Minute
+	// dataExportPurgeBatch adalah jumlah arsip kedaluwarsa yang dihapus per pemanggilan.
+	dataExportPurgeBatch = 100
+	// dataExportResumeBatch adalah jumlah ekspor pending yang diambil alih per pemanggilan.
+	dataExportResumeBatch = 20
+	dataExportContentType = "application/zip"
+)
+
+type dataPrivacyUsecase struct {
+	dataPrivacyRepo  domain.DataPrivacyRepository
+	userRepo         domain.UserRepository
+	consultationRepo domain.ConsultationRepository
+	authUsecase      domain.AuthUsecase
+	storage          domain.FileStorage
+	mailer           domain.Mailer
+	exportTTL        time.Duration
+	urlTTL           time.Duration
+	logger           *zap.Logger
+}
+
+// NewDataPrivacyUsecase membuat usecase ekspor dan penghapusan data pribadi. exportTTL adalah
+// lama arsip disimpan setelah selesai dibuat dan urlTTL adalah masa berlaku link unduhannya.
+func NewDataPrivacyUsecase(
+	dpr domain.DataPrivacyRepository,
+	ur domain.UserRepository,
+	cr domain.ConsultationRepository,
+	au domain.AuthUsecase,
+	storage domain.FileStorage,
+	mailer domain.Mailer,
+	exportTTL time.Duration,
+	urlTTL time.Duration,
+	logger *zap.Logger,
+) domain.DataPrivacyUsecase {
+	return &dataPrivacyUsecase{
+		dataPrivacyRepo:  dpr,
+		userRepo:         ur,
+		consultationRepo: cr,
+		authUsecase:      au,
+		storage:          storage,
+		mailer:           mailer,
+		exportTTL:        exportTTL,
+		urlTTL:           urlTTL,
+		logger:           logger,
+	}
+}
+
+func dataExportKey(id string) string {
+	return "data-exports/" + id + "/gopsy-data-export.zip"
+}
+
+// RequestExport mencatat permintaan ekspor lalu membuat arsipnya di latar belakang. User diberi
+// tahu lewat email ketika arsip siap diunduh.
+func (uc *dataPrivacyUsecase) RequestExport(ctx context.Context, userID uint) (*domain.DataExportResponse, error) {
+	user, err := uc.getUser(ctx, userID, "Failed to request data export")
+	if err != nil {
+		return nil, err
+	}
+
+	exports, err := uc.dataPrivacyRepo.ListExports(ctx, userID)
+	if err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to request data export", err)
+	}
+	now := time.Now()
+	for i := range exports {
+		uc.refreshStatus(ctx, &exports[i], now)
+		if exports[i].IsActive() {
+			return nil, domain.ErrDataExportInProgress
+		}
+	}
+
+	export := &domain.DataExport{
+		ID:     uuid.NewString(),
+		UserID: userID,
+		Status: domain.DataExportStatusPending,
+	}
+	if err := uc.dataPrivacyRepo.CreateExport(ctx, export); err != nil {
+		if errors.Is(err, domain.ErrDataExportInProgress) {
+			return nil, err
+		}
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to request data export", err)
+	}
+
+	// Goroutine memakai salinan agar response tidak ikut berubah selama arsip dibuat. Jika server
+	// berhenti sebelum goroutine mengambil alih ekspor, ResumePendingExports yang melanjutkannya.
+	job := *export
+	go uc.generate(context.WithoutCancel(ctx), &job, user)
+
+	uc.logger.Info("Data export requested", zap.Uint("user_id", userID), zap.String("export_id", export.ID))
+	return &domain.DataExportResponse{DataExport: export}, nil
+}
+
+// ListExports menampilkan semua ekspor milik user beserta link unduhan arsip yang siap.
+func (uc *dataPrivacyUsecase) ListExports(ctx context.Context, userID uint) ([]domain.DataExportResponse, error) {
+	exports, err := uc.dataPrivacyRepo.ListExports(ctx, userID)
+	if err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to retrieve data exports", err)
+	}
+
+	now := time.Now()
+	responses := make([]domain.DataExportResponse, 0, len(exports))
+	for i := range exports {
+		uc.refreshStatus(ctx, &exports[i], now)
+		responses = append(responses, *uc.newResponse(&exports[i], now))
+	}
+	return responses, nil
+}
+
+// GetExport menampilkan satu ekspor milik user beserta link unduhannya jika arsip sudah siap.
+func (uc *dataPrivacyUsecase) GetExport(ctx context.Context, userID uint, id string) (*domain.DataExportResponse, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, domain.ErrDataExportNotFound
+	}
+
+	export, err := uc.dataPrivacyRepo.GetExport(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrDataExportNotFound) {
+			return nil, err
+		}
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to retrieve data export", err)
+	}
+
+	now := time.Now()
+	uc.refreshStatus(ctx, export, now)
+	return uc.newResponse(export, now), nil
+}
+
+// PurgeExpiredExports menghapus file arsip yang sudah kedaluwarsa dan menandai ekspornya expired.
+// Arsip yang gagal dihapus dicoba lagi pada pemanggilan berikutnya.
+func (uc *dataPrivacyUsecase) PurgeExpiredExports(ctx context.Context) (int, error) {
+	exports, err := uc.dataPrivacyRepo.ListExpiredExports(ctx, time.Now(), dataExportPurgeBatch)
+	if err != nil {
+		return 0, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to purge data exports", err)
+	}
+
+	purged := 0
+	for i := range exports {
+		export := &exports[i]
+		if export.FileKey != nil {
+			if err := uc.storage.Delete(ctx, *export.FileKey); err != nil {
+				uc.logger.Warn("Failed to delete expired data export", zap.String("export_id", export.ID), zap.Error(err))
+				continue
+			}
+		}
+
+		export.Status = domain.DataExportStatusExpired
+		export.FileKey = nil
+		err := uc.dataPrivacyRepo.UpdateExport(ctx, export, domain.DataExportStatusReady)
+		if err != nil && !errors.Is(err, domain.ErrDataExportStatusChanged) {
+			uc.logger.Warn("Failed to mark data export as expired", zap.String("export_id", export.ID), zap.Error(err))
+			continue
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// ResumePendingExports membuat arsip untuk ekspor pending satu per satu. Setiap ekspor diambil
+// alih lewat perubahan status bersyarat, sehingga ekspor yang sudah dikerjakan goroutine
+// RequestExport atau instance lain dilewati.
+func (uc *dataPrivacyUsecase) ResumePendingExports(ctx context.Context) (int, error) {
+	exports, err := uc.dataPrivacyRepo.ListPendingExports(ctx, dataExportResumeBatch)
+	if err != nil {
+		return 0, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to resume data exports", err)
+	}
+
+	resumed := 0
+	for i := range exports {
+		export := &exports[i]
+		user, err := uc.userRepo.GetByID(ctx, export.UserID)
+		if err != nil {
+			uc.logger.Warn("Failed to load user for pending data export", zap.String("export_id", export.ID), zap.Error(err))
+			continue
+		}
+		if uc.generate(ctx, export, user) {
+			resumed++
+		}
+	}
+	return resumed, nil
+}
+
+// EraseAccount menghapus akun atas permintaan pemiliknya. Router hanya mengizinkan role dengan
+// permission account:delete, yang secara bawaan hanya dimiliki klien karena akun psikolog dan admin
+// terikat pada catatan praktik dan ditutup lewat admin. Data identitas dianonimkan dan
+// data yang tidak wajib disimpan dihapus, sedangkan konsultasi beserta riwayat statusnya tetap
+// disimpan sebagai catatan klinis dan keuangan tanpa menunjuk ke identitas klien. Pemeriksaan
+// konsultasi mendatang diulang di dalam transaksi EraseUser agar booking yang bersamaan tidak lolos.
+func (uc *dataPrivacyUsecase) EraseAccount(ctx context.Context, userID uint, payload *domain.EraseAccountPayload) error {
+	user, err := uc.getUser(ctx, userID, "Failed to delete account")
+	if err != nil {
+		return err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(payload.Password)); err != nil {
+		return domain.ErrIncorrectCurrentPassword
+	}
+
+	now := time.Now()
+	_, upcoming, err := uc.consultationRepo.List(ctx, domain.KonsultasiFilter{
+		KlienID:  userID,
+		Statuses: domain.ActiveKonsultasiStatuses,
+		From:     &now,
+		Limit:    1,
+	})
+	if err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to delete account", err)
+	}
+	if upcoming > 0 {
+		return domain.ErrAccountHasActiveConsultations
+	}
+
+	exports, err := uc.dataPrivacyRepo.ListExports(ctx, userID)
+	if err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to delete account", err)
+	}
+
+	// Sesi dicabut lebih dulu: jika penghapusan gagal, user cukup login ulang, sedangkan akun yang
+	// sudah terhapus tidak pernah menyisakan token yang masih berlaku
+	if err := uc.authUsecase.LogoutAll(ctx, userID); err != nil {
+		return err
+	}
+	if err := uc.dataPrivacyRepo.EraseUser(ctx, userID, now); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAccountHasActiveConsultations):
+			return err
+		case errors.Is(err, domain.ErrUserNotFound):
+			return domain.NewDomainError(http.StatusNotFound, "User not found")
+		}
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to delete account", err)
+	}
+
+	// File dihapus setelah database agar kegagalan di tengah jalan tidak menyisakan akun tanpa foto
+	if user.ProfilePictureKey != nil {
+		for _, size := range domain.ProfilePictureSizes {
+			uc.deleteFile(ctx, profilePictureFile(*user.ProfilePictureKey, size))
+		}
+	}
+	for _, export := range exports {
+		if export.FileKey != nil {
+			uc.deleteFile(ctx, *export.FileKey)
+		}
+	}
+
+	uc.logger.Info("Account erased at user request", zap.Uint("user_id", userID))
+	return nil
+}
+
+// generate mengambil alih ekspor pending, membuat arsipnya, menyimpannya, lalu memberi tahu user
+// lewat email. Hasilnya false jika ekspor sudah diambil alih proses lain.
+func (uc *dataPrivacyUsecase) generate(ctx context.Context, export *domain.DataExport, user *domain.User) bool {
+	ctx, cancel := context.WithTimeout(ctx, dataExportTimeout)
+	defer cancel()
+
+	export.Status = domain.DataExportStatusProcessing
+	if err := uc.dataPrivacyRepo.UpdateExport(ctx, export, domain.DataExportStatusPending); err != nil {
+		if !errors.Is(err, domain.ErrDataExportStatusChanged) {
+			uc.logger.Error("Failed to start data export", zap.String("export_id", export.ID), zap.Error(err))
+		}
+		return false
+	}
+
+	archive, err := uc.dataPrivacyRepo.CollectPersonalData(ctx, export.UserID)
+	if err != nil {
+		uc.failExport(ctx, export, err)
+		return true
+	}
+
+	generatedAt := time.Now()
+	data, err := buildDataExportArchive(export.ID, archive, generatedAt)
+	if err != nil {
+		uc.failExport(ctx, export, err)
+		return true
+	}
+
+	key := dataExportKey(export.ID)
+	if err := uc.storage.Put(ctx, key, dataExportContentType, data); err != nil {
+		uc.failExport(ctx, export, err)
+		return true
+	}
+
+	expiresAt := generatedAt.Add(uc.exportTTL)
+	export.Status = domain.DataExportStatusReady
+	export.FileKey = &key
+	export.SizeBytes = int64(len(data))
+	export.CompletedAt = &generatedAt
+	export.ExpiresAt = &expiresAt
+	if err := uc.dataPrivacyRepo.UpdateExport(ctx, export, domain.DataExportStatusProcessing); err != nil {
+		// Arsip yang ekspornya tidak tercatat tidak akan pernah dihapus, jadi langsung dibuang
+		uc.logger.Error("Failed to complete data export", zap.String("export_id", export.ID), zap.Error(err))
+		uc.deleteFile(ctx, key)
+		return true
+	}
+
+	uc.logger.Info("Data export ready", zap.Uint("user_id", export.UserID), zap.String("export_id", export.ID))
+
+	message := &domain.EmailMessage{
+		To:      user.Email,
+		Subject: "Your Gopsy data export is ready",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nThe copy of your personal data you requested is ready. Sign in to Gopsy and open your "+
+				"data exports to download it. The archive is available until %s.\n\n"+
+				"If you did not request this export, please change your password right away.\n",
+			user.Username, expiresAt.Format("2 January 2006 15:04 MST"),
+		),
+	}
+	if err := uc.mailer.Send(ctx, message); err != nil {
+		uc.logger.Error("Failed to send data export email", zap.Error(err), zap.Uint("user_id", export.UserID))
+	}
+	return true
+}
+
+func (uc *dataPrivacyUsecase) failExport(ctx context.Context, export *domain.DataExport, cause error) {
+	uc.logger.Error("Failed to generate data export", zap.String("export_id", export.ID), zap.Error(cause))
+
+	export.Status = domain.DataExportStatusFailed
+	err := uc.dataPrivacyRepo.UpdateExport(ctx, export, domain.DataExportStatusProcessing)
+	if err != nil && !errors.Is(err, domain.ErrDataExportStatusChanged) {
+		uc.logger.Error("Failed to mark data export as failed", zap.String("export_id", export.ID), zap.Error(err))
+	}
+}
+
+// refreshStatus menandai ekspor yang diproses lebih lama dari dataExportTimeout sebagai gagal agar
+// user bisa meminta ekspor baru. Ekspor pending tidak pernah dianggap macet karena selalu diambil
+// alih oleh ResumePendingExports.
+func (uc *dataPrivacyUsecase) refreshStatus(ctx context.Context, export *domain.DataExport, now time.Time) {
+	if export.Status != domain.DataExportStatusProcessing || now.Sub(export.UpdatedAt) < dataExportTimeout {
+		return
+	}
+
+	expected := export.Status
+	export.Status = domain.DataExportStatusFailed
+	err := uc.dataPrivacyRepo.UpdateExport(ctx, export, expected)
+	if err == nil {
+		return
+	}
+	if !errors.Is(err, domain.ErrDataExportStatusChanged) {
+		uc.logger.Warn("Failed to mark stale data export as failed", zap.String("export_id", export.ID), zap.Error(err))
+		export.Status = expected
+		return
+	}
+
+	// Ekspor selesai atau berubah tepat sebelum ditandai gagal; tampilkan status terbarunya
+	current, err := uc.dataPrivacyRepo.GetExport(ctx, export.UserID, export.ID)
+	if err != nil {
+		export.Status = expected
+		return
+	}
+	*export = *current
+}
+
+// newResponse menandatangani link unduhan arsip yang siap. Link tidak pernah berlaku melewati
+// masa simpan arsip; arsip yang sudah lewat masa simpannya ditampilkan sebagai expired meskipun
+// filenya belum dihapus PurgeExpiredExports.
+func (uc *dataPrivacyUsecase) newResponse(export *domain.DataExport, now time.Time) *domain.DataExportResponse {
+	response := &domain.DataExportResponse{DataExport: export}
+	if export.Status != domain.DataExportStatusReady || export.FileKey == nil || export.ExpiresAt == nil {
+		return response
+	}
+
+	remaining := export.ExpiresAt.Sub(now)
+	if remaining <= 0 {
+		export.Status = domain.DataExportStatusExpired
+		return response
+	}
+
+	ttl := uc.urlTTL
+	if remaining < ttl {
+		ttl = remaining
+	}
+	url, err := uc.storage.SignedURL(*export.FileKey, ttl)
+	if err != nil {
+		uc.logger.Error("Failed to sign data export URL", zap.String("export_id", export.ID), zap.Error(err))
+		return response
+	}
+	response.DownloadURL = url
+	return response
+}
+
+func (uc *dataPrivacyUsecase) getUser(ctx context.Context, userID uint, failureMessage string) (*domain.User, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.NewDomainError(http.StatusNotFound, "User not found")
+		}
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, failureMessage, err)
+	}
+	return user, nil
+}
+
+// deleteFile menghapus file yang tidak lagi dipakai. File yatim hanya memakan ruang, jadi
+// kegagalan dicatat tanpa menggagalkan proses.
+func (uc *dataPrivacyUsecase) deleteFile(ctx context.Context, key string) {
+	if err := uc.storage.Delete(ctx, key); err != nil {
+		uc.logger.Warn("Failed to delete file", zap.String("key", key), zap.Error(err))
+	}
+}
+
+// dataExportManifest adalah isi manifest.json yang menjelaskan arsip ekspor.
+type dataExportManifest struct {
+	ExportID    string    `json:"export_id"`
+	UserID      uint      `json:"user_id"`
+	GeneratedAt time.Time `json:"generated_at"`
+	Files       []string  `json:"files"`
+}
+
+// buildDataExportArchive menulis setiap bagian data pribadi sebagai file JSON terpisah di dalam
+// arsip zip. Bagian yang tidak dimiliki user, misalnya profil profesional milik klien, dilewati.
+func buildDataExportArchive(exportID string, archive *domain.PersonalDataArchive, generatedAt time.Time) ([]byte, error) {
+	type section struct {
+		name    string
+		content interface{}
+	}
+	sections := []section{
+		{"profile.json", archive.Profile},
+		{"consultations.json", archive.Consultations},
+		{"sessions.json", archive.Sessions},
+	}
+	if archive.PsychologistProfile != nil {
+		sections = append(sections, section{"psychologist_profile.json", archive.PsychologistProfile})
+	}
+	if archive.Availability != nil {
+		sections = append(sections, section{"availability.json", archive.Availability})
+	}
+	if archive.MFA != nil {
+		sections = append(sections, section{"mfa.json", archive.MFA})
+	}
+
+	manifest := dataExportManifest{ExportID: exportID, UserID: archive.Profile.ID, GeneratedAt: generatedAt}
+	for _, s := range sections {
+		manifest.Files = append(manifest.Files, s.name)
+	}
+	sections = append([]section{{"manifest.json", manifest}}, sections...)
+
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for _, s := range sections {
+		content, err := json.MarshalIndent(s.content, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", s.name, err)
+		}
+		file, err := writer.CreateHeader(&zip.FileHeader{Name: s.name, Method: zip.Deflate, Modified: generatedAt})
+		if err != nil {
+			return nil, fmt.Errorf("failed to add %s: %w", s.name, err)
+		}
+		if _, err := file.Write(content); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", s.name, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,423 @@
+package usecase_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/internal/mocks"
+	"github.com/X3nonxe/gopsy-backend/internal/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type dataPrivacyMocks struct {
+	privacyRepo      *mocks.MockDataPrivacyRepository
+	userRepo         *mocks.MockUserRepository
+	consultationRepo *mocks.MockConsultationRepository
+	auth             *mocks.MockAuthUsecase
+	storage          *mocks.MockFileStorage
+	mailer           *mocks.MockMailer
+}
+
+func newDataPrivacyUsecase(mockCtrl *gomock.Controller) (domain.DataPrivacyUsecase, *dataPrivacyMocks) {
+	m := &dataPrivacyMocks{
+		privacyRepo:      mocks.NewMockDataPrivacyRepository(mockCtrl),
+		userRepo:         mocks.NewMockUserRepository(mockCtrl),
+		consultationRepo: mocks.NewMockConsultationRepository(mockCtrl),
+		auth:             mocks.NewMockAuthUsecase(mockCtrl),
+		storage:          mocks.NewMockFileStorage(mockCtrl),
+		mailer:           mocks.NewMockMailer(mockCtrl),
+	}
+	uc := usecase.NewDataPrivacyUsecase(m.privacyRepo, m.userRepo, m.consultationRepo, m.auth, m.storage, m.mailer,
+		72*time.Hour, 15*time.Minute, zap.NewNop())
+	return uc, m
+}
+
+// readZip membaca semua file di dalam arsip zip.
+func readZip(t *testing.T, data []byte) map[string][]byte {
+	t.Helper()
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	files := make(map[string][]byte, len(reader.File))
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		require.NoError(t, err)
+		files[file.Name] = content
+	}
+	return files
+}
+
+func TestDataPrivacyUsecase_RequestExport(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	privacyUsecase, m := newDataPrivacyUsecase(mockCtrl)
+	ctx := context.Background()
+	phone := "+6281234567890"
+	user := &domain.User{ID: 1, Username: "budi", Email: "budi@example.com", PhoneNumber: &phone, Role: domain.RoleKlien}
+
+	t.Run("Generates Archive In Background", func(t *testing.T) {
+		done := make(chan struct{}, 1)
+		var (
+			statuses []string
+			archive  []byte
+		)
+
+		m.userRepo.EXPECT().GetByID(ctx, user.ID).Return(user, nil).Times(1)
+		m.privacyRepo.EXPECT().ListExports(ctx, user.ID).Return(nil, nil).Times(1)
+		m.privacyRepo.EXPECT().
+			CreateExport(ctx, gomock.Any()).
+			Do(func(ctx context.Context, export *domain.DataExport) {
+				assert.Equal(t, domain.DataExportStatusPending, export.Status)
+				assert.NotEmpty(t, export.ID)
+			}).
+			Return(nil).
+			Times(1)
+		m.privacyRepo.EXPECT().
+			UpdateExport(gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(ctx context.Context, export *domain.DataExport, expectedStatus string) {
+				statuses = append(statuses, expectedStatus+"->"+export.Status)
+			}).
+			Return(nil).
+			Times(2)
+		m.privacyRepo.EXPECT().
+			CollectPersonalData(gomock.Any(), user.ID).
+			Return(&domain.PersonalDataArchive{
+				Profile: user,
+				Consultations: []domain.ExportedConsultation{{
+					Konsultasi:    domain.Konsultasi{ID: 7, KlienID: 1, PsikologID: 2, Status: domain.KonsultasiStatusCompleted},
+					Role:          domain.RoleKlien,
+					StatusHistory: []domain.KonsultasiStatusHistory{},
+				}},
+				Sessions: []domain.Session{},
+			}, nil).
+			Times(1)
+		m.storage.EXPECT().
+			Put(gomock.Any(), gomock.Any(), "application/zip", gomock.Any()).
+			Do(func(ctx context.Context, key, contentType string, data []byte) {
+				assert.Regexp(t, `^data-exports/[0-9a-f-]{36}/gopsy-data-export\.zip$`, key)
+				archive = data
+			}).
+			Return(nil).
+			Times(1)
+		m.mailer.EXPECT().
+			Send(gomock.Any(), gomock.Any()).
+			Do(func(ctx context.Context, message *domain.EmailMessage) {
+				assert.Equal(t, user.Email, message.To)
+				done <- struct{}{}
+			}).
+			Return(nil).
+			Times(1)
+
+		export, err := privacyUsecase.RequestExport(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.DataExportStatusPending, export.Status)
+		assert.Empty(t, export.DownloadURL)
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("data export was not generated")
+		}
+
+		assert.Equal(t, []string{"pending->processing", "processing->ready"}, statuses)
+
+		files := readZip(t, archive)
+		assert.Contains(t, files, "manifest.json")
+		assert.Contains(t, files, "sessions.json")
+		assert.NotContains(t, files, "psychologist_profile.json")
+		assert.NotContains(t, string(files["profile.json"]), "password")
+		assert.Contains(t, string(files["profile.json"]), phone)
+
+		var consultations []map[string]interface{}
+		require.NoError(t, json.Unmarshal(files["consultations.json"], &consultations))
+		require.Len(t, consultations, 1)
+		assert.Equal(t, domain.RoleKlien, consultations[0]["role"])
+	})
+
+	t.Run("Export Already In Progress", func(t *testing.T) {
+		m.userRepo.EXPECT().GetByID(ctx, user.ID).Return(user, nil).Times(1)
+		m.privacyRepo.EXPECT().
+			ListExports(ctx, user.ID).
+			Return([]domain.DataExport{{ID: "a", UserID: 1, Status: domain.DataExportStatusProcessing, UpdatedAt: time.Now().Add(-time.Minute)}}, nil).
+			Times(1)
+
+		_, err := privacyUsecase.RequestExport(ctx, user.ID)
+
+		assert.True(t, errors.Is(err, domain.ErrDataExportInProgress))
+	})
+
+	t.Run("Stale Export Is Marked Failed", func(t *testing.T) {
+		m.userRepo.EXPECT().GetByID(ctx, user.ID).Return(user, nil).Times(1)
+		m.privacyRepo.EXPECT().
+			ListExports(ctx, user.ID).
+			Return([]domain.DataExport{{ID: "a", UserID: 1, Status: domain.DataExportStatusProcessing, UpdatedAt: time.Now().Add(-time.Hour)}}, nil).
+			Times(1)
+		m.privacyRepo.EXPECT().
+			UpdateExport(ctx, gomock.Any(), domain.DataExportStatusProcessing).
+			Do(func(ctx context.Context, export *domain.DataExport, expectedStatus string) {
+				assert.Equal(t, domain.DataExportStatusFailed, export.Status)
+			}).
+			Return(nil).
+			Times(1)
+		// Permintaan bersamaan yang lolos pemeriksaan ditolak oleh unique index
+		m.privacyRepo.EXPECT().CreateExport(ctx, gomock.Any()).Return(domain.ErrDataExportInProgress).Times(1)
+
+		_, err := privacyUsecase.RequestExport(ctx, user.ID)
+
+		assert.True(t, errors.Is(err, domain.ErrDataExportInProgress))
+	})
+}
+
+func TestDataPrivacyUsecase_ResumePendingExports(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	privacyUsecase, m := newDataPrivacyUsecase(mockCtrl)
+	ctx := context.Background()
+	user := &domain.User{ID: 1, Username: "budi", Email: "budi@example.com", Role: domain.RoleKlien}
+
+	m.privacyRepo.EXPECT().
+		ListPendingExports(ctx, gomock.Any()).
+		Return([]domain.DataExport{
+			{ID: "a", UserID: 1, Status: domain.DataExportStatusPending},
+			{ID: "b", UserID: 1, Status: domain.DataExportStatusPending},
+		}, nil).
+		Times(1)
+	m.userRepo.EXPECT().GetByID(ctx, user.ID).Return(user, nil).Times(2)
+	// Ekspor "b" sudah diambil alih goroutine RequestExport sehingga dilewati
+	m.privacyRepo.EXPECT().
+		UpdateExport(gomock.Any(), gomock.Any(), domain.DataExportStatusPending).
+		DoAndReturn(func(ctx context.Context, export *domain.DataExport, expectedStatus string) error {
+			if export.ID == "b" {
+				return domain.ErrDataExportStatusChanged
+			}
+			return nil
+		}).
+		Times(2)
+	m.privacyRepo.EXPECT().
+		CollectPersonalData(gomock.Any(), user.ID).
+		Return(&domain.PersonalDataArchive{Profile: user, Sessions: []domain.Session{}}, nil).
+		Times(1)
+	m.storage.EXPECT().Put(gomock.Any(), "data-exports/a/gopsy-data-export.zip", "application/zip", gomock.Any()).Return(nil).Times(1)
+	m.privacyRepo.EXPECT().
+		UpdateExport(gomock.Any(), gomock.Any(), domain.DataExportStatusProcessing).
+		Do(func(ctx context.Context, export *domain.DataExport, expectedStatus string) {
+			assert.Equal(t, "a", export.ID)
+			assert.Equal(t, domain.DataExportStatusReady, export.Status)
+		}).
+		Return(nil).
+		Times(1)
+	m.mailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	resumed, err := privacyUsecase.ResumePendingExports(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, resumed)
+}
+
+func TestDataPrivacyUsecase_StaleExportRace(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	privacyUsecase, m := newDataPrivacyUsecase(mockCtrl)
+	ctx := context.Background()
+	exportID := "0b8f3c52-5d0e-4a8e-9d34-0f2f0d1c8a61"
+	key := "data-exports/" + exportID + "/gopsy-data-export.zip"
+	expiresAt := time.Now().Add(time.Hour)
+
+	// Arsip selesai tepat sebelum ekspor yang terlihat macet ditandai gagal
+	m.privacyRepo.EXPECT().
+		GetExport(ctx, uint(1), exportID).
+		Return(&domain.DataExport{ID: exportID, UserID: 1, Status: domain.DataExportStatusProcessing, UpdatedAt: time.Now().Add(-time.Hour)}, nil).
+		Times(1)
+	m.privacyRepo.EXPECT().
+		UpdateExport(ctx, gomock.Any(), domain.DataExportStatusProcessing).
+		Return(domain.ErrDataExportStatusChanged).
+		Times(1)
+	m.privacyRepo.EXPECT().
+		GetExport(ctx, uint(1), exportID).
+		Return(&domain.DataExport{ID: exportID, UserID: 1, Status: domain.DataExportStatusReady, FileKey: &key, ExpiresAt: &expiresAt}, nil).
+		Times(1)
+	m.storage.EXPECT().SignedURL(key, gomock.Any()).Return("https://files.gopsy.test/"+key, nil).Times(1)
+
+	export, err := privacyUsecase.GetExport(ctx, 1, exportID)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.DataExportStatusReady, export.Status)
+	assert.Equal(t, "https://files.gopsy.test/"+key, export.DownloadURL)
+}
+
+func TestDataPrivacyUsecase_GetExport(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	privacyUsecase, m := newDataPrivacyUsecase(mockCtrl)
+	ctx := context.Background()
+	exportID := "0b8f3c52-5d0e-4a8e-9d34-0f2f0d1c8a61"
+	key := "data-exports/" + exportID + "/gopsy-data-export.zip"
+
+	t.Run("Ready Export Has Download URL", func(t *testing.T) {
+		expiresAt := time.Now().Add(5 * time.Minute)
+		m.privacyRepo.EXPECT().
+			GetExport(ctx, uint(1), exportID).
+			Return(&domain.DataExport{ID: exportID, UserID: 1, Status: domain.DataExportStatusReady, FileKey: &key, ExpiresAt: &expiresAt}, nil).
+			Times(1)
+		m.storage.EXPECT().
+			SignedURL(key, gomock.Any()).
+			DoAndReturn(func(key string, ttl time.Duration) (string, error) {
+				// Link tidak boleh berlaku lebih lama dari arsipnya
+				assert.LessOrEqual(t, ttl, 5*time.Minute)
+				return "https://files.gopsy.test/" + key, nil
+			}).
+			Times(1)
+
+		export, err := privacyUsecase.GetExport(ctx, 1, exportID)
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://files.gopsy.test/"+key, export.DownloadURL)
+	})
+
+	t.Run("Expired Export Has No Download URL", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Minute)
+		m.privacyRepo.EXPECT().
+			GetExport(ctx, uint(1), exportID).
+			Return(&domain.DataExport{ID: exportID, UserID: 1, Status: domain.DataExportStatusReady, FileKey: &key, ExpiresAt: &expiresAt}, nil).
+			Times(1)
+
+		export, err := privacyUsecase.GetExport(ctx, 1, exportID)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.DataExportStatusExpired, export.Status)
+		assert.Empty(t, export.DownloadURL)
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		_, err := privacyUsecase.GetExport(ctx, 1, "not-a-uuid")
+
+		assert.True(t, errors.Is(err, domain.ErrDataExportNotFound))
+	})
+}
+
+func TestDataPrivacyUsecase_PurgeExpiredExports(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	privacyUsecase, m := newDataPrivacyUsecase(mockCtrl)
+	ctx := context.Background()
+	firstKey, secondKey := "data-exports/a/gopsy-data-export.zip", "data-exports/b/gopsy-data-export.zip"
+
+	m.privacyRepo.EXPECT().
+		ListExpiredExports(ctx, gomock.Any(), gomock.Any()).
+		Return([]domain.DataExport{
+			{ID: "a", Status: domain.DataExportStatusReady, FileKey: &firstKey},
+			{ID: "b", Status: domain.DataExportStatusReady, FileKey: &secondKey},
+		}, nil).
+		Times(1)
+	m.storage.EXPECT().Delete(ctx, firstKey).Return(nil).Times(1)
+	m.storage.EXPECT().Delete(ctx, secondKey).Return(errors.New("storage unavailable")).Times(1)
+	m.privacyRepo.EXPECT().
+		UpdateExport(ctx, gomock.Any(), domain.DataExportStatusReady).
+		Do(func(ctx context.Context, export *domain.DataExport, expectedStatus string) {
+			assert.Equal(t, "a", export.ID)
+			assert.Equal(t, domain.DataExportStatusExpired, export.Status)
+			assert.Nil(t, export.FileKey)
+		}).
+		Return(nil).
+		Times(1)
+
+	purged, err := privacyUsecase.PurgeExpiredExports(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+}
+
+func TestDataPrivacyUsecase_EraseAccount(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	privacyUsecase, m := newDataPrivacyUsecase(mockCtrl)
+	ctx := context.Background()
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte("Rahasia-Sekali-1"), bcrypt.MinCost)
+	require.NoError(t, err)
+	pictureKey := "profile-pictures/3f1c"
+	exportKey := "data-exports/a/gopsy-data-export.zip"
+	newClient := func() *domain.User {
+		return &domain.User{ID: 1, Email: "budi@example.com", Password: string(passwordHash), Role: domain.RoleKlien, ProfilePictureKey: &pictureKey}
+	}
+	payload := &domain.EraseAccountPayload{Password: "Rahasia-Sekali-1"}
+
+	t.Run("Success", func(t *testing.T) {
+		m.userRepo.EXPECT().GetByID(ctx, uint(1)).Return(newClient(), nil).Times(1)
+		m.consultationRepo.EXPECT().
+			List(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, filter domain.KonsultasiFilter) ([]domain.Konsultasi, int64, error) {
+				assert.Equal(t, uint(1), filter.KlienID)
+				assert.Equal(t, domain.ActiveKonsultasiStatuses, filter.Statuses)
+				assert.NotNil(t, filter.From)
+				assert.False(t, filter.Preload)
+				return nil, 0, nil
+			}).
+			Times(1)
+		m.privacyRepo.EXPECT().
+			ListExports(ctx, uint(1)).
+			Return([]domain.DataExport{{ID: "a", Status: domain.DataExportStatusReady, FileKey: &exportKey}, {ID: "b", Status: domain.DataExportStatusFailed}}, nil).
+			Times(1)
+		gomock.InOrder(
+			m.auth.EXPECT().LogoutAll(ctx, uint(1)).Return(nil).Times(1),
+			m.privacyRepo.EXPECT().EraseUser(ctx, uint(1), gomock.Any()).Return(nil).Times(1),
+		)
+		for _, size := range []string{"96", "256", "512"} {
+			m.storage.EXPECT().Delete(ctx, pictureKey+"/"+size+".jpg").Return(nil).Times(1)
+		}
+		m.storage.EXPECT().Delete(ctx, exportKey).Return(nil).Times(1)
+
+		err := privacyUsecase.EraseAccount(ctx, 1, payload)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Incorrect Password", func(t *testing.T) {
+		m.userRepo.EXPECT().GetByID(ctx, uint(1)).Return(newClient(), nil).Times(1)
+
+		err := privacyUsecase.EraseAccount(ctx, 1, &domain.EraseAccountPayload{Password: "salah"})
+
+		assert.True(t, errors.Is(err, domain.ErrIncorrectCurrentPassword))
+	})
+
+	t.Run("Upcoming Consultation", func(t *testing.T) {
+		m.userRepo.EXPECT().GetByID(ctx, uint(1)).Return(newClient(), nil).Times(1)
+		m.consultationRepo.EXPECT().List(ctx, gomock.Any()).Return([]domain.Konsultasi{{ID: 9}}, int64(1), nil).Times(1)
+
+		err := privacyUsecase.EraseAccount(ctx, 1, payload)
+
+		assert.True(t, errors.Is(err, domain.ErrAccountHasActiveConsultations))
+	})
+
+	t.Run("Consultation Booked During Erasure", func(t *testing.T) {
+		m.userRepo.EXPECT().GetByID(ctx, uint(1)).Return(newClient(), nil).Times(1)
+		m.consultationRepo.EXPECT().List(ctx, gomock.Any()).Return(nil, int64(0), nil).Times(1)
+		m.privacyRepo.EXPECT().ListExports(ctx, uint(1)).Return(nil, nil).Times(1)
+		m.auth.EXPECT().LogoutAll(ctx, uint(1)).Return(nil).Times(1)
+		m.privacyRepo.EXPECT().EraseUser(ctx, uint(1), gomock.Any()).Return(domain.ErrAccountHasActiveConsultations).Times(1)
+
+		err := privacyUsecase.EraseAccount(ctx, 1, payload)
+
+		assert.True(t, errors.Is(err, domain.ErrAccountHasActiveConsultations))
+	})
+}
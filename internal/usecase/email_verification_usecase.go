@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_redis"
+	"go.uber.org/zap"
+)
+
+const verificationResendKeyPrefix = "email_verification:resend:"
+
+type emailVerificationUsecase struct {
+	userRepo              domain.UserRepository
+	emailVerificationRepo domain.EmailVerificationRepository
+	mailer                domain.Mailer
+	store                 app_redis.Redis
+	verifyURL             string
+	tokenTTL              time.Duration
+	resendCooldown        time.Duration
+	logger                *zap.Logger
+}
+
+// NewEmailVerificationUsecase membuat instance baru dari emailVerificationUsecase. verifyURL adalah
+// halaman frontend yang menerima token verifikasi sebagai query parameter "token".
+func NewEmailVerificationUsecase(
+	ur domain.UserRepository,
+	evr domain.EmailVerificationRepository,
+	mailer domain.Mailer,
+	store app_redis.Redis,
+	verifyURL string,
+	tokenTTL time.Duration,
+	resendCooldown time.Duration,
+	logger *zap.Logger,
+) domain.EmailVerificationUsecase {
+	return &emailVerificationUsecase{
+		userRepo:              ur,
+		emailVerificationRepo: evr,
+		mailer:                mailer,
+		store:                 store,
+		verifyURL:             verifyURL,
+		tokenTTL:              tokenTTL,
+		resendCooldown:        resendCooldown,
+		logger:                logger,
+	}
+}
+
+// SendVerification menerbitkan token verifikasi baru dan mengirimkannya ke email user.
+// Token sebelumnya tidak berlaku lagi.
+func (uc *emailVerificationUsecase) SendVerification(ctx context.Context, user *domain.User) error {
+	if err := uc.emailVerificationRepo.InvalidateAllForUser(ctx, user.ID); err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to send verification email", err)
+	}
+
+	rawToken, err := generateOpaqueToken()
+	if err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to send verification email", err)
+	}
+
+	token := &domain.EmailVerificationToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(rawToken),
+		ExpiresAt: time.Now().Add(uc.tokenTTL),
+	}
+	if err := uc.emailVerificationRepo.Create(ctx, token); err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to send verification email", err)
+	}
+
+	message := &domain.EmailMessage{
+		To:      user.Email,
+		Subject: "Verify your Gopsy email address",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nPlease confirm your email address by opening the link below:\n\n%s\n\n"+
+				"The link expires in %d hours. If you did not create a Gopsy account, you can ignore this email.\n",
+			user.Username, buildTokenLink(uc.verifyURL, rawToken), int(uc.tokenTTL.Hours()),
+		),
+	}
+
+	go func(ctx context.Context) {
+		if err := uc.mailer.Send(ctx, message); err != nil {
+			uc.logger.Error("Failed to send verification email", zap.Error(err), zap.Uint("user_id", user.ID))
+		}
+	}(context.WithoutCancel(ctx))
+
+	return nil
+}
+
+// VerifyEmail menandai email user sebagai terverifikasi memakai token dari email verifikasi.
+func (uc *emailVerificationUsecase) VerifyEmail(ctx context.Context, payload *domain.VerifyEmailPayload) error {
+	stored, err := uc.emailVerificationRepo.GetByHash(ctx, hashToken(payload.Token))
+	if err != nil {
+		if errors.Is(err, domain.ErrEmailVerificationTokenNotFound) {
+			return domain.ErrInvalidEmailVerificationToken
+		}
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to verify email", err)
+	}
+
+	if stored.UsedAt != nil || !time.Now().Before(stored.ExpiresAt) {
+		return domain.ErrInvalidEmailVerificationToken
+	}
+
+	if err := uc.emailVerificationRepo.MarkUsed(ctx, stored.ID); err != nil {
+		if errors.Is(err, domain.ErrInvalidEmailVerificationToken) {
+			return err
+		}
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to verify email", err)
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, stored.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return domain.ErrInvalidEmailVerificationToken
+		}
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to verify email", err)
+	}
+
+	if user.IsEmailVerified() {
+		return nil
+	}
+
+	if err := uc.userRepo.MarkEmailVerified(ctx, user.ID, time.Now()); err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to verify email", err)
+	}
+
+	uc.logger.Info("Email verified", zap.Uint("user_id", user.ID))
+	return nil
+}
+
+// ResendVerification mengirim ulang email verifikasi. Permintaan dibatasi satu kali per cooldown
+// untuk setiap akun.
+func (uc *emailVerificationUsecase) ResendVerification(ctx context.Context, userID uint) error {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return domain.NewDomainError(http.StatusNotFound, "User not found")
+		}
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to send verification email", err)
+	}
+
+	if user.IsEmailVerified() {
+		return domain.ErrEmailAlreadyVerified
+	}
+
+	// SetNX memastikan hanya satu dari beberapa permintaan bersamaan yang lolos cooldown
+	key := verificationResendKeyPrefix + strconv.FormatUint(uint64(userID), 10)
+	allowed, err := uc.store.SetNX(ctx, key, strconv.FormatInt(time.Now().Unix(), 10), uc.resendCooldown)
+	if err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to send verification email", err)
+	}
+	if !allowed {
+		return domain.ErrVerificationResendTooSoon
+	}
+
+	return uc.SendVerification(ctx, user)
+}
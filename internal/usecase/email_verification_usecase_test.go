@@ -0,0 +1,121 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/internal/mocks"
+	"github.com/X3nonxe/gopsy-backend/internal/usecase"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_redis"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestEmailVerificationUsecase_VerifyEmail(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	mockVerificationRepo := mocks.NewMockEmailVerificationRepository(mockCtrl)
+	verificationUsecase := usecase.NewEmailVerificationUsecase(mockUserRepo, mockVerificationRepo, mocks.NewMockMailer(mockCtrl),
+		app_redis.NewMemoryRedis(), "https://app.gopsy.test/verify-email", 48*time.Hour, time.Minute, zap.NewNop())
+
+	ctx := context.Background()
+	rawToken := "raw-verification-token"
+	payload := &domain.VerifyEmailPayload{Token: rawToken}
+
+	validToken := func() *domain.EmailVerificationToken {
+		return &domain.EmailVerificationToken{ID: 3, UserID: 1, TokenHash: sha256Hex(rawToken), ExpiresAt: time.Now().Add(time.Hour)}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockVerificationRepo.EXPECT().GetByHash(ctx, sha256Hex(rawToken)).Return(validToken(), nil).Times(1)
+		mockVerificationRepo.EXPECT().MarkUsed(ctx, uint(3)).Return(nil).Times(1)
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(&domain.User{ID: 1}, nil).Times(1)
+		mockUserRepo.EXPECT().
+			MarkEmailVerified(ctx, uint(1), gomock.Any()).
+			Do(func(ctx context.Context, id uint, verifiedAt time.Time) {
+				assert.False(t, verifiedAt.IsZero())
+			}).
+			Return(nil).
+			Times(1)
+
+		err := verificationUsecase.VerifyEmail(ctx, payload)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Expired Token", func(t *testing.T) {
+		expired := validToken()
+		expired.ExpiresAt = time.Now().Add(-time.Minute)
+		mockVerificationRepo.EXPECT().GetByHash(ctx, sha256Hex(rawToken)).Return(expired, nil).Times(1)
+
+		err := verificationUsecase.VerifyEmail(ctx, payload)
+
+		assert.True(t, errors.Is(err, domain.ErrInvalidEmailVerificationToken))
+	})
+
+	t.Run("Unknown Token", func(t *testing.T) {
+		mockVerificationRepo.EXPECT().GetByHash(ctx, sha256Hex(rawToken)).Return(nil, domain.ErrEmailVerificationTokenNotFound).Times(1)
+
+		err := verificationUsecase.VerifyEmail(ctx, payload)
+
+		assert.True(t, errors.Is(err, domain.ErrInvalidEmailVerificationToken))
+	})
+}
+
+func TestEmailVerificationUsecase_ResendVerification(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	mockVerificationRepo := mocks.NewMockEmailVerificationRepository(mockCtrl)
+	mockMailer := mocks.NewMockMailer(mockCtrl)
+	verificationUsecase := usecase.NewEmailVerificationUsecase(mockUserRepo, mockVerificationRepo, mockMailer,
+		app_redis.NewMemoryRedis(), "https://app.gopsy.test/verify-email", 48*time.Hour, time.Minute, zap.NewNop())
+
+	ctx := context.Background()
+	user := &domain.User{ID: 1, Username: "budi", Email: "budi@example.com"}
+
+	t.Run("Sends Once Per Cooldown", func(t *testing.T) {
+		sent := make(chan struct{}, 1)
+		mockUserRepo.EXPECT().GetByID(ctx, user.ID).Return(user, nil).Times(2)
+		mockVerificationRepo.EXPECT().InvalidateAllForUser(ctx, user.ID).Return(nil).Times(1)
+		mockVerificationRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil).Times(1)
+		mockMailer.EXPECT().
+			Send(gomock.Any(), gomock.Any()).
+			Do(func(ctx context.Context, message *domain.EmailMessage) {
+				assert.Equal(t, user.Email, message.To)
+				assert.Contains(t, message.Body, "https://app.gopsy.test/verify-email?token=")
+				sent <- struct{}{}
+			}).
+			Return(nil).
+			Times(1)
+
+		err := verificationUsecase.ResendVerification(ctx, user.ID)
+		assert.NoError(t, err)
+
+		select {
+		case <-sent:
+		case <-time.After(time.Second):
+			t.Fatal("verification email was not sent")
+		}
+
+		// Permintaan kedua dalam masa cooldown ditolak
+		err = verificationUsecase.ResendVerification(ctx, user.ID)
+		assert.True(t, errors.Is(err, domain.ErrVerificationResendTooSoon))
+	})
+
+	t.Run("Already Verified", func(t *testing.T) {
+		verifiedAt := time.Now()
+		mockUserRepo.EXPECT().GetByID(ctx, uint(2)).Return(&domain.User{ID: 2, EmailVerifiedAt: &verifiedAt}, nil).Times(1)
+
+		err := verificationUsecase.ResendVerification(ctx, 2)
+
+		assert.True(t, errors.Is(err, domain.ErrEmailAlreadyVerified))
+	})
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_redis"
+	"go.uber.org/zap"
+)
+
+// Hitungan percobaan dan batas waktu blokir disimpan pada key terpisah. Hitungan dinaikkan
+// secara atomik sebelum password diperiksa sehingga percobaan yang bersamaan tidak bisa lolos
+// sebelum kegagalan pertama tercatat.
+const (
+	loginAttemptKeyPrefix = "login:attempts:"
+	loginDelayKeyPrefix   = "login:delay:"
+	loginLockKeyPrefix    = "login:locked:"
+)
+
+type loginAttemptUsecase struct {
+	store  app_redis.Redis
+	policy domain.LoginAttemptPolicy
+	logger *zap.Logger
+}
+
+// NewLoginAttemptUsecase membuat instance baru dari loginAttemptUsecase.
+func NewLoginAttemptUsecase(store app_redis.Redis, policy domain.LoginAttemptPolicy, logger *zap.Logger) domain.LoginAttemptUsecase {
+	return &loginAttemptUsecase{
+		store:  store,
+		policy: policy,
+		logger: logger,
+	}
+}
+
+// Reserve mencatat satu percobaan login sebelum password diperiksa dan mengembalikan
+// LoginThrottledError jika percobaan itu tidak boleh dilanjutkan. Jeda dan penguncian dihitung dari
+// nilai yang dikembalikan Incr, sehingga setiap percobaan mendapat urutan yang unik: setelah
+// FreeAttempts hanya satu percobaan per jeda yang lolos, dan percobaan ke-MaxAttempts mengunci
+// email untuk percobaan berikutnya. Hitungan hanya direset lewat Reset setelah login berhasil.
+func (uc *loginAttemptUsecase) Reserve(ctx context.Context, email string) error {
+	hash := loginAttemptHash(email)
+
+	// Email yang sudah diperlambat atau dikunci ditolak tanpa menambah hitungan
+	for _, key := range []string{loginLockKeyPrefix + hash, loginDelayKeyPrefix + hash} {
+		blockedUntil, err := uc.blockedUntil(ctx, key)
+		if err != nil {
+			return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to login", err)
+		}
+		if wait := time.Until(blockedUntil); wait > 0 {
+			return &domain.LoginThrottledError{RetryAfter: wait}
+		}
+	}
+
+	count, err := uc.store.Incr(ctx, loginAttemptKeyPrefix+hash, uc.policy.Window)
+	if err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to login", err)
+	}
+	attempts := int(count)
+	now := time.Now()
+
+	if attempts >= uc.policy.MaxAttempts {
+		// SetNX menjaga batas waktu penguncian pertama; percobaan bersamaan tidak memperpanjangnya
+		blockedUntil := strconv.FormatInt(now.Add(uc.policy.Lockout).UnixMilli(), 10)
+		locked, err := uc.store.SetNX(ctx, loginLockKeyPrefix+hash, blockedUntil, uc.policy.Lockout)
+		if err != nil {
+			return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to login", err)
+		}
+		if locked {
+			uc.logger.Warn("Login locked after too many attempts", zap.Int("attempts", attempts))
+		}
+		// Hitungan ikut berakhir bersama penguncian agar email mulai dari awal setelah terbuka
+		if err := uc.store.Expire(ctx, loginAttemptKeyPrefix+hash, uc.policy.Lockout); err != nil {
+			return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to login", err)
+		}
+		if attempts > uc.policy.MaxAttempts {
+			return uc.throttled(ctx, loginLockKeyPrefix+hash, uc.policy.Lockout)
+		}
+	}
+
+	if attempts > uc.policy.FreeAttempts {
+		// Hanya percobaan yang berhasil memasang jeda yang boleh dilanjutkan
+		delay := uc.delay(attempts)
+		blockedUntil := strconv.FormatInt(now.Add(delay).UnixMilli(), 10)
+		reserved, err := uc.store.SetNX(ctx, loginDelayKeyPrefix+hash, blockedUntil, delay)
+		if err != nil {
+			return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to login", err)
+		}
+		if !reserved {
+			return uc.throttled(ctx, loginDelayKeyPrefix+hash, delay)
+		}
+	}
+	return nil
+}
+
+// Reset menghapus hitungan percobaan, dipakai setelah login berhasil atau dibuka oleh admin.
+func (uc *loginAttemptUsecase) Reset(ctx context.Context, email string) error {
+	hash := loginAttemptHash(email)
+	for _, prefix := range []string{loginAttemptKeyPrefix, loginDelayKeyPrefix, loginLockKeyPrefix} {
+		if err := uc.store.Del(ctx, prefix+hash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// delay menghitung jeda setelah percobaan ke-attempts: BaseDelay, 2x, 4x, ... hingga MaxDelay.
+func (uc *loginAttemptUsecase) delay(attempts int) time.Duration {
+	delay := uc.policy.BaseDelay
+	for i := uc.policy.FreeAttempts + 1; i < attempts && delay < uc.policy.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > uc.policy.MaxDelay {
+		delay = uc.policy.MaxDelay
+	}
+	return delay
+}
+
+// throttled membuat LoginThrottledError dari batas waktu blokir pada key. fallback dipakai jika key
+// sudah berakhir sejak dipasang.
+func (uc *loginAttemptUsecase) throttled(ctx context.Context, key string, fallback time.Duration) error {
+	blockedUntil, err := uc.blockedUntil(ctx, key)
+	if err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to login", err)
+	}
+	wait := time.Until(blockedUntil)
+	if wait <= 0 {
+		wait = fallback
+	}
+	return &domain.LoginThrottledError{RetryAfter: wait}
+}
+
+// blockedUntil membaca batas waktu blokir dari key. Key yang tidak ada berarti tidak diblokir.
+func (uc *loginAttemptUsecase) blockedUntil(ctx context.Context, key string) (time.Time, error) {
+	value, err := uc.store.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, app_redis.ErrKeyNotFound) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, err
+	}
+
+	millis, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		// Data rusak tidak boleh membuat login gagal permanen; anggap tidak diblokir
+		uc.logger.Warn("Discarding malformed login attempt state", zap.Error(err))
+		return time.Time{}, nil
+	}
+	return time.UnixMilli(millis), nil
+}
+
+// loginAttemptHash memakai hash email agar alamat email tidak tersimpan di store.
+func loginAttemptHash(email string) string {
+	return hashToken(strings.ToLower(strings.TrimSpace(email)))
+}
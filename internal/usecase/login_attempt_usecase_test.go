@@ -0,0 +1,157 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/internal/usecase"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestLoginAttemptUsecase(t *testing.T) {
+	policy := domain.LoginAttemptPolicy{
+		FreeAttempts: 2,
+		MaxAttempts:  5,
+		BaseDelay:    time.Minute,
+		MaxDelay:     3 * time.Minute,
+		Lockout:      15 * time.Minute,
+		Window:       15 * time.Minute,
+	}
+	ctx := context.Background()
+
+	retryAfter := func(t *testing.T, err error) time.Duration {
+		t.Helper()
+		var throttled *domain.LoginThrottledError
+		require.True(t, errors.As(err, &throttled), "expected LoginThrottledError, got %v", err)
+		return throttled.RetryAfter
+	}
+
+	// Jeda singkat agar masa jeda bisa ditunggu di dalam test
+	fast := policy
+	fast.BaseDelay = 50 * time.Millisecond
+	fast.MaxDelay = 150 * time.Millisecond
+
+	// reserveAfterWait menunggu masa jeda yang sedang berlaku lalu mencatat percobaan berikutnya
+	reserveAfterWait := func(t *testing.T, attempts domain.LoginAttemptUsecase, email string) {
+		t.Helper()
+		for i := 0; i < 10; i++ {
+			err := attempts.Reserve(ctx, email)
+			if err == nil {
+				return
+			}
+			time.Sleep(retryAfter(t, err) + 5*time.Millisecond)
+		}
+		t.Fatal("attempt was never allowed")
+	}
+
+	t.Run("Progressive Delay Then Lockout", func(t *testing.T) {
+		attempts := usecase.NewLoginAttemptUsecase(app_redis.NewMemoryRedis(), fast, zap.NewNop())
+		email := "klien@example.com"
+
+		for i := 0; i < fast.FreeAttempts; i++ {
+			require.NoError(t, attempts.Reserve(ctx, email))
+		}
+
+		// Percobaan ke-3 dan ke-4 lolos tetapi memasang jeda 50 lalu 100 milidetik
+		for _, delay := range []time.Duration{50 * time.Millisecond, 100 * time.Millisecond} {
+			reserveAfterWait(t, attempts, email)
+			wait := retryAfter(t, attempts.Reserve(ctx, email))
+			assert.InDelta(t, delay.Seconds(), wait.Seconds(), 0.03)
+		}
+
+		// Percobaan ke-5 masih lolos dan mengunci email untuk percobaan berikutnya
+		reserveAfterWait(t, attempts, email)
+		time.Sleep(fast.MaxDelay)
+		wait := retryAfter(t, attempts.Reserve(ctx, email))
+		assert.InDelta(t, fast.Lockout.Seconds(), wait.Seconds(), 1)
+
+		var domainErr *domain.DomainError
+		require.True(t, errors.As(attempts.Reserve(ctx, email), &domainErr))
+		assert.Equal(t, http.StatusTooManyRequests, domainErr.HTTPStatus)
+	})
+
+	t.Run("Delay Is Capped", func(t *testing.T) {
+		capped := fast
+		capped.MaxAttempts = 10
+		attempts := usecase.NewLoginAttemptUsecase(app_redis.NewMemoryRedis(), capped, zap.NewNop())
+		email := "psikolog@example.com"
+
+		for i := 0; i < 6; i++ {
+			reserveAfterWait(t, attempts, email)
+		}
+
+		wait := retryAfter(t, attempts.Reserve(ctx, email))
+		assert.InDelta(t, capped.MaxDelay.Seconds(), wait.Seconds(), 0.03)
+	})
+
+	t.Run("Reset Clears Attempts", func(t *testing.T) {
+		noDelay := policy
+		noDelay.FreeAttempts = noDelay.MaxAttempts
+		attempts := usecase.NewLoginAttemptUsecase(app_redis.NewMemoryRedis(), noDelay, zap.NewNop())
+		email := "admin@example.com"
+
+		for i := 0; i < noDelay.MaxAttempts; i++ {
+			require.NoError(t, attempts.Reserve(ctx, email))
+		}
+		require.Error(t, attempts.Reserve(ctx, email))
+
+		require.NoError(t, attempts.Reset(ctx, email))
+
+		assert.NoError(t, attempts.Reserve(ctx, email))
+	})
+
+	t.Run("Email Is Case Insensitive", func(t *testing.T) {
+		attempts := usecase.NewLoginAttemptUsecase(app_redis.NewMemoryRedis(), policy, zap.NewNop())
+
+		for i := 0; i <= policy.FreeAttempts; i++ {
+			require.NoError(t, attempts.Reserve(ctx, "Klien@Example.com"))
+		}
+
+		assert.Error(t, attempts.Reserve(ctx, "klien@example.com"))
+	})
+
+	t.Run("Concurrent Burst Is Throttled", func(t *testing.T) {
+		attempts := usecase.NewLoginAttemptUsecase(app_redis.NewMemoryRedis(), policy, zap.NewNop())
+		email := "paralel@example.com"
+
+		// Semua percobaan dilepas bersamaan sebelum ada password yang diperiksa
+		const burst = 20
+		var (
+			wg      sync.WaitGroup
+			mu      sync.Mutex
+			allowed int
+			ready   = make(chan struct{})
+		)
+		for i := 0; i < burst; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-ready
+				err := attempts.Reserve(ctx, email)
+				if err == nil {
+					mu.Lock()
+					allowed++
+					mu.Unlock()
+					return
+				}
+				retryAfter(t, err)
+			}()
+		}
+		close(ready)
+		wg.Wait()
+
+		// Hanya percobaan gratis ditambah satu percobaan per jeda yang boleh memeriksa password
+		assert.Equal(t, policy.FreeAttempts+1, allowed)
+
+		// Percobaan berikutnya tetap harus menunggu
+		retryAfter(t, attempts.Reserve(ctx, email))
+	})
+}
@@ -0,0 +1,640 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_crypto"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_redis"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_totp"
+	"go.uber.org/zap"
+)
+
+const (
+	mfaChallengeKeyPrefix = "mfa:challenge:"
+	mfaAttemptsKeyPrefix  = "mfa:attempts:"
+	mfaFailuresKeyPrefix  = "mfa:failures:"
+	mfaLockKeyPrefix      = "mfa:locked:"
+	// mfaMaxAttempts adalah jumlah percobaan kode yang diterima sebelum tantangan MFA dibatalkan.
+	mfaMaxAttempts = 5
+	// mfaUserMaxFailures adalah jumlah kode salah per user, lintas tantangan, sebelum user dikunci.
+	// Tanpa batas ini penyerang yang mengetahui password cukup meminta tantangan baru.
+	mfaUserMaxFailures = 10
+	// mfaUserLockout adalah lama penguncian sekaligus jendela hitungan kode salah per user.
+	mfaUserLockout = 15 * time.Minute
+	// mfaCodeSkew menerima kode dari satu periode sebelum dan sesudah waktu server.
+	mfaCodeSkew = 1
+	// mfaRecoveryCodeCount adalah jumlah kode pemulihan yang diterbitkan setiap kali dibuat ulang.
+	mfaRecoveryCodeCount = 10
+	// mfaRecoveryCodeBytes menghasilkan kode pemulihan 10 karakter base32.
+	mfaRecoveryCodeBytes = 5
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type mfaUsecase struct {
+	mfaRepo      domain.MFARepository
+	userRepo     domain.UserRepository
+	authUsecase  domain.AuthUsecase
+	aead         *app_crypto.AEAD
+	store        app_redis.Redis
+	issuer       string
+	challengeTTL time.Duration
+	logger       *zap.Logger
+}
+
+// NewMFAUsecase membuat instance baru dari mfaUsecase. aead dipakai untuk mengenkripsi secret TOTP
+// sebelum disimpan, sedangkan store menyimpan tantangan MFA yang sedang berjalan.
+func NewMFAUsecase(
+	mr domain.MFARepository,
+	ur domain.UserRepository,
+	au domain.AuthUsecase,
+	aead *app_crypto.AEAD,
+	store app_redis.Redis,
+	issuer string,
+	challengeTTL time.Duration,
+	logger *zap.Logger,
+) domain.MFAUsecase {
+	return &mfaUsecase{
+		mfaRepo:      mr,
+		userRepo:     ur,
+		authUsecase:  au,
+		aead:         aead,
+		store:        store,
+		issuer:       issuer,
+		challengeTTL: challengeTTL,
+		logger:       logger,
+	}
+}
+
+// mfaChallengeState adalah isi tantangan MFA yang tersimpan di store. Jumlah percobaan disimpan
+// pada key terpisah agar bisa dinaikkan secara atomik.
+type mfaChallengeState struct {
+	UserID    uint
+	ExpiresAt time.Time
+}
+
+// BeginChallenge menerbitkan tantangan MFA setelah password user terverifikasi. Hasilnya nil jika
+// user tidak perlu melewati MFA.
+func (uc *mfaUsecase) BeginChallenge(ctx context.Context, user *domain.User) (*domain.MFAChallenge, error) {
+	if !domain.IsMFARole(user.Role) {
+		return nil, nil
+	}
+
+	if err := uc.checkUserLock(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	enrollmentRequired := false
+	mfa, err := uc.mfaRepo.GetByUserID(ctx, user.ID)
+	switch {
+	case err == nil && mfa.IsEnabled():
+	case err == nil || errors.Is(err, domain.ErrMFANotFound):
+		required, err := uc.isRequired(ctx, user.Role)
+		if err != nil {
+			return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to login", err)
+		}
+		if !required {
+			return nil, nil
+		}
+		enrollmentRequired = true
+	default:
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to login", err)
+	}
+
+	rawToken, err := generateOpaqueToken()
+	if err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to login", err)
+	}
+
+	state := &mfaChallengeState{UserID: user.ID, ExpiresAt: time.Now().Add(uc.challengeTTL)}
+	if err := uc.saveChallenge(ctx, hashToken(rawToken), state); err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to login", err)
+	}
+
+	return &domain.MFAChallenge{
+		Token:              rawToken,
+		ExpiresIn:          int64(uc.challengeTTL.Seconds()),
+		EnrollmentRequired: enrollmentRequired,
+	}, nil
+}
+
+// EnrollWithChallenge memulai enrollment MFA untuk user yang wajib MFA tetapi belum mendaftar.
+// Enrollment diselesaikan lewat VerifyChallenge.
+func (uc *mfaUsecase) EnrollWithChallenge(ctx context.Context, payload *domain.MFAChallengePayload) (*domain.MFAEnrollment, error) {
+	state, _, err := uc.loadChallenge(ctx, payload.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+	return uc.Enroll(ctx, state.UserID)
+}
+
+// VerifyChallenge menyelesaikan login dua langkah. Untuk user yang sudah mengaktifkan MFA, Code
+// boleh berupa kode TOTP atau kode pemulihan; untuk enrollment yang belum dikonfirmasi, Code harus
+// kode TOTP dan kode pemulihan ikut dikembalikan.
+func (uc *mfaUsecase) VerifyChallenge(ctx context.Context, payload *domain.MFAVerifyPayload, client *domain.ClientInfo) (*domain.LoginResponse, error) {
+	state, tokenHash, err := uc.loadChallenge(ctx, payload.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.checkUserLock(ctx, state.UserID); err != nil {
+		return nil, err
+	}
+
+	// Percobaan dicatat sebelum kode diperiksa sehingga permintaan paralel tetap dibatasi
+	attempt, err := uc.store.Incr(ctx, mfaAttemptsKeyPrefix+tokenHash, time.Until(state.ExpiresAt))
+	if err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to verify MFA", err)
+	}
+	if attempt > mfaMaxAttempts {
+		uc.deleteChallenge(ctx, tokenHash, state.UserID)
+		return nil, domain.ErrInvalidMFAChallenge
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, state.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.ErrInvalidMFAChallenge
+		}
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to verify MFA", err)
+	}
+
+	mfa, err := uc.mfaRepo.GetByUserID(ctx, user.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrMFANotFound) {
+			return nil, domain.ErrMFANotEnabled
+		}
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to verify MFA", err)
+	}
+
+	var recoveryCodes []string
+	if mfa.IsEnabled() {
+		err = uc.verifyCodeOrRecovery(ctx, mfa, payload.Code)
+	} else {
+		err = uc.verifyTOTP(ctx, mfa, payload.Code)
+		if err == nil {
+			recoveryCodes, err = uc.enable(ctx, mfa)
+		}
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidMFACode) {
+			uc.recordFailedAttempt(ctx, tokenHash, state, attempt)
+		}
+		return nil, err
+	}
+
+	uc.deleteChallenge(ctx, tokenHash, user.ID)
+	if err := uc.store.Del(ctx, mfaFailuresKeyPrefix+strconv.FormatUint(uint64(user.ID), 10)); err != nil {
+		uc.logger.Warn("Failed to reset MFA failures", zap.Error(err), zap.Uint("user_id", user.ID))
+	}
+
+	tokens, err := uc.authUsecase.IssueTokens(ctx, user, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.LoginResponse{
+		TokenPair:     tokens,
+		User:          domain.NewUserResponse(user),
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+// Enroll membuat secret TOTP baru untuk user. Secret belum aktif sampai dikonfirmasi dengan kode
+// yang valid.
+func (uc *mfaUsecase) Enroll(ctx context.Context, userID uint) (*domain.MFAEnrollment, error) {
+	user, err := uc.getMFAUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, domain.ErrMFANotFound) {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to enroll MFA", err)
+	}
+	if existing != nil && existing.IsEnabled() {
+		return nil, domain.ErrMFAAlreadyEnabled
+	}
+
+	secret, err := app_totp.GenerateSecret()
+	if err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to enroll MFA", err)
+	}
+
+	encrypted, err := uc.aead.Seal([]byte(secret))
+	if err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to enroll MFA", err)
+	}
+
+	mfa := &domain.UserMFA{
+		UserID:          userID,
+		SecretEncrypted: base64.StdEncoding.EncodeToString(encrypted),
+	}
+	if err := uc.mfaRepo.Save(ctx, mfa); err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to enroll MFA", err)
+	}
+
+	return &domain.MFAEnrollment{
+		Secret:          secret,
+		ProvisioningURI: app_totp.ProvisioningURI(uc.issuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmEnrollment mengaktifkan MFA setelah user memasukkan kode dari aplikasi authenticator.
+func (uc *mfaUsecase) ConfirmEnrollment(ctx context.Context, userID uint, payload *domain.MFACodePayload) (*domain.MFARecoveryCodes, error) {
+	if _, err := uc.getMFAUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	mfa, err := uc.getMFA(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa.IsEnabled() {
+		return nil, domain.ErrMFAAlreadyEnabled
+	}
+
+	if err := uc.verifyTOTP(ctx, mfa, payload.Code); err != nil {
+		return nil, err
+	}
+
+	codes, err := uc.enable(ctx, mfa)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.MFARecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+// RegenerateRecoveryCodes mengganti semua kode pemulihan user. Kode lama tidak berlaku lagi.
+func (uc *mfaUsecase) RegenerateRecoveryCodes(ctx context.Context, userID uint, payload *domain.MFACodePayload) (*domain.MFARecoveryCodes, error) {
+	mfa, err := uc.getEnabledMFA(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.verifyTOTP(ctx, mfa, payload.Code); err != nil {
+		return nil, err
+	}
+
+	codes, err := uc.replaceRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.MFARecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+// Disable menonaktifkan MFA user. MFA yang diwajibkan untuk role user tidak bisa dinonaktifkan.
+func (uc *mfaUsecase) Disable(ctx context.Context, userID uint, payload *domain.MFACodePayload) error {
+	user, err := uc.getMFAUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	mfa, err := uc.getEnabledMFA(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	required, err := uc.isRequired(ctx, user.Role)
+	if err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to disable MFA", err)
+	}
+	if required {
+		return domain.ErrMFARequiredByPolicy
+	}
+
+	if err := uc.verifyTOTP(ctx, mfa, payload.Code); err != nil {
+		return err
+	}
+
+	if err := uc.mfaRepo.Delete(ctx, userID); err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to disable MFA", err)
+	}
+
+	uc.logger.Info("MFA disabled", zap.Uint("user_id", userID))
+	return nil
+}
+
+// GetPolicies mengambil kebijakan MFA untuk setiap role yang mendukung MFA. Role tanpa kebijakan
+// tersimpan dianggap tidak mewajibkan MFA.
+func (uc *mfaUsecase) GetPolicies(ctx context.Context) ([]domain.MFARolePolicy, error) {
+	stored, err := uc.mfaRepo.GetPolicies(ctx)
+	if err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to retrieve MFA policies", err)
+	}
+
+	byRole := make(map[string]domain.MFARolePolicy, len(stored))
+	for _, policy := range stored {
+		byRole[policy.Role] = policy
+	}
+
+	policies := make([]domain.MFARolePolicy, 0, len(domain.MFARoles))
+	for _, role := range domain.MFARoles {
+		policy, ok := byRole[role]
+		if !ok {
+			policy = domain.MFARolePolicy{Role: role}
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+// SetPolicy mengatur apakah MFA wajib untuk suatu role.
+func (uc *mfaUsecase) SetPolicy(ctx context.Context, role string, payload *domain.MFAPolicyPayload) (*domain.MFARolePolicy, error) {
+	if !domain.IsMFARole(role) {
+		return nil, domain.NewDomainError(http.StatusBadRequest, "MFA policy can only be set for admin and psikolog roles")
+	}
+
+	policy := &domain.MFARolePolicy{
+		Role:     role,
+		Required: *payload.Required,
+	}
+	if err := uc.mfaRepo.UpsertPolicy(ctx, policy); err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to update MFA policy", err)
+	}
+
+	uc.logger.Info("MFA policy updated", zap.String("role", role), zap.Bool("required", policy.Required))
+	return policy, nil
+}
+
+// ResetFailures menghapus hitungan kode MFA salah dan penguncian MFA user, dipakai ketika admin
+// membuka penguncian login.
+func (uc *mfaUsecase) ResetFailures(ctx context.Context, userID uint) error {
+	id := strconv.FormatUint(uint64(userID), 10)
+	for _, key := range []string{mfaFailuresKeyPrefix + id, mfaLockKeyPrefix + id} {
+		if err := uc.store.Del(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isRequired mengembalikan true jika kebijakan role mewajibkan MFA.
+func (uc *mfaUsecase) isRequired(ctx context.Context, role string) (bool, error) {
+	policy, err := uc.mfaRepo.GetPolicy(ctx, role)
+	if err != nil {
+		if errors.Is(err, domain.ErrMFAPolicyNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return policy.Required, nil
+}
+
+// getMFAUser mengambil user dan memastikan role-nya mendukung MFA.
+func (uc *mfaUsecase) getMFAUser(ctx context.Context, userID uint) (*domain.User, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.NewDomainError(http.StatusNotFound, "User not found")
+		}
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to retrieve user", err)
+	}
+	if !domain.IsMFARole(user.Role) {
+		return nil, domain.ErrMFANotAllowed
+	}
+	return user, nil
+}
+
+func (uc *mfaUsecase) getMFA(ctx context.Context, userID uint) (*domain.UserMFA, error) {
+	mfa, err := uc.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrMFANotFound) {
+			return nil, err
+		}
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to retrieve MFA", err)
+	}
+	return mfa, nil
+}
+
+func (uc *mfaUsecase) getEnabledMFA(ctx context.Context, userID uint) (*domain.UserMFA, error) {
+	mfa, err := uc.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrMFANotFound) {
+			return nil, domain.ErrMFANotEnabled
+		}
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to retrieve MFA", err)
+	}
+	if !mfa.IsEnabled() {
+		return nil, domain.ErrMFANotEnabled
+	}
+	return mfa, nil
+}
+
+// enable mengaktifkan MFA dan menerbitkan kode pemulihan pertama.
+func (uc *mfaUsecase) enable(ctx context.Context, mfa *domain.UserMFA) ([]string, error) {
+	codes, err := uc.replaceRecoveryCodes(ctx, mfa.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	mfa.EnabledAt = &now
+	if err := uc.mfaRepo.Save(ctx, mfa); err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to enable MFA", err)
+	}
+
+	uc.logger.Info("MFA enabled", zap.Uint("user_id", mfa.UserID))
+	return codes, nil
+}
+
+// verifyTOTP memvalidasi kode TOTP dan mencatat time step-nya agar kode yang sama tidak bisa
+// dipakai ulang.
+func (uc *mfaUsecase) verifyTOTP(ctx context.Context, mfa *domain.UserMFA, code string) error {
+	secret, err := uc.decryptSecret(mfa)
+	if err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to verify MFA code", err)
+	}
+
+	step, ok, err := app_totp.Validate(secret, code, time.Now(), mfaCodeSkew)
+	if err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to verify MFA code", err)
+	}
+	if !ok {
+		return domain.ErrInvalidMFACode
+	}
+
+	if err := uc.mfaRepo.UpdateLastUsedStep(ctx, mfa.UserID, step); err != nil {
+		if errors.Is(err, domain.ErrInvalidMFACode) {
+			return err
+		}
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to verify MFA code", err)
+	}
+	mfa.LastUsedStep = step
+	return nil
+}
+
+// verifyCodeOrRecovery menerima kode TOTP enam digit atau kode pemulihan.
+func (uc *mfaUsecase) verifyCodeOrRecovery(ctx context.Context, mfa *domain.UserMFA, code string) error {
+	code = strings.TrimSpace(code)
+	if len(code) == app_totp.Digits {
+		if _, err := strconv.Atoi(code); err == nil {
+			return uc.verifyTOTP(ctx, mfa, code)
+		}
+	}
+
+	if err := uc.mfaRepo.UseRecoveryCode(ctx, mfa.UserID, hashToken(normalizeRecoveryCode(code))); err != nil {
+		if errors.Is(err, domain.ErrInvalidMFACode) {
+			return err
+		}
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to verify MFA code", err)
+	}
+
+	uc.logger.Info("MFA recovery code used", zap.Uint("user_id", mfa.UserID))
+	return nil
+}
+
+func (uc *mfaUsecase) decryptSecret(mfa *domain.UserMFA) (string, error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(mfa.SecretEncrypted)
+	if err != nil {
+		return "", err
+	}
+	secret, err := uc.aead.Open(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	return string(secret), nil
+}
+
+// replaceRecoveryCodes membuat kode pemulihan baru dan menyimpan hash-nya.
+func (uc *mfaUsecase) replaceRecoveryCodes(ctx context.Context, userID uint) ([]string, error) {
+	codes := make([]string, 0, mfaRecoveryCodeCount)
+	hashes := make([]string, 0, mfaRecoveryCodeCount)
+	for i := 0; i < mfaRecoveryCodeCount; i++ {
+		b := make([]byte, mfaRecoveryCodeBytes)
+		if _, err := rand.Read(b); err != nil {
+			return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to generate recovery codes", err)
+		}
+		raw := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))
+		codes = append(codes, raw[:5]+"-"+raw[5:])
+		hashes = append(hashes, hashToken(raw))
+	}
+
+	if err := uc.mfaRepo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to generate recovery codes", err)
+	}
+	return codes, nil
+}
+
+// normalizeRecoveryCode menyamakan format kode pemulihan sehingga huruf besar dan tanda hubung
+// tidak berpengaruh.
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
+
+// loadChallenge mengambil tantangan MFA yang masih berlaku beserta hash token-nya.
+func (uc *mfaUsecase) loadChallenge(ctx context.Context, rawToken string) (*mfaChallengeState, string, error) {
+	tokenHash := hashToken(rawToken)
+
+	value, err := uc.store.Get(ctx, mfaChallengeKeyPrefix+tokenHash)
+	if err != nil {
+		if errors.Is(err, app_redis.ErrKeyNotFound) {
+			return nil, "", domain.ErrInvalidMFAChallenge
+		}
+		return nil, "", domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to verify MFA", err)
+	}
+
+	state, err := parseChallengeState(value)
+	if err != nil || !time.Now().Before(state.ExpiresAt) {
+		return nil, "", domain.ErrInvalidMFAChallenge
+	}
+	return state, tokenHash, nil
+}
+
+func (uc *mfaUsecase) saveChallenge(ctx context.Context, tokenHash string, state *mfaChallengeState) error {
+	value := fmt.Sprintf("%d:%d", state.UserID, state.ExpiresAt.Unix())
+	return uc.store.Set(ctx, mfaChallengeKeyPrefix+tokenHash, value, time.Until(state.ExpiresAt))
+}
+
+// deleteChallenge menghapus tantangan beserta hitungan percobaannya.
+func (uc *mfaUsecase) deleteChallenge(ctx context.Context, tokenHash string, userID uint) {
+	for _, key := range []string{mfaChallengeKeyPrefix + tokenHash, mfaAttemptsKeyPrefix + tokenHash} {
+		if err := uc.store.Del(ctx, key); err != nil {
+			uc.logger.Warn("Failed to delete MFA challenge", zap.Error(err), zap.Uint("user_id", userID))
+		}
+	}
+}
+
+// recordFailedAttempt membatalkan tantangan setelah mfaMaxAttempts percobaan dan menambah hitungan
+// kode salah milik user. Setelah mfaUserMaxFailures kegagalan, user tidak bisa memulai maupun
+// menyelesaikan tantangan MFA selama mfaUserLockout.
+func (uc *mfaUsecase) recordFailedAttempt(ctx context.Context, tokenHash string, state *mfaChallengeState, attempt int64) {
+	if attempt >= mfaMaxAttempts {
+		uc.logger.Warn("MFA challenge cancelled after too many failed attempts", zap.Uint("user_id", state.UserID))
+		uc.deleteChallenge(ctx, tokenHash, state.UserID)
+	}
+
+	id := strconv.FormatUint(uint64(state.UserID), 10)
+	failures, err := uc.store.Incr(ctx, mfaFailuresKeyPrefix+id, mfaUserLockout)
+	if err != nil {
+		uc.logger.Error("Failed to record MFA failure", zap.Error(err), zap.Uint("user_id", state.UserID))
+		return
+	}
+	if failures < mfaUserMaxFailures {
+		return
+	}
+
+	blockedUntil := strconv.FormatInt(time.Now().Add(mfaUserLockout).UnixMilli(), 10)
+	locked, err := uc.store.SetNX(ctx, mfaLockKeyPrefix+id, blockedUntil, mfaUserLockout)
+	if err != nil {
+		uc.logger.Error("Failed to lock MFA", zap.Error(err), zap.Uint("user_id", state.UserID))
+		return
+	}
+	if locked {
+		uc.logger.Warn("MFA locked after too many failed codes", zap.Uint("user_id", state.UserID), zap.Int64("failures", failures))
+	}
+}
+
+// checkUserLock mengembalikan LoginThrottledError selama user terkunci akibat kode MFA salah.
+func (uc *mfaUsecase) checkUserLock(ctx context.Context, userID uint) error {
+	value, err := uc.store.Get(ctx, mfaLockKeyPrefix+strconv.FormatUint(uint64(userID), 10))
+	if err != nil {
+		if errors.Is(err, app_redis.ErrKeyNotFound) {
+			return nil
+		}
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to verify MFA", err)
+	}
+
+	blockedUntil, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		uc.logger.Warn("Discarding malformed MFA lock", zap.Error(err), zap.Uint("user_id", userID))
+		return nil
+	}
+	if wait := time.Until(time.UnixMilli(blockedUntil)); wait > 0 {
+		return &domain.LoginThrottledError{RetryAfter: wait}
+	}
+	return nil
+}
+
+func parseChallengeState(value string) (*mfaChallengeState, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("malformed MFA challenge")
+	}
+
+	userID, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mfaChallengeState{
+		UserID:    uint(userID),
+		ExpiresAt: time.Unix(expiresAt, 0),
+	}, nil
+}
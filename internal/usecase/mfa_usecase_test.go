@@ -0,0 +1,419 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/internal/mocks"
+	"github.com/X3nonxe/gopsy-backend/internal/usecase"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_crypto"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_redis"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_totp"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testTOTPSecret = "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+
+func testMFACipher(t *testing.T) *app_crypto.AEAD {
+	t.Helper()
+	aead, err := app_crypto.NewAESGCM([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+	return aead
+}
+
+func enabledMFA(t *testing.T, aead *app_crypto.AEAD, userID uint) *domain.UserMFA {
+	t.Helper()
+	encrypted, err := aead.Seal([]byte(testTOTPSecret))
+	require.NoError(t, err)
+	enabledAt := time.Now().Add(-time.Hour)
+	return &domain.UserMFA{
+		UserID:          userID,
+		SecretEncrypted: base64.StdEncoding.EncodeToString(encrypted),
+		EnabledAt:       &enabledAt,
+	}
+}
+
+func currentTOTP(t *testing.T, secret string) string {
+	t.Helper()
+	code, err := app_totp.Code(secret, time.Now())
+	require.NoError(t, err)
+	return code
+}
+
+func TestMFAUsecase_BeginChallenge(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	aead := testMFACipher(t)
+	mockMFARepo := mocks.NewMockMFARepository(mockCtrl)
+	mfaUsecase := usecase.NewMFAUsecase(mockMFARepo, mocks.NewMockUserRepository(mockCtrl), mocks.NewMockAuthUsecase(mockCtrl),
+		aead, app_redis.NewMemoryRedis(), "Gopsy", 5*time.Minute, zap.NewNop())
+
+	ctx := context.Background()
+	psychologist := &domain.User{ID: 1, Role: "psikolog"}
+
+	t.Run("Client Role Skips MFA", func(t *testing.T) {
+		challenge, err := mfaUsecase.BeginChallenge(ctx, &domain.User{ID: 2, Role: "klien"})
+
+		assert.NoError(t, err)
+		assert.Nil(t, challenge)
+	})
+
+	t.Run("MFA Enabled", func(t *testing.T) {
+		mockMFARepo.EXPECT().GetByUserID(ctx, uint(1)).Return(enabledMFA(t, aead, 1), nil).Times(1)
+
+		challenge, err := mfaUsecase.BeginChallenge(ctx, psychologist)
+
+		assert.NoError(t, err)
+		require.NotNil(t, challenge)
+		assert.NotEmpty(t, challenge.Token)
+		assert.Equal(t, int64(300), challenge.ExpiresIn)
+		assert.False(t, challenge.EnrollmentRequired)
+	})
+
+	t.Run("Not Enrolled And Not Required", func(t *testing.T) {
+		mockMFARepo.EXPECT().GetByUserID(ctx, uint(1)).Return(nil, domain.ErrMFANotFound).Times(1)
+		mockMFARepo.EXPECT().GetPolicy(ctx, "psikolog").Return(nil, domain.ErrMFAPolicyNotFound).Times(1)
+
+		challenge, err := mfaUsecase.BeginChallenge(ctx, psychologist)
+
+		assert.NoError(t, err)
+		assert.Nil(t, challenge)
+	})
+
+	t.Run("Not Enrolled But Required", func(t *testing.T) {
+		mockMFARepo.EXPECT().GetByUserID(ctx, uint(1)).Return(nil, domain.ErrMFANotFound).Times(1)
+		mockMFARepo.EXPECT().GetPolicy(ctx, "psikolog").Return(&domain.MFARolePolicy{Role: "psikolog", Required: true}, nil).Times(1)
+
+		challenge, err := mfaUsecase.BeginChallenge(ctx, psychologist)
+
+		assert.NoError(t, err)
+		require.NotNil(t, challenge)
+		assert.True(t, challenge.EnrollmentRequired)
+	})
+}
+
+func TestMFAUsecase_VerifyChallenge(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	aead := testMFACipher(t)
+	mockMFARepo := mocks.NewMockMFARepository(mockCtrl)
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	mockAuthUsecase := mocks.NewMockAuthUsecase(mockCtrl)
+	mfaUsecase := usecase.NewMFAUsecase(mockMFARepo, mockUserRepo, mockAuthUsecase,
+		aead, app_redis.NewMemoryRedis(), "Gopsy", 5*time.Minute, zap.NewNop())
+
+	ctx := context.Background()
+	user := &domain.User{ID: 1, Email: "psikolog@example.com", Role: "admin"}
+
+	beginChallenge := func(t *testing.T) string {
+		mockMFARepo.EXPECT().GetByUserID(ctx, uint(1)).Return(enabledMFA(t, aead, 1), nil).Times(1)
+		challenge, err := mfaUsecase.BeginChallenge(ctx, user)
+		require.NoError(t, err)
+		require.NotNil(t, challenge)
+		return challenge.Token
+	}
+
+	t.Run("Success With TOTP", func(t *testing.T) {
+		token := beginChallenge(t)
+
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(user, nil).Times(1)
+		mockMFARepo.EXPECT().GetByUserID(ctx, uint(1)).Return(enabledMFA(t, aead, 1), nil).Times(1)
+		mockMFARepo.EXPECT().UpdateLastUsedStep(ctx, uint(1), gomock.Any()).Return(nil).Times(1)
+		mockAuthUsecase.EXPECT().
+			IssueTokens(ctx, user, nil).
+			Return(&domain.TokenPair{Token: "access-token", RefreshToken: "refresh-token"}, nil).
+			Times(1)
+
+		response, err := mfaUsecase.VerifyChallenge(ctx, &domain.MFAVerifyPayload{MFAToken: token, Code: currentTOTP(t, testTOTPSecret)}, nil)
+
+		assert.NoError(t, err)
+		require.NotNil(t, response)
+		assert.Equal(t, "access-token", response.Token)
+		assert.Equal(t, user.ID, response.User.ID)
+
+		// Tantangan hanya bisa dipakai sekali
+		_, err = mfaUsecase.VerifyChallenge(ctx, &domain.MFAVerifyPayload{MFAToken: token, Code: "000000"}, nil)
+		assert.True(t, errors.Is(err, domain.ErrInvalidMFAChallenge))
+	})
+
+	t.Run("Success With Recovery Code", func(t *testing.T) {
+		token := beginChallenge(t)
+
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(user, nil).Times(1)
+		mockMFARepo.EXPECT().GetByUserID(ctx, uint(1)).Return(enabledMFA(t, aead, 1), nil).Times(1)
+		mockMFARepo.EXPECT().UseRecoveryCode(ctx, uint(1), sha256Hex("abcde23456")).Return(nil).Times(1)
+		mockAuthUsecase.EXPECT().IssueTokens(ctx, user, nil).Return(&domain.TokenPair{Token: "access-token"}, nil).Times(1)
+
+		response, err := mfaUsecase.VerifyChallenge(ctx, &domain.MFAVerifyPayload{MFAToken: token, Code: "ABCDE-23456"}, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "access-token", response.Token)
+	})
+
+	t.Run("Replayed Code", func(t *testing.T) {
+		token := beginChallenge(t)
+
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(user, nil).Times(1)
+		mockMFARepo.EXPECT().GetByUserID(ctx, uint(1)).Return(enabledMFA(t, aead, 1), nil).Times(1)
+		mockMFARepo.EXPECT().UpdateLastUsedStep(ctx, uint(1), gomock.Any()).Return(domain.ErrInvalidMFACode).Times(1)
+
+		response, err := mfaUsecase.VerifyChallenge(ctx, &domain.MFAVerifyPayload{MFAToken: token, Code: currentTOTP(t, testTOTPSecret)}, nil)
+
+		assert.True(t, errors.Is(err, domain.ErrInvalidMFACode))
+		assert.Nil(t, response)
+	})
+
+	t.Run("Challenge Cancelled After Too Many Failures", func(t *testing.T) {
+		token := beginChallenge(t)
+
+		for i := 0; i < 5; i++ {
+			mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(user, nil).Times(1)
+			mockMFARepo.EXPECT().GetByUserID(ctx, uint(1)).Return(enabledMFA(t, aead, 1), nil).Times(1)
+			mockMFARepo.EXPECT().UseRecoveryCode(ctx, uint(1), gomock.Any()).Return(domain.ErrInvalidMFACode).Times(1)
+
+			_, err := mfaUsecase.VerifyChallenge(ctx, &domain.MFAVerifyPayload{MFAToken: token, Code: "wrong-code"}, nil)
+			assert.True(t, errors.Is(err, domain.ErrInvalidMFACode))
+		}
+
+		_, err := mfaUsecase.VerifyChallenge(ctx, &domain.MFAVerifyPayload{MFAToken: token, Code: currentTOTP(t, testTOTPSecret)}, nil)
+		assert.True(t, errors.Is(err, domain.ErrInvalidMFAChallenge))
+	})
+
+	t.Run("Unknown Challenge", func(t *testing.T) {
+		response, err := mfaUsecase.VerifyChallenge(ctx, &domain.MFAVerifyPayload{MFAToken: "unknown", Code: "123456"}, nil)
+
+		assert.True(t, errors.Is(err, domain.ErrInvalidMFAChallenge))
+		assert.Nil(t, response)
+	})
+}
+
+func TestMFAUsecase_FailureLimits(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	aead := testMFACipher(t)
+	mockMFARepo := mocks.NewMockMFARepository(mockCtrl)
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+
+	ctx := context.Background()
+	user := &domain.User{ID: 1, Email: "psikolog@example.com", Role: "psikolog"}
+
+	mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(user, nil).AnyTimes()
+	mockMFARepo.EXPECT().GetByUserID(ctx, uint(1)).DoAndReturn(func(context.Context, uint) (*domain.UserMFA, error) {
+		return enabledMFA(t, aead, 1), nil
+	}).AnyTimes()
+
+	newUsecase := func() domain.MFAUsecase {
+		return usecase.NewMFAUsecase(mockMFARepo, mockUserRepo, mocks.NewMockAuthUsecase(mockCtrl),
+			aead, app_redis.NewMemoryRedis(), "Gopsy", 5*time.Minute, zap.NewNop())
+	}
+
+	t.Run("Parallel Attempts Share One Challenge Limit", func(t *testing.T) {
+		mfaUsecase := newUsecase()
+		challenge, err := mfaUsecase.BeginChallenge(ctx, user)
+		require.NoError(t, err)
+
+		// Hanya lima percobaan yang sampai ke pemeriksaan kode walaupun dikirim bersamaan
+		mockMFARepo.EXPECT().UseRecoveryCode(ctx, uint(1), gomock.Any()).Return(domain.ErrInvalidMFACode).Times(5)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := mfaUsecase.VerifyChallenge(ctx, &domain.MFAVerifyPayload{MFAToken: challenge.Token, Code: "wrong-code"}, nil)
+				assert.True(t, errors.Is(err, domain.ErrInvalidMFACode) || errors.Is(err, domain.ErrInvalidMFAChallenge), "unexpected error %v", err)
+			}()
+		}
+		wg.Wait()
+
+		_, err = mfaUsecase.VerifyChallenge(ctx, &domain.MFAVerifyPayload{MFAToken: challenge.Token, Code: currentTOTP(t, testTOTPSecret)}, nil)
+		assert.True(t, errors.Is(err, domain.ErrInvalidMFAChallenge))
+	})
+
+	t.Run("User Locked Across Challenges", func(t *testing.T) {
+		mfaUsecase := newUsecase()
+		mockMFARepo.EXPECT().UseRecoveryCode(ctx, uint(1), gomock.Any()).Return(domain.ErrInvalidMFACode).Times(10)
+
+		for i := 0; i < 2; i++ {
+			challenge, err := mfaUsecase.BeginChallenge(ctx, user)
+			require.NoError(t, err)
+			for j := 0; j < 5; j++ {
+				_, err := mfaUsecase.VerifyChallenge(ctx, &domain.MFAVerifyPayload{MFAToken: challenge.Token, Code: "wrong-code"}, nil)
+				assert.True(t, errors.Is(err, domain.ErrInvalidMFACode))
+			}
+		}
+
+		_, err := mfaUsecase.BeginChallenge(ctx, user)
+		var throttled *domain.LoginThrottledError
+		require.True(t, errors.As(err, &throttled), "expected LoginThrottledError, got %v", err)
+		assert.InDelta(t, (15 * time.Minute).Seconds(), throttled.RetryAfter.Seconds(), 1)
+
+		require.NoError(t, mfaUsecase.ResetFailures(ctx, user.ID))
+
+		challenge, err := mfaUsecase.BeginChallenge(ctx, user)
+		assert.NoError(t, err)
+		assert.NotNil(t, challenge)
+	})
+}
+
+func TestMFAUsecase_EnrollAndConfirm(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	aead := testMFACipher(t)
+	mockMFARepo := mocks.NewMockMFARepository(mockCtrl)
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	mfaUsecase := usecase.NewMFAUsecase(mockMFARepo, mockUserRepo, mocks.NewMockAuthUsecase(mockCtrl),
+		aead, app_redis.NewMemoryRedis(), "Gopsy", 5*time.Minute, zap.NewNop())
+
+	ctx := context.Background()
+	user := &domain.User{ID: 1, Email: "psikolog@example.com", Role: "psikolog"}
+
+	t.Run("Success", func(t *testing.T) {
+		var saved *domain.UserMFA
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(user, nil).Times(1)
+		mockMFARepo.EXPECT().GetByUserID(ctx, uint(1)).Return(nil, domain.ErrMFANotFound).Times(1)
+		mockMFARepo.EXPECT().
+			Save(ctx, gomock.Any()).
+			Do(func(ctx context.Context, mfa *domain.UserMFA) {
+				saved = mfa
+			}).
+			Return(nil).
+			Times(1)
+
+		enrollment, err := mfaUsecase.Enroll(ctx, 1)
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, enrollment.Secret)
+		assert.Contains(t, enrollment.ProvisioningURI, "otpauth://totp/Gopsy:psikolog@example.com")
+		require.NotNil(t, saved)
+		assert.False(t, saved.IsEnabled())
+		assert.NotContains(t, saved.SecretEncrypted, enrollment.Secret)
+
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(user, nil).Times(1)
+		mockMFARepo.EXPECT().GetByUserID(ctx, uint(1)).Return(saved, nil).Times(1)
+		mockMFARepo.EXPECT().UpdateLastUsedStep(ctx, uint(1), gomock.Any()).Return(nil).Times(1)
+		mockMFARepo.EXPECT().
+			ReplaceRecoveryCodes(ctx, uint(1), gomock.Any()).
+			Do(func(ctx context.Context, userID uint, hashes []string) {
+				assert.Len(t, hashes, 10)
+			}).
+			Return(nil).
+			Times(1)
+		mockMFARepo.EXPECT().
+			Save(ctx, gomock.Any()).
+			Do(func(ctx context.Context, mfa *domain.UserMFA) {
+				assert.True(t, mfa.IsEnabled())
+			}).
+			Return(nil).
+			Times(1)
+
+		codes, err := mfaUsecase.ConfirmEnrollment(ctx, 1, &domain.MFACodePayload{Code: currentTOTP(t, enrollment.Secret)})
+
+		assert.NoError(t, err)
+		assert.Len(t, codes.RecoveryCodes, 10)
+	})
+
+	t.Run("Already Enabled", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(user, nil).Times(1)
+		mockMFARepo.EXPECT().GetByUserID(ctx, uint(1)).Return(enabledMFA(t, aead, 1), nil).Times(1)
+
+		enrollment, err := mfaUsecase.Enroll(ctx, 1)
+
+		assert.True(t, errors.Is(err, domain.ErrMFAAlreadyEnabled))
+		assert.Nil(t, enrollment)
+	})
+
+	t.Run("Client Not Allowed", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(ctx, uint(2)).Return(&domain.User{ID: 2, Role: "klien"}, nil).Times(1)
+
+		enrollment, err := mfaUsecase.Enroll(ctx, 2)
+
+		assert.True(t, errors.Is(err, domain.ErrMFANotAllowed))
+		assert.Nil(t, enrollment)
+	})
+}
+
+func TestMFAUsecase_Disable(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	aead := testMFACipher(t)
+	mockMFARepo := mocks.NewMockMFARepository(mockCtrl)
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	mfaUsecase := usecase.NewMFAUsecase(mockMFARepo, mockUserRepo, mocks.NewMockAuthUsecase(mockCtrl),
+		aead, app_redis.NewMemoryRedis(), "Gopsy", 5*time.Minute, zap.NewNop())
+
+	ctx := context.Background()
+	user := &domain.User{ID: 1, Role: "psikolog"}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(user, nil).Times(1)
+		mockMFARepo.EXPECT().GetByUserID(ctx, uint(1)).Return(enabledMFA(t, aead, 1), nil).Times(1)
+		mockMFARepo.EXPECT().GetPolicy(ctx, "psikolog").Return(&domain.MFARolePolicy{Role: "psikolog"}, nil).Times(1)
+		mockMFARepo.EXPECT().UpdateLastUsedStep(ctx, uint(1), gomock.Any()).Return(nil).Times(1)
+		mockMFARepo.EXPECT().Delete(ctx, uint(1)).Return(nil).Times(1)
+
+		err := mfaUsecase.Disable(ctx, 1, &domain.MFACodePayload{Code: currentTOTP(t, testTOTPSecret)})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Required By Policy", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(user, nil).Times(1)
+		mockMFARepo.EXPECT().GetByUserID(ctx, uint(1)).Return(enabledMFA(t, aead, 1), nil).Times(1)
+		mockMFARepo.EXPECT().GetPolicy(ctx, "psikolog").Return(&domain.MFARolePolicy{Role: "psikolog", Required: true}, nil).Times(1)
+
+		err := mfaUsecase.Disable(ctx, 1, &domain.MFACodePayload{Code: currentTOTP(t, testTOTPSecret)})
+
+		assert.True(t, errors.Is(err, domain.ErrMFARequiredByPolicy))
+	})
+}
+
+func TestMFAUsecase_Policies(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockMFARepo := mocks.NewMockMFARepository(mockCtrl)
+	mfaUsecase := usecase.NewMFAUsecase(mockMFARepo, mocks.NewMockUserRepository(mockCtrl), mocks.NewMockAuthUsecase(mockCtrl),
+		testMFACipher(t), app_redis.NewMemoryRedis(), "Gopsy", 5*time.Minute, zap.NewNop())
+
+	ctx := context.Background()
+	required := true
+
+	t.Run("Missing Policies Default To Optional", func(t *testing.T) {
+		mockMFARepo.EXPECT().GetPolicies(ctx).Return([]domain.MFARolePolicy{{Role: "psikolog", Required: true}}, nil).Times(1)
+
+		policies, err := mfaUsecase.GetPolicies(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.MFARolePolicy{{Role: "admin"}, {Role: "psikolog", Required: true}}, policies)
+	})
+
+	t.Run("Set Policy", func(t *testing.T) {
+		mockMFARepo.EXPECT().UpsertPolicy(ctx, &domain.MFARolePolicy{Role: "admin", Required: true}).Return(nil).Times(1)
+
+		policy, err := mfaUsecase.SetPolicy(ctx, "admin", &domain.MFAPolicyPayload{Required: &required})
+
+		assert.NoError(t, err)
+		assert.True(t, policy.Required)
+	})
+
+	t.Run("Unsupported Role", func(t *testing.T) {
+		policy, err := mfaUsecase.SetPolicy(ctx, "klien", &domain.MFAPolicyPayload{Required: &required})
+
+		assert.Error(t, err)
+		assert.Nil(t, policy)
+	})
+}
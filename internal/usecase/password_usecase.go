@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_password"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type passwordUsecase struct {
+	userRepo          domain.UserRepository
+	passwordResetRepo domain.PasswordResetRepository
+	authUsecase       domain.AuthUsecase
+	mailer            domain.Mailer
+	resetURL          string
+	resetTokenTTL     time.Duration
+	policy            app_password.Policy
+	logger            *zap.Logger
+}
+
+// NewPasswordUsecase membuat instance baru dari passwordUsecase. resetURL adalah halaman frontend
+// yang menerima token reset sebagai query parameter "token".
+func NewPasswordUsecase(
+	ur domain.UserRepository,
+	prr domain.PasswordResetRepository,
+	au domain.AuthUsecase,
+	mailer domain.Mailer,
+	resetURL string,
+	resetTokenTTL time.Duration,
+	policy app_password.Policy,
+	logger *zap.Logger,
+) domain.PasswordUsecase {
+	return &passwordUsecase{
+		userRepo:          ur,
+		passwordResetRepo: prr,
+		authUsecase:       au,
+		mailer:            mailer,
+		resetURL:          resetURL,
+		resetTokenTTL:     resetTokenTTL,
+		policy:            policy,
+		logger:            logger,
+	}
+}
+
+// ForgotPassword mengirim link reset password ke email yang terdaftar. Hasilnya selalu sama untuk
+// email yang terdaftar maupun tidak agar keberadaan akun tidak bisa ditebak.
+func (uc *passwordUsecase) ForgotPassword(ctx context.Context, payload *domain.ForgotPasswordPayload) error {
+	email := strings.ToLower(strings.TrimSpace(payload.Email))
+
+	user, err := uc.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			uc.logger.Debug("Password reset requested for unknown email")
+			return nil
+		}
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to process password reset request", err)
+	}
+
+	// Semua pekerjaan khusus email terdaftar berjalan di background agar waktu respons tidak
+	// membedakan email terdaftar dan tidak
+	go uc.sendResetLink(context.WithoutCancel(ctx), user)
+	return nil
+}
+
+// sendResetLink membatalkan link reset sebelumnya, menerbitkan token baru, lalu mengirimkannya.
+// Kegagalan hanya dicatat karena respons ForgotPassword sudah dikirim.
+func (uc *passwordUsecase) sendResetLink(ctx context.Context, user *domain.User) {
+	// Hanya link terbaru yang berlaku
+	if err := uc.passwordResetRepo.InvalidateAllForUser(ctx, user.ID); err != nil {
+		uc.logger.Error("Failed to invalidate password reset tokens", zap.Error(err), zap.Uint("user_id", user.ID))
+		return
+	}
+
+	rawToken, err := generateOpaqueToken()
+	if err != nil {
+		uc.logger.Error("Failed to generate password reset token", zap.Error(err), zap.Uint("user_id", user.ID))
+		return
+	}
+
+	resetToken := &domain.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(rawToken),
+		ExpiresAt: time.Now().Add(uc.resetTokenTTL),
+	}
+	if err := uc.passwordResetRepo.Create(ctx, resetToken); err != nil {
+		uc.logger.Error("Failed to store password reset token", zap.Error(err), zap.Uint("user_id", user.ID))
+		return
+	}
+
+	message := &domain.EmailMessage{
+		To:      user.Email,
+		Subject: "Reset your Gopsy password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nWe received a request to reset your password. Open the link below to choose a new one:\n\n%s\n\n"+
+				"The link expires in %d minutes and can only be used once. If you did not request this, you can ignore this email.\n",
+			user.Username, buildTokenLink(uc.resetURL, rawToken), int(uc.resetTokenTTL.Minutes()),
+		),
+	}
+	if err := uc.mailer.Send(ctx, message); err != nil {
+		uc.logger.Error("Failed to send password reset email", zap.Error(err), zap.Uint("user_id", user.ID))
+	}
+}
+
+// ResetPassword mengganti password memakai token reset lalu mencabut semua sesi user.
+func (uc *passwordUsecase) ResetPassword(ctx context.Context, payload *domain.ResetPasswordPayload) error {
+	stored, err := uc.passwordResetRepo.GetByHash(ctx, hashToken(payload.Token))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPasswordResetToken) {
+			return err
+		}
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to reset password", err)
+	}
+
+	if stored.UsedAt != nil || !time.Now().Before(stored.ExpiresAt) {
+		return domain.ErrInvalidPasswordResetToken
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, stored.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return domain.ErrInvalidPasswordResetToken
+		}
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to reset password", err)
+	}
+
+	if err := checkPasswordPolicy(uc.policy, payload.NewPassword, user.Username, user.Email); err != nil {
+		return err
+	}
+
+	// Token ditandai terpakai setelah password lolos kebijakan agar link yang sama bisa dicoba lagi.
+	// Update bersyarat memastikan token hanya bisa dipakai sekali walaupun dikirim bersamaan
+	if err := uc.passwordResetRepo.MarkUsed(ctx, stored.ID); err != nil {
+		if errors.Is(err, domain.ErrInvalidPasswordResetToken) {
+			return err
+		}
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to reset password", err)
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(payload.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to reset password", err)
+	}
+
+	if err := uc.userRepo.UpdatePassword(ctx, user.ID, string(hashedPassword)); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return domain.ErrInvalidPasswordResetToken
+		}
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to reset password", err)
+	}
+
+	// Sesi lama bisa saja milik orang yang mengambil alih akun, jadi semuanya dicabut
+	if err := uc.authUsecase.LogoutAll(ctx, user.ID); err != nil {
+		return err
+	}
+
+	uc.logger.Info("Password reset completed", zap.Uint("user_id", user.ID))
+	return nil
+}
+
+// buildTokenLink menambahkan token sebagai query parameter "token" pada URL frontend.
+func buildTokenLink(baseURL, rawToken string) string {
+	separator := "?"
+	if strings.Contains(baseURL, "?") {
+		separator = "&"
+	}
+	return baseURL + separator + "token=" + url.QueryEscape(rawToken)
+}
+
+// ChangePassword mengganti password setelah password lama dicocokkan, lalu mencabut semua sesi
+// lain agar perangkat yang mungkin sudah disusupi ikut keluar.
+func (uc *passwordUsecase) ChangePassword(ctx context.Context, userID uint, currentSessionID string, payload *domain.ChangePasswordPayload) error {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return domain.NewDomainError(http.StatusNotFound, "User not found")
+		}
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to change password", err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(payload.CurrentPassword)); err != nil {
+		return domain.ErrIncorrectCurrentPassword
+	}
+	if payload.NewPassword == payload.CurrentPassword {
+		return domain.ErrPasswordUnchanged
+	}
+	if err := checkPasswordPolicy(uc.policy, payload.NewPassword, user.Username, user.Email); err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(payload.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to change password", err)
+	}
+
+	if err := uc.userRepo.UpdatePassword(ctx, user.ID, string(hashedPassword)); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return domain.NewDomainError(http.StatusNotFound, "User not found")
+		}
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to change password", err)
+	}
+
+	// Link reset yang masih berlaku tidak boleh mengembalikan akses setelah password diganti
+	if err := uc.passwordResetRepo.InvalidateAllForUser(ctx, user.ID); err != nil {
+		uc.logger.Warn("Failed to invalidate password reset tokens", zap.Error(err), zap.Uint("user_id", user.ID))
+	}
+
+	if err := uc.authUsecase.RevokeOtherSessions(ctx, user.ID, currentSessionID); err != nil {
+		return err
+	}
+
+	uc.logger.Info("Password changed", zap.Uint("user_id", user.ID))
+	return nil
+}
+
+// checkPasswordPolicy memeriksa password baru terhadap kebijakan password dan mengembalikan
+// DomainError yang menyebutkan semua aturan yang belum dipenuhi.
+func checkPasswordPolicy(policy app_password.Policy, password string, userInputs ...string) error {
+	err := policy.Validate(password, userInputs...)
+	if err == nil {
+		return nil
+	}
+
+	var policyErr *app_password.PolicyError
+	if errors.As(err, &policyErr) {
+		return domain.NewDomainError(http.StatusBadRequest, "Password must "+strings.Join(policyErr.Violations, ", "))
+	}
+	return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to validate password", err)
+}
@@ -0,0 +1,255 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/internal/mocks"
+	"github.com/X3nonxe/gopsy-backend/internal/usecase"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_password"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestPasswordUsecase_ForgotPassword(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	mockPasswordResetRepo := mocks.NewMockPasswordResetRepository(mockCtrl)
+	mockMailer := mocks.NewMockMailer(mockCtrl)
+	passwordUsecase := usecase.NewPasswordUsecase(mockUserRepo, mockPasswordResetRepo, mocks.NewMockAuthUsecase(mockCtrl), mockMailer,
+		"https://app.gopsy.test/reset-password", time.Hour, app_password.DefaultPolicy(), zap.NewNop())
+
+	ctx := context.Background()
+	user := &domain.User{ID: 1, Username: "budi", Email: "budi@example.com"}
+
+	t.Run("Registered Email Sends Link", func(t *testing.T) {
+		var stored *domain.PasswordResetToken
+		sent := make(chan *domain.EmailMessage, 1)
+
+		mockUserRepo.EXPECT().GetByEmail(ctx, "budi@example.com").Return(user, nil).Times(1)
+		mockPasswordResetRepo.EXPECT().InvalidateAllForUser(gomock.Any(), user.ID).Return(nil).Times(1)
+		mockPasswordResetRepo.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			Do(func(ctx context.Context, token *domain.PasswordResetToken) {
+				stored = token
+			}).
+			Return(nil).
+			Times(1)
+		mockMailer.EXPECT().
+			Send(gomock.Any(), gomock.Any()).
+			Do(func(ctx context.Context, message *domain.EmailMessage) {
+				sent <- message
+			}).
+			Return(nil).
+			Times(1)
+
+		err := passwordUsecase.ForgotPassword(ctx, &domain.ForgotPasswordPayload{Email: " Budi@Example.com "})
+		require.NoError(t, err)
+
+		select {
+		case message := <-sent:
+			assert.Equal(t, user.Email, message.To)
+
+			// Link berisi token mentah, sedangkan database hanya menyimpan hash-nya
+			idx := strings.Index(message.Body, "https://app.gopsy.test/reset-password?token=")
+			require.True(t, idx >= 0)
+			rawToken := strings.Fields(message.Body[idx+len("https://app.gopsy.test/reset-password?token="):])[0]
+			assert.Equal(t, sha256Hex(rawToken), stored.TokenHash)
+			assert.WithinDuration(t, time.Now().Add(time.Hour), stored.ExpiresAt, time.Minute)
+		case <-time.After(time.Second):
+			t.Fatal("password reset email was not sent")
+		}
+	})
+
+	t.Run("Unknown Email Looks The Same", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByEmail(ctx, "unknown@example.com").Return(nil, domain.ErrUserNotFound).Times(1)
+
+		err := passwordUsecase.ForgotPassword(ctx, &domain.ForgotPasswordPayload{Email: "unknown@example.com"})
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestPasswordUsecase_ResetPassword(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	mockPasswordResetRepo := mocks.NewMockPasswordResetRepository(mockCtrl)
+	mockAuthUsecase := mocks.NewMockAuthUsecase(mockCtrl)
+	passwordUsecase := usecase.NewPasswordUsecase(mockUserRepo, mockPasswordResetRepo, mockAuthUsecase, mocks.NewMockMailer(mockCtrl),
+		"https://app.gopsy.test/reset-password", time.Hour, app_password.DefaultPolicy(), zap.NewNop())
+
+	ctx := context.Background()
+	rawToken := "raw-reset-token"
+	payload := &domain.ResetPasswordPayload{Token: rawToken, NewPassword: "Tiga-Kucing-Lompat9"}
+
+	validToken := func() *domain.PasswordResetToken {
+		return &domain.PasswordResetToken{ID: 5, UserID: 1, TokenHash: sha256Hex(rawToken), ExpiresAt: time.Now().Add(time.Hour)}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockPasswordResetRepo.EXPECT().GetByHash(ctx, sha256Hex(rawToken)).Return(validToken(), nil).Times(1)
+		mockPasswordResetRepo.EXPECT().MarkUsed(ctx, uint(5)).Return(nil).Times(1)
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(&domain.User{ID: 1, Password: "old-hash"}, nil).Times(1)
+		mockUserRepo.EXPECT().
+			UpdatePassword(ctx, uint(1), gomock.Any()).
+			Do(func(ctx context.Context, id uint, hashedPassword string) {
+				assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(payload.NewPassword)))
+			}).
+			Return(nil).
+			Times(1)
+		mockAuthUsecase.EXPECT().LogoutAll(ctx, uint(1)).Return(nil).Times(1)
+
+		err := passwordUsecase.ResetPassword(ctx, payload)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Expired Token", func(t *testing.T) {
+		expired := validToken()
+		expired.ExpiresAt = time.Now().Add(-time.Minute)
+		mockPasswordResetRepo.EXPECT().GetByHash(ctx, sha256Hex(rawToken)).Return(expired, nil).Times(1)
+
+		err := passwordUsecase.ResetPassword(ctx, payload)
+
+		assert.True(t, errors.Is(err, domain.ErrInvalidPasswordResetToken))
+	})
+
+	t.Run("Used Token", func(t *testing.T) {
+		used := validToken()
+		usedAt := time.Now().Add(-time.Minute)
+		used.UsedAt = &usedAt
+		mockPasswordResetRepo.EXPECT().GetByHash(ctx, sha256Hex(rawToken)).Return(used, nil).Times(1)
+
+		err := passwordUsecase.ResetPassword(ctx, payload)
+
+		assert.True(t, errors.Is(err, domain.ErrInvalidPasswordResetToken))
+	})
+
+	t.Run("Concurrent Use", func(t *testing.T) {
+		mockPasswordResetRepo.EXPECT().GetByHash(ctx, sha256Hex(rawToken)).Return(validToken(), nil).Times(1)
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(&domain.User{ID: 1, Password: "old-hash"}, nil).Times(1)
+		mockPasswordResetRepo.EXPECT().MarkUsed(ctx, uint(5)).Return(domain.ErrInvalidPasswordResetToken).Times(1)
+
+		err := passwordUsecase.ResetPassword(ctx, payload)
+
+		assert.True(t, errors.Is(err, domain.ErrInvalidPasswordResetToken))
+	})
+
+	t.Run("User Deleted Before Save", func(t *testing.T) {
+		mockPasswordResetRepo.EXPECT().GetByHash(ctx, sha256Hex(rawToken)).Return(validToken(), nil).Times(1)
+		mockPasswordResetRepo.EXPECT().MarkUsed(ctx, uint(5)).Return(nil).Times(1)
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(&domain.User{ID: 1, Password: "old-hash"}, nil).Times(1)
+		mockUserRepo.EXPECT().UpdatePassword(ctx, uint(1), gomock.Any()).Return(domain.ErrUserNotFound).Times(1)
+
+		err := passwordUsecase.ResetPassword(ctx, payload)
+
+		assert.True(t, errors.Is(err, domain.ErrInvalidPasswordResetToken))
+	})
+
+	t.Run("Weak Password Keeps Token Usable", func(t *testing.T) {
+		mockPasswordResetRepo.EXPECT().GetByHash(ctx, sha256Hex(rawToken)).Return(validToken(), nil).Times(1)
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(&domain.User{ID: 1, Password: "old-hash"}, nil).Times(1)
+
+		err := passwordUsecase.ResetPassword(ctx, &domain.ResetPasswordPayload{Token: rawToken, NewPassword: "password123"})
+
+		var domainErr *domain.DomainError
+		require.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusBadRequest, domainErr.HTTPStatus)
+		assert.Contains(t, domainErr.Message, "commonly used")
+	})
+
+	t.Run("Unknown Token", func(t *testing.T) {
+		mockPasswordResetRepo.EXPECT().GetByHash(ctx, sha256Hex(rawToken)).Return(nil, domain.ErrInvalidPasswordResetToken).Times(1)
+
+		err := passwordUsecase.ResetPassword(ctx, payload)
+
+		var domainErr *domain.DomainError
+		require.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusBadRequest, domainErr.HTTPStatus)
+	})
+}
+
+func TestPasswordUsecase_ChangePassword(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	mockPasswordResetRepo := mocks.NewMockPasswordResetRepository(mockCtrl)
+	mockAuthUsecase := mocks.NewMockAuthUsecase(mockCtrl)
+	passwordUsecase := usecase.NewPasswordUsecase(mockUserRepo, mockPasswordResetRepo, mockAuthUsecase, mocks.NewMockMailer(mockCtrl),
+		"https://app.gopsy.test/reset-password", time.Hour, app_password.DefaultPolicy(), zap.NewNop())
+
+	ctx := context.Background()
+	currentHash, err := bcrypt.GenerateFromPassword([]byte("Lama-Sekali-2019"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	currentUser := func() *domain.User {
+		return &domain.User{ID: 1, Username: "budi", Email: "budi@example.com", Password: string(currentHash)}
+	}
+
+	t.Run("Success Revokes Other Sessions", func(t *testing.T) {
+		payload := &domain.ChangePasswordPayload{CurrentPassword: "Lama-Sekali-2019", NewPassword: "Tiga-Kucing-Lompat9"}
+
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(currentUser(), nil).Times(1)
+		mockUserRepo.EXPECT().
+			UpdatePassword(ctx, uint(1), gomock.Any()).
+			Do(func(ctx context.Context, id uint, hashedPassword string) {
+				assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(payload.NewPassword)))
+			}).
+			Return(nil).
+			Times(1)
+		mockPasswordResetRepo.EXPECT().InvalidateAllForUser(ctx, uint(1)).Return(nil).Times(1)
+		mockAuthUsecase.EXPECT().RevokeOtherSessions(ctx, uint(1), "session-1").Return(nil).Times(1)
+
+		err := passwordUsecase.ChangePassword(ctx, 1, "session-1", payload)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Incorrect Current Password", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(currentUser(), nil).Times(1)
+
+		err := passwordUsecase.ChangePassword(ctx, 1, "session-1", &domain.ChangePasswordPayload{
+			CurrentPassword: "salah", NewPassword: "Tiga-Kucing-Lompat9",
+		})
+
+		assert.True(t, errors.Is(err, domain.ErrIncorrectCurrentPassword))
+	})
+
+	t.Run("Same As Current Password", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(currentUser(), nil).Times(1)
+
+		err := passwordUsecase.ChangePassword(ctx, 1, "session-1", &domain.ChangePasswordPayload{
+			CurrentPassword: "Lama-Sekali-2019", NewPassword: "Lama-Sekali-2019",
+		})
+
+		assert.True(t, errors.Is(err, domain.ErrPasswordUnchanged))
+	})
+
+	t.Run("Policy Violations Are Listed", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(currentUser(), nil).Times(1)
+
+		err := passwordUsecase.ChangePassword(ctx, 1, "session-1", &domain.ChangePasswordPayload{
+			CurrentPassword: "Lama-Sekali-2019", NewPassword: "budi",
+		})
+
+		var domainErr *domain.DomainError
+		require.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusBadRequest, domainErr.HTTPStatus)
+		assert.Contains(t, domainErr.Message, "at least 8 characters")
+		assert.Contains(t, domainErr.Message, "uppercase letter")
+		assert.Contains(t, domainErr.Message, "username or email")
+	})
+}
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+// permissionLoadKey adalah kunci singleflight untuk membaca ulang seluruh pemetaan permission.
+const permissionLoadKey = "role_permissions"
+
+type permissionUsecase struct {
+	permissionRepo domain.PermissionRepository
+	cacheTTL       time.Duration
+	logger         *zap.Logger
+
+	loads singleflight.Group
+
+	mu       sync.RWMutex
+	cache    map[string]map[string]struct{}
+	loadedAt time.Time
+	// generation bertambah setiap kali pemetaan diubah, sehingga hasil baca yang dimulai sebelum
+	// perubahan tidak disimpan ke cache
+	generation uint64
+}
+
+// NewPermissionUsecase membuat instance baru dari permissionUsecase. Pemetaan role ke permission
+// disimpan di memori selama cacheTTL agar middleware tidak membaca database di setiap request, dan
+// request yang bersamaan saat cache kedaluwarsa berbagi satu query. Perubahan lewat instance ini
+// langsung berlaku, sedangkan perubahan dari instance lain terlihat paling lambat setelah cacheTTL.
+func NewPermissionUsecase(pr domain.PermissionRepository, cacheTTL time.Duration, logger *zap.Logger) domain.PermissionUsecase {
+	return &permissionUsecase{
+		permissionRepo: pr,
+		cacheTTL:       cacheTTL,
+		logger:         logger,
+	}
+}
+
+// HasPermission memeriksa apakah role memiliki permission.
+func (uc *permissionUsecase) HasPermission(ctx context.Context, role, permission string) (bool, error) {
+	grants, err := uc.grants(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := grants[role][permission]
+	return ok, nil
+}
+
+// ListPermissions mengembalikan katalog permission yang bisa diberikan ke role.
+func (uc *permissionUsecase) ListPermissions(ctx context.Context) []domain.Permission {
+	return domain.PermissionCatalog
+}
+
+// ListRolePermissions mengambil permission milik setiap role yang dikenal.
+func (uc *permissionUsecase) ListRolePermissions(ctx context.Context) ([]domain.RolePermissions, error) {
+	grants, err := uc.load(ctx)
+	if err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to retrieve role permissions", err)
+	}
+
+	result := make([]domain.RolePermissions, 0, len(domain.Roles))
+	for _, role := range domain.Roles {
+		result = append(result, rolePermissions(role, grants[role]))
+	}
+	return result, nil
+}
+
+// SetRolePermissions mengganti seluruh permission milik role.
+func (uc *permissionUsecase) SetRolePermissions(ctx context.Context, role string, payload *domain.SetRolePermissionsPayload) (*domain.RolePermissions, error) {
+	if !domain.IsRole(role) {
+		return nil, domain.ErrRoleNotFound
+	}
+
+	granted := make(map[string]struct{}, len(payload.Permissions))
+	for _, permission := range payload.Permissions {
+		if !domain.IsPermission(permission) {
+			return nil, domain.NewDomainError(http.StatusBadRequest, "Unknown permission: "+permission)
+		}
+		granted[permission] = struct{}{}
+	}
+
+	// Tanpa roles:manage tidak ada lagi yang bisa memperbaiki pemetaan permission lewat API
+	if _, ok := granted[domain.PermissionRolesManage]; role == domain.RoleAdmin && !ok {
+		return nil, domain.ErrAdminMustManageRoles
+	}
+
+	updated := rolePermissions(role, granted)
+	if err := uc.permissionRepo.ReplaceForRole(ctx, role, updated.Permissions); err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to update role permissions", err)
+	}
+
+	// Cache dikosongkan setelah perubahan tersimpan agar langsung berlaku di instance ini
+	uc.invalidate()
+
+	uc.logger.Info("Role permissions updated", zap.String("role", role), zap.Strings("permissions", updated.Permissions))
+	return &updated, nil
+}
+
+// grants mengembalikan pemetaan dari cache, atau membaca ulang database jika cache sudah kedaluwarsa.
+func (uc *permissionUsecase) grants(ctx context.Context) (map[string]map[string]struct{}, error) {
+	uc.mu.RLock()
+	cache, fresh := uc.cache, time.Since(uc.loadedAt) < uc.cacheTTL
+	uc.mu.RUnlock()
+
+	if cache != nil && fresh {
+		return cache, nil
+	}
+	return uc.load(ctx)
+}
+
+// load membaca ulang pemetaan dari database. Request yang bersamaan berbagi satu query yang tidak
+// ikut dibatalkan bersama request pemicunya. Hasilnya hanya disimpan ke cache jika pemetaan tidak
+// diubah selama query berjalan.
+func (uc *permissionUsecase) load(ctx context.Context) (map[string]map[string]struct{}, error) {
+	result, err, _ := uc.loads.Do(permissionLoadKey, func() (interface{}, error) {
+		uc.mu.RLock()
+		generation := uc.generation
+		uc.mu.RUnlock()
+
+		rows, err := uc.permissionRepo.List(context.WithoutCancel(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		grants := make(map[string]map[string]struct{})
+		for _, row := range rows {
+			if grants[row.Role] == nil {
+				grants[row.Role] = make(map[string]struct{})
+			}
+			grants[row.Role][row.Permission] = struct{}{}
+		}
+
+		uc.mu.Lock()
+		if uc.generation == generation {
+			uc.cache = grants
+			uc.loadedAt = time.Now()
+		}
+		uc.mu.Unlock()
+
+		return grants, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[string]map[string]struct{}), nil
+}
+
+// invalidate mengosongkan cache setelah pemetaan diubah. Query yang sedang berjalan dilepas dari
+// singleflight agar request berikutnya membaca pemetaan yang baru.
+func (uc *permissionUsecase) invalidate() {
+	uc.mu.Lock()
+	uc.cache = nil
+	uc.generation++
+	uc.mu.Unlock()
+
+	uc.loads.Forget(permissionLoadKey)
+}
+
+// rolePermissions menyusun permission role mengikuti urutan PermissionCatalog.
+func rolePermissions(role string, granted map[string]struct{}) domain.RolePermissions {
+	permissions := make([]string, 0, len(granted))
+	for _, p := range domain.PermissionCatalog {
+		if _, ok := granted[p.Name]; ok {
+			permissions = append(permissions, p.Name)
+		}
+	}
+	return domain.RolePermissions{Role: role, Permissions: permissions}
+}
@@ -0,0 +1,225 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/internal/mocks"
+	"github.com/X3nonxe/gopsy-backend/internal/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPermissionUsecase_HasPermission(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockPermissionRepo := mocks.NewMockPermissionRepository(mockCtrl)
+	permissionUsecase := usecase.NewPermissionUsecase(mockPermissionRepo, time.Minute, zap.NewNop())
+
+	ctx := context.Background()
+
+	// Pemetaan dibaca sekali lalu dipakai dari cache
+	mockPermissionRepo.EXPECT().
+		List(gomock.Any()).
+		Return([]domain.RolePermission{
+			{Role: domain.RolePsikolog, Permission: domain.PermissionAvailabilityWrite},
+			{Role: domain.RoleKlien, Permission: domain.PermissionConsultationsBook},
+		}, nil).
+		Times(1)
+
+	granted, err := permissionUsecase.HasPermission(ctx, domain.RolePsikolog, domain.PermissionAvailabilityWrite)
+	require.NoError(t, err)
+	assert.True(t, granted)
+
+	granted, err = permissionUsecase.HasPermission(ctx, domain.RoleKlien, domain.PermissionAvailabilityWrite)
+	require.NoError(t, err)
+	assert.False(t, granted)
+
+	granted, err = permissionUsecase.HasPermission(ctx, "unknown", domain.PermissionConsultationsBook)
+	require.NoError(t, err)
+	assert.False(t, granted)
+}
+
+func TestPermissionUsecase_ConcurrentReload(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockPermissionRepo := mocks.NewMockPermissionRepository(mockCtrl)
+	permissionUsecase := usecase.NewPermissionUsecase(mockPermissionRepo, time.Minute, zap.NewNop())
+
+	ctx := context.Background()
+	release := make(chan struct{})
+
+	// Semua request yang datang saat cache kosong berbagi satu query
+	mockPermissionRepo.EXPECT().
+		List(gomock.Any()).
+		DoAndReturn(func(ctx context.Context) ([]domain.RolePermission, error) {
+			<-release
+			return []domain.RolePermission{{Role: domain.RoleKlien, Permission: domain.PermissionConsultationsBook}}, nil
+		}).
+		Times(1)
+
+	const requests = 20
+	var wg sync.WaitGroup
+	results := make(chan bool, requests)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			granted, err := permissionUsecase.HasPermission(ctx, domain.RoleKlien, domain.PermissionConsultationsBook)
+			assert.NoError(t, err)
+			results <- granted
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	for granted := range results {
+		assert.True(t, granted)
+	}
+}
+
+func TestPermissionUsecase_StaleReloadIsNotCached(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockPermissionRepo := mocks.NewMockPermissionRepository(mockCtrl)
+	permissionUsecase := usecase.NewPermissionUsecase(mockPermissionRepo, time.Hour, zap.NewNop())
+
+	ctx := context.Background()
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	gomock.InOrder(
+		// Query pertama membaca pemetaan lama dan baru selesai setelah permission diubah
+		mockPermissionRepo.EXPECT().
+			List(gomock.Any()).
+			DoAndReturn(func(ctx context.Context) ([]domain.RolePermission, error) {
+				close(started)
+				<-release
+				return []domain.RolePermission{}, nil
+			}),
+		mockPermissionRepo.EXPECT().
+			List(gomock.Any()).
+			Return([]domain.RolePermission{{Role: domain.RoleKlien, Permission: domain.PermissionConsultationsBook}}, nil),
+	)
+	mockPermissionRepo.EXPECT().
+		ReplaceForRole(ctx, domain.RoleKlien, []string{domain.PermissionConsultationsBook}).
+		Return(nil).
+		Times(1)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		granted, err := permissionUsecase.HasPermission(ctx, domain.RoleKlien, domain.PermissionConsultationsBook)
+		assert.NoError(t, err)
+		assert.False(t, granted)
+	}()
+
+	<-started
+	_, err := permissionUsecase.SetRolePermissions(ctx, domain.RoleKlien, &domain.SetRolePermissionsPayload{
+		Permissions: []string{domain.PermissionConsultationsBook},
+	})
+	require.NoError(t, err)
+	close(release)
+	<-done
+
+	// Hasil query lama tidak disimpan, sehingga pemetaan baru langsung dibaca
+	granted, err := permissionUsecase.HasPermission(ctx, domain.RoleKlien, domain.PermissionConsultationsBook)
+	require.NoError(t, err)
+	assert.True(t, granted)
+}
+
+func TestPermissionUsecase_SetRolePermissions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockPermissionRepo := mocks.NewMockPermissionRepository(mockCtrl)
+	permissionUsecase := usecase.NewPermissionUsecase(mockPermissionRepo, time.Hour, zap.NewNop())
+
+	ctx := context.Background()
+
+	t.Run("Replaces Permissions And Refreshes Cache", func(t *testing.T) {
+		gomock.InOrder(
+			mockPermissionRepo.EXPECT().List(gomock.Any()).Return([]domain.RolePermission{}, nil),
+			mockPermissionRepo.EXPECT().
+				ReplaceForRole(ctx, domain.RoleKlien, []string{domain.PermissionPsychologistsRead, domain.PermissionConsultationsBook}).
+				Return(nil),
+			mockPermissionRepo.EXPECT().List(gomock.Any()).Return([]domain.RolePermission{
+				{Role: domain.RoleKlien, Permission: domain.PermissionPsychologistsRead},
+				{Role: domain.RoleKlien, Permission: domain.PermissionConsultationsBook},
+			}, nil),
+		)
+
+		granted, err := permissionUsecase.HasPermission(ctx, domain.RoleKlien, domain.PermissionConsultationsBook)
+		require.NoError(t, err)
+		assert.False(t, granted)
+
+		// Duplikat diabaikan dan urutan mengikuti katalog
+		updated, err := permissionUsecase.SetRolePermissions(ctx, domain.RoleKlien, &domain.SetRolePermissionsPayload{
+			Permissions: []string{domain.PermissionConsultationsBook, domain.PermissionPsychologistsRead, domain.PermissionConsultationsBook},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{domain.PermissionPsychologistsRead, domain.PermissionConsultationsBook}, updated.Permissions)
+
+		granted, err = permissionUsecase.HasPermission(ctx, domain.RoleKlien, domain.PermissionConsultationsBook)
+		require.NoError(t, err)
+		assert.True(t, granted)
+	})
+
+	t.Run("Unknown Role", func(t *testing.T) {
+		_, err := permissionUsecase.SetRolePermissions(ctx, "superuser", &domain.SetRolePermissionsPayload{})
+
+		assert.True(t, errors.Is(err, domain.ErrRoleNotFound))
+	})
+
+	t.Run("Unknown Permission", func(t *testing.T) {
+		_, err := permissionUsecase.SetRolePermissions(ctx, domain.RolePsikolog, &domain.SetRolePermissionsPayload{
+			Permissions: []string{"billing:write"},
+		})
+
+		var domainErr *domain.DomainError
+		require.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusBadRequest, domainErr.HTTPStatus)
+	})
+
+	t.Run("Admin Must Keep Roles Manage", func(t *testing.T) {
+		_, err := permissionUsecase.SetRolePermissions(ctx, domain.RoleAdmin, &domain.SetRolePermissionsPayload{
+			Permissions: []string{domain.PermissionUsersManage},
+		})
+
+		assert.True(t, errors.Is(err, domain.ErrAdminMustManageRoles))
+	})
+}
+
+func TestPermissionUsecase_ListRolePermissions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockPermissionRepo := mocks.NewMockPermissionRepository(mockCtrl)
+	permissionUsecase := usecase.NewPermissionUsecase(mockPermissionRepo, time.Minute, zap.NewNop())
+
+	ctx := context.Background()
+	mockPermissionRepo.EXPECT().
+		List(gomock.Any()).
+		Return([]domain.RolePermission{{Role: domain.RoleAdmin, Permission: domain.PermissionRolesManage}}, nil).
+		Times(1)
+
+	roles, err := permissionUsecase.ListRolePermissions(ctx)
+
+	require.NoError(t, err)
+	require.Len(t, roles, len(domain.Roles))
+	assert.Equal(t, domain.RolePermissions{Role: domain.RoleAdmin, Permissions: []string{domain.PermissionRolesManage}}, roles[0])
+	assert.Empty(t, roles[2].Permissions)
+}
@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"net/http"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_image"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type profilePictureUsecase struct {
+	userRepo domain.UserRepository
+	storage  domain.FileStorage
+	maxBytes int64
+	urlTTL   time.Duration
+	logger   *zap.Logger
+}
+
+// NewProfilePictureUsecase membuat usecase foto profil. maxBytes membatasi ukuran file yang
+// diunggah dan urlTTL adalah masa berlaku URL bertanda tangan.
+func NewProfilePictureUsecase(ur domain.UserRepository, storage domain.FileStorage, maxBytes int64, urlTTL time.Duration, logger *zap.Logger) domain.ProfilePictureUsecase {
+	return &profilePictureUsecase{
+		userRepo: ur,
+		storage:  storage,
+		maxBytes: maxBytes,
+		urlTTL:   urlTTL,
+		logger:   logger,
+	}
+}
+
+// profilePictureKey adalah prefix penyimpanan foto profil; tiap ukuran disimpan di bawahnya.
+func profilePictureKey() string {
+	return "profile-pictures/" + uuid.NewString()
+}
+
+func profilePictureFile(key string, size int) string {
+	return fmt.Sprintf("%s/%d.jpg", key, size)
+}
+
+// Upload memvalidasi gambar, membuat ulang setiap ukuran sebagai JPEG tanpa metadata, lalu
+// mengganti foto profil lama. Key baru selalu unik sehingga URL lama tidak pernah menampilkan
+// gambar baru dari cache.
+func (uc *profilePictureUsecase) Upload(ctx context.Context, userID uint, data []byte) (*domain.User, error) {
+	if int64(len(data)) > uc.maxBytes {
+		return nil, domain.ErrProfilePictureTooLarge
+	}
+
+	user, err := uc.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := app_image.Decode(data, app_image.DefaultMaxPixels)
+	if err != nil {
+		if errors.Is(err, app_image.ErrUnsupportedFormat) {
+			return nil, domain.ErrUnsupportedProfilePicture
+		}
+		return nil, domain.ErrInvalidProfilePicture
+	}
+
+	files := make(map[int][]byte, len(domain.ProfilePictureSizes))
+	var source *image.RGBA
+	for _, size := range domain.ProfilePictureSizes {
+		// Ukuran yang lebih kecil dibuat dari hasil sebelumnya agar gambar besar hanya dibaca sekali
+		var thumbnail *image.RGBA
+		if source == nil {
+			thumbnail = decoded.Thumbnail(size)
+		} else {
+			thumbnail = app_image.Thumbnail(source, size)
+		}
+		var buf bytes.Buffer
+		if err := app_image.EncodeJPEG(&buf, thumbnail); err != nil {
+			return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to process profile picture", err)
+		}
+		files[size] = buf.Bytes()
+		source = thumbnail
+	}
+
+	key := profilePictureKey()
+	for _, size := range domain.ProfilePictureSizes {
+		if err := uc.storage.Put(ctx, profilePictureFile(key, size), app_image.ContentType, files[size]); err != nil {
+			uc.deleteFiles(ctx, key)
+			return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to store profile picture", err)
+		}
+	}
+
+	// Hanya key foto yang disimpan, dan hanya jika foto belum diganti request lain selama gambar
+	// diproses, agar perubahan lain pada akun di rentang waktu itu tidak tertimpa
+	oldKey := user.ProfilePictureKey
+	if err := uc.userRepo.UpdateProfilePictureKey(ctx, userID, oldKey, &key); err != nil {
+		uc.deleteFiles(ctx, key)
+		if errors.Is(err, domain.ErrProfilePictureChanged) {
+			return nil, err
+		}
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to store profile picture", err)
+	}
+	user.ProfilePictureKey = &key
+
+	if oldKey != nil {
+		uc.deleteFiles(ctx, *oldKey)
+	}
+
+	uc.logger.Info("Profile picture uploaded", zap.Uint("user_id", userID))
+	return user, nil
+}
+
+// Delete menghapus foto profil user beserta semua ukurannya.
+func (uc *profilePictureUsecase) Delete(ctx context.Context, userID uint) (*domain.User, error) {
+	user, err := uc.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.ProfilePictureKey == nil {
+		return nil, domain.ErrProfilePictureNotFound
+	}
+
+	oldKey := *user.ProfilePictureKey
+	if err := uc.userRepo.UpdateProfilePictureKey(ctx, userID, &oldKey, nil); err != nil {
+		if errors.Is(err, domain.ErrProfilePictureChanged) {
+			return nil, err
+		}
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to delete profile picture", err)
+	}
+	user.ProfilePictureKey = nil
+	uc.deleteFiles(ctx, oldKey)
+
+	uc.logger.Info("Profile picture deleted", zap.Uint("user_id", userID))
+	return user, nil
+}
+
+// URLs menandatangani URL setiap ukuran. Kegagalan hanya dicatat agar respons profil tetap
+// bisa dikirim tanpa foto.
+func (uc *profilePictureUsecase) URLs(key *string) *domain.ProfilePictureURLs {
+	if key == nil || *key == "" {
+		return nil
+	}
+
+	urls := make(map[int]string, len(domain.ProfilePictureSizes))
+	for _, size := range domain.ProfilePictureSizes {
+		signed, err := uc.storage.SignedURL(profilePictureFile(*key, size), uc.urlTTL)
+		if err != nil {
+			uc.logger.Error("Failed to sign profile picture URL", zap.String("key", *key), zap.Error(err))
+			return nil
+		}
+		urls[size] = signed
+	}
+
+	return &domain.ProfilePictureURLs{
+		Small:  urls[domain.ProfilePictureSmall],
+		Medium: urls[domain.ProfilePictureMedium],
+		Large:  urls[domain.ProfilePictureLarge],
+	}
+}
+
+func (uc *profilePictureUsecase) MaxUploadBytes() int64 {
+	return uc.maxBytes
+}
+
+func (uc *profilePictureUsecase) getUser(ctx context.Context, userID uint) (*domain.User, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.NewDomainError(http.StatusNotFound, "User not found")
+		}
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to load user", err)
+	}
+	return user, nil
+}
+
+// deleteFiles menghapus semua ukuran di bawah key. File yatim hanya memakan ruang, jadi
+// kegagalan dicatat tanpa menggagalkan request.
+func (uc *profilePictureUsecase) deleteFiles(ctx context.Context, key string) {
+	for _, size := range domain.ProfilePictureSizes {
+		if err := uc.storage.Delete(ctx, profilePictureFile(key, size)); err != nil {
+			uc.logger.Warn("Failed to delete profile picture file", zap.String("key", key), zap.Int("size", size), zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/internal/mocks"
+	"github.com/X3nonxe/gopsy-backend/internal/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// testPNG membuat gambar PNG polos berukuran w x h.
+func testPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{G: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestProfilePictureUsecase_Upload(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	mockStorage := mocks.NewMockFileStorage(mockCtrl)
+	pictureUsecase := usecase.NewProfilePictureUsecase(mockUserRepo, mockStorage, 1<<20, 15*time.Minute, zap.NewNop())
+
+	ctx := context.Background()
+
+	t.Run("Stores Every Size And Replaces Old Picture", func(t *testing.T) {
+		oldKey := "profile-pictures/old"
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(&domain.User{ID: 1, ProfilePictureKey: &oldKey}, nil).Times(1)
+
+		var stored []string
+		mockStorage.EXPECT().Put(ctx, gomock.Any(), "image/jpeg", gomock.Any()).DoAndReturn(
+			func(_ context.Context, key, _ string, data []byte) error {
+				stored = append(stored, key)
+				assert.True(t, bytes.HasPrefix(data, []byte{0xFF, 0xD8}), "stored file is not a JPEG")
+				return nil
+			}).Times(3)
+		mockUserRepo.EXPECT().UpdateProfilePictureKey(ctx, uint(1), &oldKey, gomock.Any()).Return(nil).Times(1)
+		mockStorage.EXPECT().Delete(ctx, "profile-pictures/old/512.jpg").Return(nil).Times(1)
+		mockStorage.EXPECT().Delete(ctx, "profile-pictures/old/256.jpg").Return(nil).Times(1)
+		mockStorage.EXPECT().Delete(ctx, "profile-pictures/old/96.jpg").Return(errors.New("storage down")).Times(1)
+
+		user, err := pictureUsecase.Upload(ctx, 1, testPNG(t, 600, 400))
+
+		require.NoError(t, err)
+		require.NotNil(t, user.ProfilePictureKey)
+		newKey := *user.ProfilePictureKey
+		assert.NotEqual(t, oldKey, newKey)
+		assert.True(t, strings.HasPrefix(newKey, "profile-pictures/"))
+		assert.Equal(t, []string{newKey + "/512.jpg", newKey + "/256.jpg", newKey + "/96.jpg"}, stored)
+	})
+
+	t.Run("File Too Large", func(t *testing.T) {
+		_, err := pictureUsecase.Upload(ctx, 1, make([]byte, 1<<20+1))
+		assert.ErrorIs(t, err, domain.ErrProfilePictureTooLarge)
+	})
+
+	t.Run("Unsupported Content", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(&domain.User{ID: 1}, nil).Times(1)
+
+		_, err := pictureUsecase.Upload(ctx, 1, []byte("<svg xmlns=\"http://www.w3.org/2000/svg\"></svg>"))
+		assert.ErrorIs(t, err, domain.ErrUnsupportedProfilePicture)
+	})
+
+	t.Run("Corrupt Image", func(t *testing.T) {
+		data := testPNG(t, 64, 64)
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(&domain.User{ID: 1}, nil).Times(1)
+
+		_, err := pictureUsecase.Upload(ctx, 1, data[:len(data)/2])
+		assert.ErrorIs(t, err, domain.ErrInvalidProfilePicture)
+	})
+
+	t.Run("Picture Changed Concurrently Cleans Up", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(&domain.User{ID: 1}, nil).Times(1)
+
+		var stored []string
+		mockStorage.EXPECT().Put(ctx, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, key, _ string, _ []byte) error {
+				stored = append(stored, key)
+				return nil
+			}).Times(3)
+		mockUserRepo.EXPECT().
+			UpdateProfilePictureKey(ctx, uint(1), (*string)(nil), gomock.Any()).
+			Return(domain.ErrProfilePictureChanged).
+			Times(1)
+
+		// File baru dihapus karena tidak pernah dipakai
+		var deleted []string
+		mockStorage.EXPECT().Delete(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, key string) error {
+				deleted = append(deleted, key)
+				return nil
+			}).Times(3)
+
+		_, err := pictureUsecase.Upload(ctx, 1, testPNG(t, 32, 32))
+
+		assert.ErrorIs(t, err, domain.ErrProfilePictureChanged)
+		assert.Equal(t, stored, deleted)
+	})
+
+	t.Run("Storage Failure Cleans Up", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(&domain.User{ID: 1}, nil).Times(1)
+		mockStorage.EXPECT().Put(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockStorage.EXPECT().Put(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("bucket unavailable")).Times(1)
+		mockStorage.EXPECT().Delete(ctx, gomock.Any()).Return(nil).Times(3)
+
+		_, err := pictureUsecase.Upload(ctx, 1, testPNG(t, 32, 32))
+
+		var domainErr *domain.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, http.StatusInternalServerError, domainErr.HTTPStatus)
+	})
+}
+
+func TestProfilePictureUsecase_Delete(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	mockStorage := mocks.NewMockFileStorage(mockCtrl)
+	pictureUsecase := usecase.NewProfilePictureUsecase(mockUserRepo, mockStorage, 1<<20, 15*time.Minute, zap.NewNop())
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		key := "profile-pictures/abc"
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(&domain.User{ID: 1, ProfilePictureKey: &key}, nil).Times(1)
+		mockUserRepo.EXPECT().UpdateProfilePictureKey(ctx, uint(1), &key, (*string)(nil)).Return(nil).Times(1)
+		mockStorage.EXPECT().Delete(ctx, gomock.Any()).Return(nil).Times(3)
+
+		user, err := pictureUsecase.Delete(ctx, 1)
+
+		require.NoError(t, err)
+		assert.Nil(t, user.ProfilePictureKey)
+	})
+
+	t.Run("No Picture", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(&domain.User{ID: 1}, nil).Times(1)
+
+		_, err := pictureUsecase.Delete(ctx, 1)
+		assert.ErrorIs(t, err, domain.ErrProfilePictureNotFound)
+	})
+
+	t.Run("Picture Changed Concurrently Keeps Files", func(t *testing.T) {
+		key := "profile-pictures/abc"
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(&domain.User{ID: 1, ProfilePictureKey: &key}, nil).Times(1)
+		mockUserRepo.EXPECT().UpdateProfilePictureKey(ctx, uint(1), &key, (*string)(nil)).Return(domain.ErrProfilePictureChanged).Times(1)
+
+		_, err := pictureUsecase.Delete(ctx, 1)
+		assert.ErrorIs(t, err, domain.ErrProfilePictureChanged)
+	})
+}
+
+func TestProfilePictureUsecase_URLs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockStorage := mocks.NewMockFileStorage(mockCtrl)
+	pictureUsecase := usecase.NewProfilePictureUsecase(mocks.NewMockUserRepository(mockCtrl), mockStorage, 1<<20, 15*time.Minute, zap.NewNop())
+
+	assert.Nil(t, pictureUsecase.URLs(nil))
+
+	key := "profile-pictures/abc"
+	mockStorage.EXPECT().SignedURL(gomock.Any(), 15*time.Minute).DoAndReturn(func(file string, _ time.Duration) (string, error) {
+		return "https://cdn.test/" + file + "?signature=x", nil
+	}).Times(3)
+
+	urls := pictureUsecase.URLs(&key)
+	require.NotNil(t, urls)
+	assert.Equal(t, "https://cdn.test/profile-pictures/abc/96.jpg?signature=x", urls.Small)
+	assert.Equal(t, "https://cdn.test/profile-pictures/abc/256.jpg?signature=x", urls.Medium)
+	assert.Equal(t, "https://cdn.test/profile-pictures/abc/512.jpg?signature=x", urls.Large)
+}
@@ -0,0 +1,269 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"go.uber.org/zap"
+)
+
+type psychologistProfileUsecase struct {
+	profileRepo domain.PsychologistProfileRepository
+	userRepo    domain.UserRepository
+	location    *time.Location
+	logger      *zap.Logger
+}
+
+// NewPsychologistProfileUsecase membuat instance baru dari psychologistProfileUsecase. Masa berlaku
+// izin praktik dibandingkan dengan tanggal hari ini pada zona waktu location.
+func NewPsychologistProfileUsecase(pr domain.PsychologistProfileRepository, ur domain.UserRepository, location *time.Location, logger *zap.Logger) domain.PsychologistProfileUsecase {
+	return &psychologistProfileUsecase{
+		profileRepo: pr,
+		userRepo:    ur,
+		location:    location,
+		logger:      logger,
+	}
+}
+
+// GetOwnProfile mengambil profil profesional milik psikolog yang sedang login.
+func (uc *psychologistProfileUsecase) GetOwnProfile(ctx context.Context, userID uint) (*domain.PsychologistProfile, error) {
+	profile, err := uc.profileRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrPsychologistProfileNotFound) {
+			return nil, err
+		}
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to retrieve psychologist profile", err)
+	}
+	return profile, nil
+}
+
+// UpdateOwnProfile membuat atau menggantikan profil profesional psikolog. Profil baru, profil yang
+// mengubah data izin praktik, dan profil yang sebelumnya ditolak diajukan ulang untuk verifikasi;
+// perubahan lain pada profil terverifikasi tidak mengubah statusnya.
+func (uc *psychologistProfileUsecase) UpdateOwnProfile(ctx context.Context, userID uint, payload *domain.PsychologistProfilePayload) (*domain.PsychologistProfile, error) {
+	sippExpiresAt, err := time.Parse("2006-01-02", payload.SIPPExpiresAt)
+	if err != nil {
+		return nil, domain.NewDomainError(http.StatusBadRequest, "Invalid SIPP expiry date format")
+	}
+
+	var strExpiresAt *time.Time
+	if payload.STRExpiresAt != "" {
+		parsed, err := time.Parse("2006-01-02", payload.STRExpiresAt)
+		if err != nil {
+			return nil, domain.NewDomainError(http.StatusBadRequest, "Invalid STR expiry date format")
+		}
+		strExpiresAt = &parsed
+	}
+
+	now := time.Now().In(uc.location)
+	exists := true
+	profile, err := uc.profileRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrPsychologistProfileNotFound) {
+			return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to update psychologist profile", err)
+		}
+		exists = false
+		profile = &domain.PsychologistProfile{UserID: userID}
+	}
+
+	updated := *profile
+	updated.SIPPNumber = strings.TrimSpace(payload.SIPPNumber)
+	updated.SIPPExpiresAt = sippExpiresAt
+	updated.STRNumber = optionalString(payload.STRNumber)
+	updated.STRExpiresAt = strExpiresAt
+	updated.Specializations = trimStrings(payload.Specializations)
+	updated.Languages = trimStrings(payload.Languages)
+	updated.Bio = strings.TrimSpace(payload.Bio)
+	updated.YearsOfPractice = payload.YearsOfPractice
+	updated.SessionFee = payload.SessionFee
+
+	if !updated.LicenseValid(now) {
+		return nil, domain.ErrLicenseExpired
+	}
+
+	resubmit := profile.SubmittedAt.IsZero() || profile.VerificationStatus == domain.VerificationStatusRejected || licenseChanged(profile, &updated)
+	if resubmit {
+		updated.VerificationStatus = domain.VerificationStatusPending
+		updated.RejectionReason = nil
+		updated.ReviewedBy = nil
+		updated.ReviewedAt = nil
+		updated.SubmittedAt = now
+	}
+
+	// Profil yang sudah ada diajukan ulang secara kondisional agar keputusan admin yang tersimpan
+	// setelah profil dibaca tidak tertimpa
+	if resubmit && exists {
+		err = uc.profileRepo.Resubmit(ctx, &updated, profile.VerificationStatus, profile.SubmittedAt)
+	} else {
+		err = uc.profileRepo.Save(ctx, &updated)
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrPsychologistProfileChanged) {
+			return nil, err
+		}
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to update psychologist profile", err)
+	}
+
+	uc.logger.Info("Psychologist profile updated",
+		zap.Uint("user_id", userID), zap.String("verification_status", updated.VerificationStatus))
+	return &updated, nil
+}
+
+// ListProfiles mengambil profil psikolog untuk ditinjau admin, dapat difilter berdasarkan status.
+func (uc *psychologistProfileUsecase) ListProfiles(ctx context.Context, query *domain.PsychologistProfileQuery) (*domain.PsychologistProfileResult, error) {
+	query.Normalize()
+
+	profiles, total, err := uc.profileRepo.List(ctx, domain.PsychologistProfileFilter{
+		Status:  query.Status,
+		Limit:   query.Limit,
+		Offset:  query.Offset(),
+		Preload: true,
+	})
+	if err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to retrieve psychologist profiles", err)
+	}
+
+	return &domain.PsychologistProfileResult{
+		Items:      profiles,
+		Pagination: domain.NewPagination(query.PaginationQuery, total),
+	}, nil
+}
+
+// GetProfile mengambil profil psikolog beserta akunnya untuk ditinjau admin.
+func (uc *psychologistProfileUsecase) GetProfile(ctx context.Context, userID uint) (*domain.PsychologistProfile, error) {
+	profile, err := uc.GetOwnProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to retrieve psychologist profile", err)
+	}
+	profile.User = user
+
+	return profile, nil
+}
+
+// Review mencatat keputusan admin atas profil psikolog. Profil yang menunggu bisa diverifikasi atau
+// ditolak, dan profil terverifikasi bisa dicabut verifikasinya dengan menolaknya.
+func (uc *psychologistProfileUsecase) Review(ctx context.Context, reviewerID, userID uint, payload *domain.PsychologistVerificationPayload) (*domain.PsychologistProfile, error) {
+	profile, err := uc.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canTransitionVerification(profile.VerificationStatus, payload.Status) {
+		return nil, domain.ErrInvalidVerificationTransition
+	}
+
+	now := time.Now().In(uc.location)
+	if payload.Status == domain.VerificationStatusVerified && !profile.LicenseValid(now) {
+		return nil, domain.ErrLicenseExpired
+	}
+
+	expectedStatus, expectedSubmittedAt := profile.VerificationStatus, profile.SubmittedAt
+
+	profile.VerificationStatus = payload.Status
+	profile.RejectionReason = nil
+	if payload.Status == domain.VerificationStatusRejected {
+		profile.RejectionReason = optionalString(payload.Reason)
+	}
+	profile.ReviewedBy = &reviewerID
+	profile.ReviewedAt = &now
+
+	if err := uc.profileRepo.UpdateVerification(ctx, profile, expectedStatus, expectedSubmittedAt); err != nil {
+		if errors.Is(err, domain.ErrPsychologistProfileChanged) {
+			return nil, err
+		}
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to review psychologist profile", err)
+	}
+
+	uc.logger.Info("Psychologist profile reviewed",
+		zap.Uint("user_id", userID), zap.Uint("reviewer_id", reviewerID), zap.String("status", payload.Status))
+	return profile, nil
+}
+
+// canTransitionVerification memeriksa perpindahan status verifikasi oleh admin. Profil yang ditolak
+// harus diajukan ulang oleh psikolog sebelum bisa ditinjau lagi.
+func canTransitionVerification(from, to string) bool {
+	switch from {
+	case domain.VerificationStatusPending:
+		return to == domain.VerificationStatusVerified || to == domain.VerificationStatusRejected
+	case domain.VerificationStatusVerified:
+		return to == domain.VerificationStatusRejected
+	default:
+		return false
+	}
+}
+
+// licenseChanged mengembalikan true jika data izin praktik pada profil berubah.
+func licenseChanged(before, after *domain.PsychologistProfile) bool {
+	return before.SIPPNumber != after.SIPPNumber ||
+		!before.SIPPExpiresAt.Equal(after.SIPPExpiresAt) ||
+		!equalStringPtr(before.STRNumber, after.STRNumber) ||
+		!equalTimePtr(before.STRExpiresAt, after.STRExpiresAt)
+}
+
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func equalTimePtr(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// trimStrings membersihkan spasi pada setiap elemen dan membuang duplikat dengan urutan tetap.
+func trimStrings(values []string) []string {
+	result := make([]string, 0, len(values))
+	seen := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if _, ok := seen[strings.ToLower(value)]; ok || value == "" {
+			continue
+		}
+		seen[strings.ToLower(value)] = struct{}{}
+		result = append(result, value)
+	}
+	return result
+}
+
+// loadBookablePsychologist mengambil psikolog yang boleh ditampilkan dan dipesan klien, yaitu
+// berperan psikolog, tidak dinonaktifkan, dengan profil terverifikasi dan izin praktik yang masih
+// berlaku pada now. ErrPsychologistNotFound dikembalikan untuk psikolog lain; error repository
+// dikembalikan apa adanya.
+func loadBookablePsychologist(ctx context.Context, userRepo domain.UserRepository, profileRepo domain.PsychologistProfileRepository, psikologID uint, now time.Time) (*domain.User, error) {
+	psikolog, err := userRepo.GetByID(ctx, psikologID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.ErrPsychologistNotFound
+		}
+		return nil, err
+	}
+	if psikolog.Role != domain.RolePsikolog || psikolog.IsDeactivated() {
+		return nil, domain.ErrPsychologistNotFound
+	}
+
+	profile, err := profileRepo.GetByUserID(ctx, psikologID)
+	if err != nil {
+		if errors.Is(err, domain.ErrPsychologistProfileNotFound) {
+			return nil, domain.ErrPsychologistNotFound
+		}
+		return nil, err
+	}
+	if !profile.IsBookable(now) {
+		return nil, domain.ErrPsychologistNotFound
+	}
+
+	return psikolog, nil
+}
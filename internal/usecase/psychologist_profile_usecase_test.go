@@ -0,0 +1,241 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/internal/mocks"
+	"github.com/X3nonxe/gopsy-backend/internal/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// verifiedProfile membuat profil psikolog terverifikasi dengan SIPP yang berlaku satu tahun lagi.
+func verifiedProfile(userID uint) *domain.PsychologistProfile {
+	sippExpiresAt := time.Now().UTC().AddDate(1, 0, 0).Truncate(24 * time.Hour)
+	return &domain.PsychologistProfile{
+		UserID:             userID,
+		SIPPNumber:         "SIPP-001",
+		SIPPExpiresAt:      sippExpiresAt,
+		Specializations:    []string{"Kecemasan"},
+		Languages:          []string{"Indonesia"},
+		YearsOfPractice:    5,
+		SessionFee:         350000,
+		VerificationStatus: domain.VerificationStatusVerified,
+		SubmittedAt:        time.Now().Add(-24 * time.Hour),
+	}
+}
+
+func TestPsychologistProfileUsecase_UpdateOwnProfile(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockProfileRepo := mocks.NewMockPsychologistProfileRepository(mockCtrl)
+	profileUsecase := usecase.NewPsychologistProfileUsecase(mockProfileRepo, mocks.NewMockUserRepository(mockCtrl), time.UTC, zap.NewNop())
+
+	ctx := context.Background()
+	nextYear := time.Now().UTC().AddDate(1, 0, 0).Format("2006-01-02")
+
+	newPayload := func() *domain.PsychologistProfilePayload {
+		return &domain.PsychologistProfilePayload{
+			SIPPNumber:      " SIPP-001 ",
+			SIPPExpiresAt:   nextYear,
+			Specializations: []string{"Kecemasan", " kecemasan", "Depresi"},
+			Languages:       []string{"Indonesia"},
+			Bio:             "Psikolog klinis dewasa",
+			YearsOfPractice: 5,
+			SessionFee:      350000,
+		}
+	}
+
+	t.Run("First Submission Is Pending", func(t *testing.T) {
+		mockProfileRepo.EXPECT().GetByUserID(ctx, uint(2)).Return(nil, domain.ErrPsychologistProfileNotFound).Times(1)
+		mockProfileRepo.EXPECT().Save(ctx, gomock.Any()).Return(nil).Times(1)
+
+		profile, err := profileUsecase.UpdateOwnProfile(ctx, 2, newPayload())
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(2), profile.UserID)
+		assert.Equal(t, "SIPP-001", profile.SIPPNumber)
+		assert.Equal(t, []string{"Kecemasan", "Depresi"}, profile.Specializations)
+		assert.Equal(t, domain.VerificationStatusPending, profile.VerificationStatus)
+		assert.False(t, profile.SubmittedAt.IsZero())
+	})
+
+	t.Run("Verified Profile Keeps Status When License Is Unchanged", func(t *testing.T) {
+		existing := verifiedProfile(2)
+		existing.SIPPExpiresAt, _ = time.Parse("2006-01-02", nextYear)
+		submittedAt := existing.SubmittedAt
+
+		payload := newPayload()
+		payload.SessionFee = 400000
+
+		mockProfileRepo.EXPECT().GetByUserID(ctx, uint(2)).Return(existing, nil).Times(1)
+		mockProfileRepo.EXPECT().Save(ctx, gomock.Any()).Return(nil).Times(1)
+
+		profile, err := profileUsecase.UpdateOwnProfile(ctx, 2, payload)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.VerificationStatusVerified, profile.VerificationStatus)
+		assert.Equal(t, int64(400000), profile.SessionFee)
+		assert.Equal(t, submittedAt, profile.SubmittedAt)
+	})
+
+	t.Run("Changed License Is Resubmitted", func(t *testing.T) {
+		reviewerID := uint(1)
+		existing := verifiedProfile(2)
+		existing.ReviewedBy = &reviewerID
+
+		payload := newPayload()
+		payload.SIPPNumber = "SIPP-002"
+
+		mockProfileRepo.EXPECT().GetByUserID(ctx, uint(2)).Return(existing, nil).Times(1)
+		mockProfileRepo.EXPECT().
+			Resubmit(ctx, gomock.Any(), domain.VerificationStatusVerified, existing.SubmittedAt).
+			Return(nil).
+			Times(1)
+
+		profile, err := profileUsecase.UpdateOwnProfile(ctx, 2, payload)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.VerificationStatusPending, profile.VerificationStatus)
+		assert.Nil(t, profile.ReviewedBy)
+		assert.True(t, profile.SubmittedAt.After(existing.SubmittedAt))
+	})
+
+	t.Run("Resubmission Conflicts With Review", func(t *testing.T) {
+		existing := verifiedProfile(2)
+
+		payload := newPayload()
+		payload.SIPPNumber = "SIPP-002"
+
+		mockProfileRepo.EXPECT().GetByUserID(ctx, uint(2)).Return(existing, nil).Times(1)
+		mockProfileRepo.EXPECT().
+			Resubmit(ctx, gomock.Any(), domain.VerificationStatusVerified, existing.SubmittedAt).
+			Return(domain.ErrPsychologistProfileChanged).
+			Times(1)
+
+		profile, err := profileUsecase.UpdateOwnProfile(ctx, 2, payload)
+
+		assert.True(t, errors.Is(err, domain.ErrPsychologistProfileChanged))
+		assert.Nil(t, profile)
+	})
+
+	t.Run("Expired License", func(t *testing.T) {
+		payload := newPayload()
+		payload.SIPPExpiresAt = time.Now().UTC().AddDate(0, 0, -1).Format("2006-01-02")
+
+		mockProfileRepo.EXPECT().GetByUserID(ctx, uint(2)).Return(nil, domain.ErrPsychologistProfileNotFound).Times(1)
+
+		profile, err := profileUsecase.UpdateOwnProfile(ctx, 2, payload)
+
+		assert.True(t, errors.Is(err, domain.ErrLicenseExpired))
+		assert.Nil(t, profile)
+	})
+}
+
+func TestPsychologistProfileUsecase_Review(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockProfileRepo := mocks.NewMockPsychologistProfileRepository(mockCtrl)
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	profileUsecase := usecase.NewPsychologistProfileUsecase(mockProfileRepo, mockUserRepo, time.UTC, zap.NewNop())
+
+	ctx := context.Background()
+	reviewerID := uint(1)
+	psikolog := &domain.User{ID: 2, Username: "dr.budi", Role: domain.RolePsikolog}
+
+	pendingProfile := func() *domain.PsychologistProfile {
+		profile := verifiedProfile(psikolog.ID)
+		profile.VerificationStatus = domain.VerificationStatusPending
+		return profile
+	}
+
+	t.Run("Verify Pending Profile", func(t *testing.T) {
+		profile := pendingProfile()
+		submittedAt := profile.SubmittedAt
+
+		mockProfileRepo.EXPECT().GetByUserID(ctx, psikolog.ID).Return(profile, nil).Times(1)
+		mockUserRepo.EXPECT().GetByID(ctx, psikolog.ID).Return(psikolog, nil).Times(1)
+		mockProfileRepo.EXPECT().
+			UpdateVerification(ctx, gomock.Any(), domain.VerificationStatusPending, submittedAt).
+			Return(nil).
+			Times(1)
+
+		reviewed, err := profileUsecase.Review(ctx, reviewerID, psikolog.ID, &domain.PsychologistVerificationPayload{Status: domain.VerificationStatusVerified})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.VerificationStatusVerified, reviewed.VerificationStatus)
+		assert.Equal(t, &reviewerID, reviewed.ReviewedBy)
+		assert.NotNil(t, reviewed.ReviewedAt)
+		assert.Nil(t, reviewed.RejectionReason)
+	})
+
+	t.Run("Revoke Verified Profile", func(t *testing.T) {
+		mockProfileRepo.EXPECT().GetByUserID(ctx, psikolog.ID).Return(verifiedProfile(psikolog.ID), nil).Times(1)
+		mockUserRepo.EXPECT().GetByID(ctx, psikolog.ID).Return(psikolog, nil).Times(1)
+		mockProfileRepo.EXPECT().
+			UpdateVerification(ctx, gomock.Any(), domain.VerificationStatusVerified, gomock.Any()).
+			Return(nil).
+			Times(1)
+
+		reviewed, err := profileUsecase.Review(ctx, reviewerID, psikolog.ID, &domain.PsychologistVerificationPayload{
+			Status: domain.VerificationStatusRejected,
+			Reason: "SIPP number does not match the issuing authority",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.VerificationStatusRejected, reviewed.VerificationStatus)
+		require.NotNil(t, reviewed.RejectionReason)
+		assert.Equal(t, "SIPP number does not match the issuing authority", *reviewed.RejectionReason)
+	})
+
+	t.Run("Rejected Profile Must Be Resubmitted", func(t *testing.T) {
+		profile := pendingProfile()
+		profile.VerificationStatus = domain.VerificationStatusRejected
+
+		mockProfileRepo.EXPECT().GetByUserID(ctx, psikolog.ID).Return(profile, nil).Times(1)
+		mockUserRepo.EXPECT().GetByID(ctx, psikolog.ID).Return(psikolog, nil).Times(1)
+
+		reviewed, err := profileUsecase.Review(ctx, reviewerID, psikolog.ID, &domain.PsychologistVerificationPayload{Status: domain.VerificationStatusVerified})
+
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusConflict, domainErr.HTTPStatus)
+		assert.Nil(t, reviewed)
+	})
+
+	t.Run("Cannot Verify Expired License", func(t *testing.T) {
+		profile := pendingProfile()
+		profile.SIPPExpiresAt = time.Now().UTC().AddDate(0, 0, -2)
+
+		mockProfileRepo.EXPECT().GetByUserID(ctx, psikolog.ID).Return(profile, nil).Times(1)
+		mockUserRepo.EXPECT().GetByID(ctx, psikolog.ID).Return(psikolog, nil).Times(1)
+
+		reviewed, err := profileUsecase.Review(ctx, reviewerID, psikolog.ID, &domain.PsychologistVerificationPayload{Status: domain.VerificationStatusVerified})
+
+		assert.True(t, errors.Is(err, domain.ErrLicenseExpired))
+		assert.Nil(t, reviewed)
+	})
+
+	t.Run("Profile Changed During Review", func(t *testing.T) {
+		mockProfileRepo.EXPECT().GetByUserID(ctx, psikolog.ID).Return(pendingProfile(), nil).Times(1)
+		mockUserRepo.EXPECT().GetByID(ctx, psikolog.ID).Return(psikolog, nil).Times(1)
+		mockProfileRepo.EXPECT().
+			UpdateVerification(ctx, gomock.Any(), domain.VerificationStatusPending, gomock.Any()).
+			Return(domain.ErrPsychologistProfileChanged).
+			Times(1)
+
+		reviewed, err := profileUsecase.Review(ctx, reviewerID, psikolog.ID, &domain.PsychologistVerificationPayload{Status: domain.VerificationStatusVerified})
+
+		assert.True(t, errors.Is(err, domain.ErrPsychologistProfileChanged))
+		assert.Nil(t, reviewed)
+	})
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"go.uber.org/zap"
+)
+
+type sessionUsecase struct {
+	sessionRepo domain.SessionRepository
+	authUsecase domain.AuthUsecase
+	logger      *zap.Logger
+}
+
+// NewSessionUsecase membuat instance baru dari sessionUsecase.
+func NewSessionUsecase(sr domain.SessionRepository, au domain.AuthUsecase, logger *zap.Logger) domain.SessionUsecase {
+	return &sessionUsecase{
+		sessionRepo: sr,
+		authUsecase: au,
+		logger:      logger,
+	}
+}
+
+// ListSessions mengambil sesi aktif milik user.
+func (uc *sessionUsecase) ListSessions(ctx context.Context, userID uint, currentSessionID string) ([]domain.SessionResponse, error) {
+	sessions, err := uc.sessionRepo.ListActiveByUser(ctx, userID)
+	if err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to retrieve sessions", err)
+	}
+
+	responses := make([]domain.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		responses = append(responses, domain.SessionResponse{
+			ID:         session.ID,
+			DeviceName: session.DeviceName,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			LastSeenAt: session.LastSeenAt,
+			ExpiresAt:  session.ExpiresAt,
+			CreatedAt:  session.CreatedAt,
+			Current:    session.ID == currentSessionID,
+		})
+	}
+	return responses, nil
+}
+
+// RevokeSession mencabut satu sesi milik user. Sesi milik user lain dianggap tidak ada.
+func (uc *sessionUsecase) RevokeSession(ctx context.Context, userID uint, sessionID string) error {
+	session, err := uc.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return err
+		}
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to revoke session", err)
+	}
+
+	if session.UserID != userID || session.RevokedAt != nil {
+		return domain.ErrSessionNotFound
+	}
+
+	if err := uc.authUsecase.RevokeSession(ctx, session.ID); err != nil {
+		return err
+	}
+
+	uc.logger.Info("Session revoked", zap.Uint("user_id", userID), zap.String("session_id", sessionID))
+	return nil
+}
+
+// RevokeOtherSessions mencabut semua sesi user kecuali currentSessionID. currentSessionID kosong
+// mencabut semua sesi.
+func (uc *sessionUsecase) RevokeOtherSessions(ctx context.Context, userID uint, currentSessionID string) error {
+	if err := uc.authUsecase.RevokeOtherSessions(ctx, userID, currentSessionID); err != nil {
+		return err
+	}
+
+	uc.logger.Info("Sessions revoked", zap.Uint("user_id", userID), zap.Bool("kept_current", currentSessionID != ""))
+	return nil
+}
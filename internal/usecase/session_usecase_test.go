@@ -0,0 +1,95 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/internal/mocks"
+	"github.com/X3nonxe/gopsy-backend/internal/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestSessionUsecase_ListSessions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSessionRepo := mocks.NewMockSessionRepository(mockCtrl)
+	sessionUsecase := usecase.NewSessionUsecase(mockSessionRepo, mocks.NewMockAuthUsecase(mockCtrl), zap.NewNop())
+
+	ctx := context.Background()
+	mockSessionRepo.EXPECT().
+		ListActiveByUser(ctx, uint(1)).
+		Return([]domain.Session{
+			{ID: "session-1", UserID: 1, DeviceName: "Chrome on Windows"},
+			{ID: "session-2", UserID: 1, DeviceName: "Safari on iOS"},
+		}, nil).
+		Times(1)
+
+	sessions, err := sessionUsecase.ListSessions(ctx, 1, "session-2")
+
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+	assert.False(t, sessions[0].Current)
+	assert.True(t, sessions[1].Current)
+	assert.Equal(t, "Safari on iOS", sessions[1].DeviceName)
+}
+
+func TestSessionUsecase_RevokeSession(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSessionRepo := mocks.NewMockSessionRepository(mockCtrl)
+	mockAuthUsecase := mocks.NewMockAuthUsecase(mockCtrl)
+	sessionUsecase := usecase.NewSessionUsecase(mockSessionRepo, mockAuthUsecase, zap.NewNop())
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		mockSessionRepo.EXPECT().GetByID(ctx, "session-1").Return(&domain.Session{ID: "session-1", UserID: 1}, nil).Times(1)
+		mockAuthUsecase.EXPECT().RevokeSession(ctx, "session-1").Return(nil).Times(1)
+
+		err := sessionUsecase.RevokeSession(ctx, 1, "session-1")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Session Of Another User", func(t *testing.T) {
+		mockSessionRepo.EXPECT().GetByID(ctx, "session-1").Return(&domain.Session{ID: "session-1", UserID: 2}, nil).Times(1)
+
+		err := sessionUsecase.RevokeSession(ctx, 1, "session-1")
+
+		assert.True(t, errors.Is(err, domain.ErrSessionNotFound))
+	})
+
+	t.Run("Already Revoked", func(t *testing.T) {
+		revokedAt := time.Now()
+		mockSessionRepo.EXPECT().
+			GetByID(ctx, "session-1").
+			Return(&domain.Session{ID: "session-1", UserID: 1, RevokedAt: &revokedAt}, nil).
+			Times(1)
+
+		err := sessionUsecase.RevokeSession(ctx, 1, "session-1")
+
+		assert.True(t, errors.Is(err, domain.ErrSessionNotFound))
+	})
+}
+
+func TestSessionUsecase_RevokeOtherSessions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockAuthUsecase := mocks.NewMockAuthUsecase(mockCtrl)
+	sessionUsecase := usecase.NewSessionUsecase(mocks.NewMockSessionRepository(mockCtrl), mockAuthUsecase, zap.NewNop())
+
+	ctx := context.Background()
+	mockAuthUsecase.EXPECT().RevokeOtherSessions(ctx, uint(1), "current").Return(nil).Times(1)
+
+	err := sessionUsecase.RevokeOtherSessions(ctx, 1, "current")
+
+	assert.NoError(t, err)
+}
@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"go.uber.org/zap"
+)
+
+type userAdminUsecase struct {
+	userRepo    domain.UserRepository
+	authUsecase domain.AuthUsecase
+	logger      *zap.Logger
+}
+
+// NewUserAdminUsecase membuat instance baru dari userAdminUsecase.
+func NewUserAdminUsecase(ur domain.UserRepository, au domain.AuthUsecase, logger *zap.Logger) domain.UserAdminUsecase {
+	return &userAdminUsecase{
+		userRepo:    ur,
+		authUsecase: au,
+		logger:      logger,
+	}
+}
+
+// ListUsers menampilkan user dengan pencarian, filter role dan status, serta paginasi.
+func (uc *userAdminUsecase) ListUsers(ctx context.Context, query *domain.AdminUserQuery) (*domain.AdminUserResult, error) {
+	query.Normalize()
+
+	users, total, err := uc.userRepo.List(ctx, domain.UserFilter{
+		Search: query.Search,
+		Role:   query.Role,
+		Status: query.Status,
+		Limit:  query.Limit,
+		Offset: query.Offset(),
+	})
+	if err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to retrieve users", err)
+	}
+
+	items := make([]*domain.AdminUserResponse, 0, len(users))
+	for i := range users {
+		items = append(items, domain.NewAdminUserResponse(&users[i]))
+	}
+
+	return &domain.AdminUserResult{
+		Items:      items,
+		Pagination: domain.NewPagination(query.PaginationQuery, total),
+	}, nil
+}
+
+// GetUser menampilkan detail satu user.
+func (uc *userAdminUsecase) GetUser(ctx context.Context, userID uint) (*domain.User, error) {
+	return uc.getUser(ctx, userID, "Failed to retrieve user")
+}
+
+// ChangeRole mengganti role user. Role dibawa access token, sehingga semua sesi user dicabut
+// agar role lama tidak bisa dipakai lagi dan user login ulang dengan role baru.
+func (uc *userAdminUsecase) ChangeRole(ctx context.Context, adminID, userID uint, payload *domain.ChangeRolePayload) (*domain.User, error) {
+	if adminID == userID {
+		return nil, domain.ErrCannotManageOwnAccount
+	}
+
+	user, err := uc.getUser(ctx, userID, "Failed to change role")
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == payload.Role {
+		return user, nil
+	}
+
+	previousRole := user.Role
+	if err := uc.userRepo.UpdateRole(ctx, userID, payload.Role); err != nil {
+		return nil, uc.writeError(err, "Failed to change role")
+	}
+	user.Role = payload.Role
+	if err := uc.authUsecase.LogoutAll(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("User role changed by admin",
+		zap.Uint("admin_id", adminID),
+		zap.Uint("user_id", userID),
+		zap.String("previous_role", previousRole),
+		zap.String("role", user.Role),
+	)
+	return user, nil
+}
+
+// Deactivate menonaktifkan akun dan langsung mencabut semua sesi serta token yang sudah
+// diterbitkan. Menonaktifkan ulang akun yang sudah nonaktif tetap mencabut sesinya, sehingga
+// aman diulang jika pencabutan sebelumnya gagal.
+func (uc *userAdminUsecase) Deactivate(ctx context.Context, adminID, userID uint) (*domain.User, error) {
+	if adminID == userID {
+		return nil, domain.ErrCannotManageOwnAccount
+	}
+
+	user, err := uc.getUser(ctx, userID, "Failed to deactivate user")
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsDeactivated() {
+		now := time.Now()
+		if err := uc.userRepo.SetDeactivatedAt(ctx, userID, &now); err != nil {
+			return nil, uc.writeError(err, "Failed to deactivate user")
+		}
+		user.DeactivatedAt = &now
+	}
+
+	// Login baru sudah ditolak sejak DeactivatedAt tersimpan; token lama dicabut setelahnya
+	if err := uc.authUsecase.LogoutAll(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("User deactivated by admin", zap.Uint("admin_id", adminID), zap.Uint("user_id", userID))
+	return user, nil
+}
+
+// Reactivate mengaktifkan kembali akun yang dinonaktifkan. User harus login ulang.
+func (uc *userAdminUsecase) Reactivate(ctx context.Context, adminID, userID uint) (*domain.User, error) {
+	if adminID == userID {
+		return nil, domain.ErrCannotManageOwnAccount
+	}
+
+	user, err := uc.getUser(ctx, userID, "Failed to reactivate user")
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsDeactivated() {
+		return user, nil
+	}
+
+	if err := uc.userRepo.SetDeactivatedAt(ctx, userID, nil); err != nil {
+		return nil, uc.writeError(err, "Failed to reactivate user")
+	}
+	user.DeactivatedAt = nil
+
+	uc.logger.Info("User reactivated by admin", zap.Uint("admin_id", adminID), zap.Uint("user_id", userID))
+	return user, nil
+}
+
+// DeleteUser menghapus akun dengan soft delete sehingga konsultasi yang mereferensikannya tetap
+// utuh, lalu mencabut semua sesinya. Email akun yang dihapus tetap tidak bisa dipakai mendaftar.
+func (uc *userAdminUsecase) DeleteUser(ctx context.Context, adminID, userID uint) error {
+	if adminID == userID {
+		return domain.ErrCannotManageOwnAccount
+	}
+
+	if _, err := uc.getUser(ctx, userID, "Failed to delete user"); err != nil {
+		return err
+	}
+
+	if err := uc.userRepo.Delete(ctx, userID); err != nil {
+		return uc.writeError(err, "Failed to delete user")
+	}
+	if err := uc.authUsecase.LogoutAll(ctx, userID); err != nil {
+		return err
+	}
+
+	uc.logger.Info("User deleted by admin", zap.Uint("admin_id", adminID), zap.Uint("user_id", userID))
+	return nil
+}
+
+func (uc *userAdminUsecase) getUser(ctx context.Context, userID uint, failureMessage string) (*domain.User, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, uc.writeError(err, failureMessage)
+	}
+	return user, nil
+}
+
+// writeError memetakan user yang tidak ditemukan ke 404 dan error lain ke 500.
+func (uc *userAdminUsecase) writeError(err error, failureMessage string) error {
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return domain.ErrManagedUserNotFound
+	}
+	return domain.NewDomainErrorWithCause(http.StatusInternalServerError, failureMessage, err)
+}
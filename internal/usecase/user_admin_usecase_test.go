@@ -0,0 +1,233 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/internal/domain"
+	"github.com/X3nonxe/gopsy-backend/internal/mocks"
+	"github.com/X3nonxe/gopsy-backend/internal/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUserAdminUsecase_ListUsers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	adminUsecase := usecase.NewUserAdminUsecase(mockUserRepo, mocks.NewMockAuthUsecase(mockCtrl), zap.NewNop())
+
+	ctx := context.Background()
+	deactivatedAt := time.Now().Add(-time.Hour)
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo.EXPECT().
+			List(ctx, domain.UserFilter{Search: "budi", Role: domain.RoleKlien, Status: domain.UserStatusDeactivated, Limit: 10, Offset: 10}).
+			Return([]domain.User{{ID: 3, Username: "budi", Role: domain.RoleKlien, DeactivatedAt: &deactivatedAt}}, int64(11), nil).
+			Times(1)
+
+		result, err := adminUsecase.ListUsers(ctx, &domain.AdminUserQuery{
+			PaginationQuery: domain.PaginationQuery{Page: 2, Limit: 10},
+			Search:          "budi",
+			Role:            domain.RoleKlien,
+			Status:          domain.UserStatusDeactivated,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, result.Items, 1)
+		assert.Equal(t, uint(3), result.Items[0].ID)
+		assert.Equal(t, domain.UserStatusDeactivated, result.Items[0].Status)
+		assert.Equal(t, 2, result.Pagination.TotalPages)
+	})
+
+	t.Run("Applies Default Pagination", func(t *testing.T) {
+		mockUserRepo.EXPECT().
+			List(ctx, domain.UserFilter{Limit: domain.DefaultPageLimit}).
+			Return(nil, int64(0), nil).
+			Times(1)
+
+		result, err := adminUsecase.ListUsers(ctx, &domain.AdminUserQuery{})
+
+		require.NoError(t, err)
+		assert.NotNil(t, result.Items)
+		assert.Equal(t, 1, result.Pagination.Page)
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		mockUserRepo.EXPECT().List(ctx, gomock.Any()).Return(nil, int64(0), errors.New("db down")).Times(1)
+
+		_, err := adminUsecase.ListUsers(ctx, &domain.AdminUserQuery{})
+
+		var domainErr *domain.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, 500, domainErr.HTTPStatus)
+	})
+}
+
+func TestUserAdminUsecase_ChangeRole(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	mockAuthUsecase := mocks.NewMockAuthUsecase(mockCtrl)
+	adminUsecase := usecase.NewUserAdminUsecase(mockUserRepo, mockAuthUsecase, zap.NewNop())
+
+	ctx := context.Background()
+	payload := &domain.ChangeRolePayload{Role: domain.RolePsikolog}
+
+	t.Run("Success Revokes Sessions", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(ctx, uint(2)).Return(&domain.User{ID: 2, Role: domain.RoleKlien}, nil).Times(1)
+		mockUserRepo.EXPECT().UpdateRole(ctx, uint(2), domain.RolePsikolog).Return(nil).Times(1)
+		mockAuthUsecase.EXPECT().LogoutAll(ctx, uint(2)).Return(nil).Times(1)
+
+		user, err := adminUsecase.ChangeRole(ctx, 1, 2, payload)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.RolePsikolog, user.Role)
+	})
+
+	t.Run("Same Role Is No-op", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(ctx, uint(2)).Return(&domain.User{ID: 2, Role: domain.RolePsikolog}, nil).Times(1)
+
+		user, err := adminUsecase.ChangeRole(ctx, 1, 2, payload)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.RolePsikolog, user.Role)
+	})
+
+	t.Run("Own Account", func(t *testing.T) {
+		_, err := adminUsecase.ChangeRole(ctx, 1, 1, &domain.ChangeRolePayload{Role: domain.RoleKlien})
+		assert.ErrorIs(t, err, domain.ErrCannotManageOwnAccount)
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(ctx, uint(9)).Return(nil, domain.ErrUserNotFound).Times(1)
+
+		_, err := adminUsecase.ChangeRole(ctx, 1, 9, payload)
+		assert.ErrorIs(t, err, domain.ErrManagedUserNotFound)
+	})
+
+	t.Run("Deleted Concurrently", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(ctx, uint(2)).Return(&domain.User{ID: 2, Role: domain.RoleKlien}, nil).Times(1)
+		mockUserRepo.EXPECT().UpdateRole(ctx, uint(2), domain.RolePsikolog).Return(domain.ErrUserNotFound).Times(1)
+
+		_, err := adminUsecase.ChangeRole(ctx, 1, 2, payload)
+		assert.ErrorIs(t, err, domain.ErrManagedUserNotFound)
+	})
+}
+
+func TestUserAdminUsecase_Deactivate(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	mockAuthUsecase := mocks.NewMockAuthUsecase(mockCtrl)
+	adminUsecase := usecase.NewUserAdminUsecase(mockUserRepo, mockAuthUsecase, zap.NewNop())
+
+	ctx := context.Background()
+
+	t.Run("Success Blocks Login And Revokes Tokens", func(t *testing.T) {
+		gomock.InOrder(
+			mockUserRepo.EXPECT().GetByID(ctx, uint(2)).Return(&domain.User{ID: 2}, nil),
+			mockUserRepo.EXPECT().SetDeactivatedAt(ctx, uint(2), gomock.Not(gomock.Nil())).Return(nil),
+			mockAuthUsecase.EXPECT().LogoutAll(ctx, uint(2)).Return(nil),
+		)
+
+		user, err := adminUsecase.Deactivate(ctx, 1, 2)
+
+		require.NoError(t, err)
+		assert.True(t, user.IsDeactivated())
+	})
+
+	t.Run("Already Deactivated Retries Revocation", func(t *testing.T) {
+		deactivatedAt := time.Now().Add(-time.Hour)
+		mockUserRepo.EXPECT().GetByID(ctx, uint(2)).Return(&domain.User{ID: 2, DeactivatedAt: &deactivatedAt}, nil).Times(1)
+		mockAuthUsecase.EXPECT().LogoutAll(ctx, uint(2)).Return(nil).Times(1)
+
+		user, err := adminUsecase.Deactivate(ctx, 1, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, &deactivatedAt, user.DeactivatedAt)
+	})
+
+	t.Run("Revocation Failure", func(t *testing.T) {
+		revokeErr := domain.NewDomainError(500, "Failed to logout from all devices")
+		mockUserRepo.EXPECT().GetByID(ctx, uint(2)).Return(&domain.User{ID: 2}, nil).Times(1)
+		mockUserRepo.EXPECT().SetDeactivatedAt(ctx, uint(2), gomock.Any()).Return(nil).Times(1)
+		mockAuthUsecase.EXPECT().LogoutAll(ctx, uint(2)).Return(revokeErr).Times(1)
+
+		_, err := adminUsecase.Deactivate(ctx, 1, 2)
+		assert.ErrorIs(t, err, revokeErr)
+	})
+
+	t.Run("Own Account", func(t *testing.T) {
+		_, err := adminUsecase.Deactivate(ctx, 1, 1)
+		assert.ErrorIs(t, err, domain.ErrCannotManageOwnAccount)
+	})
+}
+
+func TestUserAdminUsecase_Reactivate(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	adminUsecase := usecase.NewUserAdminUsecase(mockUserRepo, mocks.NewMockAuthUsecase(mockCtrl), zap.NewNop())
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		deactivatedAt := time.Now().Add(-time.Hour)
+		mockUserRepo.EXPECT().GetByID(ctx, uint(2)).Return(&domain.User{ID: 2, DeactivatedAt: &deactivatedAt}, nil).Times(1)
+		mockUserRepo.EXPECT().SetDeactivatedAt(ctx, uint(2), nil).Return(nil).Times(1)
+
+		user, err := adminUsecase.Reactivate(ctx, 1, 2)
+
+		require.NoError(t, err)
+		assert.False(t, user.IsDeactivated())
+	})
+
+	t.Run("Active User Is No-op", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(ctx, uint(2)).Return(&domain.User{ID: 2}, nil).Times(1)
+
+		user, err := adminUsecase.Reactivate(ctx, 1, 2)
+
+		require.NoError(t, err)
+		assert.False(t, user.IsDeactivated())
+	})
+}
+
+func TestUserAdminUsecase_DeleteUser(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	mockAuthUsecase := mocks.NewMockAuthUsecase(mockCtrl)
+	adminUsecase := usecase.NewUserAdminUsecase(mockUserRepo, mockAuthUsecase, zap.NewNop())
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		gomock.InOrder(
+			mockUserRepo.EXPECT().GetByID(ctx, uint(2)).Return(&domain.User{ID: 2}, nil),
+			mockUserRepo.EXPECT().Delete(ctx, uint(2)).Return(nil),
+			mockAuthUsecase.EXPECT().LogoutAll(ctx, uint(2)).Return(nil),
+		)
+
+		assert.NoError(t, adminUsecase.DeleteUser(ctx, 1, 2))
+	})
+
+	t.Run("Own Account", func(t *testing.T) {
+		assert.ErrorIs(t, adminUsecase.DeleteUser(ctx, 1, 1), domain.ErrCannotManageOwnAccount)
+	})
+
+	t.Run("Already Deleted", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(ctx, uint(2)).Return(nil, domain.ErrUserNotFound).Times(1)
+
+		assert.ErrorIs(t, adminUsecase.DeleteUser(ctx, 1, 2), domain.ErrManagedUserNotFound)
+	})
+}
@@ -3,31 +3,61 @@ package usecase
 import (
 	"context"
 	"errors"
+	"net/http"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/X3nonxe/gopsy-backend/internal/domain"
-	"github.com/golang-jwt/jwt/v5"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_password"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_phone"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type userUsecase struct {
-	userRepo           domain.UserRepository
-	jwtSecret          string
-	jwtExpirationHours int
-	logger             *zap.Logger
+	userRepo         domain.UserRepository
+	availabilityRepo domain.AvailabilityRepository
+	profileRepo      domain.PsychologistProfileRepository
+	authUsecase      domain.AuthUsecase
+	verification     domain.EmailVerificationUsecase
+	mfaUsecase       domain.MFAUsecase
+	loginAttempts    domain.LoginAttemptUsecase
+	passwordPolicy   app_password.Policy
+	location         *time.Location
+	logger           *zap.Logger
 }
 
-func NewUserUsecase(ur domain.UserRepository, jwtSecret string, jwtExpirationHours int, logger *zap.Logger) domain.UserUsecase {
+func NewUserUsecase(ur domain.UserRepository, ar domain.AvailabilityRepository, pr domain.PsychologistProfileRepository, au domain.AuthUsecase, evu domain.EmailVerificationUsecase, mu domain.MFAUsecase, la domain.LoginAttemptUsecase, policy app_password.Policy, location *time.Location, logger *zap.Logger) domain.UserUsecase {
 	return &userUsecase{
-		userRepo:           ur,
-		jwtSecret:          jwtSecret,
-		jwtExpirationHours: jwtExpirationHours,
-		logger:             logger,
+		userRepo:         ur,
+		availabilityRepo: ar,
+		profileRepo:      pr,
+		authUsecase:      au,
+		verification:     evu,
+		mfaUsecase:       mu,
+		loginAttempts:    la,
+		passwordPolicy:   policy,
+		location:         location,
+		logger:           logger,
 	}
 }
 
+var (
+	dummyPasswordHash     []byte
+	dummyPasswordHashOnce sync.Once
+)
+
+// compareDummyPassword menjalankan bcrypt untuk email yang tidak terdaftar agar waktu respons
+// login tidak membedakan akun yang ada dan tidak ada.
+func compareDummyPassword(password string) {
+	dummyPasswordHashOnce.Do(func() {
+		dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("gopsy-dummy-password"), bcrypt.DefaultCost)
+	})
+	_ = bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(password))
+}
+
 func (uc *userUsecase) registerUser(ctx context.Context, payload *domain.RegisterPayload, role string) (*domain.User, error) {
 	// Normalize email
 	payload.Email = strings.ToLower(strings.TrimSpace(payload.Email))
@@ -47,13 +77,18 @@ func (uc *userUsecase) registerUser(ctx context.Context, payload *domain.Registe
 
 	// Email is available, proceed with registration
 
-	// 2. Hash password
+	// 2. Check password policy
+	if err := checkPasswordPolicy(uc.passwordPolicy, payload.Password, payload.Username, payload.Email); err != nil {
+		return nil, err
+	}
+
+	// 3. Hash password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(payload.Password), bcrypt.DefaultCost)
 	if err != nil {
 		return nil, err
 	}
 
-	// 3. Create new user
+	// 4. Create new user
 	user := &domain.User{
 		Username: strings.TrimSpace(payload.Username),
 		Email:    payload.Email, // Already normalized above
@@ -61,7 +96,7 @@ func (uc *userUsecase) registerUser(ctx context.Context, payload *domain.Registe
 		Role:     role,
 	}
 
-	// 4. Save to database
+	// 5. Save to database
 	if err := uc.userRepo.Create(ctx, user); err != nil {
 		// Handle database-specific unique constraint violations
 		if isDuplicateKeyError(err) {
@@ -70,6 +105,11 @@ func (uc *userUsecase) registerUser(ctx context.Context, payload *domain.Registe
 		return nil, err
 	}
 
+	// 6. Kirim email verifikasi; kegagalan tidak membatalkan registrasi karena user bisa meminta ulang
+	if err := uc.verification.SendVerification(ctx, user); err != nil {
+		uc.logger.Warn("Failed to send verification email after registration", zap.Error(err), zap.Uint("user_id", user.ID))
+	}
+
 	return user, nil
 }
 
@@ -84,47 +124,99 @@ func isDuplicateKeyError(err error) bool {
 }
 
 func (uc *userUsecase) Register(ctx context.Context, payload *domain.RegisterPayload) (*domain.User, error) {
-	return uc.registerUser(ctx, payload, "klien")
+	return uc.registerUser(ctx, payload, domain.RoleKlien)
 }
 
 func (uc *userUsecase) RegisterPsychologist(ctx context.Context, payload *domain.RegisterPayload) (*domain.User, error) {
-	return uc.registerUser(ctx, payload, "psikolog")
+	return uc.registerUser(ctx, payload, domain.RolePsikolog)
 }
 
-func (uc *userUsecase) Login(ctx context.Context, payload *domain.LoginPayload) (*domain.LoginResponse, error) {
+func (uc *userUsecase) Login(ctx context.Context, payload *domain.LoginPayload, client *domain.ClientInfo) (*domain.LoginResponse, error) {
 	// Normalize email for login
 	payload.Email = strings.ToLower(strings.TrimSpace(payload.Email))
 
+	// Percobaan dicatat sebelum password diperiksa, sehingga email yang diperlambat atau dikunci
+	// ditolak meskipun banyak percobaan datang bersamaan
+	if err := uc.loginAttempts.Reserve(ctx, payload.Email); err != nil {
+		return nil, err
+	}
+
 	user, err := uc.userRepo.GetByEmail(ctx, payload.Email)
 	if err != nil {
 		if errors.Is(err, domain.ErrUserNotFound) {
-			return nil, domain.ErrInvalidCredentials
+			return nil, failLogin(payload)
 		}
 		return nil, err
 	}
+	if user == nil {
+		return nil, failLogin(payload)
+	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(payload.Password)); err != nil {
 		return nil, domain.ErrInvalidCredentials
 	}
 
-	token, err := uc.generateJWT(user)
+	// Status akun baru diungkap setelah password benar agar tidak bisa ditebak dari luar
+	if user.IsDeactivated() {
+		return nil, domain.ErrAccountDeactivated
+	}
+
+	if err := uc.loginAttempts.Reset(ctx, payload.Email); err != nil {
+		uc.logger.Warn("Failed to reset failed login attempts", zap.Error(err), zap.Uint("user_id", user.ID))
+	}
+
+	// Token baru diterbitkan setelah tantangan MFA selesai jika user wajib atau sudah memakai MFA
+	challenge, err := uc.mfaUsecase.BeginChallenge(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != nil {
+		return &domain.LoginResponse{
+			MFARequired:           true,
+			MFAEnrollmentRequired: challenge.EnrollmentRequired,
+			MFAToken:              challenge.Token,
+			MFATokenExpiresIn:     challenge.ExpiresIn,
+		}, nil
+	}
+
+	tokens, err := uc.authUsecase.IssueTokens(ctx, user, client)
 	if err != nil {
 		return nil, err
 	}
 
 	return &domain.LoginResponse{
-		Token: token,
-		User: &domain.UserResponse{
-			ID:        user.ID,
-			Username:  user.Username,
-			Email:     user.Email,
-			Role:      user.Role,
-			CreatedAt: user.CreatedAt,
-			UpdatedAt: user.UpdatedAt,
-		},
+		TokenPair: tokens,
+		User:      domain.NewUserResponse(user),
 	}, nil
 }
 
+// UnlockLogin menghapus penguncian login akibat password atau kode MFA salah untuk user tertentu.
+func (uc *userUsecase) UnlockLogin(ctx context.Context, userID uint) error {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return domain.NewDomainError(http.StatusNotFound, "User not found")
+		}
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to unlock login", err)
+	}
+
+	if err := uc.loginAttempts.Reset(ctx, user.Email); err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to unlock login", err)
+	}
+	if err := uc.mfaUsecase.ResetFailures(ctx, user.ID); err != nil {
+		return domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to unlock login", err)
+	}
+
+	uc.logger.Info("Login unlocked by admin", zap.Uint("user_id", userID))
+	return nil
+}
+
+// failLogin menangani login dengan email yang tidak terdaftar seperti password yang salah.
+func failLogin(payload *domain.LoginPayload) error {
+	compareDummyPassword(payload.Password)
+	return domain.ErrInvalidCredentials
+}
+
 func (uc *userUsecase) GetProfile(ctx context.Context, userID uint) (*domain.User, error) {
 	user, err := uc.userRepo.GetByID(ctx, userID)
 	if err != nil {
@@ -133,14 +225,121 @@ func (uc *userUsecase) GetProfile(ctx context.Context, userID uint) (*domain.Use
 	return user, nil
 }
 
-func (uc *userUsecase) generateJWT(user *domain.User) (string, error) {
-	expirationTime := time.Now().Add(time.Hour * time.Duration(uc.jwtExpirationHours))
-	claims := jwt.MapClaims{
-		"user_id": user.ID,
-		"role":    user.Role,
-		"exp":     expirationTime.Unix(),
-		"iat":     time.Now().Unix(),
+// UpdateProfile mengganti username, nomor telepon, dan gender milik user.
+func (uc *userUsecase) UpdateProfile(ctx context.Context, userID uint, payload *domain.UpdateProfilePayload) (*domain.User, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.NewDomainError(http.StatusNotFound, "User not found")
+		}
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to update profile", err)
+	}
+
+	var phoneNumber *string
+	if raw := strings.TrimSpace(payload.PhoneNumber); raw != "" {
+		normalized, err := app_phone.Normalize(raw)
+		if err != nil {
+			return nil, domain.ErrInvalidPhoneNumber
+		}
+		phoneNumber = &normalized
+	}
+
+	user.Username = strings.TrimSpace(payload.Username)
+	user.PhoneNumber = phoneNumber
+	user.Gender = optionalString(payload.Gender)
+
+	if err := uc.userRepo.UpdateProfile(ctx, user); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.NewDomainError(http.StatusNotFound, "User not found")
+		}
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to update profile", err)
+	}
+
+	uc.logger.Info("Profile updated", zap.Uint("user_id", userID))
+	return user, nil
+}
+
+// optionalString mengubah string kosong menjadi nil agar kolom nullable dikosongkan.
+func optionalString(value string) *string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// GetAvailablePsychologists mencari psikolog terverifikasi untuk direktori klien beserta profil
+// profesional dan ringkasan jadwalnya.
+func (uc *userUsecase) GetAvailablePsychologists(ctx context.Context, query *domain.PsychologistDirectoryQuery) (*domain.PsychologistDirectoryResult, error) {
+	query.Normalize()
+
+	if query.WaktuMulai != "" && query.WaktuSelesai != "" && query.WaktuMulai >= query.WaktuSelesai {
+		return nil, domain.NewDomainError(http.StatusBadRequest, "Start time must be before end time")
+	}
+
+	psychologists, total, err := uc.userRepo.FindPsychologists(ctx, domain.PsychologistFilter{
+		VerifiedOn:   time.Now().In(uc.location),
+		Hari:         query.Hari,
+		WaktuMulai:   query.WaktuMulai,
+		WaktuSelesai: query.WaktuSelesai,
+		Query:        query.Q,
+		Limit:        query.Limit,
+		Offset:       query.Offset(),
+	})
+	if err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to retrieve psychologists", err)
+	}
+
+	ids := make([]uint, 0, len(psychologists))
+	for _, p := range psychologists {
+		ids = append(ids, p.ID)
+	}
+
+	slots, err := uc.availabilityRepo.GetByPsikologIDs(ctx, ids)
+	if err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to retrieve psychologists", err)
+	}
+
+	profiles, err := uc.profileRepo.GetByUserIDs(ctx, ids)
+	if err != nil {
+		return nil, domain.NewDomainErrorWithCause(http.StatusInternalServerError, "Failed to retrieve psychologists", err)
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(uc.jwtSecret))
+	professional := make(map[uint]*domain.PsychologistProfessionalInfo, len(profiles))
+	for i := range profiles {
+		professional[profiles[i].UserID] = domain.NewPsychologistProfessionalInfo(&profiles[i])
+	}
+
+	// Urutkan slot dari Senin hingga Minggu sebelum dikelompokkan per psikolog
+	sort.SliceStable(slots, func(i, j int) bool {
+		if domain.HariOrder(slots[i].Hari) != domain.HariOrder(slots[j].Hari) {
+			return domain.HariOrder(slots[i].Hari) < domain.HariOrder(slots[j].Hari)
+		}
+		return slots[i].WaktuMulai < slots[j].WaktuMulai
+	})
+	summaries := make(map[uint][]domain.AvailabilitySummary, len(psychologists))
+	for _, slot := range slots {
+		summaries[slot.PsikologID] = append(summaries[slot.PsikologID], domain.AvailabilitySummary{
+			Hari:         slot.Hari,
+			WaktuMulai:   slot.WaktuMulai,
+			WaktuSelesai: slot.WaktuSelesai,
+		})
+	}
+
+	items := make([]domain.PsychologistDirectoryEntry, 0, len(psychologists))
+	for i := range psychologists {
+		availability := summaries[psychologists[i].ID]
+		if availability == nil {
+			availability = []domain.AvailabilitySummary{}
+		}
+		items = append(items, domain.PsychologistDirectoryEntry{
+			PsychologistPublicProfile: *domain.NewPsychologistPublicProfile(&psychologists[i]),
+			Professional:              professional[psychologists[i].ID],
+			Availability:              availability,
+		})
+	}
+
+	return &domain.PsychologistDirectoryResult{
+		Items:      items,
+		Pagination: domain.NewPagination(query.PaginationQuery, total),
+	}, nil
 }
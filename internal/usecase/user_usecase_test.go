@@ -2,14 +2,19 @@ package usecase_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/X3nonxe/gopsy-backend/internal/domain"
 	"github.com/X3nonxe/gopsy-backend/internal/mocks"
 	"github.com/X3nonxe/gopsy-backend/internal/usecase"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_password"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -19,13 +24,14 @@ func TestUserUsecase_Register(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	mockVerification := mocks.NewMockEmailVerificationUsecase(mockCtrl)
 	logger := zap.NewNop()
-	userUsecase := usecase.NewUserUsecase(mockUserRepo, "test-secret", 3600, logger)
+	userUsecase := usecase.NewUserUsecase(mockUserRepo, mocks.NewMockAvailabilityRepository(mockCtrl), mocks.NewMockPsychologistProfileRepository(mockCtrl), mocks.NewMockAuthUsecase(mockCtrl), mockVerification, mocks.NewMockMFAUsecase(mockCtrl), mocks.NewMockLoginAttemptUsecase(mockCtrl), app_password.DefaultPolicy(), time.UTC, logger)
 
 	payload := &domain.RegisterPayload{
 		Username: "testuser",
 		Email:    "test@example.com",
-		Password: "password123",
+		Password: "Tiga-Kucing-Lompat9",
 	}
 
 	t.Run("Success", func(t *testing.T) {
@@ -55,6 +61,16 @@ func TestUserUsecase_Register(t *testing.T) {
 			Return(nil).
 			Times(1)
 
+		// Mock: Email verifikasi dikirim untuk user baru
+		mockVerification.EXPECT().
+			SendVerification(gomock.Any(), gomock.Any()).
+			Do(func(ctx context.Context, user *domain.User) {
+				assert.Equal(t, payload.Email, user.Email)
+				assert.Nil(t, user.EmailVerifiedAt)
+			}).
+			Return(nil).
+			Times(1)
+
 		user, err := userUsecase.Register(context.Background(), payload)
 
 		assert.NoError(t, err)
@@ -64,6 +80,26 @@ func TestUserUsecase_Register(t *testing.T) {
 		assert.Equal(t, "klien", user.Role)
 	})
 
+	t.Run("Verification Email Failure Does Not Fail Registration", func(t *testing.T) {
+		mockUserRepo.EXPECT().
+			GetByEmail(gomock.Any(), payload.Email).
+			Return(nil, domain.ErrUserNotFound).
+			Times(1)
+		mockUserRepo.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			Return(nil).
+			Times(1)
+		mockVerification.EXPECT().
+			SendVerification(gomock.Any(), gomock.Any()).
+			Return(errors.New("smtp down")).
+			Times(1)
+
+		user, err := userUsecase.Register(context.Background(), payload)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, user)
+	})
+
 	t.Run("Email Already Exists", func(t *testing.T) {
 		existingUser := &domain.User{ID: 1, Email: payload.Email}
 
@@ -79,6 +115,22 @@ func TestUserUsecase_Register(t *testing.T) {
 		assert.Nil(t, user)
 	})
 
+	t.Run("Weak Password", func(t *testing.T) {
+		mockUserRepo.EXPECT().
+			GetByEmail(gomock.Any(), payload.Email).
+			Return(nil, domain.ErrUserNotFound).
+			Times(1)
+
+		weak := *payload
+		weak.Password = "password123"
+		user, err := userUsecase.Register(context.Background(), &weak)
+
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusBadRequest, domainErr.HTTPStatus)
+		assert.Nil(t, user)
+	})
+
 	t.Run("Database Error on GetByEmail", func(t *testing.T) {
 		dbError := errors.New("database error")
 
@@ -119,7 +171,10 @@ func TestUserUsecase_Login(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
-	userUsecase := usecase.NewUserUsecase(mockUserRepo, "test-secret", 3600, zap.NewNop())
+	mockAuthUsecase := mocks.NewMockAuthUsecase(mockCtrl)
+	mockMFAUsecase := mocks.NewMockMFAUsecase(mockCtrl)
+	mockLoginAttempts := mocks.NewMockLoginAttemptUsecase(mockCtrl)
+	userUsecase := usecase.NewUserUsecase(mockUserRepo, mocks.NewMockAvailabilityRepository(mockCtrl), mocks.NewMockPsychologistProfileRepository(mockCtrl), mockAuthUsecase, mocks.NewMockEmailVerificationUsecase(mockCtrl), mockMFAUsecase, mockLoginAttempts, app_password.DefaultPolicy(), time.UTC, zap.NewNop())
 
 	password := "password123"
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -137,27 +192,85 @@ func TestUserUsecase_Login(t *testing.T) {
 	}
 
 	t.Run("Success", func(t *testing.T) {
+		mockLoginAttempts.EXPECT().Reserve(gomock.Any(), payload.Email).Return(nil).Times(1)
 		mockUserRepo.EXPECT().
 			GetByEmail(gomock.Any(), payload.Email).
 			Return(user, nil).
 			Times(1)
 
-		response, err := userUsecase.Login(context.Background(), payload)
+		mockLoginAttempts.EXPECT().Reset(gomock.Any(), payload.Email).Return(nil).Times(1)
+
+		mockMFAUsecase.EXPECT().
+			BeginChallenge(gomock.Any(), user).
+			Return(nil, nil).
+			Times(1)
+
+		mockAuthUsecase.EXPECT().
+			IssueTokens(gomock.Any(), user, gomock.Any()).
+			Return(&domain.TokenPair{Token: "access-token", RefreshToken: "refresh-token", TokenType: "Bearer", ExpiresIn: 900}, nil).
+			Times(1)
+
+		response, err := userUsecase.Login(context.Background(), payload, nil)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, response)
-		assert.NotEmpty(t, response.Token)
+		assert.Equal(t, "access-token", response.Token)
+		assert.Equal(t, "refresh-token", response.RefreshToken)
 		assert.NotNil(t, response.User)
 		assert.Equal(t, user.ID, response.User.ID)
+		assert.False(t, response.MFARequired)
+	})
+
+	t.Run("MFA Challenge", func(t *testing.T) {
+		mockLoginAttempts.EXPECT().Reserve(gomock.Any(), payload.Email).Return(nil).Times(1)
+		mockUserRepo.EXPECT().
+			GetByEmail(gomock.Any(), payload.Email).
+			Return(user, nil).
+			Times(1)
+
+		mockLoginAttempts.EXPECT().Reset(gomock.Any(), payload.Email).Return(nil).Times(1)
+
+		mockMFAUsecase.EXPECT().
+			BeginChallenge(gomock.Any(), user).
+			Return(&domain.MFAChallenge{Token: "mfa-token", ExpiresIn: 300, EnrollmentRequired: true}, nil).
+			Times(1)
+
+		response, err := userUsecase.Login(context.Background(), payload, nil)
+
+		assert.NoError(t, err)
+		assert.True(t, response.MFARequired)
+		assert.True(t, response.MFAEnrollmentRequired)
+		assert.Equal(t, "mfa-token", response.MFAToken)
+		assert.Equal(t, int64(300), response.MFATokenExpiresIn)
+		assert.Nil(t, response.TokenPair)
+		assert.Nil(t, response.User)
+	})
+
+	t.Run("Deactivated Account", func(t *testing.T) {
+		deactivatedAt := time.Now().Add(-time.Hour)
+		deactivated := *user
+		deactivated.DeactivatedAt = &deactivatedAt
+
+		mockLoginAttempts.EXPECT().Reserve(gomock.Any(), payload.Email).Return(nil).Times(1)
+		mockUserRepo.EXPECT().
+			GetByEmail(gomock.Any(), payload.Email).
+			Return(&deactivated, nil).
+			Times(1)
+
+		response, err := userUsecase.Login(context.Background(), payload, nil)
+
+		assert.ErrorIs(t, err, domain.ErrAccountDeactivated)
+		assert.Nil(t, response)
 	})
 
 	t.Run("User Not Found", func(t *testing.T) {
+		mockLoginAttempts.EXPECT().Reserve(gomock.Any(), payload.Email).Return(nil).Times(1)
 		mockUserRepo.EXPECT().
 			GetByEmail(gomock.Any(), payload.Email).
 			Return(nil, domain.ErrUserNotFound).
 			Times(1)
 
-		response, err := userUsecase.Login(context.Background(), payload)
+		response, err := userUsecase.Login(context.Background(), payload, nil)
 
 		assert.Error(t, err)
 		assert.True(t, errors.Is(err, domain.ErrInvalidCredentials))
@@ -165,6 +278,7 @@ func TestUserUsecase_Login(t *testing.T) {
 	})
 
 	t.Run("Incorrect Password", func(t *testing.T) {
+		mockLoginAttempts.EXPECT().Reserve(gomock.Any(), payload.Email).Return(nil).Times(1)
 		mockUserRepo.EXPECT().
 			GetByEmail(gomock.Any(), payload.Email).
 			Return(user, nil).
@@ -175,7 +289,7 @@ func TestUserUsecase_Login(t *testing.T) {
 			Password: "wrongpassword",
 		}
 
-		response, err := userUsecase.Login(context.Background(), incorrectPayload)
+		response, err := userUsecase.Login(context.Background(), incorrectPayload, nil)
 
 		assert.Error(t, err)
 		assert.True(t, errors.Is(err, domain.ErrInvalidCredentials))
@@ -185,12 +299,13 @@ func TestUserUsecase_Login(t *testing.T) {
 	t.Run("Database Error", func(t *testing.T) {
 		dbError := errors.New("database error")
 
+		mockLoginAttempts.EXPECT().Reserve(gomock.Any(), payload.Email).Return(nil).Times(1)
 		mockUserRepo.EXPECT().
 			GetByEmail(gomock.Any(), payload.Email).
 			Return(nil, dbError).
 			Times(1)
 
-		response, err := userUsecase.Login(context.Background(), payload)
+		response, err := userUsecase.Login(context.Background(), payload, nil)
 
 		assert.Error(t, err)
 		assert.Equal(t, dbError, err)
@@ -199,15 +314,196 @@ func TestUserUsecase_Login(t *testing.T) {
 
 	t.Run("User Nil Pointer", func(t *testing.T) {
 		// Simulasikan repository return nil user tanpa error
+		mockLoginAttempts.EXPECT().Reserve(gomock.Any(), payload.Email).Return(nil).Times(1)
 		mockUserRepo.EXPECT().
 			GetByEmail(gomock.Any(), payload.Email).
 			Return(nil, nil). // Tidak error tapi user nil
 			Times(1)
 
-		response, err := userUsecase.Login(context.Background(), payload)
+		response, err := userUsecase.Login(context.Background(), payload, nil)
 
 		assert.Error(t, err)
 		assert.True(t, errors.Is(err, domain.ErrInvalidCredentials))
 		assert.Nil(t, response)
 	})
+
+	t.Run("Throttled", func(t *testing.T) {
+		throttled := &domain.LoginThrottledError{RetryAfter: 30 * time.Second}
+		mockLoginAttempts.EXPECT().Reserve(gomock.Any(), payload.Email).Return(throttled).Times(1)
+
+		response, err := userUsecase.Login(context.Background(), payload, nil)
+
+		assert.Equal(t, throttled, err)
+		assert.Nil(t, response)
+	})
+}
+
+func TestUserUsecase_UnlockLogin(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	mockLoginAttempts := mocks.NewMockLoginAttemptUsecase(mockCtrl)
+	mockMFAUsecase := mocks.NewMockMFAUsecase(mockCtrl)
+	userUsecase := usecase.NewUserUsecase(mockUserRepo, mocks.NewMockAvailabilityRepository(mockCtrl), mocks.NewMockPsychologistProfileRepository(mockCtrl), mocks.NewMockAuthUsecase(mockCtrl), mocks.NewMockEmailVerificationUsecase(mockCtrl), mockMFAUsecase, mockLoginAttempts, app_password.DefaultPolicy(), time.UTC, zap.NewNop())
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), uint(1)).Return(&domain.User{ID: 1, Email: "klien@example.com"}, nil).Times(1)
+		mockLoginAttempts.EXPECT().Reset(gomock.Any(), "klien@example.com").Return(nil).Times(1)
+		mockMFAUsecase.EXPECT().ResetFailures(gomock.Any(), uint(1)).Return(nil).Times(1)
+
+		err := userUsecase.UnlockLogin(context.Background(), 1)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), uint(2)).Return(nil, domain.ErrUserNotFound).Times(1)
+
+		err := userUsecase.UnlockLogin(context.Background(), 2)
+
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusNotFound, domainErr.HTTPStatus)
+	})
+}
+
+func TestUserUsecase_UpdateProfile(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	userUsecase := usecase.NewUserUsecase(mockUserRepo, mocks.NewMockAvailabilityRepository(mockCtrl), mocks.NewMockPsychologistProfileRepository(mockCtrl), mocks.NewMockAuthUsecase(mockCtrl), mocks.NewMockEmailVerificationUsecase(mockCtrl), mocks.NewMockMFAUsecase(mockCtrl), mocks.NewMockLoginAttemptUsecase(mockCtrl), app_password.DefaultPolicy(), time.UTC, zap.NewNop())
+
+	ctx := context.Background()
+
+	t.Run("Normalizes Phone Number", func(t *testing.T) {
+		pictureKey := "profile-pictures/abc"
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(&domain.User{ID: 1, Username: "budi", ProfilePictureKey: &pictureKey}, nil).Times(1)
+		mockUserRepo.EXPECT().UpdateProfile(ctx, gomock.Any()).Return(nil).Times(1)
+
+		user, err := userUsecase.UpdateProfile(ctx, 1, &domain.UpdateProfilePayload{
+			Username:    " Budi Santoso ",
+			PhoneNumber: "0812-3456-7890",
+			Gender:      "laki-laki",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Budi Santoso", user.Username)
+		require.NotNil(t, user.PhoneNumber)
+		assert.Equal(t, "+6281234567890", *user.PhoneNumber)
+		require.NotNil(t, user.Gender)
+		assert.Equal(t, "laki-laki", *user.Gender)
+		// Foto profil hanya berubah lewat endpoint unggah
+		assert.Equal(t, &pictureKey, user.ProfilePictureKey)
+	})
+
+	t.Run("Empty Fields Clear Values", func(t *testing.T) {
+		phone, gender := "+6281234567890", "perempuan"
+		mockUserRepo.EXPECT().
+			GetByID(ctx, uint(1)).
+			Return(&domain.User{ID: 1, Username: "sari", PhoneNumber: &phone, Gender: &gender}, nil).
+			Times(1)
+		mockUserRepo.EXPECT().UpdateProfile(ctx, gomock.Any()).Return(nil).Times(1)
+
+		user, err := userUsecase.UpdateProfile(ctx, 1, &domain.UpdateProfilePayload{Username: "sari"})
+
+		require.NoError(t, err)
+		assert.Nil(t, user.PhoneNumber)
+		assert.Nil(t, user.Gender)
+	})
+
+	t.Run("User Deleted Before Save", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(&domain.User{ID: 1, Username: "budi"}, nil).Times(1)
+		mockUserRepo.EXPECT().UpdateProfile(ctx, gomock.Any()).Return(domain.ErrUserNotFound).Times(1)
+
+		user, err := userUsecase.UpdateProfile(ctx, 1, &domain.UpdateProfilePayload{Username: "budi"})
+
+		var domainErr *domain.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, http.StatusNotFound, domainErr.HTTPStatus)
+		assert.Nil(t, user)
+	})
+
+	t.Run("Invalid Phone Number", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(&domain.User{ID: 1, Username: "budi"}, nil).Times(1)
+
+		user, err := userUsecase.UpdateProfile(ctx, 1, &domain.UpdateProfilePayload{
+			Username:    "budi",
+			PhoneNumber: "+65 9123 4567",
+		})
+
+		assert.True(t, errors.Is(err, domain.ErrInvalidPhoneNumber))
+		assert.Nil(t, user)
+	})
+}
+
+func TestUserUsecase_GetAvailablePsychologists(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(mockCtrl)
+	mockAvailabilityRepo := mocks.NewMockAvailabilityRepository(mockCtrl)
+	mockProfileRepo := mocks.NewMockPsychologistProfileRepository(mockCtrl)
+	userUsecase := usecase.NewUserUsecase(mockUserRepo, mockAvailabilityRepo, mockProfileRepo, mocks.NewMockAuthUsecase(mockCtrl), mocks.NewMockEmailVerificationUsecase(mockCtrl), mocks.NewMockMFAUsecase(mockCtrl), mocks.NewMockLoginAttemptUsecase(mockCtrl), app_password.DefaultPolicy(), time.UTC, zap.NewNop())
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		query := &domain.PsychologistDirectoryQuery{Hari: "Senin", WaktuMulai: "09:00:00", Q: "budi"}
+		psychologists := []domain.User{
+			{ID: 2, Username: "dr.budi", Email: "budi@test.com", Password: "hash", Role: "psikolog"},
+			{ID: 3, Username: "dr.budiman", Email: "budiman@test.com", Password: "hash", Role: "psikolog"},
+		}
+
+		mockUserRepo.EXPECT().
+			FindPsychologists(ctx, gomock.Any()).
+			Do(func(ctx context.Context, filter domain.PsychologistFilter) {
+				assert.Equal(t, "Senin", filter.Hari)
+				assert.Equal(t, "09:00:00", filter.WaktuMulai)
+				assert.Equal(t, "budi", filter.Query)
+				assert.Equal(t, domain.DefaultPageLimit, filter.Limit)
+				assert.False(t, filter.VerifiedOn.IsZero())
+			}).
+			Return(psychologists, int64(2), nil).
+			Times(1)
+		mockAvailabilityRepo.EXPECT().
+			GetByPsikologIDs(ctx, []uint{2, 3}).
+			Return([]domain.WaktuKonsultasi{
+				{PsikologID: 2, Hari: "Rabu", WaktuMulai: "13:00:00", WaktuSelesai: "15:00:00"},
+				{PsikologID: 2, Hari: "Senin", WaktuMulai: "09:00:00", WaktuSelesai: "12:00:00"},
+			}, nil).
+			Times(1)
+		mockProfileRepo.EXPECT().
+			GetByUserIDs(ctx, []uint{2, 3}).
+			Return([]domain.PsychologistProfile{*verifiedProfile(2), *verifiedProfile(3)}, nil).
+			Times(1)
+
+		result, err := userUsecase.GetAvailablePsychologists(ctx, query)
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Items, 2)
+		assert.Equal(t, "dr.budi", result.Items[0].Username)
+		assert.Equal(t, []string{"Kecemasan"}, result.Items[0].Professional.Specializations)
+		assert.Equal(t, int64(350000), result.Items[1].Professional.SessionFee)
+		assert.Len(t, result.Items[0].Availability, 2)
+		assert.Equal(t, "Senin", result.Items[0].Availability[0].Hari)
+		assert.Empty(t, result.Items[1].Availability)
+		assert.Equal(t, int64(2), result.Pagination.Total)
+
+		// Direktori tidak boleh membocorkan email maupun hash password
+		body, _ := json.Marshal(result)
+		assert.NotContains(t, string(body), "budi@test.com")
+		assert.NotContains(t, string(body), "hash")
+		assert.NotContains(t, string(body), "sipp")
+	})
+
+	t.Run("Invalid Time Window", func(t *testing.T) {
+		query := &domain.PsychologistDirectoryQuery{WaktuMulai: "12:00:00", WaktuSelesai: "09:00:00"}
+
+		result, err := userUsecase.GetAvailablePsychologists(ctx, query)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
 }
@@ -0,0 +1,27 @@
+CREATE TABLE "konsultasi" (
+  "id" bigserial PRIMARY KEY,
+  "klien_id" bigint NOT NULL,
+  "psikolog_id" bigint NOT NULL,
+  "waktu_mulai" timestamptz NOT NULL,
+  "waktu_selesai" timestamptz NOT NULL,
+  "status" varchar(20) NOT NULL DEFAULT 'pending',
+  "catatan" text,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+
+  -- Riwayat konsultasi tidak ikut terhapus ketika akun dihapus
+  CONSTRAINT fk_konsultasi_klien
+    FOREIGN KEY("klien_id")
+    REFERENCES "users"("id")
+    ON DELETE RESTRICT,
+  CONSTRAINT fk_konsultasi_psikolog
+    FOREIGN KEY("psikolog_id")
+    REFERENCES "users"("id")
+    ON DELETE RESTRICT,
+  CONSTRAINT chk_konsultasi_waktu CHECK ("waktu_mulai" < "waktu_selesai")
+);
+
+-- Menambahkan indeks untuk pencarian bentrok jadwal dan riwayat
+CREATE INDEX ON "konsultasi" ("psikolog_id", "waktu_mulai");
+CREATE INDEX ON "konsultasi" ("klien_id");
+CREATE INDEX ON "konsultasi" ("status");
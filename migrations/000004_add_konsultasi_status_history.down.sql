@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS konsultasi_status_history;
+
+ALTER TABLE "konsultasi"
+  DROP COLUMN IF EXISTS "status_reason",
+  DROP COLUMN IF EXISTS "status_changed_at";
@@ -0,0 +1,20 @@
+ALTER TABLE "konsultasi"
+  ADD COLUMN "status_reason" text,
+  ADD COLUMN "status_changed_at" timestamptz;
+
+CREATE TABLE "konsultasi_status_history" (
+  "id" bigserial PRIMARY KEY,
+  "konsultasi_id" bigint NOT NULL,
+  "from_status" varchar(20) NOT NULL,
+  "to_status" varchar(20) NOT NULL,
+  "reason" text,
+  "changed_by" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+
+  CONSTRAINT fk_status_history_konsultasi
+    FOREIGN KEY("konsultasi_id")
+    REFERENCES "konsultasi"("id")
+    ON DELETE CASCADE
+);
+
+CREATE INDEX ON "konsultasi_status_history" ("konsultasi_id");
@@ -0,0 +1,27 @@
+CREATE TABLE "pengecualian_jadwal" (
+  "id" bigserial PRIMARY KEY,
+  "psikolog_id" bigint NOT NULL,
+  "tanggal" date NOT NULL,
+  "jenis" varchar(20) NOT NULL,
+  "waktu_mulai" time,
+  "waktu_selesai" time,
+  "alasan" varchar(255),
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+
+  CONSTRAINT fk_pengecualian_psikolog
+    FOREIGN KEY("psikolog_id")
+    REFERENCES "users"("id")
+    ON DELETE CASCADE,
+
+  CONSTRAINT chk_pengecualian_jenis
+    CHECK ("jenis" IN ('full_day', 'partial_block', 'extra_hours')),
+
+  CONSTRAINT chk_pengecualian_waktu
+    CHECK (
+      ("jenis" = 'full_day' AND "waktu_mulai" IS NULL AND "waktu_selesai" IS NULL)
+      OR ("jenis" <> 'full_day' AND "waktu_mulai" < "waktu_selesai")
+    )
+);
+
+CREATE INDEX idx_pengecualian_psikolog_tanggal ON "pengecualian_jadwal" ("psikolog_id", "tanggal");
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS pengaturan_sesi;
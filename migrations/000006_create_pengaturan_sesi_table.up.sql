@@ -0,0 +1,18 @@
+CREATE TABLE "pengaturan_sesi" (
+  "psikolog_id" bigint PRIMARY KEY,
+  "durasi_sesi_menit" integer NOT NULL DEFAULT 60,
+  "buffer_menit" integer NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+
+  CONSTRAINT fk_pengaturan_sesi_psikolog
+    FOREIGN KEY("psikolog_id")
+    REFERENCES "users"("id")
+    ON DELETE CASCADE,
+
+  CONSTRAINT chk_pengaturan_sesi_durasi
+    CHECK ("durasi_sesi_menit" BETWEEN 15 AND 240),
+
+  CONSTRAINT chk_pengaturan_sesi_buffer
+    CHECK ("buffer_menit" BETWEEN 0 AND 120)
+);
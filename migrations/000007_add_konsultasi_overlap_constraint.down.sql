@@ -0,0 +1 @@
+ALTER TABLE "konsultasi" DROP CONSTRAINT IF EXISTS excl_konsultasi_psikolog_waktu;
@@ -0,0 +1,9 @@
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- Dua konsultasi aktif milik psikolog yang sama tidak boleh beririsan waktunya. Daftar status harus
+-- sama dengan domain.ActiveKonsultasiStatuses; jeda antar sesi hanya ditegakkan oleh aplikasi.
+ALTER TABLE "konsultasi" ADD CONSTRAINT excl_konsultasi_psikolog_waktu
+  EXCLUDE USING gist (
+    "psikolog_id" WITH =,
+    tstzrange("waktu_mulai", "waktu_selesai", '[)') WITH &&
+  ) WHERE ("status" IN ('pending', 'accepted'));
@@ -0,0 +1,2 @@
+ALTER TABLE "waktu_konsultasi"
+  DROP COLUMN IF EXISTS "version";
@@ -0,0 +1,2 @@
+ALTER TABLE "waktu_konsultasi"
+  ADD COLUMN "version" integer NOT NULL DEFAULT 1;
@@ -0,0 +1,18 @@
+CREATE TABLE "refresh_tokens" (
+  "id" bigserial PRIMARY KEY,
+  "user_id" bigint NOT NULL,
+  "family_id" varchar(36) NOT NULL,
+  "token_hash" varchar(64) NOT NULL UNIQUE,
+  "expires_at" timestamptz NOT NULL,
+  "used_at" timestamptz,
+  "revoked_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+
+  CONSTRAINT fk_refresh_tokens_user
+    FOREIGN KEY("user_id")
+    REFERENCES "users"("id")
+    ON DELETE CASCADE
+);
+
+CREATE INDEX idx_refresh_tokens_user_id ON "refresh_tokens" ("user_id");
+CREATE INDEX idx_refresh_tokens_family_id ON "refresh_tokens" ("family_id");
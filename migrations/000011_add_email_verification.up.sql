@@ -0,0 +1,21 @@
+ALTER TABLE "users"
+  ADD COLUMN "email_verified_at" timestamptz;
+
+-- Akun yang sudah ada sebelum verifikasi email diberlakukan dianggap terverifikasi
+UPDATE "users" SET "email_verified_at" = "created_at";
+
+CREATE TABLE "email_verification_tokens" (
+  "id" bigserial PRIMARY KEY,
+  "user_id" bigint NOT NULL,
+  "token_hash" varchar(64) NOT NULL UNIQUE,
+  "expires_at" timestamptz NOT NULL,
+  "used_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+
+  CONSTRAINT fk_email_verification_tokens_user
+    FOREIGN KEY("user_id")
+    REFERENCES "users"("id")
+    ON DELETE CASCADE
+);
+
+CREATE INDEX idx_email_verification_tokens_user_id ON "email_verification_tokens" ("user_id");
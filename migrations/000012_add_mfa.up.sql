@@ -0,0 +1,34 @@
+CREATE TABLE "user_mfa" (
+  "user_id" bigint PRIMARY KEY,
+  "secret_encrypted" text NOT NULL,
+  "enabled_at" timestamptz,
+  "last_used_step" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+
+  CONSTRAINT fk_user_mfa_user
+    FOREIGN KEY("user_id")
+    REFERENCES "users"("id")
+    ON DELETE CASCADE
+);
+
+CREATE TABLE "mfa_recovery_codes" (
+  "id" bigserial PRIMARY KEY,
+  "user_id" bigint NOT NULL,
+  "code_hash" varchar(64) NOT NULL,
+  "used_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+
+  CONSTRAINT fk_mfa_recovery_codes_user
+    FOREIGN KEY("user_id")
+    REFERENCES "users"("id")
+    ON DELETE CASCADE
+);
+
+CREATE INDEX idx_mfa_recovery_codes_user_id ON "mfa_recovery_codes" ("user_id");
+
+CREATE TABLE "mfa_role_policies" (
+  "role" varchar(20) PRIMARY KEY,
+  "required" boolean NOT NULL DEFAULT false,
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
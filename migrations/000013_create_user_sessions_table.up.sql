@@ -0,0 +1,18 @@
+CREATE TABLE "user_sessions" (
+  "id" varchar(36) PRIMARY KEY,
+  "user_id" bigint NOT NULL,
+  "device_name" varchar(100),
+  "user_agent" varchar(512),
+  "ip_address" varchar(45),
+  "last_seen_at" timestamptz NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "revoked_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+
+  CONSTRAINT fk_user_sessions_user
+    FOREIGN KEY("user_id")
+    REFERENCES "users"("id")
+    ON DELETE CASCADE
+);
+
+CREATE INDEX idx_user_sessions_user_id ON "user_sessions" ("user_id");
@@ -0,0 +1,17 @@
+CREATE TABLE "role_permissions" (
+  "role" varchar(20) NOT NULL,
+  "permission" varchar(50) NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+
+  PRIMARY KEY ("role", "permission")
+);
+
+INSERT INTO "role_permissions" ("role", "permission") VALUES
+  ('admin', 'users:manage'),
+  ('admin', 'roles:manage'),
+  ('admin', 'mfa:manage'),
+  ('psikolog', 'availability:write'),
+  ('psikolog', 'consultations:manage'),
+  ('psikolog', 'mfa:manage'),
+  ('klien', 'psychologists:read'),
+  ('klien', 'consultations:book');
@@ -0,0 +1,7 @@
+-- Kolom updated_at dipakai model User tetapi belum ada di tabel users
+ALTER TABLE "users"
+  ADD COLUMN IF NOT EXISTS "updated_at" timestamptz NOT NULL DEFAULT (now());
+
+-- Username adalah nama tampilan dan tidak unik; constraint ini hanya ada pada database yang
+-- dibuat lewat AutoMigrate sebelum model diselaraskan
+ALTER TABLE "users" DROP CONSTRAINT IF EXISTS "uni_users_username";
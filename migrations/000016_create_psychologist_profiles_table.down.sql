@@ -0,0 +1,3 @@
+DELETE FROM role_permissions WHERE permission IN ('psychologist-profile:write', 'psychologists:verify');
+
+DROP TABLE IF EXISTS psychologist_profiles;
@@ -0,0 +1,31 @@
+CREATE TABLE "psychologist_profiles" (
+  "user_id" bigint PRIMARY KEY,
+  "sipp_number" varchar(50) NOT NULL,
+  "sipp_expires_at" date NOT NULL,
+  "str_number" varchar(50),
+  "str_expires_at" date,
+  "specializations" jsonb NOT NULL,
+  "languages" jsonb NOT NULL,
+  "bio" text NOT NULL DEFAULT '',
+  "years_of_practice" bigint NOT NULL DEFAULT 0,
+  "session_fee" bigint NOT NULL,
+  "verification_status" varchar(20) NOT NULL DEFAULT 'pending',
+  "rejection_reason" text,
+  "reviewed_by" bigint,
+  "reviewed_at" timestamptz,
+  "submitted_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+
+  CONSTRAINT fk_psychologist_profiles_user
+    FOREIGN KEY("user_id")
+    REFERENCES "users"("id")
+    ON DELETE CASCADE
+);
+
+CREATE INDEX idx_psychologist_profiles_verification_status ON "psychologist_profiles" ("verification_status");
+
+INSERT INTO "role_permissions" ("role", "permission") VALUES
+  ('psikolog', 'psychologist-profile:write'),
+  ('admin', 'psychologists:verify')
+ON CONFLICT DO NOTHING;
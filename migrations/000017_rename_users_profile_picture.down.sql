@@ -0,0 +1 @@
+ALTER TABLE "users" RENAME COLUMN "profile_picture_key" TO "profile_picture";
@@ -0,0 +1,5 @@
+-- Foto profil kini disimpan di FileStorage dan kolom hanya berisi key opak, bukan URL
+ALTER TABLE "users" RENAME COLUMN "profile_picture" TO "profile_picture_key";
+
+-- URL lama tidak bisa dipetakan ke key sehingga dikosongkan
+UPDATE "users" SET "profile_picture_key" = NULL WHERE "profile_picture_key" LIKE '%://%';
@@ -0,0 +1,7 @@
+-- Akun yang dinonaktifkan admin tidak bisa login; akun yang dihapus disimpan dengan soft delete
+-- agar konsultasi yang mereferensikannya tetap utuh
+ALTER TABLE "users"
+  ADD COLUMN IF NOT EXISTS "deactivated_at" timestamptz,
+  ADD COLUMN IF NOT EXISTS "deleted_at" timestamptz;
+
+CREATE INDEX IF NOT EXISTS "idx_users_deleted_at" ON "users" ("deleted_at");
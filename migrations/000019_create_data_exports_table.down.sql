@@ -0,0 +1,3 @@
+DELETE FROM role_permissions WHERE permission = 'account:delete';
+
+DROP TABLE IF EXISTS data_exports;
@@ -0,0 +1,28 @@
+CREATE TABLE "data_exports" (
+  "id" varchar(36) PRIMARY KEY,
+  "user_id" bigint NOT NULL,
+  "status" varchar(20) NOT NULL,
+  "file_key" varchar(255),
+  "size_bytes" bigint NOT NULL DEFAULT 0,
+  "completed_at" timestamptz,
+  "expires_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+
+  CONSTRAINT fk_data_exports_user
+    FOREIGN KEY("user_id")
+    REFERENCES "users"("id")
+    ON DELETE CASCADE
+);
+
+CREATE INDEX idx_data_exports_user_id ON "data_exports" ("user_id");
+CREATE INDEX idx_data_exports_status ON "data_exports" ("status");
+CREATE INDEX idx_data_exports_expires_at ON "data_exports" ("expires_at");
+
+-- Satu ekspor aktif per user; status harus sama dengan DataExport.IsActive
+CREATE UNIQUE INDEX idx_data_exports_active_user ON "data_exports" ("user_id")
+  WHERE ("status" IN ('pending', 'processing'));
+
+INSERT INTO "role_permissions" ("role", "permission") VALUES
+  ('klien', 'account:delete')
+ON CONFLICT DO NOTHING;
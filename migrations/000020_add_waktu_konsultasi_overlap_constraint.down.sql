@@ -0,0 +1 @@
+ALTER TABLE "waktu_konsultasi" DROP CONSTRAINT IF EXISTS excl_waktu_konsultasi_psikolog_hari;
@@ -0,0 +1,10 @@
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- Dua slot ketersediaan milik psikolog yang sama tidak boleh beririsan pada hari yang sama. Range
+-- untuk tipe time tidak ada, sehingga jam dipasang pada tanggal tetap.
+ALTER TABLE "waktu_konsultasi" ADD CONSTRAINT excl_waktu_konsultasi_psikolog_hari
+  EXCLUDE USING gist (
+    "psikolog_id" WITH =,
+    "hari" WITH =,
+    tsrange(DATE '2000-01-01' + "waktu_mulai", DATE '2000-01-01' + "waktu_selesai", '[)') WITH &&
+  );
@@ -0,0 +1,50 @@
+package app_crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"io"
+)
+
+// AEAD encrypts and authenticates data using AES-GCM. Unlike Cipher, tampered ciphertext is
+// rejected on Open, so it is suitable for secrets stored at rest.
+type AEAD struct {
+	aead cipher.AEAD
+}
+
+// NewAESGCM creates a new AEAD with the given 16, 24 or 32 byte key.
+func NewAESGCM(key []byte) (*AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AEAD{aead: aead}, nil
+}
+
+// Seal encrypts plaintext with a random nonce. The nonce is prepended to the returned ciphertext.
+func (a *AEAD) Seal(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, a.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return a.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Open decrypts and authenticates ciphertext produced by Seal.
+func (a *AEAD) Open(ciphertext []byte) ([]byte, error) {
+	nonceSize := a.aead.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	return a.aead.Open(nil, ciphertext[:nonceSize], ciphertext[nonceSize:], nil)
+}
@@ -0,0 +1,44 @@
+package app_crypto_test
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/X3nonxe/gopsy-backend/pkg/app_crypto"
+)
+
+func TestAEADSealOpen(t *testing.T) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatal(err)
+	}
+
+	aead, err := app_crypto.NewAESGCM(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plaintext := []byte("JBSWY3DPEHPK3PXP")
+	ciphertext, err := aead.Seal(plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decrypted, err := aead.Open(ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(decrypted) != string(plaintext) {
+		t.Fatalf("expected %q, got %q", plaintext, decrypted)
+	}
+
+	// Tampered ciphertext must be rejected
+	ciphertext[len(ciphertext)-1] ^= 0xff
+	if _, err := aead.Open(ciphertext); err == nil {
+		t.Fatal("expected error for tampered ciphertext")
+	}
+
+	if _, err := aead.Open([]byte("short")); err == nil {
+		t.Fatal("expected error for short ciphertext")
+	}
+}
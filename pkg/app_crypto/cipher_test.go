@@ -6,7 +6,7 @@ import (
 	"crypto/rand"
 	"testing"
 
-	"github.com/DoWithLogic/golang-clean-architecture/pkg/app_crypto"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_crypto"
 )
 
 func TestCipherEncryptDecrypt(t *testing.T) {
@@ -3,7 +3,7 @@ package app_crypto_test
 import (
 	"testing"
 
-	"github.com/DoWithLogic/golang-clean-architecture/pkg/app_crypto"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_crypto"
 )
 
 func TestCrypto_EncodeSHA1HMACBase64(t *testing.T) {
@@ -0,0 +1,125 @@
+// Package app_image decodes untrusted uploaded images and re-encodes them as square JPEG
+// thumbnails. Re-encoding drops every metadata segment of the original file, including EXIF,
+// after the EXIF orientation has been applied to the pixels of the thumbnail.
+package app_image
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+
+	"golang.org/x/image/draw"
+)
+
+// ContentType of images produced by EncodeJPEG.
+const ContentType = "image/jpeg"
+
+// DefaultMaxPixels bounds the decoded size of an image so that a small, highly compressed file
+// cannot exhaust memory when decoded. 12 megapixels covers photos from common phone cameras while
+// keeping a decoded image below roughly 50 MB.
+const DefaultMaxPixels = 12_000_000
+
+var (
+	// ErrUnsupportedFormat is returned when the sniffed content type is not JPEG or PNG.
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+	// ErrInvalidImage is returned when the data claims to be an image but cannot be decoded.
+	ErrInvalidImage = errors.New("invalid image")
+	// ErrTooManyPixels is returned when the image dimensions exceed the allowed pixel count.
+	ErrTooManyPixels = errors.New("image dimensions are too large")
+)
+
+// Sniff detects the content type from the first bytes of data, ignoring any name or header
+// supplied by the client, and returns it if it is a supported image type.
+func Sniff(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+	switch contentType {
+	case "image/jpeg", "image/png":
+		return contentType, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// Decoded is an image as stored in the file together with the EXIF orientation that still has to
+// be applied to display it upright.
+type Decoded struct {
+	Image       image.Image
+	Orientation int
+}
+
+// Decode sniffs, bounds-checks and decodes data. For JPEG images the EXIF orientation is read but
+// not applied, so that only the much smaller thumbnail has to be rotated.
+func Decode(data []byte, maxPixels int) (*Decoded, error) {
+	contentType, err := Sniff(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		decodeConfig func(io.Reader) (image.Config, error)
+		decode       func(io.Reader) (image.Image, error)
+	)
+	switch contentType {
+	case "image/jpeg":
+		decodeConfig, decode = jpeg.DecodeConfig, jpeg.Decode
+	default:
+		decodeConfig, decode = png.DecodeConfig, png.Decode
+	}
+
+	cfg, err := decodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, ErrInvalidImage
+	}
+	if maxPixels > 0 && int64(cfg.Width)*int64(cfg.Height) > int64(maxPixels) {
+		return nil, ErrTooManyPixels
+	}
+
+	img, err := decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	decoded := &Decoded{Image: img, Orientation: 1}
+	if contentType == "image/jpeg" {
+		decoded.Orientation = Orientation(data)
+	}
+	return decoded, nil
+}
+
+// Thumbnail is like the package level Thumbnail but returns the thumbnail upright. The square is
+// cropped around the center, which every orientation keeps in place, so the crop can be scaled
+// before it is rotated.
+func (d *Decoded) Thumbnail(size int) *image.RGBA {
+	return applyOrientation(Thumbnail(d.Image, size), d.Orientation)
+}
+
+// Thumbnail center-crops img to a square and scales it to size x size pixels with a Catmull-Rom
+// filter. Transparent pixels are flattened onto white because JPEG has no alpha channel.
+func Thumbnail(img image.Image, size int) *image.RGBA {
+	bounds := img.Bounds()
+	side := bounds.Dx()
+	if bounds.Dy() < side {
+		side = bounds.Dy()
+	}
+	crop := image.Rect(0, 0, side, side).Add(image.Pt(
+		bounds.Min.X+(bounds.Dx()-side)/2,
+		bounds.Min.Y+(bounds.Dy()-side)/2,
+	))
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, crop, draw.Over, nil)
+	return dst
+}
+
+// EncodeJPEG writes img as a baseline JPEG without any metadata.
+func EncodeJPEG(w io.Writer, img image.Image) error {
+	return jpeg.Encode(w, img, &jpeg.Options{Quality: 85})
+}
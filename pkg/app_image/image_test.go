@@ -0,0 +1,167 @@
+package app_image_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/X3nonxe/gopsy-backend/pkg/app_image"
+)
+
+// newTestImage returns a w x h image whose left half is red and right half is blue.
+func newTestImage(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.RGBA{R: 255, A: 255}
+			if x >= w/2 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}); err != nil {
+		t.Fatalf("failed to encode JPEG: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// withOrientation inserts an APP1 EXIF segment carrying the given orientation after the SOI marker.
+func withOrientation(jpegData []byte, orientation uint16) []byte {
+	tiff := make([]byte, 8+2+12+4)
+	copy(tiff, "MM")
+	binary.BigEndian.PutUint16(tiff[2:], 42)
+	binary.BigEndian.PutUint32(tiff[4:], 8)
+	binary.BigEndian.PutUint16(tiff[8:], 1)
+	binary.BigEndian.PutUint16(tiff[10:], 0x0112)
+	binary.BigEndian.PutUint16(tiff[12:], 3)
+	binary.BigEndian.PutUint32(tiff[14:], 1)
+	binary.BigEndian.PutUint16(tiff[18:], orientation)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	out := append([]byte{}, jpegData[:2]...)
+	out = append(out, segment...)
+	return append(out, jpegData[2:]...)
+}
+
+func TestSniff(t *testing.T) {
+	jpegData := encodeJPEG(t, newTestImage(4, 4))
+	if got, err := app_image.Sniff(jpegData); err != nil || got != "image/jpeg" {
+		t.Errorf("Sniff(jpeg) = %q, %v", got, err)
+	}
+
+	var pngData bytes.Buffer
+	if err := png.Encode(&pngData, newTestImage(4, 4)); err != nil {
+		t.Fatalf("failed to encode PNG: %v", err)
+	}
+	if got, err := app_image.Sniff(pngData.Bytes()); err != nil || got != "image/png" {
+		t.Errorf("Sniff(png) = %q, %v", got, err)
+	}
+
+	for _, data := range [][]byte{
+		[]byte("<html><script>alert(1)</script></html>"),
+		[]byte("GIF89a\x01\x00\x01\x00"),
+		[]byte("%PDF-1.7"),
+	} {
+		if _, err := app_image.Sniff(data); !errors.Is(err, app_image.ErrUnsupportedFormat) {
+			t.Errorf("Sniff(%q) error = %v, want ErrUnsupportedFormat", data, err)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("Truncated Image", func(t *testing.T) {
+		data := encodeJPEG(t, newTestImage(64, 64))
+		if _, err := app_image.Decode(data[:len(data)/2], app_image.DefaultMaxPixels); !errors.Is(err, app_image.ErrInvalidImage) {
+			t.Errorf("Decode(truncated) error = %v, want ErrInvalidImage", err)
+		}
+	})
+
+	t.Run("Too Many Pixels", func(t *testing.T) {
+		data := encodeJPEG(t, newTestImage(100, 100))
+		if _, err := app_image.Decode(data, 5000); !errors.Is(err, app_image.ErrTooManyPixels) {
+			t.Errorf("Decode error = %v, want ErrTooManyPixels", err)
+		}
+	})
+
+	t.Run("Applies EXIF Orientation", func(t *testing.T) {
+		// Rotating 90 degrees clockwise moves the red left half to the top
+		data := withOrientation(encodeJPEG(t, newTestImage(40, 20)), 6)
+		if got := app_image.Orientation(data); got != 6 {
+			t.Fatalf("Orientation = %d, want 6", got)
+		}
+
+		decoded, err := app_image.Decode(data, app_image.DefaultMaxPixels)
+		if err != nil {
+			t.Fatalf("Decode returned error %v", err)
+		}
+		if decoded.Orientation != 6 {
+			t.Fatalf("Decoded orientation = %d, want 6", decoded.Orientation)
+		}
+		// The decoded pixels stay as stored, only the thumbnail is rotated
+		if decoded.Image.Bounds().Dx() != 40 || decoded.Image.Bounds().Dy() != 20 {
+			t.Fatalf("Decode bounds = %v, want 40x20", decoded.Image.Bounds())
+		}
+
+		thumb := decoded.Thumbnail(20)
+		if c := thumb.RGBAAt(10, 2); c.R < c.B {
+			t.Errorf("top of rotated thumbnail = %v, want red", c)
+		}
+		if c := thumb.RGBAAt(10, 17); c.B < c.R {
+			t.Errorf("bottom of rotated thumbnail = %v, want blue", c)
+		}
+	})
+}
+
+func TestThumbnail(t *testing.T) {
+	thumb := app_image.Thumbnail(newTestImage(300, 200), 64)
+	if thumb.Bounds().Dx() != 64 || thumb.Bounds().Dy() != 64 {
+		t.Fatalf("Thumbnail bounds = %v, want 64x64", thumb.Bounds())
+	}
+	if c := thumb.RGBAAt(5, 32); c.R < 200 || c.B > 50 {
+		t.Errorf("left of thumbnail = %v, want red", c)
+	}
+	if c := thumb.RGBAAt(58, 32); c.B < 200 || c.R > 50 {
+		t.Errorf("right of thumbnail = %v, want blue", c)
+	}
+
+	// Transparent pixels become white
+	transparent := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	if c := app_image.Thumbnail(transparent, 4).RGBAAt(1, 1); c != (color.RGBA{R: 255, G: 255, B: 255, A: 255}) {
+		t.Errorf("transparent pixel = %v, want white", c)
+	}
+}
+
+func TestEncodeJPEGStripsMetadata(t *testing.T) {
+	data := withOrientation(encodeJPEG(t, newTestImage(32, 32)), 3)
+	decoded, err := app_image.Decode(data, app_image.DefaultMaxPixels)
+	if err != nil {
+		t.Fatalf("Decode returned error %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := app_image.EncodeJPEG(&out, decoded.Thumbnail(16)); err != nil {
+		t.Fatalf("EncodeJPEG returned error %v", err)
+	}
+	if bytes.Contains(out.Bytes(), []byte("Exif")) {
+		t.Error("encoded JPEG still contains EXIF data")
+	}
+	if got := app_image.Orientation(out.Bytes()); got != 1 {
+		t.Errorf("Orientation of encoded JPEG = %d, want 1", got)
+	}
+}
@@ -4,91 +4,114 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"strconv"
 	"time"
 
-	"github.com/DoWithLogic/golang-clean-architecture/config"
-	"github.com/DoWithLogic/golang-clean-architecture/pkg/app_redis"
-	"github.com/DoWithLogic/golang-clean-architecture/pkg/apperror"
-	"github.com/DoWithLogic/golang-clean-architecture/pkg/constant"
-	"github.com/DoWithLogic/golang-clean-architecture/pkg/response"
-	"github.com/golang-jwt/jwt"
-	"github.com/labstack/echo/v4"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_redis"
+	"github.com/X3nonxe/gopsy-backend/pkg/constant"
 )
 
-type (
-	PayloadToken struct {
-		Data *Data `json:"data"`
-		jwt.StandardClaims
-	}
-
-	Data struct {
-		UserID int64  `json:"user_id"`
-		Email  string `json:"email"`
-	}
-)
-
-var (
-	errMissingJwtToken = errors.New("Missing JWT token")
-	errInvalidJwtToken = errors.New("Invalid JWT token")
+const (
+	revokedTokenKeyPrefix = "jwt:revoked:jti:"
+	revokedUserKeyPrefix  = "jwt:revoked:user:"
+	revokedSessionPrefix  = "jwt:revoked:sid:"
 )
 
+// JWT keeps a denylist of revoked access tokens on top of app_redis.Redis. Entries only live as
+// long as the tokens they revoke, so the denylist never grows beyond the access token lifetime.
 type JWT struct {
-	cfg   config.JWTConfig
 	redis app_redis.Redis
 }
 
-func NewJWT(cfg config.JWTConfig, redis app_redis.Redis) *JWT {
-	return &JWT{cfg: cfg}
+func NewJWT(redis app_redis.Redis) *JWT {
+	return &JWT{redis: redis}
 }
 
-func (j *JWT) GenerateToken(ctx context.Context, request PayloadToken) (token string, err error) {
-	return jwt.NewWithClaims(jwt.SigningMethodHS256, request).SignedString([]byte(j.cfg.Key))
+// RevokeToken denylists a single access token by its jti until the token would have expired.
+func (j *JWT) RevokeToken(ctx context.Context, jti string, expiration time.Duration) error {
+	if expiration <= 0 {
+		// The token has already expired and is rejected without the denylist
+		return nil
+	}
+	return j.redis.Set(ctx, revokedTokenKeyPrefix+jti, constant.TokenRevoked, expiration)
 }
 
-func (j *JWT) ValidateToken(c echo.Context, token string) error {
-	tokenWithoutBearer := token[len("Bearer "):]
-	if j.IsTokenRevoked(c.Request().Context(), tokenWithoutBearer) {
-		return response.ErrorBuilder(apperror.Unauthorized(errInvalidJwtToken)).Send(c)
+// IsTokenRevoked reports whether the access token with the given jti has been revoked.
+func (j *JWT) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	revoked, err := j.redis.Get(ctx, revokedTokenKeyPrefix+jti)
+	if err != nil {
+		if errors.Is(err, app_redis.ErrKeyNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check revoked token: %w", err)
 	}
 
-	newToken, err := jwt.ParseWithClaims(tokenWithoutBearer, &PayloadToken{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(j.cfg.Key), nil
-	})
+	return revoked == constant.TokenRevoked, nil
+}
+
+// RevokeUserTokens revokes every access token of a user issued at or before issuedBefore.
+// expiration should be the access token lifetime so all affected tokens are covered. The cut-off
+// is kept with millisecond precision so that a login right after the revocation, within the same
+// second, is not revoked as well.
+func (j *JWT) RevokeUserTokens(ctx context.Context, userID uint, issuedBefore time.Time, expiration time.Duration) error {
+	return j.redis.Set(ctx, revokedUserKey(userID), FormatNumericDate(issuedBefore), expiration)
+}
 
+// IsUserTokenRevoked reports whether a token of the user issued at issuedAt was revoked by RevokeUserTokens.
+func (j *JWT) IsUserTokenRevoked(ctx context.Context, userID uint, issuedAt time.Time) (bool, error) {
+	value, err := j.redis.Get(ctx, revokedUserKey(userID))
 	if err != nil {
-		return response.ErrorBuilder(apperror.Unauthorized(errInvalidJwtToken)).Send(c)
+		if errors.Is(err, app_redis.ErrKeyNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check revoked user tokens: %w", err)
 	}
-	if !newToken.Valid {
-		return response.ErrorBuilder(apperror.Unauthorized(errInvalidJwtToken)).Send(c)
+
+	// Entries written before millisecond precision hold whole seconds and parse the same way
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return false, fmt.Errorf("invalid revoked user tokens entry: %w", err)
 	}
 
-	// Store the token claims in the request context for later use
-	c.Set(constant.AuthCredentialKey, newToken.Claims.(*PayloadToken))
+	return !issuedAt.After(ParseNumericDate(seconds)), nil
+}
 
-	return nil
+// FormatNumericDate formats t as a JWT NumericDate in seconds with millisecond precision.
+func FormatNumericDate(t time.Time) string {
+	return strconv.FormatFloat(NumericDate(t), 'f', 3, 64)
 }
 
-func (j *JWT) RevokeToken(ctx context.Context, token string, expiration time.Duration) error {
-	return j.redis.Set(ctx, token, constant.TokenRevoked, expiration)
+// NumericDate returns t as a JWT NumericDate, i.e. seconds since the epoch, keeping milliseconds
+// as the fraction.
+func NumericDate(t time.Time) float64 {
+	return float64(t.UnixMilli()) / 1000
 }
 
-func (j *JWT) IsTokenRevoked(ctx context.Context, token string) bool {
-	revoked, err := j.redis.Get(ctx, token)
-	if err != nil {
-		return false
-	}
+// ParseNumericDate converts a JWT NumericDate back to a time with millisecond precision.
+func ParseNumericDate(seconds float64) time.Time {
+	return time.UnixMilli(int64(math.Round(seconds * 1000)))
+}
 
-	return revoked == constant.TokenRevoked
+// RevokeSession revokes every access token carrying the given sid claim. expiration should be the
+// access token lifetime so all tokens of the session are covered.
+func (j *JWT) RevokeSession(ctx context.Context, sessionID string, expiration time.Duration) error {
+	return j.redis.Set(ctx, revokedSessionPrefix+sessionID, constant.TokenRevoked, expiration)
 }
 
-func NewTokenInformation(ctx echo.Context) (*PayloadToken, error) {
-	tokenInformation, ok := ctx.Get(constant.AuthCredentialKey).(*PayloadToken)
-	if !ok {
-		return tokenInformation, apperror.Unauthorized(apperror.ErrFailedGetTokenInformation)
+// IsSessionRevoked reports whether the session with the given sid has been revoked.
+func (j *JWT) IsSessionRevoked(ctx context.Context, sessionID string) (bool, error) {
+	revoked, err := j.redis.Get(ctx, revokedSessionPrefix+sessionID)
+	if err != nil {
+		if errors.Is(err, app_redis.ErrKeyNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check revoked session: %w", err)
 	}
 
-	return tokenInformation, nil
+	return revoked == constant.TokenRevoked, nil
+}
+
+func revokedUserKey(userID uint) string {
+	return revokedUserKeyPrefix + strconv.FormatUint(uint64(userID), 10)
 }
@@ -0,0 +1,102 @@
+package app_jwt_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/pkg/app_jwt"
+	"github.com/X3nonxe/gopsy-backend/pkg/app_redis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevokeToken(t *testing.T) {
+	ctx := context.Background()
+	denylist := app_jwt.NewJWT(app_redis.NewMemoryRedis())
+
+	revoked, err := denylist.IsTokenRevoked(ctx, "jti-1")
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	assert.NoError(t, denylist.RevokeToken(ctx, "jti-1", time.Minute))
+
+	revoked, err = denylist.IsTokenRevoked(ctx, "jti-1")
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+
+	revoked, err = denylist.IsTokenRevoked(ctx, "jti-2")
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	// Expired tokens are not stored
+	assert.NoError(t, denylist.RevokeToken(ctx, "jti-3", 0))
+	revoked, err = denylist.IsTokenRevoked(ctx, "jti-3")
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+}
+
+func TestRevokeUserTokens(t *testing.T) {
+	ctx := context.Background()
+	denylist := app_jwt.NewJWT(app_redis.NewMemoryRedis())
+	revokedAt := time.Now()
+
+	assert.NoError(t, denylist.RevokeUserTokens(ctx, 1, revokedAt, time.Minute))
+
+	revoked, err := denylist.IsUserTokenRevoked(ctx, 1, revokedAt.Add(-time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+
+	revoked, err = denylist.IsUserTokenRevoked(ctx, 1, revokedAt.Add(time.Second))
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	revoked, err = denylist.IsUserTokenRevoked(ctx, 2, revokedAt.Add(-time.Minute))
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+}
+
+func TestRevokeUserTokensSameSecond(t *testing.T) {
+	ctx := context.Background()
+	redis := app_redis.NewMemoryRedis()
+	denylist := app_jwt.NewJWT(redis)
+	second := time.Now().Truncate(time.Second)
+
+	// Logout from every device at .300 followed by a new login at .700 within the same second
+	assert.NoError(t, denylist.RevokeUserTokens(ctx, 1, second.Add(300*time.Millisecond), time.Minute))
+
+	revoked, err := denylist.IsUserTokenRevoked(ctx, 1, second.Add(100*time.Millisecond))
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+
+	revoked, err = denylist.IsUserTokenRevoked(ctx, 1, second.Add(700*time.Millisecond))
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	// The iat claim survives the round trip through a NumericDate
+	iat := app_jwt.ParseNumericDate(app_jwt.NumericDate(second.Add(700 * time.Millisecond)))
+	revoked, err = denylist.IsUserTokenRevoked(ctx, 1, iat)
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	// Entries in whole seconds are still understood
+	assert.NoError(t, redis.Set(ctx, "jwt:revoked:user:2", strconv.FormatInt(second.Unix(), 10), time.Minute))
+	revoked, err = denylist.IsUserTokenRevoked(ctx, 2, second)
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func TestRevokeSession(t *testing.T) {
+	ctx := context.Background()
+	denylist := app_jwt.NewJWT(app_redis.NewMemoryRedis())
+
+	assert.NoError(t, denylist.RevokeSession(ctx, "sid-1", time.Minute))
+
+	revoked, err := denylist.IsSessionRevoked(ctx, "sid-1")
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+
+	revoked, err = denylist.IsSessionRevoked(ctx, "sid-2")
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+}
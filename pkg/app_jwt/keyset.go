@@ -0,0 +1,241 @@
+package app_jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrUnknownKeyID       = errors.New("unknown key id")
+	ErrUnexpectedAlg      = errors.New("unexpected signing method")
+	ErrUnsupportedKeyType = errors.New("unsupported key type")
+)
+
+// Key is a single signing or verification key identified by its kid.
+type Key struct {
+	ID     string
+	Method jwt.SigningMethod
+	// signKey is the private key (or HMAC secret); nil for verification-only keys.
+	signKey interface{}
+	// verifyKey is the public key (or HMAC secret).
+	verifyKey interface{}
+}
+
+// NewHMACKey returns an HS256 key. HMAC keys carry no kid and are never published in the JWKS.
+func NewHMACKey(secret []byte) *Key {
+	return &Key{Method: jwt.SigningMethodHS256, signKey: secret, verifyKey: secret}
+}
+
+// NewPrivateKey wraps an RSA or Ed25519 private key. The kid is the RFC 7638 thumbprint of the public key.
+func NewPrivateKey(key crypto.Signer) (*Key, error) {
+	k, err := NewPublicKey(key.Public())
+	if err != nil {
+		return nil, err
+	}
+	k.signKey = key
+	return k, nil
+}
+
+// NewPublicKey wraps an RSA or Ed25519 public key for verification only.
+func NewPublicKey(key crypto.PublicKey) (*Key, error) {
+	var method jwt.SigningMethod
+	switch pub := key.(type) {
+	case *rsa.PublicKey:
+		method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedKeyType, pub)
+	}
+
+	k := &Key{Method: method, verifyKey: key}
+	thumbprint, err := json.Marshal(k.jwk())
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(thumbprint)
+	k.ID = base64.RawURLEncoding.EncodeToString(sum[:])
+	return k, nil
+}
+
+// ParseKeyPEM parses a PEM encoded private key (PKCS#1 or PKCS#8) or public key (PKIX).
+// Private keys can sign; public keys are verification only.
+func ParseKeyPEM(data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to decode PEM block")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse RSA private key: %w", err)
+		}
+		return NewPrivateKey(key)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse private key: %w", err)
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T", ErrUnsupportedKeyType, key)
+		}
+		return NewPrivateKey(signer)
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key: %w", err)
+		}
+		return NewPublicKey(key)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+}
+
+// JWK is a public key in JSON Web Key format. Field order follows RFC 7638 so the
+// thumbprint can be computed from the marshalled required members.
+type JWK struct {
+	Crv string `json:"crv,omitempty"`
+	E   string `json:"e,omitempty"`
+	Kty string `json:"kty"`
+	N   string `json:"n,omitempty"`
+	X   string `json:"x,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+}
+
+// JWKS is the document served at /.well-known/jwks.json.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// jwk returns the required members of the public key, as used for the thumbprint.
+func (k *Key) jwk() JWK {
+	switch pub := k.verifyKey.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}
+	case ed25519.PublicKey:
+		return JWK{Kty: "OKP", Crv: "Ed25519", X: base64.RawURLEncoding.EncodeToString(pub)}
+	default:
+		return JWK{}
+	}
+}
+
+// KeySet signs tokens with the current key and verifies tokens signed by any key in the set.
+// During rotation the previous key stays in the set until tokens signed with it have expired.
+type KeySet struct {
+	signing *Key
+	keys    map[string]*Key
+}
+
+// NewKeySet builds a key set that signs with signing and additionally accepts the verification keys.
+func NewKeySet(signing *Key, verification ...*Key) (*KeySet, error) {
+	if signing == nil || signing.signKey == nil {
+		return nil, errors.New("signing key must include a private key")
+	}
+
+	ks := &KeySet{signing: signing, keys: map[string]*Key{signing.ID: signing}}
+	for _, key := range verification {
+		if key.Method == jwt.SigningMethodHS256 {
+			return nil, errors.New("HMAC keys cannot be used as verification keys")
+		}
+		ks.keys[key.ID] = key
+	}
+	return ks, nil
+}
+
+// SigningKeyID returns the kid of the current signing key.
+func (ks *KeySet) SigningKeyID() string {
+	return ks.signing.ID
+}
+
+// Sign signs the claims with the current key and sets the kid header.
+func (ks *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ks.signing.Method, claims)
+	if ks.signing.ID != "" {
+		token.Header["kid"] = ks.signing.ID
+	}
+	return token.SignedString(ks.signing.signKey)
+}
+
+// Keyfunc resolves the verification key by kid for jwt.Parse. The token's alg must match the
+// key's algorithm so a public key can never be used as an HMAC secret.
+func (ks *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := ks.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKeyID, kid)
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("%w: %v", ErrUnexpectedAlg, token.Header["alg"])
+	}
+	return key.verifyKey, nil
+}
+
+// ValidMethods returns the algorithms accepted by the key set, for jwt.WithValidMethods.
+func (ks *KeySet) ValidMethods() []string {
+	seen := make(map[string]bool)
+	methods := make([]string, 0, len(ks.keys))
+	for _, key := range ks.keys {
+		if alg := key.Method.Alg(); !seen[alg] {
+			seen[alg] = true
+			methods = append(methods, alg)
+		}
+	}
+	return methods
+}
+
+// Parse verifies a token string against the key set and returns its claims.
+func (ks *KeySet) Parse(tokenString string) (*jwt.Token, jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, ks.Keyfunc, jwt.WithValidMethods(ks.ValidMethods()))
+	if err != nil {
+		return nil, nil, err
+	}
+	return token, claims, nil
+}
+
+// JWKS returns the public keys of the set. HMAC keys are never published.
+func (ks *KeySet) JWKS() JWKS {
+	jwks := JWKS{Keys: []JWK{}}
+	// The current signing key is always listed first
+	previous := make([]*Key, 0, len(ks.keys))
+	for _, key := range ks.keys {
+		if key != ks.signing {
+			previous = append(previous, key)
+		}
+	}
+	sort.Slice(previous, func(i, j int) bool { return previous[i].ID < previous[j].ID })
+	ordered := append([]*Key{ks.signing}, previous...)
+
+	for _, key := range ordered {
+		if key.Method == jwt.SigningMethodHS256 {
+			continue
+		}
+		jwk := key.jwk()
+		jwk.Alg = key.Method.Alg()
+		jwk.Kid = key.ID
+		jwk.Use = "sig"
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return jwks
+}
@@ -0,0 +1,173 @@
+package app_jwt_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/pkg/app_jwt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRSAKey(t *testing.T) *app_jwt.Key {
+	t.Helper()
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	key, err := app_jwt.NewPrivateKey(private)
+	require.NoError(t, err)
+	return key
+}
+
+func newEd25519Key(t *testing.T) *app_jwt.Key {
+	t.Helper()
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	key, err := app_jwt.NewPrivateKey(private)
+	require.NoError(t, err)
+	return key
+}
+
+func testClaims() jwt.MapClaims {
+	return jwt.MapClaims{"user_id": 1, "exp": time.Now().Add(time.Minute).Unix()}
+}
+
+func TestKeySet_SignAndParse(t *testing.T) {
+	for name, newKey := range map[string]func(*testing.T) *app_jwt.Key{
+		"RS256": newRSAKey,
+		"EdDSA": newEd25519Key,
+	} {
+		t.Run(name, func(t *testing.T) {
+			key := newKey(t)
+			keys, err := app_jwt.NewKeySet(key)
+			require.NoError(t, err)
+
+			signed, err := keys.Sign(testClaims())
+			require.NoError(t, err)
+
+			token, claims, err := keys.Parse(signed)
+			require.NoError(t, err)
+			assert.Equal(t, name, token.Method.Alg())
+			assert.Equal(t, key.ID, token.Header["kid"])
+			assert.Equal(t, float64(1), claims["user_id"])
+		})
+	}
+}
+
+func TestKeySet_Rotation(t *testing.T) {
+	previousPrivate, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	previous, err := app_jwt.NewPrivateKey(previousPrivate)
+	require.NoError(t, err)
+	current := newEd25519Key(t)
+
+	oldKeys, err := app_jwt.NewKeySet(previous)
+	require.NoError(t, err)
+	oldToken, err := oldKeys.Sign(testClaims())
+	require.NoError(t, err)
+
+	// After rotation the previous key is kept as a public key for verification only
+	previousPublic, err := app_jwt.NewPublicKey(&previousPrivate.PublicKey)
+	require.NoError(t, err)
+	assert.Equal(t, previous.ID, previousPublic.ID)
+
+	rotated, err := app_jwt.NewKeySet(current, previousPublic)
+	require.NoError(t, err)
+	assert.Equal(t, current.ID, rotated.SigningKeyID())
+
+	_, _, err = rotated.Parse(oldToken)
+	assert.NoError(t, err)
+
+	newToken, err := rotated.Sign(testClaims())
+	require.NoError(t, err)
+	_, _, err = rotated.Parse(newToken)
+	assert.NoError(t, err)
+
+	// Once the previous key is removed its tokens are rejected
+	withoutPrevious, err := app_jwt.NewKeySet(current)
+	require.NoError(t, err)
+	_, _, err = withoutPrevious.Parse(oldToken)
+	assert.Error(t, err)
+
+	jwks := rotated.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, current.ID, jwks.Keys[0].Kid)
+	assert.Equal(t, "EdDSA", jwks.Keys[0].Alg)
+	assert.Equal(t, "OKP", jwks.Keys[0].Kty)
+	assert.Equal(t, "sig", jwks.Keys[0].Use)
+	assert.Equal(t, previous.ID, jwks.Keys[1].Kid)
+	assert.Equal(t, "RS256", jwks.Keys[1].Alg)
+	assert.Equal(t, "AQAB", jwks.Keys[1].E)
+}
+
+func TestKeySet_UnknownKeyID(t *testing.T) {
+	keys, err := app_jwt.NewKeySet(newRSAKey(t))
+	require.NoError(t, err)
+	otherKeys, err := app_jwt.NewKeySet(newRSAKey(t))
+	require.NoError(t, err)
+
+	signed, err := otherKeys.Sign(testClaims())
+	require.NoError(t, err)
+
+	_, _, err = keys.Parse(signed)
+	assert.True(t, errors.Is(err, app_jwt.ErrUnknownKeyID))
+}
+
+func TestKeySet_RejectsAlgorithmConfusion(t *testing.T) {
+	key := newRSAKey(t)
+	keys, err := app_jwt.NewKeySet(key)
+	require.NoError(t, err)
+
+	// An HS256 token reusing the kid must not be verified with the public key as the secret
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims())
+	forged.Header["kid"] = key.ID
+	signed, err := forged.SignedString([]byte("attacker-secret"))
+	require.NoError(t, err)
+
+	_, _, err = keys.Parse(signed)
+	assert.Error(t, err)
+}
+
+func TestKeySet_HMAC(t *testing.T) {
+	keys, err := app_jwt.NewKeySet(app_jwt.NewHMACKey([]byte("secret")))
+	require.NoError(t, err)
+
+	signed, err := keys.Sign(testClaims())
+	require.NoError(t, err)
+
+	token, _, err := keys.Parse(signed)
+	require.NoError(t, err)
+	assert.Equal(t, "HS256", token.Method.Alg())
+	assert.Empty(t, keys.JWKS().Keys)
+}
+
+func TestParseKeyPEM(t *testing.T) {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	pkcs1 := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(private)})
+	fromPKCS1, err := app_jwt.ParseKeyPEM(pkcs1)
+	require.NoError(t, err)
+
+	publicDER, err := x509.MarshalPKIXPublicKey(&private.PublicKey)
+	require.NoError(t, err)
+	public := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+	fromPublic, err := app_jwt.ParseKeyPEM(public)
+	require.NoError(t, err)
+
+	// The kid is derived from the public key, so it matches for both halves
+	assert.Equal(t, fromPKCS1.ID, fromPublic.ID)
+
+	// A public key alone cannot sign
+	_, err = app_jwt.NewKeySet(fromPublic)
+	assert.Error(t, err)
+
+	_, err = app_jwt.ParseKeyPEM([]byte("not a key"))
+	assert.Error(t, err)
+}
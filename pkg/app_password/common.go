@@ -0,0 +1,37 @@
+package app_password
+
+import (
+	_ "embed"
+	"strings"
+	"sync"
+)
+
+// commonPasswordList is a newline separated list of passwords that appear in public breach
+// corpora and top-password lists. Lines starting with '#' are comments.
+//
+//go:embed common_passwords.txt
+var commonPasswordList string
+
+var (
+	commonPasswords     map[string]struct{}
+	commonPasswordsOnce sync.Once
+)
+
+// IsCommon reports whether password is on the bundled list of common passwords. The comparison
+// ignores case, so "Password1" matches "password1".
+func IsCommon(password string) bool {
+	commonPasswordsOnce.Do(loadCommonPasswords)
+	_, found := commonPasswords[strings.ToLower(password)]
+	return found
+}
+
+func loadCommonPasswords() {
+	commonPasswords = make(map[string]struct{})
+	for _, line := range strings.Split(commonPasswordList, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		commonPasswords[strings.ToLower(line)] = struct{}{}
+	}
+}
@@ -0,0 +1,116 @@
+// Package app_password checks new passwords against a configurable policy: length limits,
+// required character classes and a bundled list of common and breached passwords.
+package app_password
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// BcryptMaxLength is the number of bytes bcrypt hashes; anything longer is rejected by bcrypt.
+const BcryptMaxLength = 72
+
+// Policy describes the rules a password must satisfy.
+type Policy struct {
+	MinLength        int
+	MaxLength        int
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+	RejectCommon     bool
+}
+
+// DefaultPolicy returns the policy used when nothing is configured.
+func DefaultPolicy() Policy {
+	return Policy{
+		MinLength:        8,
+		MaxLength:        BcryptMaxLength,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RejectCommon:     true,
+	}
+}
+
+// PolicyError lists every rule a password failed so the user can fix them in one attempt.
+type PolicyError struct {
+	Violations []string
+}
+
+func (e *PolicyError) Error() string {
+	return "password must " + strings.Join(e.Violations, ", ")
+}
+
+// Validate checks password against the policy. userInputs are values tied to the account, such
+// as the username and email, that must not be used as the password. It returns a *PolicyError
+// when one or more rules are violated.
+func (p Policy) Validate(password string, userInputs ...string) error {
+	var violations []string
+
+	if length := utf8.RuneCountInString(password); length < p.MinLength {
+		violations = append(violations, fmt.Sprintf("be at least %d characters long", p.MinLength))
+	}
+	if p.MaxLength > 0 && len(password) > p.MaxLength {
+		violations = append(violations, fmt.Sprintf("be at most %d bytes long", p.MaxLength))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	if p.RequireUppercase && !hasUpper {
+		violations = append(violations, "contain an uppercase letter")
+	}
+	if p.RequireLowercase && !hasLower {
+		violations = append(violations, "contain a lowercase letter")
+	}
+	if p.RequireDigit && !hasDigit {
+		violations = append(violations, "contain a digit")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		violations = append(violations, "contain a symbol")
+	}
+
+	if p.RejectCommon && IsCommon(password) {
+		violations = append(violations, "not be a commonly used or breached password")
+	}
+	if matchesUserInput(password, userInputs) {
+		violations = append(violations, "not be based on your username or email")
+	}
+
+	if len(violations) > 0 {
+		return &PolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// matchesUserInput reports whether password is one of the inputs, or the local part of an email
+// input, ignoring case.
+func matchesUserInput(password string, userInputs []string) bool {
+	normalized := strings.ToLower(password)
+	for _, input := range userInputs {
+		input = strings.ToLower(strings.TrimSpace(input))
+		if input == "" {
+			continue
+		}
+		if normalized == input {
+			return true
+		}
+		if local, _, found := strings.Cut(input, "@"); found && local != "" && normalized == local {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package app_password_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/X3nonxe/gopsy-backend/pkg/app_password"
+)
+
+func TestPolicyValidate(t *testing.T) {
+	policy := app_password.DefaultPolicy()
+
+	tests := []struct {
+		name       string
+		password   string
+		userInputs []string
+		violations int
+	}{
+		{name: "Strong Password", password: "Tiga-Kucing-Lompat9", violations: 0},
+		{name: "Too Short", password: "Ab1xyz", violations: 1},
+		{name: "Missing Classes", password: "alllowercaseletters", violations: 2},
+		{name: "Common Password", password: "Password123", violations: 1},
+		{name: "Matches Email Local Part", password: "Budi1987x", userInputs: []string{"budi1987x@example.com"}, violations: 1},
+		{name: "Longer Than Bcrypt Limit", password: "Aa1" + string(make([]byte, 80)), violations: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Validate(tt.password, tt.userInputs...)
+			if tt.violations == 0 {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+
+			var policyErr *app_password.PolicyError
+			if !errors.As(err, &policyErr) {
+				t.Fatalf("expected PolicyError, got %v", err)
+			}
+			if len(policyErr.Violations) != tt.violations {
+				t.Fatalf("expected %d violations, got %v", tt.violations, policyErr.Violations)
+			}
+		})
+	}
+}
+
+func TestPolicyValidateRequireSymbol(t *testing.T) {
+	policy := app_password.DefaultPolicy()
+	policy.RequireSymbol = true
+
+	if err := policy.Validate("Tigakucinglompat9"); err == nil {
+		t.Fatal("expected missing symbol to be rejected")
+	}
+	if err := policy.Validate("Tiga kucing lompat9"); err != nil {
+		t.Fatalf("expected space to count as a symbol, got %v", err)
+	}
+}
+
+func TestIsCommon(t *testing.T) {
+	for _, password := range []string{"password", "QWERTY123", "Bismillah123", "indonesia45"} {
+		if !app_password.IsCommon(password) {
+			t.Errorf("expected %q to be common", password)
+		}
+	}
+	if app_password.IsCommon("Tiga-Kucing-Lompat9") {
+		t.Error("expected a random passphrase not to be common")
+	}
+}
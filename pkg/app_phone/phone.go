@@ -0,0 +1,61 @@
+// Package app_phone validates Indonesian phone numbers and normalizes them to E.164.
+package app_phone
+
+import (
+	"errors"
+	"strings"
+)
+
+// CountryCode is the Indonesian calling code without the leading plus sign.
+const CountryCode = "62"
+
+// ErrInvalidNumber is returned when the input is not a valid Indonesian phone number.
+var ErrInvalidNumber = errors.New("invalid Indonesian phone number")
+
+// Normalize accepts the common ways Indonesian numbers are written, such as "0812-3456-7890",
+// "+62 812 3456 7890", "6281234567890" or "(021) 5550123", and returns the E.164 form,
+// for example "+6281234567890".
+func Normalize(raw string) (string, error) {
+	number := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(raw))
+
+	switch {
+	case strings.HasPrefix(number, "+"+CountryCode):
+		number = number[len(CountryCode)+1:]
+	case strings.HasPrefix(number, CountryCode):
+		number = number[len(CountryCode):]
+	case strings.HasPrefix(number, "0"):
+		number = number[1:]
+	default:
+		return "", ErrInvalidNumber
+	}
+
+	if !isValidNationalNumber(number) {
+		return "", ErrInvalidNumber
+	}
+	return "+" + CountryCode + number, nil
+}
+
+// isValidNationalNumber checks the national significant number, which excludes the trunk prefix
+// "0". Mobile numbers start with 8 and have 9 to 12 digits; landlines start with a 1 to 3 digit
+// area code and have 8 to 11 digits in total.
+func isValidNationalNumber(number string) bool {
+	for _, r := range number {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	if number == "" || number[0] == '0' {
+		return false
+	}
+
+	if number[0] == '8' {
+		return len(number) >= 9 && len(number) <= 12
+	}
+	return len(number) >= 8 && len(number) <= 11
+}
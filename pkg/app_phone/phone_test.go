@@ -0,0 +1,46 @@
+package app_phone_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/X3nonxe/gopsy-backend/pkg/app_phone"
+)
+
+func TestNormalize(t *testing.T) {
+	valid := map[string]string{
+		"081234567890":      "+6281234567890",
+		"0812-3456-7890":    "+6281234567890",
+		"+62 812 3456 7890": "+6281234567890",
+		"62812345678":       "+62812345678",
+		"(021) 5550123":     "+62215550123",
+		"+62 22 8765 4321":  "+622287654321",
+		" 0857.1234.5678 ":  "+6285712345678",
+	}
+	for raw, want := range valid {
+		got, err := app_phone.Normalize(raw)
+		if err != nil {
+			t.Errorf("Normalize(%q) returned error %v", raw, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", raw, got, want)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"12345678",       // no trunk prefix or country code
+		"+6512345678",    // another country
+		"+620812345678",  // zero after the country code
+		"0812345",        // too short
+		"08123456789012", // too long
+		"0812-3456-78ab", // contains letters
+		"+62 21 555",     // landline too short
+	}
+	for _, raw := range invalid {
+		if _, err := app_phone.Normalize(raw); !errors.Is(err, app_phone.ErrInvalidNumber) {
+			t.Errorf("Normalize(%q) error = %v, want ErrInvalidNumber", raw, err)
+		}
+	}
+}
@@ -2,17 +2,41 @@ package app_redis
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/go-redis/redis/v8"
 )
 
+// ErrKeyNotFound is returned by Get when the key does not exist or has expired.
+var ErrKeyNotFound = redis.Nil
+
 type Redis interface {
 	Set(ctx context.Context, key string, value string, expiration time.Duration) error
 	Get(ctx context.Context, key string) (data string, err error)
 	Del(ctx context.Context, key string) error
+	// Incr atomically increments the integer stored at key and returns the new value. A missing
+	// key starts at zero. A positive expiration resets the key's TTL in the same operation.
+	Incr(ctx context.Context, key string, expiration time.Duration) (int64, error)
+	// SetNX sets key only if it does not exist yet and reports whether it was set.
+	SetNX(ctx context.Context, key string, value string, expiration time.Duration) (bool, error)
+	// Expire sets a new TTL on an existing key. Missing keys are ignored.
+	Expire(ctx context.Context, key string, expiration time.Duration) error
 }
 
+// ErrNotInteger is returned by Incr when the stored value is not an integer.
+var ErrNotInteger = errors.New("app_redis: value is not an integer")
+
+// incrScript increments a counter and refreshes its TTL atomically, so a crash between the two
+// commands can never leave a counter without expiration.
+var incrScript = redis.NewScript(`
+local n = redis.call("INCR", KEYS[1])
+if tonumber(ARGV[1]) > 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return n
+`)
+
 // redisManager is a concrete implementation of RedisClient
 type redisManager struct {
 	client *redis.Client
@@ -43,6 +67,21 @@ func (r *redisManager) Del(ctx context.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
 }
 
+// Incr increments a counter and refreshes its TTL in one atomic script.
+func (r *redisManager) Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	return incrScript.Run(ctx, r.client, []string{key}, expiration.Milliseconds()).Int64()
+}
+
+// SetNX sets a value only if the key does not exist.
+func (r *redisManager) SetNX(ctx context.Context, key string, value string, expiration time.Duration) (bool, error) {
+	return r.client.SetNX(ctx, key, value, expiration).Result()
+}
+
+// Expire sets a new TTL on an existing key.
+func (r *redisManager) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	return r.client.Expire(ctx, key, expiration).Err()
+}
+
 // Close closes the connection to the Redis server.
 func (r *redisManager) Close() error {
 	return r.client.Close()
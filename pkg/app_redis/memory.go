@@ -0,0 +1,153 @@
+package app_redis
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often expired keys are purged from the in-memory store.
+const sweepInterval = time.Minute
+
+type memoryEntry struct {
+	value     string
+	expiresAt time.Time
+}
+
+func (e memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// memoryRedis is an in-process implementation of Redis used when no Redis server is configured.
+// Data is not shared between instances and is lost on restart.
+type memoryRedis struct {
+	mu        sync.Mutex
+	data      map[string]memoryEntry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryRedis returns an in-memory Redis with per-key expiration.
+func NewMemoryRedis() Redis {
+	return &memoryRedis{
+		data: make(map[string]memoryEntry),
+		now:  time.Now,
+	}
+}
+
+// Set stores a value with a specified expiration. A zero expiration keeps the key forever.
+func (m *memoryRedis) Set(ctx context.Context, key string, value string, expiration time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	entry := memoryEntry{value: value}
+	if expiration > 0 {
+		entry.expiresAt = now.Add(expiration)
+	}
+	m.data[key] = entry
+	m.sweep(now)
+
+	return nil
+}
+
+// Get retrieves a value by key. Missing or expired keys return ErrKeyNotFound.
+func (m *memoryRedis) Get(ctx context.Context, key string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.data[key]
+	if !ok {
+		return "", ErrKeyNotFound
+	}
+	if entry.expired(m.now()) {
+		delete(m.data, key)
+		return "", ErrKeyNotFound
+	}
+	return entry.value, nil
+}
+
+// Incr increments the integer stored at key. A positive expiration resets the key's TTL.
+func (m *memoryRedis) Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	entry, ok := m.data[key]
+	if !ok || entry.expired(now) {
+		entry = memoryEntry{value: "0"}
+	}
+
+	n, err := strconv.ParseInt(entry.value, 10, 64)
+	if err != nil {
+		return 0, ErrNotInteger
+	}
+	n++
+	entry.value = strconv.FormatInt(n, 10)
+	if expiration > 0 {
+		entry.expiresAt = now.Add(expiration)
+	}
+	m.data[key] = entry
+	m.sweep(now)
+
+	return n, nil
+}
+
+// SetNX stores a value only if the key is missing or expired.
+func (m *memoryRedis) SetNX(ctx context.Context, key string, value string, expiration time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if entry, ok := m.data[key]; ok && !entry.expired(now) {
+		return false, nil
+	}
+
+	entry := memoryEntry{value: value}
+	if expiration > 0 {
+		entry.expiresAt = now.Add(expiration)
+	}
+	m.data[key] = entry
+	m.sweep(now)
+
+	return true, nil
+}
+
+// Expire sets a new TTL on an existing key. Like Redis, a non-positive expiration deletes the key.
+func (m *memoryRedis) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	entry, ok := m.data[key]
+	if !ok || entry.expired(now) || expiration <= 0 {
+		delete(m.data, key)
+		return nil
+	}
+	entry.expiresAt = now.Add(expiration)
+	m.data[key] = entry
+	return nil
+}
+
+// Del deletes a key.
+func (m *memoryRedis) Del(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.data, key)
+	return nil
+}
+
+// sweep purges expired keys at most once per sweepInterval. Callers must hold m.mu.
+func (m *memoryRedis) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < sweepInterval {
+		return
+	}
+	for k, e := range m.data {
+		if e.expired(now) {
+			delete(m.data, k)
+		}
+	}
+	m.lastSweep = now
+}
@@ -0,0 +1,146 @@
+package app_redis
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRedis(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	store := NewMemoryRedis().(*memoryRedis)
+	store.now = func() time.Time { return now }
+
+	t.Run("Get Missing Key", func(t *testing.T) {
+		_, err := store.Get(ctx, "missing")
+		assert.True(t, errors.Is(err, ErrKeyNotFound))
+	})
+
+	t.Run("Set And Get", func(t *testing.T) {
+		assert.NoError(t, store.Set(ctx, "key", "value", time.Minute))
+
+		value, err := store.Get(ctx, "key")
+		assert.NoError(t, err)
+		assert.Equal(t, "value", value)
+	})
+
+	t.Run("Expired Key", func(t *testing.T) {
+		assert.NoError(t, store.Set(ctx, "short", "value", time.Second))
+
+		now = now.Add(time.Second)
+		_, err := store.Get(ctx, "short")
+		assert.True(t, errors.Is(err, ErrKeyNotFound))
+	})
+
+	t.Run("Zero Expiration Never Expires", func(t *testing.T) {
+		assert.NoError(t, store.Set(ctx, "forever", "value", 0))
+
+		now = now.Add(24 * time.Hour)
+		value, err := store.Get(ctx, "forever")
+		assert.NoError(t, err)
+		assert.Equal(t, "value", value)
+	})
+
+	t.Run("Sweep Removes Expired Keys", func(t *testing.T) {
+		assert.NoError(t, store.Set(ctx, "stale", "value", time.Second))
+		now = now.Add(2 * sweepInterval)
+		assert.NoError(t, store.Set(ctx, "fresh", "value", time.Minute))
+
+		store.mu.Lock()
+		_, exists := store.data["stale"]
+		store.mu.Unlock()
+		assert.False(t, exists)
+	})
+
+	t.Run("Del", func(t *testing.T) {
+		assert.NoError(t, store.Set(ctx, "key", "value", time.Minute))
+		assert.NoError(t, store.Del(ctx, "key"))
+
+		_, err := store.Get(ctx, "key")
+		assert.True(t, errors.Is(err, ErrKeyNotFound))
+	})
+
+	t.Run("Incr Counts And Refreshes Expiration", func(t *testing.T) {
+		n, err := store.Incr(ctx, "counter", time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), n)
+
+		now = now.Add(50 * time.Second)
+		n, err = store.Incr(ctx, "counter", time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), n)
+
+		now = now.Add(50 * time.Second)
+		value, err := store.Get(ctx, "counter")
+		assert.NoError(t, err)
+		assert.Equal(t, "2", value)
+
+		now = now.Add(time.Minute)
+		n, err = store.Incr(ctx, "counter", time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), n)
+	})
+
+	t.Run("Incr Rejects Non Integer", func(t *testing.T) {
+		assert.NoError(t, store.Set(ctx, "text", "value", time.Minute))
+
+		_, err := store.Incr(ctx, "text", time.Minute)
+		assert.True(t, errors.Is(err, ErrNotInteger))
+	})
+
+	t.Run("SetNX", func(t *testing.T) {
+		set, err := store.SetNX(ctx, "lock", "first", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, set)
+
+		set, err = store.SetNX(ctx, "lock", "second", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, set)
+
+		value, err := store.Get(ctx, "lock")
+		assert.NoError(t, err)
+		assert.Equal(t, "first", value)
+
+		now = now.Add(time.Minute)
+		set, err = store.SetNX(ctx, "lock", "third", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, set)
+	})
+
+	t.Run("Expire", func(t *testing.T) {
+		assert.NoError(t, store.Set(ctx, "key", "value", 0))
+		assert.NoError(t, store.Expire(ctx, "key", time.Second))
+
+		now = now.Add(time.Second)
+		_, err := store.Get(ctx, "key")
+		assert.True(t, errors.Is(err, ErrKeyNotFound))
+
+		assert.NoError(t, store.Expire(ctx, "missing", time.Second))
+		_, err = store.Get(ctx, "missing")
+		assert.True(t, errors.Is(err, ErrKeyNotFound))
+	})
+
+	t.Run("Concurrent Incr", func(t *testing.T) {
+		const workers = 50
+		var wg sync.WaitGroup
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := store.Incr(ctx, "parallel", time.Minute)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		value, err := store.Get(ctx, "parallel")
+		assert.NoError(t, err)
+		assert.Equal(t, "50", value)
+	})
+}
@@ -0,0 +1,178 @@
+// Package app_sigv4 implements AWS Signature Version 4 for S3-compatible object storage, both as
+// an Authorization header and as a presigned URL.
+package app_sigv4
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	algorithm = "AWS4-HMAC-SHA256"
+	// UnsignedPayload is used as the payload hash of presigned URLs.
+	UnsignedPayload = "UNSIGNED-PAYLOAD"
+	// MaxPresignExpiry is the longest validity S3 accepts for a presigned URL.
+	MaxPresignExpiry = 7 * 24 * time.Hour
+)
+
+// Credentials identify the signer and the scope the signature is valid for.
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	Region          string
+	Service         string
+}
+
+// PayloadHash returns the hex SHA-256 of payload as expected in the x-amz-content-sha256 header.
+func PayloadHash(payload []byte) string {
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+// SignRequest signs req in place. It sets the X-Amz-Date, X-Amz-Content-Sha256 and Authorization
+// headers; every other header already on the request is included in the signature.
+func SignRequest(req *http.Request, payloadHash string, creds Credentials, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if lower == "authorization" || lower == "user-agent" {
+			continue
+		}
+		headers[lower] = strings.Join(values, ",")
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI(req.URL),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := credentialScope(now, creds)
+	signature := sign(canonicalRequest, amzDate, scope, now, creds)
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		algorithm, creds.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// PresignURL returns u with query parameters that authorize method on it for expires, signing
+// only the host header. The URL must be used with the exact host it was signed for.
+func PresignURL(method string, u *url.URL, creds Credentials, expires time.Duration, now time.Time) (string, error) {
+	if expires <= 0 || expires > MaxPresignExpiry {
+		return "", fmt.Errorf("presign expiry must be between 1s and %s", MaxPresignExpiry)
+	}
+
+	amzDate := now.UTC().Format("20060102T150405Z")
+	scope := credentialScope(now, creds)
+
+	query := u.Query()
+	query.Set("X-Amz-Algorithm", algorithm)
+	query.Set("X-Amz-Credential", creds.AccessKeyID+"/"+scope)
+	query.Set("X-Amz-Date", amzDate)
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expires/time.Second)))
+	query.Set("X-Amz-SignedHeaders", "host")
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		canonicalURI(u),
+		canonicalQuery(query),
+		"host:" + u.Host + "\n",
+		"host",
+		UnsignedPayload,
+	}, "\n")
+
+	signed := *u
+	signed.RawQuery = canonicalQuery(query) + "&X-Amz-Signature=" + sign(canonicalRequest, amzDate, scope, now, creds)
+	return signed.String(), nil
+}
+
+func credentialScope(now time.Time, creds Credentials) string {
+	return strings.Join([]string{now.UTC().Format("20060102"), creds.Region, creds.Service, "aws4_request"}, "/")
+}
+
+func sign(canonicalRequest, amzDate, scope string, now time.Time, creds Credentials) string {
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{algorithm, amzDate, scope, hex.EncodeToString(hash[:])}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), now.UTC().Format("20060102"))
+	key = hmacSHA256(key, creds.Region)
+	key = hmacSHA256(key, creds.Service)
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// canonicalURI encodes each path segment once, as S3 expects.
+func canonicalURI(u *url.URL) string {
+	path := u.Path
+	if path == "" {
+		return "/"
+	}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = encode(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, key := range keys {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, encode(key)+"="+encode(value))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// encode percent-encodes every byte except the RFC 3986 unreserved characters.
+func encode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
@@ -0,0 +1,77 @@
+package app_totp_test
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/X3nonxe/gopsy-backend/pkg/app_totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA1 seed from RFC 6238 appendix B.
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode_RFC6238Vectors(t *testing.T) {
+	// Expected values are the last 6 digits of the 8 digit vectors in RFC 6238 appendix B.
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for unix, expected := range vectors {
+		code, err := app_totp.Code(rfcSecret, time.Unix(unix, 0))
+		require.NoError(t, err)
+		assert.Equal(t, expected, code, "time %d", unix)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+
+	step, ok, err := app_totp.Validate(rfcSecret, "050471", now, 1)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, app_totp.Step(now), step)
+
+	// The previous period is accepted within the skew window
+	previous, err := app_totp.Code(rfcSecret, now.Add(-app_totp.Period))
+	require.NoError(t, err)
+	step, ok, err = app_totp.Validate(rfcSecret, previous, now, 1)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, app_totp.Step(now)-1, step)
+
+	// Codes outside the skew window are rejected
+	old, err := app_totp.Code(rfcSecret, now.Add(-3*app_totp.Period))
+	require.NoError(t, err)
+	_, ok, err = app_totp.Validate(rfcSecret, old, now, 1)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, ok, err = app_totp.Validate(rfcSecret, "12345", now, 1)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = app_totp.Validate("not base32!", "123456", now, 1)
+	assert.Error(t, err)
+}
+
+func TestGenerateSecretAndProvisioningURI(t *testing.T) {
+	secret, err := app_totp.GenerateSecret()
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	uri := app_totp.ProvisioningURI("Gopsy", "dr.budi@example.com", secret)
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Gopsy:dr.budi@example.com?"))
+	assert.Contains(t, uri, "secret="+secret)
+	assert.Contains(t, uri, "issuer=Gopsy")
+	assert.Contains(t, uri, "digits=6")
+	assert.Contains(t, uri, "period=30")
+}